@@ -0,0 +1,24 @@
+// Code generated by protoc-gen-go-json. DO NOT EDIT.
+// source: api/events/v1/events.proto
+
+package pb
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// MarshalJSON implements json.Marshaler
+func (msg *Envelope) MarshalJSON() ([]byte, error) {
+	return protojson.MarshalOptions{
+		UseEnumNumbers:  false,
+		EmitUnpopulated: false,
+		UseProtoNames:   false,
+	}.Marshal(msg)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (msg *Envelope) UnmarshalJSON(b []byte) error {
+	return protojson.UnmarshalOptions{
+		DiscardUnknown: false,
+	}.Unmarshal(b, msg)
+}
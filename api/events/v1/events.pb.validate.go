@@ -0,0 +1,174 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: api/events/v1/events.proto
+
+package pb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Envelope with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Envelope) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Envelope with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EnvelopeMultiError, or nil
+// if none found.
+func (m *Envelope) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Envelope) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for EventType
+
+	// no validation rules for SchemaVersion
+
+	if all {
+		switch v := interface{}(m.GetOccurredAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EnvelopeValidationError{
+					field:  "OccurredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EnvelopeValidationError{
+					field:  "OccurredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOccurredAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EnvelopeValidationError{
+				field:  "OccurredAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Producer
+
+	// no validation rules for CorrelationId
+
+	// no validation rules for Payload
+
+	if len(errors) > 0 {
+		return EnvelopeMultiError(errors)
+	}
+
+	return nil
+}
+
+// EnvelopeMultiError is an error wrapping multiple validation errors returned
+// by Envelope.ValidateAll() if the designated constraints aren't met.
+type EnvelopeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EnvelopeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EnvelopeMultiError) AllErrors() []error { return m }
+
+// EnvelopeValidationError is the validation error returned by
+// Envelope.Validate if the designated constraints aren't met.
+type EnvelopeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EnvelopeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EnvelopeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EnvelopeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EnvelopeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EnvelopeValidationError) ErrorName() string { return "EnvelopeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EnvelopeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEnvelope.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EnvelopeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EnvelopeValidationError{}
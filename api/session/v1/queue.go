@@ -0,0 +1,78 @@
+package pb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/ZergsLaw/back-template1/internal/events"
+)
+
+// Topics.
+const (
+	description          = "Events from session service for notifying about creating and removing sessions and changing their status."
+	Stream               = "session"
+	prefix               = Stream + ".events."
+	TopicCreated         = prefix + "created"
+	TopicRemoved         = prefix + "removed"
+	TopicStatusChanged   = prefix + "status_changed"
+	SubscribeToAllEvents = prefix + "*"
+)
+
+// Envelope fields of session events.
+// Topic is used as event type, payload is Event of SchemaVersion.
+// SchemaVersion must be increased on incompatible change of Event with adding upcaster to Registry.
+const (
+	Producer      = "session"
+	SchemaVersion = 1
+)
+
+// Topics contains all topics of session stream.
+var Topics = []string{TopicCreated, TopicRemoved, TopicStatusChanged}
+
+// Registry returns registry decoding session events of all schema versions.
+func Registry() *events.Registry {
+	r := events.NewRegistry()
+	for _, topic := range Topics {
+		r.Register(topic, events.DecoderOf[Event](), nil)
+	}
+
+	return r
+}
+
+const (
+	maxMsgReplicas  = 1
+	duplicateWindow = time.Second * 30
+)
+
+// Migrate for init streams.
+func Migrate(js nats.JetStreamManager) error {
+	replicas := maxMsgReplicas
+	eventStream := &nats.StreamConfig{
+		Name:        Stream,
+		Description: description,
+		Subjects:    Topics,
+		Retention:   nats.LimitsPolicy,
+		Storage:     nats.FileStorage,
+		Replicas:    replicas,
+		NoAck:       false,
+		Duplicates:  duplicateWindow,
+	}
+
+	_, err := js.AddStream(eventStream)
+	switch {
+	case errors.Is(err, nats.ErrStreamNameAlreadyInUse):
+		_, err = js.UpdateStream(eventStream)
+		if err != nil {
+			return fmt.Errorf("js.UpdateStream: %w", err)
+		}
+
+		return nil
+	case err != nil:
+		return fmt.Errorf("js.AddStream: %w", err)
+	}
+
+	return nil
+}
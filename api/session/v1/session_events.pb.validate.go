@@ -0,0 +1,580 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: api/session/v1/session_events.proto
+
+package pb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	pb "github.com/ZergsLaw/back-template1/api/user_status/v1"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+
+	_ = pb.StatusKind(0)
+)
+
+// Validate checks the field values on Created with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Created) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Created with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in CreatedMultiError, or nil if none found.
+func (m *Created) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Created) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SessionId
+
+	// no validation rules for UserId
+
+	// no validation rules for Kind
+
+	// no validation rules for Ip
+
+	// no validation rules for UserAgent
+
+	if len(errors) > 0 {
+		return CreatedMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreatedMultiError is an error wrapping multiple validation errors returned
+// by Created.ValidateAll() if the designated constraints aren't met.
+type CreatedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreatedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreatedMultiError) AllErrors() []error { return m }
+
+// CreatedValidationError is the validation error returned by Created.Validate
+// if the designated constraints aren't met.
+type CreatedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreatedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreatedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreatedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreatedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreatedValidationError) ErrorName() string { return "CreatedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CreatedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreated.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreatedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreatedValidationError{}
+
+// Validate checks the field values on Removed with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Removed) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Removed with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in RemovedMultiError, or nil if none found.
+func (m *Removed) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Removed) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SessionId
+
+	// no validation rules for UserId
+
+	if len(errors) > 0 {
+		return RemovedMultiError(errors)
+	}
+
+	return nil
+}
+
+// RemovedMultiError is an error wrapping multiple validation errors returned
+// by Removed.ValidateAll() if the designated constraints aren't met.
+type RemovedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RemovedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RemovedMultiError) AllErrors() []error { return m }
+
+// RemovedValidationError is the validation error returned by Removed.Validate
+// if the designated constraints aren't met.
+type RemovedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RemovedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RemovedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RemovedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RemovedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RemovedValidationError) ErrorName() string { return "RemovedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RemovedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRemoved.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RemovedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RemovedValidationError{}
+
+// Validate checks the field values on StatusChanged with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StatusChanged) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StatusChanged with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StatusChangedMultiError, or
+// nil if none found.
+func (m *StatusChanged) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StatusChanged) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Kind
+
+	if len(errors) > 0 {
+		return StatusChangedMultiError(errors)
+	}
+
+	return nil
+}
+
+// StatusChangedMultiError is an error wrapping multiple validation errors
+// returned by StatusChanged.ValidateAll() if the designated constraints
+// aren't met.
+type StatusChangedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StatusChangedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StatusChangedMultiError) AllErrors() []error { return m }
+
+// StatusChangedValidationError is the validation error returned by
+// StatusChanged.Validate if the designated constraints aren't met.
+type StatusChangedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StatusChangedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StatusChangedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StatusChangedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StatusChangedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StatusChangedValidationError) ErrorName() string { return "StatusChangedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StatusChangedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStatusChanged.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StatusChangedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StatusChangedValidationError{}
+
+// Validate checks the field values on Event with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Event) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Event with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in EventMultiError, or nil if none found.
+func (m *Event) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Event) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	switch v := m.Body.(type) {
+	case *Event_Created:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetCreated()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "Created",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "Created",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCreated()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "Created",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Event_Removed:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetRemoved()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "Removed",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "Removed",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRemoved()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "Removed",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Event_StatusChanged:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetStatusChanged()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "StatusChanged",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "StatusChanged",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetStatusChanged()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "StatusChanged",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return EventMultiError(errors)
+	}
+
+	return nil
+}
+
+// EventMultiError is an error wrapping multiple validation errors returned by
+// Event.ValidateAll() if the designated constraints aren't met.
+type EventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EventMultiError) AllErrors() []error { return m }
+
+// EventValidationError is the validation error returned by Event.Validate if
+// the designated constraints aren't met.
+type EventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventValidationError) ErrorName() string { return "EventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventValidationError{}
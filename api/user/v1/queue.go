@@ -6,20 +6,51 @@ import (
 	"time"
 
 	"github.com/nats-io/nats.go"
+
+	"github.com/ZergsLaw/back-template1/internal/events"
 )
 
 // Topics.
 const (
-	description          = "Events from user service for notifying about new registration, deleting or updating account."
+	description          = "Events from user service for notifying about new registration, deleting or updating account and preferences, logins, logouts, password and avatar changes."
 	Stream               = "user"
-	events               = Stream + ".events"
-	version              = events + ".v1."
-	TopicAdd             = version + "add"
-	TopicDel             = version + "del"
-	TopicUpdate          = version + "update"
-	SubscribeToAllEvents = version + "*"
+	prefix               = Stream + ".events.v1." // Subjects are kept since events were sent without envelope.
+	TopicAdd             = prefix + "add"
+	TopicDel             = prefix + "del"
+	TopicUpdate          = prefix + "update"
+	TopicPreferences     = prefix + "preferences"
+	TopicLoggedIn        = prefix + "logged_in"
+	TopicLoggedOut       = prefix + "logged_out"
+	TopicLoginFailed     = prefix + "login_failed"
+	TopicPasswordChanged = prefix + "password_changed"
+	TopicAvatarChanged   = prefix + "avatar_changed"
+	SubscribeToAllEvents = prefix + "*"
 )
 
+// Envelope fields of user events.
+// Topic is used as event type, payload is Event of SchemaVersion.
+// SchemaVersion must be increased on incompatible change of Event with adding upcaster to Registry.
+const (
+	Producer      = "user"
+	SchemaVersion = 1
+)
+
+// Topics contains all topics of user stream.
+var Topics = []string{
+	TopicAdd, TopicDel, TopicUpdate, TopicPreferences,
+	TopicLoggedIn, TopicLoggedOut, TopicLoginFailed, TopicPasswordChanged, TopicAvatarChanged,
+}
+
+// Registry returns registry decoding user events of all schema versions.
+func Registry() *events.Registry {
+	r := events.NewRegistry()
+	for _, topic := range Topics {
+		r.Register(topic, events.DecoderOf[Event](), nil)
+	}
+
+	return r
+}
+
 const (
 	maxMsgReplicas  = 1
 	duplicateWindow = time.Second * 30
@@ -31,7 +62,7 @@ func Migrate(js nats.JetStreamManager) error {
 	eventStream := &nats.StreamConfig{
 		Name:        Stream,
 		Description: description,
-		Subjects:    []string{TopicAdd, TopicDel, TopicUpdate},
+		Subjects:    Topics,
 		Retention:   nats.LimitsPolicy,
 		Storage:     nats.FileStorage,
 		Replicas:    replicas,
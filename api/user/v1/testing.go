@@ -11,27 +11,57 @@ var (
 	_ gomock.Matcher = &CreateUserRequest{}
 	_ gomock.Matcher = &LoginRequest{}
 	_ gomock.Matcher = &GetUserRequest{}
+	_ gomock.Matcher = &ResolveUsernameRequest{}
 	_ gomock.Matcher = &SearchUsersRequest{}
 	_ gomock.Matcher = &LogoutRequest{}
 	_ gomock.Matcher = &UpdatePasswordRequest{}
 	_ gomock.Matcher = &UpdateUserRequest{}
 	_ gomock.Matcher = &RemoveAvatarRequest{}
 	_ gomock.Matcher = &ListUserAvatarRequest{}
+	_ gomock.Matcher = &SetCurrentAvatarRequest{}
+	_ gomock.Matcher = &ReorderAvatarsRequest{}
+	_ gomock.Matcher = &CreateAvatarUploadRequest{}
+	_ gomock.Matcher = &CompleteAvatarUploadRequest{}
+	_ gomock.Matcher = &GetAvatarURLRequest{}
+	_ gomock.Matcher = &GetStorageUsageRequest{}
 	_ gomock.Matcher = &GetUsersByIDsRequest{}
+	_ gomock.Matcher = &BatchGetUsersRequest{}
+	_ gomock.Matcher = &GetPrivacySettingsRequest{}
+	_ gomock.Matcher = &UpdatePrivacySettingsRequest{}
+	_ gomock.Matcher = &GetPreferencesRequest{}
+	_ gomock.Matcher = &SetPreferencesRequest{}
+	_ gomock.Matcher = &ListDeadTasksRequest{}
+	_ gomock.Matcher = &RetryDeadTaskRequest{}
+	_ gomock.Matcher = &DiscardDeadTaskRequest{}
 )
 
-func (x *VerificationEmailRequest) Matches(y interface{}) bool    { return match(x, y) }
-func (x *VerificationUsernameRequest) Matches(y interface{}) bool { return match(x, y) }
-func (x *CreateUserRequest) Matches(y interface{}) bool           { return match(x, y) }
-func (x *LoginRequest) Matches(y interface{}) bool                { return match(x, y) }
-func (x *GetUserRequest) Matches(y interface{}) bool              { return match(x, y) }
-func (x *SearchUsersRequest) Matches(y interface{}) bool          { return match(x, y) }
-func (x *LogoutRequest) Matches(y interface{}) bool               { return match(x, y) }
-func (x *UpdatePasswordRequest) Matches(y interface{}) bool       { return match(x, y) }
-func (x *UpdateUserRequest) Matches(y interface{}) bool           { return match(x, y) }
-func (x *RemoveAvatarRequest) Matches(y interface{}) bool         { return match(x, y) }
-func (x *ListUserAvatarRequest) Matches(y interface{}) bool       { return match(x, y) }
-func (x *GetUsersByIDsRequest) Matches(y interface{}) bool        { return match(x, y) }
+func (x *VerificationEmailRequest) Matches(y interface{}) bool     { return match(x, y) }
+func (x *VerificationUsernameRequest) Matches(y interface{}) bool  { return match(x, y) }
+func (x *CreateUserRequest) Matches(y interface{}) bool            { return match(x, y) }
+func (x *LoginRequest) Matches(y interface{}) bool                 { return match(x, y) }
+func (x *GetUserRequest) Matches(y interface{}) bool               { return match(x, y) }
+func (x *ResolveUsernameRequest) Matches(y interface{}) bool       { return match(x, y) }
+func (x *SearchUsersRequest) Matches(y interface{}) bool           { return match(x, y) }
+func (x *LogoutRequest) Matches(y interface{}) bool                { return match(x, y) }
+func (x *UpdatePasswordRequest) Matches(y interface{}) bool        { return match(x, y) }
+func (x *UpdateUserRequest) Matches(y interface{}) bool            { return match(x, y) }
+func (x *RemoveAvatarRequest) Matches(y interface{}) bool          { return match(x, y) }
+func (x *ListUserAvatarRequest) Matches(y interface{}) bool        { return match(x, y) }
+func (x *SetCurrentAvatarRequest) Matches(y interface{}) bool      { return match(x, y) }
+func (x *ReorderAvatarsRequest) Matches(y interface{}) bool        { return match(x, y) }
+func (x *CreateAvatarUploadRequest) Matches(y interface{}) bool    { return match(x, y) }
+func (x *CompleteAvatarUploadRequest) Matches(y interface{}) bool  { return match(x, y) }
+func (x *GetAvatarURLRequest) Matches(y interface{}) bool          { return match(x, y) }
+func (x *GetStorageUsageRequest) Matches(y interface{}) bool       { return match(x, y) }
+func (x *GetUsersByIDsRequest) Matches(y interface{}) bool         { return match(x, y) }
+func (x *BatchGetUsersRequest) Matches(y interface{}) bool         { return match(x, y) }
+func (x *GetPrivacySettingsRequest) Matches(y interface{}) bool    { return match(x, y) }
+func (x *UpdatePrivacySettingsRequest) Matches(y interface{}) bool { return match(x, y) }
+func (x *GetPreferencesRequest) Matches(y interface{}) bool        { return match(x, y) }
+func (x *SetPreferencesRequest) Matches(y interface{}) bool        { return match(x, y) }
+func (x *ListDeadTasksRequest) Matches(y interface{}) bool         { return match(x, y) }
+func (x *RetryDeadTaskRequest) Matches(y interface{}) bool         { return match(x, y) }
+func (x *DiscardDeadTaskRequest) Matches(y interface{}) bool       { return match(x, y) }
 
 func match(x proto.Message, y interface{}) bool {
 	p2, ok := y.(proto.Message)
@@ -39,42 +39,213 @@ var (
 	_ = pb.StatusKind(0)
 )
 
-// Validate checks the field values on GetUsersByIDsRequest with the rules
+// Validate checks the field values on DeadTask with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DeadTask) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeadTask with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DeadTaskMultiError, or nil
+// if none found.
+func (m *DeadTask) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeadTask) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Kind
+
+	// no validation rules for UserId
+
+	// no validation rules for Attempts
+
+	// no validation rules for LastError
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DeadTaskValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DeadTaskValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DeadTaskValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetDeadAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DeadTaskValidationError{
+					field:  "DeadAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DeadTaskValidationError{
+					field:  "DeadAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeadAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DeadTaskValidationError{
+				field:  "DeadAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DeadTaskMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeadTaskMultiError is an error wrapping multiple validation errors returned
+// by DeadTask.ValidateAll() if the designated constraints aren't met.
+type DeadTaskMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeadTaskMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeadTaskMultiError) AllErrors() []error { return m }
+
+// DeadTaskValidationError is the validation error returned by
+// DeadTask.Validate if the designated constraints aren't met.
+type DeadTaskValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeadTaskValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeadTaskValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeadTaskValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeadTaskValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeadTaskValidationError) ErrorName() string { return "DeadTaskValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DeadTaskValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeadTask.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeadTaskValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeadTaskValidationError{}
+
+// Validate checks the field values on ListDeadTasksRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetUsersByIDsRequest) Validate() error {
+func (m *ListDeadTasksRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUsersByIDsRequest with the rules
+// ValidateAll checks the field values on ListDeadTasksRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetUsersByIDsRequestMultiError, or nil if none found.
-func (m *GetUsersByIDsRequest) ValidateAll() error {
+// ListDeadTasksRequestMultiError, or nil if none found.
+func (m *ListDeadTasksRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUsersByIDsRequest) validate(all bool) error {
+func (m *ListDeadTasksRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for Limit
+
+	// no validation rules for Offset
+
 	if len(errors) > 0 {
-		return GetUsersByIDsRequestMultiError(errors)
+		return ListDeadTasksRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetUsersByIDsRequestMultiError is an error wrapping multiple validation
-// errors returned by GetUsersByIDsRequest.ValidateAll() if the designated
+// ListDeadTasksRequestMultiError is an error wrapping multiple validation
+// errors returned by ListDeadTasksRequest.ValidateAll() if the designated
 // constraints aren't met.
-type GetUsersByIDsRequestMultiError []error
+type ListDeadTasksRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUsersByIDsRequestMultiError) Error() string {
+func (m ListDeadTasksRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -83,11 +254,11 @@ func (m GetUsersByIDsRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUsersByIDsRequestMultiError) AllErrors() []error { return m }
+func (m ListDeadTasksRequestMultiError) AllErrors() []error { return m }
 
-// GetUsersByIDsRequestValidationError is the validation error returned by
-// GetUsersByIDsRequest.Validate if the designated constraints aren't met.
-type GetUsersByIDsRequestValidationError struct {
+// ListDeadTasksRequestValidationError is the validation error returned by
+// ListDeadTasksRequest.Validate if the designated constraints aren't met.
+type ListDeadTasksRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -95,24 +266,24 @@ type GetUsersByIDsRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUsersByIDsRequestValidationError) Field() string { return e.field }
+func (e ListDeadTasksRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUsersByIDsRequestValidationError) Reason() string { return e.reason }
+func (e ListDeadTasksRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUsersByIDsRequestValidationError) Cause() error { return e.cause }
+func (e ListDeadTasksRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUsersByIDsRequestValidationError) Key() bool { return e.key }
+func (e ListDeadTasksRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUsersByIDsRequestValidationError) ErrorName() string {
-	return "GetUsersByIDsRequestValidationError"
+func (e ListDeadTasksRequestValidationError) ErrorName() string {
+	return "ListDeadTasksRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetUsersByIDsRequestValidationError) Error() string {
+func (e ListDeadTasksRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -124,14 +295,14 @@ func (e GetUsersByIDsRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUsersByIDsRequest.%s: %s%s",
+		"invalid %sListDeadTasksRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUsersByIDsRequestValidationError{}
+var _ error = ListDeadTasksRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -139,47 +310,47 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUsersByIDsRequestValidationError{}
+} = ListDeadTasksRequestValidationError{}
 
-// Validate checks the field values on GetUsersByIDsResponse with the rules
+// Validate checks the field values on ListDeadTasksResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetUsersByIDsResponse) Validate() error {
+func (m *ListDeadTasksResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUsersByIDsResponse with the rules
+// ValidateAll checks the field values on ListDeadTasksResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetUsersByIDsResponseMultiError, or nil if none found.
-func (m *GetUsersByIDsResponse) ValidateAll() error {
+// ListDeadTasksResponseMultiError, or nil if none found.
+func (m *ListDeadTasksResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUsersByIDsResponse) validate(all bool) error {
+func (m *ListDeadTasksResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetResult() {
+	for idx, item := range m.GetTasks() {
 		_, _ = idx, item
 
 		if all {
 			switch v := interface{}(item).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, GetUsersByIDsResponseValidationError{
-						field:  fmt.Sprintf("Result[%v]", idx),
+					errors = append(errors, ListDeadTasksResponseValidationError{
+						field:  fmt.Sprintf("Tasks[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, GetUsersByIDsResponseValidationError{
-						field:  fmt.Sprintf("Result[%v]", idx),
+					errors = append(errors, ListDeadTasksResponseValidationError{
+						field:  fmt.Sprintf("Tasks[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
@@ -187,8 +358,8 @@ func (m *GetUsersByIDsResponse) validate(all bool) error {
 			}
 		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return GetUsersByIDsResponseValidationError{
-					field:  fmt.Sprintf("Result[%v]", idx),
+				return ListDeadTasksResponseValidationError{
+					field:  fmt.Sprintf("Tasks[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
@@ -197,20 +368,22 @@ func (m *GetUsersByIDsResponse) validate(all bool) error {
 
 	}
 
+	// no validation rules for Total
+
 	if len(errors) > 0 {
-		return GetUsersByIDsResponseMultiError(errors)
+		return ListDeadTasksResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetUsersByIDsResponseMultiError is an error wrapping multiple validation
-// errors returned by GetUsersByIDsResponse.ValidateAll() if the designated
+// ListDeadTasksResponseMultiError is an error wrapping multiple validation
+// errors returned by ListDeadTasksResponse.ValidateAll() if the designated
 // constraints aren't met.
-type GetUsersByIDsResponseMultiError []error
+type ListDeadTasksResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUsersByIDsResponseMultiError) Error() string {
+func (m ListDeadTasksResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -219,11 +392,11 @@ func (m GetUsersByIDsResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUsersByIDsResponseMultiError) AllErrors() []error { return m }
+func (m ListDeadTasksResponseMultiError) AllErrors() []error { return m }
 
-// GetUsersByIDsResponseValidationError is the validation error returned by
-// GetUsersByIDsResponse.Validate if the designated constraints aren't met.
-type GetUsersByIDsResponseValidationError struct {
+// ListDeadTasksResponseValidationError is the validation error returned by
+// ListDeadTasksResponse.Validate if the designated constraints aren't met.
+type ListDeadTasksResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -231,24 +404,24 @@ type GetUsersByIDsResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUsersByIDsResponseValidationError) Field() string { return e.field }
+func (e ListDeadTasksResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUsersByIDsResponseValidationError) Reason() string { return e.reason }
+func (e ListDeadTasksResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUsersByIDsResponseValidationError) Cause() error { return e.cause }
+func (e ListDeadTasksResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUsersByIDsResponseValidationError) Key() bool { return e.key }
+func (e ListDeadTasksResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUsersByIDsResponseValidationError) ErrorName() string {
-	return "GetUsersByIDsResponseValidationError"
+func (e ListDeadTasksResponseValidationError) ErrorName() string {
+	return "ListDeadTasksResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetUsersByIDsResponseValidationError) Error() string {
+func (e ListDeadTasksResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -260,14 +433,14 @@ func (e GetUsersByIDsResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUsersByIDsResponse.%s: %s%s",
+		"invalid %sListDeadTasksResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUsersByIDsResponseValidationError{}
+var _ error = ListDeadTasksResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -275,46 +448,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUsersByIDsResponseValidationError{}
+} = ListDeadTasksResponseValidationError{}
 
-// Validate checks the field values on VerificationEmailRequest with the rules
+// Validate checks the field values on RetryDeadTaskRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *VerificationEmailRequest) Validate() error {
+func (m *RetryDeadTaskRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on VerificationEmailRequest with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on RetryDeadTaskRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// VerificationEmailRequestMultiError, or nil if none found.
-func (m *VerificationEmailRequest) ValidateAll() error {
+// RetryDeadTaskRequestMultiError, or nil if none found.
+func (m *RetryDeadTaskRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *VerificationEmailRequest) validate(all bool) error {
+func (m *RetryDeadTaskRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Email
+	// no validation rules for TaskId
 
 	if len(errors) > 0 {
-		return VerificationEmailRequestMultiError(errors)
+		return RetryDeadTaskRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// VerificationEmailRequestMultiError is an error wrapping multiple validation
-// errors returned by VerificationEmailRequest.ValidateAll() if the designated
+// RetryDeadTaskRequestMultiError is an error wrapping multiple validation
+// errors returned by RetryDeadTaskRequest.ValidateAll() if the designated
 // constraints aren't met.
-type VerificationEmailRequestMultiError []error
+type RetryDeadTaskRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m VerificationEmailRequestMultiError) Error() string {
+func (m RetryDeadTaskRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -323,11 +496,11 @@ func (m VerificationEmailRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m VerificationEmailRequestMultiError) AllErrors() []error { return m }
+func (m RetryDeadTaskRequestMultiError) AllErrors() []error { return m }
 
-// VerificationEmailRequestValidationError is the validation error returned by
-// VerificationEmailRequest.Validate if the designated constraints aren't met.
-type VerificationEmailRequestValidationError struct {
+// RetryDeadTaskRequestValidationError is the validation error returned by
+// RetryDeadTaskRequest.Validate if the designated constraints aren't met.
+type RetryDeadTaskRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -335,24 +508,24 @@ type VerificationEmailRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e VerificationEmailRequestValidationError) Field() string { return e.field }
+func (e RetryDeadTaskRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e VerificationEmailRequestValidationError) Reason() string { return e.reason }
+func (e RetryDeadTaskRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e VerificationEmailRequestValidationError) Cause() error { return e.cause }
+func (e RetryDeadTaskRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e VerificationEmailRequestValidationError) Key() bool { return e.key }
+func (e RetryDeadTaskRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e VerificationEmailRequestValidationError) ErrorName() string {
-	return "VerificationEmailRequestValidationError"
+func (e RetryDeadTaskRequestValidationError) ErrorName() string {
+	return "RetryDeadTaskRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e VerificationEmailRequestValidationError) Error() string {
+func (e RetryDeadTaskRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -364,14 +537,14 @@ func (e VerificationEmailRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sVerificationEmailRequest.%s: %s%s",
+		"invalid %sRetryDeadTaskRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = VerificationEmailRequestValidationError{}
+var _ error = RetryDeadTaskRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -379,24 +552,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = VerificationEmailRequestValidationError{}
+} = RetryDeadTaskRequestValidationError{}
 
-// Validate checks the field values on VerificationEmailResponse with the rules
+// Validate checks the field values on RetryDeadTaskResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *VerificationEmailResponse) Validate() error {
+func (m *RetryDeadTaskResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on VerificationEmailResponse with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on RetryDeadTaskResponse with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// VerificationEmailResponseMultiError, or nil if none found.
-func (m *VerificationEmailResponse) ValidateAll() error {
+// RetryDeadTaskResponseMultiError, or nil if none found.
+func (m *RetryDeadTaskResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *VerificationEmailResponse) validate(all bool) error {
+func (m *RetryDeadTaskResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -404,19 +577,19 @@ func (m *VerificationEmailResponse) validate(all bool) error {
 	var errors []error
 
 	if len(errors) > 0 {
-		return VerificationEmailResponseMultiError(errors)
+		return RetryDeadTaskResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// VerificationEmailResponseMultiError is an error wrapping multiple validation
-// errors returned by VerificationEmailResponse.ValidateAll() if the
-// designated constraints aren't met.
-type VerificationEmailResponseMultiError []error
+// RetryDeadTaskResponseMultiError is an error wrapping multiple validation
+// errors returned by RetryDeadTaskResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RetryDeadTaskResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m VerificationEmailResponseMultiError) Error() string {
+func (m RetryDeadTaskResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -425,11 +598,11 @@ func (m VerificationEmailResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m VerificationEmailResponseMultiError) AllErrors() []error { return m }
+func (m RetryDeadTaskResponseMultiError) AllErrors() []error { return m }
 
-// VerificationEmailResponseValidationError is the validation error returned by
-// VerificationEmailResponse.Validate if the designated constraints aren't met.
-type VerificationEmailResponseValidationError struct {
+// RetryDeadTaskResponseValidationError is the validation error returned by
+// RetryDeadTaskResponse.Validate if the designated constraints aren't met.
+type RetryDeadTaskResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -437,24 +610,24 @@ type VerificationEmailResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e VerificationEmailResponseValidationError) Field() string { return e.field }
+func (e RetryDeadTaskResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e VerificationEmailResponseValidationError) Reason() string { return e.reason }
+func (e RetryDeadTaskResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e VerificationEmailResponseValidationError) Cause() error { return e.cause }
+func (e RetryDeadTaskResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e VerificationEmailResponseValidationError) Key() bool { return e.key }
+func (e RetryDeadTaskResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e VerificationEmailResponseValidationError) ErrorName() string {
-	return "VerificationEmailResponseValidationError"
+func (e RetryDeadTaskResponseValidationError) ErrorName() string {
+	return "RetryDeadTaskResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e VerificationEmailResponseValidationError) Error() string {
+func (e RetryDeadTaskResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -466,14 +639,14 @@ func (e VerificationEmailResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sVerificationEmailResponse.%s: %s%s",
+		"invalid %sRetryDeadTaskResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = VerificationEmailResponseValidationError{}
+var _ error = RetryDeadTaskResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -481,46 +654,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = VerificationEmailResponseValidationError{}
+} = RetryDeadTaskResponseValidationError{}
 
-// Validate checks the field values on VerificationUsernameRequest with the
-// rules defined in the proto definition for this message. If any rules are
+// Validate checks the field values on DiscardDeadTaskRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *VerificationUsernameRequest) Validate() error {
+func (m *DiscardDeadTaskRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on VerificationUsernameRequest with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on DiscardDeadTaskRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// VerificationUsernameRequestMultiError, or nil if none found.
-func (m *VerificationUsernameRequest) ValidateAll() error {
+// DiscardDeadTaskRequestMultiError, or nil if none found.
+func (m *DiscardDeadTaskRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *VerificationUsernameRequest) validate(all bool) error {
+func (m *DiscardDeadTaskRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Username
+	// no validation rules for TaskId
 
 	if len(errors) > 0 {
-		return VerificationUsernameRequestMultiError(errors)
+		return DiscardDeadTaskRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// VerificationUsernameRequestMultiError is an error wrapping multiple
-// validation errors returned by VerificationUsernameRequest.ValidateAll() if
-// the designated constraints aren't met.
-type VerificationUsernameRequestMultiError []error
+// DiscardDeadTaskRequestMultiError is an error wrapping multiple validation
+// errors returned by DiscardDeadTaskRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DiscardDeadTaskRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m VerificationUsernameRequestMultiError) Error() string {
+func (m DiscardDeadTaskRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -529,12 +702,11 @@ func (m VerificationUsernameRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m VerificationUsernameRequestMultiError) AllErrors() []error { return m }
+func (m DiscardDeadTaskRequestMultiError) AllErrors() []error { return m }
 
-// VerificationUsernameRequestValidationError is the validation error returned
-// by VerificationUsernameRequest.Validate if the designated constraints
-// aren't met.
-type VerificationUsernameRequestValidationError struct {
+// DiscardDeadTaskRequestValidationError is the validation error returned by
+// DiscardDeadTaskRequest.Validate if the designated constraints aren't met.
+type DiscardDeadTaskRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -542,24 +714,24 @@ type VerificationUsernameRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e VerificationUsernameRequestValidationError) Field() string { return e.field }
+func (e DiscardDeadTaskRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e VerificationUsernameRequestValidationError) Reason() string { return e.reason }
+func (e DiscardDeadTaskRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e VerificationUsernameRequestValidationError) Cause() error { return e.cause }
+func (e DiscardDeadTaskRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e VerificationUsernameRequestValidationError) Key() bool { return e.key }
+func (e DiscardDeadTaskRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e VerificationUsernameRequestValidationError) ErrorName() string {
-	return "VerificationUsernameRequestValidationError"
+func (e DiscardDeadTaskRequestValidationError) ErrorName() string {
+	return "DiscardDeadTaskRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e VerificationUsernameRequestValidationError) Error() string {
+func (e DiscardDeadTaskRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -571,14 +743,14 @@ func (e VerificationUsernameRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sVerificationUsernameRequest.%s: %s%s",
+		"invalid %sDiscardDeadTaskRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = VerificationUsernameRequestValidationError{}
+var _ error = DiscardDeadTaskRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -586,24 +758,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = VerificationUsernameRequestValidationError{}
+} = DiscardDeadTaskRequestValidationError{}
 
-// Validate checks the field values on VerificationUsernameResponse with the
-// rules defined in the proto definition for this message. If any rules are
+// Validate checks the field values on DiscardDeadTaskResponse with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *VerificationUsernameResponse) Validate() error {
+func (m *DiscardDeadTaskResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on VerificationUsernameResponse with the
+// ValidateAll checks the field values on DiscardDeadTaskResponse with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// VerificationUsernameResponseMultiError, or nil if none found.
-func (m *VerificationUsernameResponse) ValidateAll() error {
+// DiscardDeadTaskResponseMultiError, or nil if none found.
+func (m *DiscardDeadTaskResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *VerificationUsernameResponse) validate(all bool) error {
+func (m *DiscardDeadTaskResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -611,19 +783,19 @@ func (m *VerificationUsernameResponse) validate(all bool) error {
 	var errors []error
 
 	if len(errors) > 0 {
-		return VerificationUsernameResponseMultiError(errors)
+		return DiscardDeadTaskResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// VerificationUsernameResponseMultiError is an error wrapping multiple
-// validation errors returned by VerificationUsernameResponse.ValidateAll() if
-// the designated constraints aren't met.
-type VerificationUsernameResponseMultiError []error
+// DiscardDeadTaskResponseMultiError is an error wrapping multiple validation
+// errors returned by DiscardDeadTaskResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DiscardDeadTaskResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m VerificationUsernameResponseMultiError) Error() string {
+func (m DiscardDeadTaskResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -632,12 +804,11 @@ func (m VerificationUsernameResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m VerificationUsernameResponseMultiError) AllErrors() []error { return m }
+func (m DiscardDeadTaskResponseMultiError) AllErrors() []error { return m }
 
-// VerificationUsernameResponseValidationError is the validation error returned
-// by VerificationUsernameResponse.Validate if the designated constraints
-// aren't met.
-type VerificationUsernameResponseValidationError struct {
+// DiscardDeadTaskResponseValidationError is the validation error returned by
+// DiscardDeadTaskResponse.Validate if the designated constraints aren't met.
+type DiscardDeadTaskResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -645,24 +816,24 @@ type VerificationUsernameResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e VerificationUsernameResponseValidationError) Field() string { return e.field }
+func (e DiscardDeadTaskResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e VerificationUsernameResponseValidationError) Reason() string { return e.reason }
+func (e DiscardDeadTaskResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e VerificationUsernameResponseValidationError) Cause() error { return e.cause }
+func (e DiscardDeadTaskResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e VerificationUsernameResponseValidationError) Key() bool { return e.key }
+func (e DiscardDeadTaskResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e VerificationUsernameResponseValidationError) ErrorName() string {
-	return "VerificationUsernameResponseValidationError"
+func (e DiscardDeadTaskResponseValidationError) ErrorName() string {
+	return "DiscardDeadTaskResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e VerificationUsernameResponseValidationError) Error() string {
+func (e DiscardDeadTaskResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -674,14 +845,14 @@ func (e VerificationUsernameResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sVerificationUsernameResponse.%s: %s%s",
+		"invalid %sDiscardDeadTaskResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = VerificationUsernameResponseValidationError{}
+var _ error = DiscardDeadTaskResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -689,52 +860,52 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = VerificationUsernameResponseValidationError{}
+} = DiscardDeadTaskResponseValidationError{}
 
-// Validate checks the field values on CreateUserRequest with the rules defined
+// Validate checks the field values on PrivacySettings with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *CreateUserRequest) Validate() error {
+func (m *PrivacySettings) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CreateUserRequest with the rules
+// ValidateAll checks the field values on PrivacySettings with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// CreateUserRequestMultiError, or nil if none found.
-func (m *CreateUserRequest) ValidateAll() error {
+// PrivacySettingsMultiError, or nil if none found.
+func (m *PrivacySettings) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CreateUserRequest) validate(all bool) error {
+func (m *PrivacySettings) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Username
+	// no validation rules for HideEmail
 
-	// no validation rules for Email
+	// no validation rules for HideStatus
 
-	// no validation rules for Password
+	// no validation rules for HideAvatar
 
-	// no validation rules for FullName
+	// no validation rules for HideFromSearch
 
 	if len(errors) > 0 {
-		return CreateUserRequestMultiError(errors)
+		return PrivacySettingsMultiError(errors)
 	}
 
 	return nil
 }
 
-// CreateUserRequestMultiError is an error wrapping multiple validation errors
-// returned by CreateUserRequest.ValidateAll() if the designated constraints
+// PrivacySettingsMultiError is an error wrapping multiple validation errors
+// returned by PrivacySettings.ValidateAll() if the designated constraints
 // aren't met.
-type CreateUserRequestMultiError []error
+type PrivacySettingsMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CreateUserRequestMultiError) Error() string {
+func (m PrivacySettingsMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -743,11 +914,11 @@ func (m CreateUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CreateUserRequestMultiError) AllErrors() []error { return m }
+func (m PrivacySettingsMultiError) AllErrors() []error { return m }
 
-// CreateUserRequestValidationError is the validation error returned by
-// CreateUserRequest.Validate if the designated constraints aren't met.
-type CreateUserRequestValidationError struct {
+// PrivacySettingsValidationError is the validation error returned by
+// PrivacySettings.Validate if the designated constraints aren't met.
+type PrivacySettingsValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -755,24 +926,22 @@ type CreateUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e CreateUserRequestValidationError) Field() string { return e.field }
+func (e PrivacySettingsValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CreateUserRequestValidationError) Reason() string { return e.reason }
+func (e PrivacySettingsValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CreateUserRequestValidationError) Cause() error { return e.cause }
+func (e PrivacySettingsValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CreateUserRequestValidationError) Key() bool { return e.key }
+func (e PrivacySettingsValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CreateUserRequestValidationError) ErrorName() string {
-	return "CreateUserRequestValidationError"
-}
+func (e PrivacySettingsValidationError) ErrorName() string { return "PrivacySettingsValidationError" }
 
 // Error satisfies the builtin error interface
-func (e CreateUserRequestValidationError) Error() string {
+func (e PrivacySettingsValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -784,14 +953,14 @@ func (e CreateUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCreateUserRequest.%s: %s%s",
+		"invalid %sPrivacySettings.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CreateUserRequestValidationError{}
+var _ error = PrivacySettingsValidationError{}
 
 var _ interface {
 	Field() string
@@ -799,46 +968,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CreateUserRequestValidationError{}
+} = PrivacySettingsValidationError{}
 
-// Validate checks the field values on CreateUserResponse with the rules
+// Validate checks the field values on GetPrivacySettingsRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *CreateUserResponse) Validate() error {
+func (m *GetPrivacySettingsRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CreateUserResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on GetPrivacySettingsRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// CreateUserResponseMultiError, or nil if none found.
-func (m *CreateUserResponse) ValidateAll() error {
+// GetPrivacySettingsRequestMultiError, or nil if none found.
+func (m *GetPrivacySettingsRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CreateUserResponse) validate(all bool) error {
+func (m *GetPrivacySettingsRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
 	if len(errors) > 0 {
-		return CreateUserResponseMultiError(errors)
+		return GetPrivacySettingsRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// CreateUserResponseMultiError is an error wrapping multiple validation errors
-// returned by CreateUserResponse.ValidateAll() if the designated constraints
-// aren't met.
-type CreateUserResponseMultiError []error
+// GetPrivacySettingsRequestMultiError is an error wrapping multiple validation
+// errors returned by GetPrivacySettingsRequest.ValidateAll() if the
+// designated constraints aren't met.
+type GetPrivacySettingsRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CreateUserResponseMultiError) Error() string {
+func (m GetPrivacySettingsRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -847,11 +1014,11 @@ func (m CreateUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CreateUserResponseMultiError) AllErrors() []error { return m }
+func (m GetPrivacySettingsRequestMultiError) AllErrors() []error { return m }
 
-// CreateUserResponseValidationError is the validation error returned by
-// CreateUserResponse.Validate if the designated constraints aren't met.
-type CreateUserResponseValidationError struct {
+// GetPrivacySettingsRequestValidationError is the validation error returned by
+// GetPrivacySettingsRequest.Validate if the designated constraints aren't met.
+type GetPrivacySettingsRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -859,24 +1026,24 @@ type CreateUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e CreateUserResponseValidationError) Field() string { return e.field }
+func (e GetPrivacySettingsRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CreateUserResponseValidationError) Reason() string { return e.reason }
+func (e GetPrivacySettingsRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CreateUserResponseValidationError) Cause() error { return e.cause }
+func (e GetPrivacySettingsRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CreateUserResponseValidationError) Key() bool { return e.key }
+func (e GetPrivacySettingsRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CreateUserResponseValidationError) ErrorName() string {
-	return "CreateUserResponseValidationError"
+func (e GetPrivacySettingsRequestValidationError) ErrorName() string {
+	return "GetPrivacySettingsRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e CreateUserResponseValidationError) Error() string {
+func (e GetPrivacySettingsRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -888,14 +1055,14 @@ func (e CreateUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCreateUserResponse.%s: %s%s",
+		"invalid %sGetPrivacySettingsRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CreateUserResponseValidationError{}
+var _ error = GetPrivacySettingsRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -903,47 +1070,73 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CreateUserResponseValidationError{}
+} = GetPrivacySettingsRequestValidationError{}
 
-// Validate checks the field values on LoginRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *LoginRequest) Validate() error {
+// Validate checks the field values on GetPrivacySettingsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetPrivacySettingsResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on LoginRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in LoginRequestMultiError, or
-// nil if none found.
-func (m *LoginRequest) ValidateAll() error {
+// ValidateAll checks the field values on GetPrivacySettingsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetPrivacySettingsResponseMultiError, or nil if none found.
+func (m *GetPrivacySettingsResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *LoginRequest) validate(all bool) error {
+func (m *GetPrivacySettingsResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Email
-
-	// no validation rules for Password
+	if all {
+		switch v := interface{}(m.GetSettings()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetPrivacySettingsResponseValidationError{
+					field:  "Settings",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetPrivacySettingsResponseValidationError{
+					field:  "Settings",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSettings()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetPrivacySettingsResponseValidationError{
+				field:  "Settings",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
 	if len(errors) > 0 {
-		return LoginRequestMultiError(errors)
+		return GetPrivacySettingsResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// LoginRequestMultiError is an error wrapping multiple validation errors
-// returned by LoginRequest.ValidateAll() if the designated constraints aren't met.
-type LoginRequestMultiError []error
+// GetPrivacySettingsResponseMultiError is an error wrapping multiple
+// validation errors returned by GetPrivacySettingsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type GetPrivacySettingsResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m LoginRequestMultiError) Error() string {
+func (m GetPrivacySettingsResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -952,11 +1145,11 @@ func (m LoginRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m LoginRequestMultiError) AllErrors() []error { return m }
+func (m GetPrivacySettingsResponseMultiError) AllErrors() []error { return m }
 
-// LoginRequestValidationError is the validation error returned by
-// LoginRequest.Validate if the designated constraints aren't met.
-type LoginRequestValidationError struct {
+// GetPrivacySettingsResponseValidationError is the validation error returned
+// by GetPrivacySettingsResponse.Validate if the designated constraints aren't met.
+type GetPrivacySettingsResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -964,22 +1157,24 @@ type LoginRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e LoginRequestValidationError) Field() string { return e.field }
+func (e GetPrivacySettingsResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LoginRequestValidationError) Reason() string { return e.reason }
+func (e GetPrivacySettingsResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LoginRequestValidationError) Cause() error { return e.cause }
+func (e GetPrivacySettingsResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LoginRequestValidationError) Key() bool { return e.key }
+func (e GetPrivacySettingsResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LoginRequestValidationError) ErrorName() string { return "LoginRequestValidationError" }
+func (e GetPrivacySettingsResponseValidationError) ErrorName() string {
+	return "GetPrivacySettingsResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e LoginRequestValidationError) Error() string {
+func (e GetPrivacySettingsResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -991,14 +1186,14 @@ func (e LoginRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLoginRequest.%s: %s%s",
+		"invalid %sGetPrivacySettingsResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LoginRequestValidationError{}
+var _ error = GetPrivacySettingsResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1006,46 +1201,73 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LoginRequestValidationError{}
+} = GetPrivacySettingsResponseValidationError{}
 
-// Validate checks the field values on LoginResponse with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *LoginResponse) Validate() error {
+// Validate checks the field values on UpdatePrivacySettingsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdatePrivacySettingsRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on LoginResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in LoginResponseMultiError, or
-// nil if none found.
-func (m *LoginResponse) ValidateAll() error {
+// ValidateAll checks the field values on UpdatePrivacySettingsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdatePrivacySettingsRequestMultiError, or nil if none found.
+func (m *UpdatePrivacySettingsRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *LoginResponse) validate(all bool) error {
+func (m *UpdatePrivacySettingsRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UserId
+	if all {
+		switch v := interface{}(m.GetSettings()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdatePrivacySettingsRequestValidationError{
+					field:  "Settings",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdatePrivacySettingsRequestValidationError{
+					field:  "Settings",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSettings()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdatePrivacySettingsRequestValidationError{
+				field:  "Settings",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
 	if len(errors) > 0 {
-		return LoginResponseMultiError(errors)
+		return UpdatePrivacySettingsRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// LoginResponseMultiError is an error wrapping multiple validation errors
-// returned by LoginResponse.ValidateAll() if the designated constraints
-// aren't met.
-type LoginResponseMultiError []error
+// UpdatePrivacySettingsRequestMultiError is an error wrapping multiple
+// validation errors returned by UpdatePrivacySettingsRequest.ValidateAll() if
+// the designated constraints aren't met.
+type UpdatePrivacySettingsRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m LoginResponseMultiError) Error() string {
+func (m UpdatePrivacySettingsRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1054,11 +1276,12 @@ func (m LoginResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m LoginResponseMultiError) AllErrors() []error { return m }
+func (m UpdatePrivacySettingsRequestMultiError) AllErrors() []error { return m }
 
-// LoginResponseValidationError is the validation error returned by
-// LoginResponse.Validate if the designated constraints aren't met.
-type LoginResponseValidationError struct {
+// UpdatePrivacySettingsRequestValidationError is the validation error returned
+// by UpdatePrivacySettingsRequest.Validate if the designated constraints
+// aren't met.
+type UpdatePrivacySettingsRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1066,22 +1289,24 @@ type LoginResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e LoginResponseValidationError) Field() string { return e.field }
+func (e UpdatePrivacySettingsRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LoginResponseValidationError) Reason() string { return e.reason }
+func (e UpdatePrivacySettingsRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LoginResponseValidationError) Cause() error { return e.cause }
+func (e UpdatePrivacySettingsRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LoginResponseValidationError) Key() bool { return e.key }
+func (e UpdatePrivacySettingsRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LoginResponseValidationError) ErrorName() string { return "LoginResponseValidationError" }
+func (e UpdatePrivacySettingsRequestValidationError) ErrorName() string {
+	return "UpdatePrivacySettingsRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e LoginResponseValidationError) Error() string {
+func (e UpdatePrivacySettingsRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1093,14 +1318,14 @@ func (e LoginResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLoginResponse.%s: %s%s",
+		"invalid %sUpdatePrivacySettingsRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LoginResponseValidationError{}
+var _ error = UpdatePrivacySettingsRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1108,46 +1333,73 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LoginResponseValidationError{}
+} = UpdatePrivacySettingsRequestValidationError{}
 
-// Validate checks the field values on GetUserRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *GetUserRequest) Validate() error {
+// Validate checks the field values on UpdatePrivacySettingsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdatePrivacySettingsResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUserRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in GetUserRequestMultiError,
-// or nil if none found.
-func (m *GetUserRequest) ValidateAll() error {
+// ValidateAll checks the field values on UpdatePrivacySettingsResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// UpdatePrivacySettingsResponseMultiError, or nil if none found.
+func (m *UpdatePrivacySettingsResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUserRequest) validate(all bool) error {
+func (m *UpdatePrivacySettingsResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
-	if len(errors) > 0 {
-		return GetUserRequestMultiError(errors)
-	}
-
-	return nil
-}
-
-// GetUserRequestMultiError is an error wrapping multiple validation errors
-// returned by GetUserRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetUserRequestMultiError []error
+	if all {
+		switch v := interface{}(m.GetSettings()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdatePrivacySettingsResponseValidationError{
+					field:  "Settings",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdatePrivacySettingsResponseValidationError{
+					field:  "Settings",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSettings()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdatePrivacySettingsResponseValidationError{
+				field:  "Settings",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdatePrivacySettingsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdatePrivacySettingsResponseMultiError is an error wrapping multiple
+// validation errors returned by UpdatePrivacySettingsResponse.ValidateAll()
+// if the designated constraints aren't met.
+type UpdatePrivacySettingsResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUserRequestMultiError) Error() string {
+func (m UpdatePrivacySettingsResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1156,11 +1408,12 @@ func (m GetUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUserRequestMultiError) AllErrors() []error { return m }
+func (m UpdatePrivacySettingsResponseMultiError) AllErrors() []error { return m }
 
-// GetUserRequestValidationError is the validation error returned by
-// GetUserRequest.Validate if the designated constraints aren't met.
-type GetUserRequestValidationError struct {
+// UpdatePrivacySettingsResponseValidationError is the validation error
+// returned by UpdatePrivacySettingsResponse.Validate if the designated
+// constraints aren't met.
+type UpdatePrivacySettingsResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1168,22 +1421,24 @@ type GetUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUserRequestValidationError) Field() string { return e.field }
+func (e UpdatePrivacySettingsResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUserRequestValidationError) Reason() string { return e.reason }
+func (e UpdatePrivacySettingsResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUserRequestValidationError) Cause() error { return e.cause }
+func (e UpdatePrivacySettingsResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUserRequestValidationError) Key() bool { return e.key }
+func (e UpdatePrivacySettingsResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUserRequestValidationError) ErrorName() string { return "GetUserRequestValidationError" }
+func (e UpdatePrivacySettingsResponseValidationError) ErrorName() string {
+	return "UpdatePrivacySettingsResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetUserRequestValidationError) Error() string {
+func (e UpdatePrivacySettingsResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1195,14 +1450,14 @@ func (e GetUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUserRequest.%s: %s%s",
+		"invalid %sUpdatePrivacySettingsResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUserRequestValidationError{}
+var _ error = UpdatePrivacySettingsResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1210,73 +1465,86 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUserRequestValidationError{}
+} = UpdatePrivacySettingsResponseValidationError{}
 
-// Validate checks the field values on GetUserResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetUserResponse) Validate() error {
+// Validate checks the field values on Preference with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Preference) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUserResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetUserResponseMultiError, or nil if none found.
-func (m *GetUserResponse) ValidateAll() error {
+// ValidateAll checks the field values on Preference with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PreferenceMultiError, or
+// nil if none found.
+func (m *Preference) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUserResponse) validate(all bool) error {
+func (m *Preference) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetUser()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetUserResponseValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+	// no validation rules for Key
+
+	switch v := m.Value.(type) {
+	case *Preference_BoolValue:
+		if v == nil {
+			err := PreferenceValidationError{
+				field:  "Value",
+				reason: "oneof value cannot be a typed-nil",
 			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetUserResponseValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+			if !all {
+				return err
 			}
+			errors = append(errors, err)
 		}
-	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetUserResponseValidationError{
-				field:  "User",
-				reason: "embedded message failed validation",
-				cause:  err,
+		// no validation rules for BoolValue
+	case *Preference_IntValue:
+		if v == nil {
+			err := PreferenceValidationError{
+				field:  "Value",
+				reason: "oneof value cannot be a typed-nil",
 			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for IntValue
+	case *Preference_StringValue:
+		if v == nil {
+			err := PreferenceValidationError{
+				field:  "Value",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
 		}
+		// no validation rules for StringValue
+	default:
+		_ = v // ensures v is used
 	}
 
 	if len(errors) > 0 {
-		return GetUserResponseMultiError(errors)
+		return PreferenceMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetUserResponseMultiError is an error wrapping multiple validation errors
-// returned by GetUserResponse.ValidateAll() if the designated constraints
-// aren't met.
-type GetUserResponseMultiError []error
+// PreferenceMultiError is an error wrapping multiple validation errors
+// returned by Preference.ValidateAll() if the designated constraints aren't met.
+type PreferenceMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUserResponseMultiError) Error() string {
+func (m PreferenceMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1285,11 +1553,11 @@ func (m GetUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUserResponseMultiError) AllErrors() []error { return m }
+func (m PreferenceMultiError) AllErrors() []error { return m }
 
-// GetUserResponseValidationError is the validation error returned by
-// GetUserResponse.Validate if the designated constraints aren't met.
-type GetUserResponseValidationError struct {
+// PreferenceValidationError is the validation error returned by
+// Preference.Validate if the designated constraints aren't met.
+type PreferenceValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1297,22 +1565,22 @@ type GetUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUserResponseValidationError) Field() string { return e.field }
+func (e PreferenceValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUserResponseValidationError) Reason() string { return e.reason }
+func (e PreferenceValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUserResponseValidationError) Cause() error { return e.cause }
+func (e PreferenceValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUserResponseValidationError) Key() bool { return e.key }
+func (e PreferenceValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUserResponseValidationError) ErrorName() string { return "GetUserResponseValidationError" }
+func (e PreferenceValidationError) ErrorName() string { return "PreferenceValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetUserResponseValidationError) Error() string {
+func (e PreferenceValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1324,14 +1592,14 @@ func (e GetUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUserResponse.%s: %s%s",
+		"invalid %sPreference.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUserResponseValidationError{}
+var _ error = PreferenceValidationError{}
 
 var _ interface {
 	Field() string
@@ -1339,50 +1607,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUserResponseValidationError{}
+} = PreferenceValidationError{}
 
-// Validate checks the field values on SearchUsersRequest with the rules
+// Validate checks the field values on GetPreferencesRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *SearchUsersRequest) Validate() error {
+func (m *GetPreferencesRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SearchUsersRequest with the rules
+// ValidateAll checks the field values on GetPreferencesRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// SearchUsersRequestMultiError, or nil if none found.
-func (m *SearchUsersRequest) ValidateAll() error {
+// GetPreferencesRequestMultiError, or nil if none found.
+func (m *GetPreferencesRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SearchUsersRequest) validate(all bool) error {
+func (m *GetPreferencesRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Name
-
-	// no validation rules for Limit
-
-	// no validation rules for Offset
-
 	if len(errors) > 0 {
-		return SearchUsersRequestMultiError(errors)
+		return GetPreferencesRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// SearchUsersRequestMultiError is an error wrapping multiple validation errors
-// returned by SearchUsersRequest.ValidateAll() if the designated constraints
-// aren't met.
-type SearchUsersRequestMultiError []error
+// GetPreferencesRequestMultiError is an error wrapping multiple validation
+// errors returned by GetPreferencesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetPreferencesRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SearchUsersRequestMultiError) Error() string {
+func (m GetPreferencesRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1391,11 +1653,11 @@ func (m SearchUsersRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SearchUsersRequestMultiError) AllErrors() []error { return m }
+func (m GetPreferencesRequestMultiError) AllErrors() []error { return m }
 
-// SearchUsersRequestValidationError is the validation error returned by
-// SearchUsersRequest.Validate if the designated constraints aren't met.
-type SearchUsersRequestValidationError struct {
+// GetPreferencesRequestValidationError is the validation error returned by
+// GetPreferencesRequest.Validate if the designated constraints aren't met.
+type GetPreferencesRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1403,24 +1665,24 @@ type SearchUsersRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e SearchUsersRequestValidationError) Field() string { return e.field }
+func (e GetPreferencesRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SearchUsersRequestValidationError) Reason() string { return e.reason }
+func (e GetPreferencesRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SearchUsersRequestValidationError) Cause() error { return e.cause }
+func (e GetPreferencesRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SearchUsersRequestValidationError) Key() bool { return e.key }
+func (e GetPreferencesRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SearchUsersRequestValidationError) ErrorName() string {
-	return "SearchUsersRequestValidationError"
+func (e GetPreferencesRequestValidationError) ErrorName() string {
+	return "GetPreferencesRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e SearchUsersRequestValidationError) Error() string {
+func (e GetPreferencesRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1432,14 +1694,14 @@ func (e SearchUsersRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSearchUsersRequest.%s: %s%s",
+		"invalid %sGetPreferencesRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SearchUsersRequestValidationError{}
+var _ error = GetPreferencesRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1447,47 +1709,47 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SearchUsersRequestValidationError{}
+} = GetPreferencesRequestValidationError{}
 
-// Validate checks the field values on SearchUsersResponse with the rules
+// Validate checks the field values on GetPreferencesResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *SearchUsersResponse) Validate() error {
+func (m *GetPreferencesResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SearchUsersResponse with the rules
+// ValidateAll checks the field values on GetPreferencesResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// SearchUsersResponseMultiError, or nil if none found.
-func (m *SearchUsersResponse) ValidateAll() error {
+// GetPreferencesResponseMultiError, or nil if none found.
+func (m *GetPreferencesResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SearchUsersResponse) validate(all bool) error {
+func (m *GetPreferencesResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetUsers() {
+	for idx, item := range m.GetPreferences() {
 		_, _ = idx, item
 
 		if all {
 			switch v := interface{}(item).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, SearchUsersResponseValidationError{
-						field:  fmt.Sprintf("Users[%v]", idx),
+					errors = append(errors, GetPreferencesResponseValidationError{
+						field:  fmt.Sprintf("Preferences[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, SearchUsersResponseValidationError{
-						field:  fmt.Sprintf("Users[%v]", idx),
+					errors = append(errors, GetPreferencesResponseValidationError{
+						field:  fmt.Sprintf("Preferences[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
@@ -1495,8 +1757,8 @@ func (m *SearchUsersResponse) validate(all bool) error {
 			}
 		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return SearchUsersResponseValidationError{
-					field:  fmt.Sprintf("Users[%v]", idx),
+				return GetPreferencesResponseValidationError{
+					field:  fmt.Sprintf("Preferences[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
@@ -1505,22 +1767,20 @@ func (m *SearchUsersResponse) validate(all bool) error {
 
 	}
 
-	// no validation rules for Total
-
 	if len(errors) > 0 {
-		return SearchUsersResponseMultiError(errors)
+		return GetPreferencesResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// SearchUsersResponseMultiError is an error wrapping multiple validation
-// errors returned by SearchUsersResponse.ValidateAll() if the designated
+// GetPreferencesResponseMultiError is an error wrapping multiple validation
+// errors returned by GetPreferencesResponse.ValidateAll() if the designated
 // constraints aren't met.
-type SearchUsersResponseMultiError []error
+type GetPreferencesResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SearchUsersResponseMultiError) Error() string {
+func (m GetPreferencesResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1529,11 +1789,11 @@ func (m SearchUsersResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SearchUsersResponseMultiError) AllErrors() []error { return m }
+func (m GetPreferencesResponseMultiError) AllErrors() []error { return m }
 
-// SearchUsersResponseValidationError is the validation error returned by
-// SearchUsersResponse.Validate if the designated constraints aren't met.
-type SearchUsersResponseValidationError struct {
+// GetPreferencesResponseValidationError is the validation error returned by
+// GetPreferencesResponse.Validate if the designated constraints aren't met.
+type GetPreferencesResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1541,24 +1801,24 @@ type SearchUsersResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e SearchUsersResponseValidationError) Field() string { return e.field }
+func (e GetPreferencesResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SearchUsersResponseValidationError) Reason() string { return e.reason }
+func (e GetPreferencesResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SearchUsersResponseValidationError) Cause() error { return e.cause }
+func (e GetPreferencesResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SearchUsersResponseValidationError) Key() bool { return e.key }
+func (e GetPreferencesResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SearchUsersResponseValidationError) ErrorName() string {
-	return "SearchUsersResponseValidationError"
+func (e GetPreferencesResponseValidationError) ErrorName() string {
+	return "GetPreferencesResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e SearchUsersResponseValidationError) Error() string {
+func (e GetPreferencesResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1570,14 +1830,14 @@ func (e SearchUsersResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSearchUsersResponse.%s: %s%s",
+		"invalid %sGetPreferencesResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SearchUsersResponseValidationError{}
+var _ error = GetPreferencesResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1585,44 +1845,78 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SearchUsersResponseValidationError{}
+} = GetPreferencesResponseValidationError{}
 
-// Validate checks the field values on LogoutRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *LogoutRequest) Validate() error {
+// Validate checks the field values on SetPreferencesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetPreferencesRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on LogoutRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in LogoutRequestMultiError, or
-// nil if none found.
-func (m *LogoutRequest) ValidateAll() error {
+// ValidateAll checks the field values on SetPreferencesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetPreferencesRequestMultiError, or nil if none found.
+func (m *SetPreferencesRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *LogoutRequest) validate(all bool) error {
+func (m *SetPreferencesRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	for idx, item := range m.GetPreferences() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SetPreferencesRequestValidationError{
+						field:  fmt.Sprintf("Preferences[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SetPreferencesRequestValidationError{
+						field:  fmt.Sprintf("Preferences[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SetPreferencesRequestValidationError{
+					field:  fmt.Sprintf("Preferences[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
-		return LogoutRequestMultiError(errors)
+		return SetPreferencesRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// LogoutRequestMultiError is an error wrapping multiple validation errors
-// returned by LogoutRequest.ValidateAll() if the designated constraints
-// aren't met.
-type LogoutRequestMultiError []error
+// SetPreferencesRequestMultiError is an error wrapping multiple validation
+// errors returned by SetPreferencesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SetPreferencesRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m LogoutRequestMultiError) Error() string {
+func (m SetPreferencesRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1631,11 +1925,11 @@ func (m LogoutRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m LogoutRequestMultiError) AllErrors() []error { return m }
+func (m SetPreferencesRequestMultiError) AllErrors() []error { return m }
 
-// LogoutRequestValidationError is the validation error returned by
-// LogoutRequest.Validate if the designated constraints aren't met.
-type LogoutRequestValidationError struct {
+// SetPreferencesRequestValidationError is the validation error returned by
+// SetPreferencesRequest.Validate if the designated constraints aren't met.
+type SetPreferencesRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1643,22 +1937,24 @@ type LogoutRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e LogoutRequestValidationError) Field() string { return e.field }
+func (e SetPreferencesRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LogoutRequestValidationError) Reason() string { return e.reason }
+func (e SetPreferencesRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LogoutRequestValidationError) Cause() error { return e.cause }
+func (e SetPreferencesRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LogoutRequestValidationError) Key() bool { return e.key }
+func (e SetPreferencesRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LogoutRequestValidationError) ErrorName() string { return "LogoutRequestValidationError" }
+func (e SetPreferencesRequestValidationError) ErrorName() string {
+	return "SetPreferencesRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e LogoutRequestValidationError) Error() string {
+func (e SetPreferencesRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1670,14 +1966,14 @@ func (e LogoutRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLogoutRequest.%s: %s%s",
+		"invalid %sSetPreferencesRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LogoutRequestValidationError{}
+var _ error = SetPreferencesRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1685,44 +1981,3746 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LogoutRequestValidationError{}
+} = SetPreferencesRequestValidationError{}
 
-// Validate checks the field values on LogoutResponse with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *LogoutResponse) Validate() error {
+// Validate checks the field values on SetPreferencesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetPreferencesResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on LogoutResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in LogoutResponseMultiError,
-// or nil if none found.
-func (m *LogoutResponse) ValidateAll() error {
+// ValidateAll checks the field values on SetPreferencesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetPreferencesResponseMultiError, or nil if none found.
+func (m *SetPreferencesResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *LogoutResponse) validate(all bool) error {
+func (m *SetPreferencesResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(errors) > 0 {
-		return LogoutResponseMultiError(errors)
-	}
-
-	return nil
-}
+	for idx, item := range m.GetPreferences() {
+		_, _ = idx, item
 
-// LogoutResponseMultiError is an error wrapping multiple validation errors
-// returned by LogoutResponse.ValidateAll() if the designated constraints
-// aren't met.
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SetPreferencesResponseValidationError{
+						field:  fmt.Sprintf("Preferences[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SetPreferencesResponseValidationError{
+						field:  fmt.Sprintf("Preferences[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SetPreferencesResponseValidationError{
+					field:  fmt.Sprintf("Preferences[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return SetPreferencesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetPreferencesResponseMultiError is an error wrapping multiple validation
+// errors returned by SetPreferencesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SetPreferencesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetPreferencesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetPreferencesResponseMultiError) AllErrors() []error { return m }
+
+// SetPreferencesResponseValidationError is the validation error returned by
+// SetPreferencesResponse.Validate if the designated constraints aren't met.
+type SetPreferencesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetPreferencesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetPreferencesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetPreferencesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetPreferencesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetPreferencesResponseValidationError) ErrorName() string {
+	return "SetPreferencesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SetPreferencesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetPreferencesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetPreferencesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetPreferencesResponseValidationError{}
+
+// Validate checks the field values on BatchGetUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetUsersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetUsersRequestMultiError, or nil if none found.
+func (m *BatchGetUsersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetUsersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return BatchGetUsersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetUsersRequestMultiError is an error wrapping multiple validation
+// errors returned by BatchGetUsersRequest.ValidateAll() if the designated
+// constraints aren't met.
+type BatchGetUsersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetUsersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetUsersRequestMultiError) AllErrors() []error { return m }
+
+// BatchGetUsersRequestValidationError is the validation error returned by
+// BatchGetUsersRequest.Validate if the designated constraints aren't met.
+type BatchGetUsersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetUsersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetUsersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetUsersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetUsersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetUsersRequestValidationError) ErrorName() string {
+	return "BatchGetUsersRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetUsersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetUsersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetUsersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetUsersRequestValidationError{}
+
+// Validate checks the field values on BatchGetUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetUsersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetUsersResponseMultiError, or nil if none found.
+func (m *BatchGetUsersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetUsersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	{
+		sorted_keys := make([]string, len(m.GetUsers()))
+		i := 0
+		for key := range m.GetUsers() {
+			sorted_keys[i] = key
+			i++
+		}
+		sort.Slice(sorted_keys, func(i, j int) bool { return sorted_keys[i] < sorted_keys[j] })
+		for _, key := range sorted_keys {
+			val := m.GetUsers()[key]
+			_ = val
+
+			// no validation rules for Users[key]
+
+			if all {
+				switch v := interface{}(val).(type) {
+				case interface{ ValidateAll() error }:
+					if err := v.ValidateAll(); err != nil {
+						errors = append(errors, BatchGetUsersResponseValidationError{
+							field:  fmt.Sprintf("Users[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				case interface{ Validate() error }:
+					if err := v.Validate(); err != nil {
+						errors = append(errors, BatchGetUsersResponseValidationError{
+							field:  fmt.Sprintf("Users[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				}
+			} else if v, ok := interface{}(val).(interface{ Validate() error }); ok {
+				if err := v.Validate(); err != nil {
+					return BatchGetUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", key),
+						reason: "embedded message failed validation",
+						cause:  err,
+					}
+				}
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return BatchGetUsersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetUsersResponseMultiError is an error wrapping multiple validation
+// errors returned by BatchGetUsersResponse.ValidateAll() if the designated
+// constraints aren't met.
+type BatchGetUsersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetUsersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetUsersResponseMultiError) AllErrors() []error { return m }
+
+// BatchGetUsersResponseValidationError is the validation error returned by
+// BatchGetUsersResponse.Validate if the designated constraints aren't met.
+type BatchGetUsersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetUsersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetUsersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetUsersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetUsersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetUsersResponseValidationError) ErrorName() string {
+	return "BatchGetUsersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetUsersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetUsersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetUsersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetUsersResponseValidationError{}
+
+// Validate checks the field values on GetStorageUsageRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetStorageUsageRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetStorageUsageRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetStorageUsageRequestMultiError, or nil if none found.
+func (m *GetStorageUsageRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetStorageUsageRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetStorageUsageRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetStorageUsageRequestMultiError is an error wrapping multiple validation
+// errors returned by GetStorageUsageRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetStorageUsageRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetStorageUsageRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetStorageUsageRequestMultiError) AllErrors() []error { return m }
+
+// GetStorageUsageRequestValidationError is the validation error returned by
+// GetStorageUsageRequest.Validate if the designated constraints aren't met.
+type GetStorageUsageRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetStorageUsageRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetStorageUsageRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetStorageUsageRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetStorageUsageRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetStorageUsageRequestValidationError) ErrorName() string {
+	return "GetStorageUsageRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetStorageUsageRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetStorageUsageRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetStorageUsageRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetStorageUsageRequestValidationError{}
+
+// Validate checks the field values on GetStorageUsageResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetStorageUsageResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetStorageUsageResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetStorageUsageResponseMultiError, or nil if none found.
+func (m *GetStorageUsageResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetStorageUsageResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UsedBytes
+
+	// no validation rules for QuotaBytes
+
+	if len(errors) > 0 {
+		return GetStorageUsageResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetStorageUsageResponseMultiError is an error wrapping multiple validation
+// errors returned by GetStorageUsageResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetStorageUsageResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetStorageUsageResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetStorageUsageResponseMultiError) AllErrors() []error { return m }
+
+// GetStorageUsageResponseValidationError is the validation error returned by
+// GetStorageUsageResponse.Validate if the designated constraints aren't met.
+type GetStorageUsageResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetStorageUsageResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetStorageUsageResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetStorageUsageResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetStorageUsageResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetStorageUsageResponseValidationError) ErrorName() string {
+	return "GetStorageUsageResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetStorageUsageResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetStorageUsageResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetStorageUsageResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetStorageUsageResponseValidationError{}
+
+// Validate checks the field values on GetUsersByIDsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUsersByIDsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUsersByIDsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUsersByIDsRequestMultiError, or nil if none found.
+func (m *GetUsersByIDsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUsersByIDsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetUsersByIDsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUsersByIDsRequestMultiError is an error wrapping multiple validation
+// errors returned by GetUsersByIDsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetUsersByIDsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUsersByIDsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUsersByIDsRequestMultiError) AllErrors() []error { return m }
+
+// GetUsersByIDsRequestValidationError is the validation error returned by
+// GetUsersByIDsRequest.Validate if the designated constraints aren't met.
+type GetUsersByIDsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUsersByIDsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUsersByIDsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUsersByIDsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUsersByIDsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUsersByIDsRequestValidationError) ErrorName() string {
+	return "GetUsersByIDsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetUsersByIDsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUsersByIDsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUsersByIDsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUsersByIDsRequestValidationError{}
+
+// Validate checks the field values on GetUsersByIDsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUsersByIDsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUsersByIDsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUsersByIDsResponseMultiError, or nil if none found.
+func (m *GetUsersByIDsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUsersByIDsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetResult() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetUsersByIDsResponseValidationError{
+						field:  fmt.Sprintf("Result[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetUsersByIDsResponseValidationError{
+						field:  fmt.Sprintf("Result[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetUsersByIDsResponseValidationError{
+					field:  fmt.Sprintf("Result[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetUsersByIDsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUsersByIDsResponseMultiError is an error wrapping multiple validation
+// errors returned by GetUsersByIDsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetUsersByIDsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUsersByIDsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUsersByIDsResponseMultiError) AllErrors() []error { return m }
+
+// GetUsersByIDsResponseValidationError is the validation error returned by
+// GetUsersByIDsResponse.Validate if the designated constraints aren't met.
+type GetUsersByIDsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUsersByIDsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUsersByIDsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUsersByIDsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUsersByIDsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUsersByIDsResponseValidationError) ErrorName() string {
+	return "GetUsersByIDsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetUsersByIDsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUsersByIDsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUsersByIDsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUsersByIDsResponseValidationError{}
+
+// Validate checks the field values on VerificationEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerificationEmailRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerificationEmailRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerificationEmailRequestMultiError, or nil if none found.
+func (m *VerificationEmailRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerificationEmailRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Email
+
+	if len(errors) > 0 {
+		return VerificationEmailRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerificationEmailRequestMultiError is an error wrapping multiple validation
+// errors returned by VerificationEmailRequest.ValidateAll() if the designated
+// constraints aren't met.
+type VerificationEmailRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerificationEmailRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerificationEmailRequestMultiError) AllErrors() []error { return m }
+
+// VerificationEmailRequestValidationError is the validation error returned by
+// VerificationEmailRequest.Validate if the designated constraints aren't met.
+type VerificationEmailRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerificationEmailRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerificationEmailRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerificationEmailRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerificationEmailRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerificationEmailRequestValidationError) ErrorName() string {
+	return "VerificationEmailRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerificationEmailRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerificationEmailRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerificationEmailRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerificationEmailRequestValidationError{}
+
+// Validate checks the field values on VerificationEmailResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerificationEmailResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerificationEmailResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerificationEmailResponseMultiError, or nil if none found.
+func (m *VerificationEmailResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerificationEmailResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return VerificationEmailResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerificationEmailResponseMultiError is an error wrapping multiple validation
+// errors returned by VerificationEmailResponse.ValidateAll() if the
+// designated constraints aren't met.
+type VerificationEmailResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerificationEmailResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerificationEmailResponseMultiError) AllErrors() []error { return m }
+
+// VerificationEmailResponseValidationError is the validation error returned by
+// VerificationEmailResponse.Validate if the designated constraints aren't met.
+type VerificationEmailResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerificationEmailResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerificationEmailResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerificationEmailResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerificationEmailResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerificationEmailResponseValidationError) ErrorName() string {
+	return "VerificationEmailResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerificationEmailResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerificationEmailResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerificationEmailResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerificationEmailResponseValidationError{}
+
+// Validate checks the field values on VerificationUsernameRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerificationUsernameRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerificationUsernameRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerificationUsernameRequestMultiError, or nil if none found.
+func (m *VerificationUsernameRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerificationUsernameRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Username
+
+	if len(errors) > 0 {
+		return VerificationUsernameRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerificationUsernameRequestMultiError is an error wrapping multiple
+// validation errors returned by VerificationUsernameRequest.ValidateAll() if
+// the designated constraints aren't met.
+type VerificationUsernameRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerificationUsernameRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerificationUsernameRequestMultiError) AllErrors() []error { return m }
+
+// VerificationUsernameRequestValidationError is the validation error returned
+// by VerificationUsernameRequest.Validate if the designated constraints
+// aren't met.
+type VerificationUsernameRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerificationUsernameRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerificationUsernameRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerificationUsernameRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerificationUsernameRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerificationUsernameRequestValidationError) ErrorName() string {
+	return "VerificationUsernameRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerificationUsernameRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerificationUsernameRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerificationUsernameRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerificationUsernameRequestValidationError{}
+
+// Validate checks the field values on VerificationUsernameResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerificationUsernameResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerificationUsernameResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerificationUsernameResponseMultiError, or nil if none found.
+func (m *VerificationUsernameResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerificationUsernameResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return VerificationUsernameResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerificationUsernameResponseMultiError is an error wrapping multiple
+// validation errors returned by VerificationUsernameResponse.ValidateAll() if
+// the designated constraints aren't met.
+type VerificationUsernameResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerificationUsernameResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerificationUsernameResponseMultiError) AllErrors() []error { return m }
+
+// VerificationUsernameResponseValidationError is the validation error returned
+// by VerificationUsernameResponse.Validate if the designated constraints
+// aren't met.
+type VerificationUsernameResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerificationUsernameResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerificationUsernameResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerificationUsernameResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerificationUsernameResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerificationUsernameResponseValidationError) ErrorName() string {
+	return "VerificationUsernameResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerificationUsernameResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerificationUsernameResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerificationUsernameResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerificationUsernameResponseValidationError{}
+
+// Validate checks the field values on CreateUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateUserRequestMultiError, or nil if none found.
+func (m *CreateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Username
+
+	// no validation rules for Email
+
+	// no validation rules for Password
+
+	// no validation rules for FullName
+
+	if len(errors) > 0 {
+		return CreateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateUserRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateUserRequestMultiError) AllErrors() []error { return m }
+
+// CreateUserRequestValidationError is the validation error returned by
+// CreateUserRequest.Validate if the designated constraints aren't met.
+type CreateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateUserRequestValidationError) ErrorName() string {
+	return "CreateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateUserRequestValidationError{}
+
+// Validate checks the field values on CreateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateUserResponseMultiError, or nil if none found.
+func (m *CreateUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return CreateUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateUserResponseMultiError is an error wrapping multiple validation errors
+// returned by CreateUserResponse.ValidateAll() if the designated constraints
+// aren't met.
+type CreateUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateUserResponseMultiError) AllErrors() []error { return m }
+
+// CreateUserResponseValidationError is the validation error returned by
+// CreateUserResponse.Validate if the designated constraints aren't met.
+type CreateUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateUserResponseValidationError) ErrorName() string {
+	return "CreateUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateUserResponseValidationError{}
+
+// Validate checks the field values on LoginRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LoginRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoginRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LoginRequestMultiError, or
+// nil if none found.
+func (m *LoginRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoginRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Email
+
+	// no validation rules for Password
+
+	if len(errors) > 0 {
+		return LoginRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoginRequestMultiError is an error wrapping multiple validation errors
+// returned by LoginRequest.ValidateAll() if the designated constraints aren't met.
+type LoginRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoginRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoginRequestMultiError) AllErrors() []error { return m }
+
+// LoginRequestValidationError is the validation error returned by
+// LoginRequest.Validate if the designated constraints aren't met.
+type LoginRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoginRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoginRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoginRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoginRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoginRequestValidationError) ErrorName() string { return "LoginRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoginRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoginRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoginRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoginRequestValidationError{}
+
+// Validate checks the field values on LoginResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LoginResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoginResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LoginResponseMultiError, or
+// nil if none found.
+func (m *LoginResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoginResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	if len(errors) > 0 {
+		return LoginResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoginResponseMultiError is an error wrapping multiple validation errors
+// returned by LoginResponse.ValidateAll() if the designated constraints
+// aren't met.
+type LoginResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoginResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoginResponseMultiError) AllErrors() []error { return m }
+
+// LoginResponseValidationError is the validation error returned by
+// LoginResponse.Validate if the designated constraints aren't met.
+type LoginResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoginResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoginResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoginResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoginResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoginResponseValidationError) ErrorName() string { return "LoginResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoginResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoginResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoginResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoginResponseValidationError{}
+
+// Validate checks the field values on GetUserRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetUserRequestMultiError,
+// or nil if none found.
+func (m *GetUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return GetUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUserRequestMultiError is an error wrapping multiple validation errors
+// returned by GetUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserRequestMultiError) AllErrors() []error { return m }
+
+// GetUserRequestValidationError is the validation error returned by
+// GetUserRequest.Validate if the designated constraints aren't met.
+type GetUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserRequestValidationError) ErrorName() string { return "GetUserRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserRequestValidationError{}
+
+// Validate checks the field values on GetUserResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUserResponseMultiError, or nil if none found.
+func (m *GetUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetUserResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUserResponseMultiError is an error wrapping multiple validation errors
+// returned by GetUserResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserResponseMultiError) AllErrors() []error { return m }
+
+// GetUserResponseValidationError is the validation error returned by
+// GetUserResponse.Validate if the designated constraints aren't met.
+type GetUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserResponseValidationError) ErrorName() string { return "GetUserResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserResponseValidationError{}
+
+// Validate checks the field values on ResolveUsernameRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResolveUsernameRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResolveUsernameRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResolveUsernameRequestMultiError, or nil if none found.
+func (m *ResolveUsernameRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResolveUsernameRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Username
+
+	if len(errors) > 0 {
+		return ResolveUsernameRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResolveUsernameRequestMultiError is an error wrapping multiple validation
+// errors returned by ResolveUsernameRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ResolveUsernameRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResolveUsernameRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResolveUsernameRequestMultiError) AllErrors() []error { return m }
+
+// ResolveUsernameRequestValidationError is the validation error returned by
+// ResolveUsernameRequest.Validate if the designated constraints aren't met.
+type ResolveUsernameRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResolveUsernameRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResolveUsernameRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResolveUsernameRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResolveUsernameRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResolveUsernameRequestValidationError) ErrorName() string {
+	return "ResolveUsernameRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResolveUsernameRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResolveUsernameRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResolveUsernameRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResolveUsernameRequestValidationError{}
+
+// Validate checks the field values on ResolveUsernameResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResolveUsernameResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResolveUsernameResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResolveUsernameResponseMultiError, or nil if none found.
+func (m *ResolveUsernameResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResolveUsernameResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ResolveUsernameResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ResolveUsernameResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ResolveUsernameResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Renamed
+
+	if len(errors) > 0 {
+		return ResolveUsernameResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResolveUsernameResponseMultiError is an error wrapping multiple validation
+// errors returned by ResolveUsernameResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ResolveUsernameResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResolveUsernameResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResolveUsernameResponseMultiError) AllErrors() []error { return m }
+
+// ResolveUsernameResponseValidationError is the validation error returned by
+// ResolveUsernameResponse.Validate if the designated constraints aren't met.
+type ResolveUsernameResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResolveUsernameResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResolveUsernameResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResolveUsernameResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResolveUsernameResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResolveUsernameResponseValidationError) ErrorName() string {
+	return "ResolveUsernameResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResolveUsernameResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResolveUsernameResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResolveUsernameResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResolveUsernameResponseValidationError{}
+
+// Validate checks the field values on SearchUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SearchUsersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchUsersRequestMultiError, or nil if none found.
+func (m *SearchUsersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchUsersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Limit
+
+	// no validation rules for Offset
+
+	// no validation rules for Mode
+
+	if len(errors) > 0 {
+		return SearchUsersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchUsersRequestMultiError is an error wrapping multiple validation errors
+// returned by SearchUsersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SearchUsersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchUsersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchUsersRequestMultiError) AllErrors() []error { return m }
+
+// SearchUsersRequestValidationError is the validation error returned by
+// SearchUsersRequest.Validate if the designated constraints aren't met.
+type SearchUsersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchUsersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchUsersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchUsersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchUsersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchUsersRequestValidationError) ErrorName() string {
+	return "SearchUsersRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchUsersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchUsersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchUsersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchUsersRequestValidationError{}
+
+// Validate checks the field values on SearchUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SearchUsersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchUsersResponseMultiError, or nil if none found.
+func (m *SearchUsersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchUsersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SearchUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SearchUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SearchUsersResponseValidationError{
+					field:  fmt.Sprintf("Users[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return SearchUsersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchUsersResponseMultiError is an error wrapping multiple validation
+// errors returned by SearchUsersResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SearchUsersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchUsersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchUsersResponseMultiError) AllErrors() []error { return m }
+
+// SearchUsersResponseValidationError is the validation error returned by
+// SearchUsersResponse.Validate if the designated constraints aren't met.
+type SearchUsersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchUsersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchUsersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchUsersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchUsersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchUsersResponseValidationError) ErrorName() string {
+	return "SearchUsersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchUsersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchUsersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchUsersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchUsersResponseValidationError{}
+
+// Validate checks the field values on LogoutRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogoutRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LogoutRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogoutRequestMultiError, or
+// nil if none found.
+func (m *LogoutRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LogoutRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return LogoutRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// LogoutRequestMultiError is an error wrapping multiple validation errors
+// returned by LogoutRequest.ValidateAll() if the designated constraints
+// aren't met.
+type LogoutRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogoutRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogoutRequestMultiError) AllErrors() []error { return m }
+
+// LogoutRequestValidationError is the validation error returned by
+// LogoutRequest.Validate if the designated constraints aren't met.
+type LogoutRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogoutRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogoutRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogoutRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogoutRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogoutRequestValidationError) ErrorName() string { return "LogoutRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogoutRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogoutRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogoutRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogoutRequestValidationError{}
+
+// Validate checks the field values on LogoutResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogoutResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LogoutResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogoutResponseMultiError,
+// or nil if none found.
+func (m *LogoutResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LogoutResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return LogoutResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// LogoutResponseMultiError is an error wrapping multiple validation errors
+// returned by LogoutResponse.ValidateAll() if the designated constraints
+// aren't met.
 type LogoutResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m LogoutResponseMultiError) Error() string {
+func (m LogoutResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogoutResponseMultiError) AllErrors() []error { return m }
+
+// LogoutResponseValidationError is the validation error returned by
+// LogoutResponse.Validate if the designated constraints aren't met.
+type LogoutResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogoutResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogoutResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogoutResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogoutResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogoutResponseValidationError) ErrorName() string { return "LogoutResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogoutResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogoutResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogoutResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogoutResponseValidationError{}
+
+// Validate checks the field values on UpdatePasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdatePasswordRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdatePasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdatePasswordRequestMultiError, or nil if none found.
+func (m *UpdatePasswordRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdatePasswordRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Old
+
+	// no validation rules for New
+
+	if len(errors) > 0 {
+		return UpdatePasswordRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdatePasswordRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdatePasswordRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdatePasswordRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdatePasswordRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdatePasswordRequestMultiError) AllErrors() []error { return m }
+
+// UpdatePasswordRequestValidationError is the validation error returned by
+// UpdatePasswordRequest.Validate if the designated constraints aren't met.
+type UpdatePasswordRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdatePasswordRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdatePasswordRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdatePasswordRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdatePasswordRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdatePasswordRequestValidationError) ErrorName() string {
+	return "UpdatePasswordRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdatePasswordRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdatePasswordRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdatePasswordRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdatePasswordRequestValidationError{}
+
+// Validate checks the field values on UpdatePasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdatePasswordResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdatePasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdatePasswordResponseMultiError, or nil if none found.
+func (m *UpdatePasswordResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdatePasswordResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return UpdatePasswordResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdatePasswordResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdatePasswordResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UpdatePasswordResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdatePasswordResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdatePasswordResponseMultiError) AllErrors() []error { return m }
+
+// UpdatePasswordResponseValidationError is the validation error returned by
+// UpdatePasswordResponse.Validate if the designated constraints aren't met.
+type UpdatePasswordResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdatePasswordResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdatePasswordResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdatePasswordResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdatePasswordResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdatePasswordResponseValidationError) ErrorName() string {
+	return "UpdatePasswordResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdatePasswordResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdatePasswordResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdatePasswordResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdatePasswordResponseValidationError{}
+
+// Validate checks the field values on UpdateUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserRequestMultiError, or nil if none found.
+func (m *UpdateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Username
+
+	// no validation rules for AvatarId
+
+	// no validation rules for FullName
+
+	if all {
+		switch v := interface{}(m.GetProfile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateUserRequestValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateUserRequestValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetProfile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateUserRequestValidationError{
+				field:  "Profile",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserRequestMultiError) AllErrors() []error { return m }
+
+// UpdateUserRequestValidationError is the validation error returned by
+// UpdateUserRequest.Validate if the designated constraints aren't met.
+type UpdateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserRequestValidationError) ErrorName() string {
+	return "UpdateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserRequestValidationError{}
+
+// Validate checks the field values on UpdateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserResponseMultiError, or nil if none found.
+func (m *UpdateUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return UpdateUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserResponseMultiError is an error wrapping multiple validation errors
+// returned by UpdateUserResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserResponseMultiError) AllErrors() []error { return m }
+
+// UpdateUserResponseValidationError is the validation error returned by
+// UpdateUserResponse.Validate if the designated constraints aren't met.
+type UpdateUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserResponseValidationError) ErrorName() string {
+	return "UpdateUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserResponseValidationError{}
+
+// Validate checks the field values on User with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *User) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on User with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in UserMultiError, or nil if none found.
+func (m *User) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *User) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Username
+
+	// no validation rules for Email
+
+	// no validation rules for AvatarId
+
+	// no validation rules for Kind
+
+	// no validation rules for FullName
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetProfile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetProfile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserValidationError{
+				field:  "Profile",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UserMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserMultiError is an error wrapping multiple validation errors returned by
+// User.ValidateAll() if the designated constraints aren't met.
+type UserMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserMultiError) AllErrors() []error { return m }
+
+// UserValidationError is the validation error returned by User.Validate if the
+// designated constraints aren't met.
+type UserValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserValidationError) ErrorName() string { return "UserValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUser.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserValidationError{}
+
+// Validate checks the field values on Profile with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Profile) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Profile with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in ProfileMultiError, or nil if none found.
+func (m *Profile) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Profile) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Bio
+
+	// no validation rules for Locale
+
+	// no validation rules for Timezone
+
+	if all {
+		switch v := interface{}(m.GetBirthday()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ProfileValidationError{
+					field:  "Birthday",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ProfileValidationError{
+					field:  "Birthday",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBirthday()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ProfileValidationError{
+				field:  "Birthday",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ProfileMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProfileMultiError is an error wrapping multiple validation errors returned
+// by Profile.ValidateAll() if the designated constraints aren't met.
+type ProfileMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProfileMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProfileMultiError) AllErrors() []error { return m }
+
+// ProfileValidationError is the validation error returned by Profile.Validate
+// if the designated constraints aren't met.
+type ProfileValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProfileValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProfileValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProfileValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProfileValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProfileValidationError) ErrorName() string { return "ProfileValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProfileValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProfile.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProfileValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProfileValidationError{}
+
+// Validate checks the field values on RemoveAvatarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RemoveAvatarRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RemoveAvatarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RemoveAvatarRequestMultiError, or nil if none found.
+func (m *RemoveAvatarRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RemoveAvatarRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for FileId
+
+	if len(errors) > 0 {
+		return RemoveAvatarRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RemoveAvatarRequestMultiError is an error wrapping multiple validation
+// errors returned by RemoveAvatarRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RemoveAvatarRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RemoveAvatarRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RemoveAvatarRequestMultiError) AllErrors() []error { return m }
+
+// RemoveAvatarRequestValidationError is the validation error returned by
+// RemoveAvatarRequest.Validate if the designated constraints aren't met.
+type RemoveAvatarRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RemoveAvatarRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RemoveAvatarRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RemoveAvatarRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RemoveAvatarRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RemoveAvatarRequestValidationError) ErrorName() string {
+	return "RemoveAvatarRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RemoveAvatarRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRemoveAvatarRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RemoveAvatarRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RemoveAvatarRequestValidationError{}
+
+// Validate checks the field values on RemoveAvatarResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RemoveAvatarResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RemoveAvatarResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RemoveAvatarResponseMultiError, or nil if none found.
+func (m *RemoveAvatarResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RemoveAvatarResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return RemoveAvatarResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RemoveAvatarResponseMultiError is an error wrapping multiple validation
+// errors returned by RemoveAvatarResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RemoveAvatarResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RemoveAvatarResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RemoveAvatarResponseMultiError) AllErrors() []error { return m }
+
+// RemoveAvatarResponseValidationError is the validation error returned by
+// RemoveAvatarResponse.Validate if the designated constraints aren't met.
+type RemoveAvatarResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RemoveAvatarResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RemoveAvatarResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RemoveAvatarResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RemoveAvatarResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RemoveAvatarResponseValidationError) ErrorName() string {
+	return "RemoveAvatarResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RemoveAvatarResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRemoveAvatarResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RemoveAvatarResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RemoveAvatarResponseValidationError{}
+
+// Validate checks the field values on ListUserAvatarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListUserAvatarRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUserAvatarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUserAvatarRequestMultiError, or nil if none found.
+func (m *ListUserAvatarRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUserAvatarRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	if len(errors) > 0 {
+		return ListUserAvatarRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUserAvatarRequestMultiError is an error wrapping multiple validation
+// errors returned by ListUserAvatarRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListUserAvatarRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUserAvatarRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUserAvatarRequestMultiError) AllErrors() []error { return m }
+
+// ListUserAvatarRequestValidationError is the validation error returned by
+// ListUserAvatarRequest.Validate if the designated constraints aren't met.
+type ListUserAvatarRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUserAvatarRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUserAvatarRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUserAvatarRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUserAvatarRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUserAvatarRequestValidationError) ErrorName() string {
+	return "ListUserAvatarRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUserAvatarRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUserAvatarRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUserAvatarRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUserAvatarRequestValidationError{}
+
+// Validate checks the field values on ListUserAvatarResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListUserAvatarResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUserAvatarResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUserAvatarResponseMultiError, or nil if none found.
+func (m *ListUserAvatarResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUserAvatarResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetAvatars() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUserAvatarResponseValidationError{
+						field:  fmt.Sprintf("Avatars[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUserAvatarResponseValidationError{
+						field:  fmt.Sprintf("Avatars[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUserAvatarResponseValidationError{
+					field:  fmt.Sprintf("Avatars[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListUserAvatarResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUserAvatarResponseMultiError is an error wrapping multiple validation
+// errors returned by ListUserAvatarResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListUserAvatarResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUserAvatarResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1731,11 +5729,176 @@ func (m LogoutResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m LogoutResponseMultiError) AllErrors() []error { return m }
+func (m ListUserAvatarResponseMultiError) AllErrors() []error { return m }
 
-// LogoutResponseValidationError is the validation error returned by
-// LogoutResponse.Validate if the designated constraints aren't met.
-type LogoutResponseValidationError struct {
+// ListUserAvatarResponseValidationError is the validation error returned by
+// ListUserAvatarResponse.Validate if the designated constraints aren't met.
+type ListUserAvatarResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUserAvatarResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUserAvatarResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUserAvatarResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUserAvatarResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUserAvatarResponseValidationError) ErrorName() string {
+	return "ListUserAvatarResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUserAvatarResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUserAvatarResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUserAvatarResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUserAvatarResponseValidationError{}
+
+// Validate checks the field values on UserAvatar with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserAvatar) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserAvatar with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserAvatarMultiError, or
+// nil if none found.
+func (m *UserAvatar) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserAvatar) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for FileId
+
+	// no validation rules for Position
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserAvatarValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserAvatarValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserAvatarValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserAvatarValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserAvatarValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserAvatarValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UserAvatarMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserAvatarMultiError is an error wrapping multiple validation errors
+// returned by UserAvatar.ValidateAll() if the designated constraints aren't met.
+type UserAvatarMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserAvatarMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserAvatarMultiError) AllErrors() []error { return m }
+
+// UserAvatarValidationError is the validation error returned by
+// UserAvatar.Validate if the designated constraints aren't met.
+type UserAvatarValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1743,22 +5906,22 @@ type LogoutResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e LogoutResponseValidationError) Field() string { return e.field }
+func (e UserAvatarValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LogoutResponseValidationError) Reason() string { return e.reason }
+func (e UserAvatarValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LogoutResponseValidationError) Cause() error { return e.cause }
+func (e UserAvatarValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LogoutResponseValidationError) Key() bool { return e.key }
+func (e UserAvatarValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LogoutResponseValidationError) ErrorName() string { return "LogoutResponseValidationError" }
+func (e UserAvatarValidationError) ErrorName() string { return "UserAvatarValidationError" }
 
 // Error satisfies the builtin error interface
-func (e LogoutResponseValidationError) Error() string {
+func (e UserAvatarValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1770,14 +5933,14 @@ func (e LogoutResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLogoutResponse.%s: %s%s",
+		"invalid %sUserAvatar.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LogoutResponseValidationError{}
+var _ error = UserAvatarValidationError{}
 
 var _ interface {
 	Field() string
@@ -1785,48 +5948,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LogoutResponseValidationError{}
+} = UserAvatarValidationError{}
 
-// Validate checks the field values on UpdatePasswordRequest with the rules
+// Validate checks the field values on SetCurrentAvatarRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdatePasswordRequest) Validate() error {
+func (m *SetCurrentAvatarRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdatePasswordRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on SetCurrentAvatarRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdatePasswordRequestMultiError, or nil if none found.
-func (m *UpdatePasswordRequest) ValidateAll() error {
+// SetCurrentAvatarRequestMultiError, or nil if none found.
+func (m *SetCurrentAvatarRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdatePasswordRequest) validate(all bool) error {
+func (m *SetCurrentAvatarRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Old
-
-	// no validation rules for New
+	// no validation rules for FileId
 
 	if len(errors) > 0 {
-		return UpdatePasswordRequestMultiError(errors)
+		return SetCurrentAvatarRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdatePasswordRequestMultiError is an error wrapping multiple validation
-// errors returned by UpdatePasswordRequest.ValidateAll() if the designated
+// SetCurrentAvatarRequestMultiError is an error wrapping multiple validation
+// errors returned by SetCurrentAvatarRequest.ValidateAll() if the designated
 // constraints aren't met.
-type UpdatePasswordRequestMultiError []error
+type SetCurrentAvatarRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdatePasswordRequestMultiError) Error() string {
+func (m SetCurrentAvatarRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1835,11 +5996,11 @@ func (m UpdatePasswordRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdatePasswordRequestMultiError) AllErrors() []error { return m }
+func (m SetCurrentAvatarRequestMultiError) AllErrors() []error { return m }
 
-// UpdatePasswordRequestValidationError is the validation error returned by
-// UpdatePasswordRequest.Validate if the designated constraints aren't met.
-type UpdatePasswordRequestValidationError struct {
+// SetCurrentAvatarRequestValidationError is the validation error returned by
+// SetCurrentAvatarRequest.Validate if the designated constraints aren't met.
+type SetCurrentAvatarRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1847,24 +6008,24 @@ type UpdatePasswordRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdatePasswordRequestValidationError) Field() string { return e.field }
+func (e SetCurrentAvatarRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdatePasswordRequestValidationError) Reason() string { return e.reason }
+func (e SetCurrentAvatarRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdatePasswordRequestValidationError) Cause() error { return e.cause }
+func (e SetCurrentAvatarRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdatePasswordRequestValidationError) Key() bool { return e.key }
+func (e SetCurrentAvatarRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdatePasswordRequestValidationError) ErrorName() string {
-	return "UpdatePasswordRequestValidationError"
+func (e SetCurrentAvatarRequestValidationError) ErrorName() string {
+	return "SetCurrentAvatarRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdatePasswordRequestValidationError) Error() string {
+func (e SetCurrentAvatarRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1876,14 +6037,14 @@ func (e UpdatePasswordRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdatePasswordRequest.%s: %s%s",
+		"invalid %sSetCurrentAvatarRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdatePasswordRequestValidationError{}
+var _ error = SetCurrentAvatarRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1891,24 +6052,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdatePasswordRequestValidationError{}
+} = SetCurrentAvatarRequestValidationError{}
 
-// Validate checks the field values on UpdatePasswordResponse with the rules
+// Validate checks the field values on SetCurrentAvatarResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdatePasswordResponse) Validate() error {
+func (m *SetCurrentAvatarResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdatePasswordResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on SetCurrentAvatarResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdatePasswordResponseMultiError, or nil if none found.
-func (m *UpdatePasswordResponse) ValidateAll() error {
+// SetCurrentAvatarResponseMultiError, or nil if none found.
+func (m *SetCurrentAvatarResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdatePasswordResponse) validate(all bool) error {
+func (m *SetCurrentAvatarResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -1916,19 +6077,19 @@ func (m *UpdatePasswordResponse) validate(all bool) error {
 	var errors []error
 
 	if len(errors) > 0 {
-		return UpdatePasswordResponseMultiError(errors)
+		return SetCurrentAvatarResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdatePasswordResponseMultiError is an error wrapping multiple validation
-// errors returned by UpdatePasswordResponse.ValidateAll() if the designated
+// SetCurrentAvatarResponseMultiError is an error wrapping multiple validation
+// errors returned by SetCurrentAvatarResponse.ValidateAll() if the designated
 // constraints aren't met.
-type UpdatePasswordResponseMultiError []error
+type SetCurrentAvatarResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdatePasswordResponseMultiError) Error() string {
+func (m SetCurrentAvatarResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1937,11 +6098,11 @@ func (m UpdatePasswordResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdatePasswordResponseMultiError) AllErrors() []error { return m }
+func (m SetCurrentAvatarResponseMultiError) AllErrors() []error { return m }
 
-// UpdatePasswordResponseValidationError is the validation error returned by
-// UpdatePasswordResponse.Validate if the designated constraints aren't met.
-type UpdatePasswordResponseValidationError struct {
+// SetCurrentAvatarResponseValidationError is the validation error returned by
+// SetCurrentAvatarResponse.Validate if the designated constraints aren't met.
+type SetCurrentAvatarResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1949,24 +6110,24 @@ type UpdatePasswordResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdatePasswordResponseValidationError) Field() string { return e.field }
+func (e SetCurrentAvatarResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdatePasswordResponseValidationError) Reason() string { return e.reason }
+func (e SetCurrentAvatarResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdatePasswordResponseValidationError) Cause() error { return e.cause }
+func (e SetCurrentAvatarResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdatePasswordResponseValidationError) Key() bool { return e.key }
+func (e SetCurrentAvatarResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdatePasswordResponseValidationError) ErrorName() string {
-	return "UpdatePasswordResponseValidationError"
+func (e SetCurrentAvatarResponseValidationError) ErrorName() string {
+	return "SetCurrentAvatarResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdatePasswordResponseValidationError) Error() string {
+func (e SetCurrentAvatarResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1978,14 +6139,14 @@ func (e UpdatePasswordResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdatePasswordResponse.%s: %s%s",
+		"invalid %sSetCurrentAvatarResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdatePasswordResponseValidationError{}
+var _ error = SetCurrentAvatarResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1993,50 +6154,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdatePasswordResponseValidationError{}
+} = SetCurrentAvatarResponseValidationError{}
 
-// Validate checks the field values on UpdateUserRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *UpdateUserRequest) Validate() error {
+// Validate checks the field values on ReorderAvatarsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReorderAvatarsRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateUserRequest with the rules
+// ValidateAll checks the field values on ReorderAvatarsRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateUserRequestMultiError, or nil if none found.
-func (m *UpdateUserRequest) ValidateAll() error {
+// ReorderAvatarsRequestMultiError, or nil if none found.
+func (m *ReorderAvatarsRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateUserRequest) validate(all bool) error {
+func (m *ReorderAvatarsRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Username
-
-	// no validation rules for AvatarId
-
-	// no validation rules for FullName
-
 	if len(errors) > 0 {
-		return UpdateUserRequestMultiError(errors)
+		return ReorderAvatarsRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateUserRequestMultiError is an error wrapping multiple validation errors
-// returned by UpdateUserRequest.ValidateAll() if the designated constraints
-// aren't met.
-type UpdateUserRequestMultiError []error
+// ReorderAvatarsRequestMultiError is an error wrapping multiple validation
+// errors returned by ReorderAvatarsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ReorderAvatarsRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateUserRequestMultiError) Error() string {
+func (m ReorderAvatarsRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2045,11 +6200,11 @@ func (m UpdateUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateUserRequestMultiError) AllErrors() []error { return m }
+func (m ReorderAvatarsRequestMultiError) AllErrors() []error { return m }
 
-// UpdateUserRequestValidationError is the validation error returned by
-// UpdateUserRequest.Validate if the designated constraints aren't met.
-type UpdateUserRequestValidationError struct {
+// ReorderAvatarsRequestValidationError is the validation error returned by
+// ReorderAvatarsRequest.Validate if the designated constraints aren't met.
+type ReorderAvatarsRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2057,24 +6212,24 @@ type UpdateUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateUserRequestValidationError) Field() string { return e.field }
+func (e ReorderAvatarsRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateUserRequestValidationError) Reason() string { return e.reason }
+func (e ReorderAvatarsRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateUserRequestValidationError) Cause() error { return e.cause }
+func (e ReorderAvatarsRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateUserRequestValidationError) Key() bool { return e.key }
+func (e ReorderAvatarsRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateUserRequestValidationError) ErrorName() string {
-	return "UpdateUserRequestValidationError"
+func (e ReorderAvatarsRequestValidationError) ErrorName() string {
+	return "ReorderAvatarsRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateUserRequestValidationError) Error() string {
+func (e ReorderAvatarsRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2086,14 +6241,14 @@ func (e UpdateUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateUserRequest.%s: %s%s",
+		"invalid %sReorderAvatarsRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateUserRequestValidationError{}
+var _ error = ReorderAvatarsRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2101,44 +6256,78 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateUserRequestValidationError{}
+} = ReorderAvatarsRequestValidationError{}
 
-// Validate checks the field values on UpdateUserResponse with the rules
+// Validate checks the field values on ReorderAvatarsResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdateUserResponse) Validate() error {
+func (m *ReorderAvatarsResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateUserResponse with the rules
+// ValidateAll checks the field values on ReorderAvatarsResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateUserResponseMultiError, or nil if none found.
-func (m *UpdateUserResponse) ValidateAll() error {
+// ReorderAvatarsResponseMultiError, or nil if none found.
+func (m *ReorderAvatarsResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateUserResponse) validate(all bool) error {
+func (m *ReorderAvatarsResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	for idx, item := range m.GetAvatars() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ReorderAvatarsResponseValidationError{
+						field:  fmt.Sprintf("Avatars[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ReorderAvatarsResponseValidationError{
+						field:  fmt.Sprintf("Avatars[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ReorderAvatarsResponseValidationError{
+					field:  fmt.Sprintf("Avatars[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
-		return UpdateUserResponseMultiError(errors)
+		return ReorderAvatarsResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateUserResponseMultiError is an error wrapping multiple validation errors
-// returned by UpdateUserResponse.ValidateAll() if the designated constraints
-// aren't met.
-type UpdateUserResponseMultiError []error
+// ReorderAvatarsResponseMultiError is an error wrapping multiple validation
+// errors returned by ReorderAvatarsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ReorderAvatarsResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateUserResponseMultiError) Error() string {
+func (m ReorderAvatarsResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2147,11 +6336,11 @@ func (m UpdateUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateUserResponseMultiError) AllErrors() []error { return m }
+func (m ReorderAvatarsResponseMultiError) AllErrors() []error { return m }
 
-// UpdateUserResponseValidationError is the validation error returned by
-// UpdateUserResponse.Validate if the designated constraints aren't met.
-type UpdateUserResponseValidationError struct {
+// ReorderAvatarsResponseValidationError is the validation error returned by
+// ReorderAvatarsResponse.Validate if the designated constraints aren't met.
+type ReorderAvatarsResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2159,24 +6348,24 @@ type UpdateUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateUserResponseValidationError) Field() string { return e.field }
+func (e ReorderAvatarsResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateUserResponseValidationError) Reason() string { return e.reason }
+func (e ReorderAvatarsResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateUserResponseValidationError) Cause() error { return e.cause }
+func (e ReorderAvatarsResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateUserResponseValidationError) Key() bool { return e.key }
+func (e ReorderAvatarsResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateUserResponseValidationError) ErrorName() string {
-	return "UpdateUserResponseValidationError"
+func (e ReorderAvatarsResponseValidationError) ErrorName() string {
+	return "ReorderAvatarsResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateUserResponseValidationError) Error() string {
+func (e ReorderAvatarsResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2188,14 +6377,14 @@ func (e UpdateUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateUserResponse.%s: %s%s",
+		"invalid %sReorderAvatarsResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateUserResponseValidationError{}
+var _ error = ReorderAvatarsResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2203,112 +6392,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateUserResponseValidationError{}
+} = ReorderAvatarsResponseValidationError{}
 
-// Validate checks the field values on User with the rules defined in the proto
-// definition for this message. If any rules are violated, the first error
-// encountered is returned, or nil if there are no violations.
-func (m *User) Validate() error {
+// Validate checks the field values on CreateAvatarUploadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateAvatarUploadRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on User with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in UserMultiError, or nil if none found.
-func (m *User) ValidateAll() error {
+// ValidateAll checks the field values on CreateAvatarUploadRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateAvatarUploadRequestMultiError, or nil if none found.
+func (m *CreateAvatarUploadRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *User) validate(all bool) error {
+func (m *CreateAvatarUploadRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
-	// no validation rules for Username
-
-	// no validation rules for Email
-
-	// no validation rules for AvatarId
-
-	// no validation rules for Kind
-
-	// no validation rules for FullName
+	// no validation rules for Name
 
-	if all {
-		switch v := interface{}(m.GetCreatedAt()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UserValidationError{
-					field:  "CreatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UserValidationError{
-					field:  "CreatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UserValidationError{
-				field:  "CreatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for ContentType
 
-	if all {
-		switch v := interface{}(m.GetUpdatedAt()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UserValidationError{
-					field:  "UpdatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UserValidationError{
-					field:  "UpdatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UserValidationError{
-				field:  "UpdatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Size
 
 	if len(errors) > 0 {
-		return UserMultiError(errors)
+		return CreateAvatarUploadRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// UserMultiError is an error wrapping multiple validation errors returned by
-// User.ValidateAll() if the designated constraints aren't met.
-type UserMultiError []error
+// CreateAvatarUploadRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateAvatarUploadRequest.ValidateAll() if the
+// designated constraints aren't met.
+type CreateAvatarUploadRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UserMultiError) Error() string {
+func (m CreateAvatarUploadRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2317,11 +6444,11 @@ func (m UserMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UserMultiError) AllErrors() []error { return m }
+func (m CreateAvatarUploadRequestMultiError) AllErrors() []error { return m }
 
-// UserValidationError is the validation error returned by User.Validate if the
-// designated constraints aren't met.
-type UserValidationError struct {
+// CreateAvatarUploadRequestValidationError is the validation error returned by
+// CreateAvatarUploadRequest.Validate if the designated constraints aren't met.
+type CreateAvatarUploadRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2329,22 +6456,24 @@ type UserValidationError struct {
 }
 
 // Field function returns field value.
-func (e UserValidationError) Field() string { return e.field }
+func (e CreateAvatarUploadRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UserValidationError) Reason() string { return e.reason }
+func (e CreateAvatarUploadRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UserValidationError) Cause() error { return e.cause }
+func (e CreateAvatarUploadRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UserValidationError) Key() bool { return e.key }
+func (e CreateAvatarUploadRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UserValidationError) ErrorName() string { return "UserValidationError" }
+func (e CreateAvatarUploadRequestValidationError) ErrorName() string {
+	return "CreateAvatarUploadRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e UserValidationError) Error() string {
+func (e CreateAvatarUploadRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2356,14 +6485,14 @@ func (e UserValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUser.%s: %s%s",
+		"invalid %sCreateAvatarUploadRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UserValidationError{}
+var _ error = CreateAvatarUploadRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2371,46 +6500,79 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UserValidationError{}
+} = CreateAvatarUploadRequestValidationError{}
 
-// Validate checks the field values on RemoveAvatarRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on CreateAvatarUploadResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RemoveAvatarRequest) Validate() error {
+func (m *CreateAvatarUploadResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RemoveAvatarRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on CreateAvatarUploadResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// RemoveAvatarRequestMultiError, or nil if none found.
-func (m *RemoveAvatarRequest) ValidateAll() error {
+// CreateAvatarUploadResponseMultiError, or nil if none found.
+func (m *CreateAvatarUploadResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RemoveAvatarRequest) validate(all bool) error {
+func (m *CreateAvatarUploadResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for FileId
+	// no validation rules for UploadId
+
+	// no validation rules for Url
+
+	// no validation rules for Headers
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateAvatarUploadResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateAvatarUploadResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateAvatarUploadResponseValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
 	if len(errors) > 0 {
-		return RemoveAvatarRequestMultiError(errors)
+		return CreateAvatarUploadResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// RemoveAvatarRequestMultiError is an error wrapping multiple validation
-// errors returned by RemoveAvatarRequest.ValidateAll() if the designated
-// constraints aren't met.
-type RemoveAvatarRequestMultiError []error
+// CreateAvatarUploadResponseMultiError is an error wrapping multiple
+// validation errors returned by CreateAvatarUploadResponse.ValidateAll() if
+// the designated constraints aren't met.
+type CreateAvatarUploadResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RemoveAvatarRequestMultiError) Error() string {
+func (m CreateAvatarUploadResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2419,11 +6581,11 @@ func (m RemoveAvatarRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RemoveAvatarRequestMultiError) AllErrors() []error { return m }
+func (m CreateAvatarUploadResponseMultiError) AllErrors() []error { return m }
 
-// RemoveAvatarRequestValidationError is the validation error returned by
-// RemoveAvatarRequest.Validate if the designated constraints aren't met.
-type RemoveAvatarRequestValidationError struct {
+// CreateAvatarUploadResponseValidationError is the validation error returned
+// by CreateAvatarUploadResponse.Validate if the designated constraints aren't met.
+type CreateAvatarUploadResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2431,24 +6593,24 @@ type RemoveAvatarRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e RemoveAvatarRequestValidationError) Field() string { return e.field }
+func (e CreateAvatarUploadResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RemoveAvatarRequestValidationError) Reason() string { return e.reason }
+func (e CreateAvatarUploadResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RemoveAvatarRequestValidationError) Cause() error { return e.cause }
+func (e CreateAvatarUploadResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RemoveAvatarRequestValidationError) Key() bool { return e.key }
+func (e CreateAvatarUploadResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RemoveAvatarRequestValidationError) ErrorName() string {
-	return "RemoveAvatarRequestValidationError"
+func (e CreateAvatarUploadResponseValidationError) ErrorName() string {
+	return "CreateAvatarUploadResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RemoveAvatarRequestValidationError) Error() string {
+func (e CreateAvatarUploadResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2460,14 +6622,14 @@ func (e RemoveAvatarRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRemoveAvatarRequest.%s: %s%s",
+		"invalid %sCreateAvatarUploadResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RemoveAvatarRequestValidationError{}
+var _ error = CreateAvatarUploadResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2475,44 +6637,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RemoveAvatarRequestValidationError{}
+} = CreateAvatarUploadResponseValidationError{}
 
-// Validate checks the field values on RemoveAvatarResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on CompleteAvatarUploadRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RemoveAvatarResponse) Validate() error {
+func (m *CompleteAvatarUploadRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RemoveAvatarResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on CompleteAvatarUploadRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// RemoveAvatarResponseMultiError, or nil if none found.
-func (m *RemoveAvatarResponse) ValidateAll() error {
+// CompleteAvatarUploadRequestMultiError, or nil if none found.
+func (m *CompleteAvatarUploadRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RemoveAvatarResponse) validate(all bool) error {
+func (m *CompleteAvatarUploadRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for UploadId
+
 	if len(errors) > 0 {
-		return RemoveAvatarResponseMultiError(errors)
+		return CompleteAvatarUploadRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// RemoveAvatarResponseMultiError is an error wrapping multiple validation
-// errors returned by RemoveAvatarResponse.ValidateAll() if the designated
-// constraints aren't met.
-type RemoveAvatarResponseMultiError []error
+// CompleteAvatarUploadRequestMultiError is an error wrapping multiple
+// validation errors returned by CompleteAvatarUploadRequest.ValidateAll() if
+// the designated constraints aren't met.
+type CompleteAvatarUploadRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RemoveAvatarResponseMultiError) Error() string {
+func (m CompleteAvatarUploadRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2521,11 +6685,12 @@ func (m RemoveAvatarResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RemoveAvatarResponseMultiError) AllErrors() []error { return m }
+func (m CompleteAvatarUploadRequestMultiError) AllErrors() []error { return m }
 
-// RemoveAvatarResponseValidationError is the validation error returned by
-// RemoveAvatarResponse.Validate if the designated constraints aren't met.
-type RemoveAvatarResponseValidationError struct {
+// CompleteAvatarUploadRequestValidationError is the validation error returned
+// by CompleteAvatarUploadRequest.Validate if the designated constraints
+// aren't met.
+type CompleteAvatarUploadRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2533,24 +6698,24 @@ type RemoveAvatarResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e RemoveAvatarResponseValidationError) Field() string { return e.field }
+func (e CompleteAvatarUploadRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RemoveAvatarResponseValidationError) Reason() string { return e.reason }
+func (e CompleteAvatarUploadRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RemoveAvatarResponseValidationError) Cause() error { return e.cause }
+func (e CompleteAvatarUploadRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RemoveAvatarResponseValidationError) Key() bool { return e.key }
+func (e CompleteAvatarUploadRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RemoveAvatarResponseValidationError) ErrorName() string {
-	return "RemoveAvatarResponseValidationError"
+func (e CompleteAvatarUploadRequestValidationError) ErrorName() string {
+	return "CompleteAvatarUploadRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RemoveAvatarResponseValidationError) Error() string {
+func (e CompleteAvatarUploadRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2562,14 +6727,14 @@ func (e RemoveAvatarResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRemoveAvatarResponse.%s: %s%s",
+		"invalid %sCompleteAvatarUploadRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RemoveAvatarResponseValidationError{}
+var _ error = CompleteAvatarUploadRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2577,46 +6742,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RemoveAvatarResponseValidationError{}
+} = CompleteAvatarUploadRequestValidationError{}
 
-// Validate checks the field values on ListUserAvatarRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on CompleteAvatarUploadResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ListUserAvatarRequest) Validate() error {
+func (m *CompleteAvatarUploadResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListUserAvatarRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on CompleteAvatarUploadResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListUserAvatarRequestMultiError, or nil if none found.
-func (m *ListUserAvatarRequest) ValidateAll() error {
+// CompleteAvatarUploadResponseMultiError, or nil if none found.
+func (m *CompleteAvatarUploadResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListUserAvatarRequest) validate(all bool) error {
+func (m *CompleteAvatarUploadResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UserId
+	// no validation rules for FileId
 
 	if len(errors) > 0 {
-		return ListUserAvatarRequestMultiError(errors)
+		return CompleteAvatarUploadResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListUserAvatarRequestMultiError is an error wrapping multiple validation
-// errors returned by ListUserAvatarRequest.ValidateAll() if the designated
-// constraints aren't met.
-type ListUserAvatarRequestMultiError []error
+// CompleteAvatarUploadResponseMultiError is an error wrapping multiple
+// validation errors returned by CompleteAvatarUploadResponse.ValidateAll() if
+// the designated constraints aren't met.
+type CompleteAvatarUploadResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListUserAvatarRequestMultiError) Error() string {
+func (m CompleteAvatarUploadResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2625,11 +6790,12 @@ func (m ListUserAvatarRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListUserAvatarRequestMultiError) AllErrors() []error { return m }
+func (m CompleteAvatarUploadResponseMultiError) AllErrors() []error { return m }
 
-// ListUserAvatarRequestValidationError is the validation error returned by
-// ListUserAvatarRequest.Validate if the designated constraints aren't met.
-type ListUserAvatarRequestValidationError struct {
+// CompleteAvatarUploadResponseValidationError is the validation error returned
+// by CompleteAvatarUploadResponse.Validate if the designated constraints
+// aren't met.
+type CompleteAvatarUploadResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2637,24 +6803,24 @@ type ListUserAvatarRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListUserAvatarRequestValidationError) Field() string { return e.field }
+func (e CompleteAvatarUploadResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListUserAvatarRequestValidationError) Reason() string { return e.reason }
+func (e CompleteAvatarUploadResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListUserAvatarRequestValidationError) Cause() error { return e.cause }
+func (e CompleteAvatarUploadResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListUserAvatarRequestValidationError) Key() bool { return e.key }
+func (e CompleteAvatarUploadResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListUserAvatarRequestValidationError) ErrorName() string {
-	return "ListUserAvatarRequestValidationError"
+func (e CompleteAvatarUploadResponseValidationError) ErrorName() string {
+	return "CompleteAvatarUploadResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListUserAvatarRequestValidationError) Error() string {
+func (e CompleteAvatarUploadResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2666,14 +6832,14 @@ func (e ListUserAvatarRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListUserAvatarRequest.%s: %s%s",
+		"invalid %sCompleteAvatarUploadResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListUserAvatarRequestValidationError{}
+var _ error = CompleteAvatarUploadResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2681,78 +6847,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListUserAvatarRequestValidationError{}
+} = CompleteAvatarUploadResponseValidationError{}
 
-// Validate checks the field values on ListUserAvatarResponse with the rules
+// Validate checks the field values on GetAvatarURLRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ListUserAvatarResponse) Validate() error {
+func (m *GetAvatarURLRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListUserAvatarResponse with the rules
+// ValidateAll checks the field values on GetAvatarURLRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListUserAvatarResponseMultiError, or nil if none found.
-func (m *ListUserAvatarResponse) ValidateAll() error {
+// GetAvatarURLRequestMultiError, or nil if none found.
+func (m *GetAvatarURLRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListUserAvatarResponse) validate(all bool) error {
+func (m *GetAvatarURLRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetAvatars() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ListUserAvatarResponseValidationError{
-						field:  fmt.Sprintf("Avatars[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, ListUserAvatarResponseValidationError{
-						field:  fmt.Sprintf("Avatars[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ListUserAvatarResponseValidationError{
-					field:  fmt.Sprintf("Avatars[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	// no validation rules for FileId
 
-	}
+	// no validation rules for Size
 
 	if len(errors) > 0 {
-		return ListUserAvatarResponseMultiError(errors)
+		return GetAvatarURLRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListUserAvatarResponseMultiError is an error wrapping multiple validation
-// errors returned by ListUserAvatarResponse.ValidateAll() if the designated
+// GetAvatarURLRequestMultiError is an error wrapping multiple validation
+// errors returned by GetAvatarURLRequest.ValidateAll() if the designated
 // constraints aren't met.
-type ListUserAvatarResponseMultiError []error
+type GetAvatarURLRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListUserAvatarResponseMultiError) Error() string {
+func (m GetAvatarURLRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2761,11 +6897,11 @@ func (m ListUserAvatarResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListUserAvatarResponseMultiError) AllErrors() []error { return m }
+func (m GetAvatarURLRequestMultiError) AllErrors() []error { return m }
 
-// ListUserAvatarResponseValidationError is the validation error returned by
-// ListUserAvatarResponse.Validate if the designated constraints aren't met.
-type ListUserAvatarResponseValidationError struct {
+// GetAvatarURLRequestValidationError is the validation error returned by
+// GetAvatarURLRequest.Validate if the designated constraints aren't met.
+type GetAvatarURLRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2773,24 +6909,24 @@ type ListUserAvatarResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListUserAvatarResponseValidationError) Field() string { return e.field }
+func (e GetAvatarURLRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListUserAvatarResponseValidationError) Reason() string { return e.reason }
+func (e GetAvatarURLRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListUserAvatarResponseValidationError) Cause() error { return e.cause }
+func (e GetAvatarURLRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListUserAvatarResponseValidationError) Key() bool { return e.key }
+func (e GetAvatarURLRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListUserAvatarResponseValidationError) ErrorName() string {
-	return "ListUserAvatarResponseValidationError"
+func (e GetAvatarURLRequestValidationError) ErrorName() string {
+	return "GetAvatarURLRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListUserAvatarResponseValidationError) Error() string {
+func (e GetAvatarURLRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2802,14 +6938,14 @@ func (e ListUserAvatarResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListUserAvatarResponse.%s: %s%s",
+		"invalid %sGetAvatarURLRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListUserAvatarResponseValidationError{}
+var _ error = GetAvatarURLRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2817,47 +6953,75 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListUserAvatarResponseValidationError{}
+} = GetAvatarURLRequestValidationError{}
 
-// Validate checks the field values on UserAvatar with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *UserAvatar) Validate() error {
+// Validate checks the field values on GetAvatarURLResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetAvatarURLResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UserAvatar with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in UserAvatarMultiError, or
-// nil if none found.
-func (m *UserAvatar) ValidateAll() error {
+// ValidateAll checks the field values on GetAvatarURLResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetAvatarURLResponseMultiError, or nil if none found.
+func (m *GetAvatarURLResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UserAvatar) validate(all bool) error {
+func (m *GetAvatarURLResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UserId
+	// no validation rules for Url
 
-	// no validation rules for FileId
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetAvatarURLResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetAvatarURLResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetAvatarURLResponseValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
 	if len(errors) > 0 {
-		return UserAvatarMultiError(errors)
+		return GetAvatarURLResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// UserAvatarMultiError is an error wrapping multiple validation errors
-// returned by UserAvatar.ValidateAll() if the designated constraints aren't met.
-type UserAvatarMultiError []error
+// GetAvatarURLResponseMultiError is an error wrapping multiple validation
+// errors returned by GetAvatarURLResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetAvatarURLResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UserAvatarMultiError) Error() string {
+func (m GetAvatarURLResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2866,11 +7030,11 @@ func (m UserAvatarMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UserAvatarMultiError) AllErrors() []error { return m }
+func (m GetAvatarURLResponseMultiError) AllErrors() []error { return m }
 
-// UserAvatarValidationError is the validation error returned by
-// UserAvatar.Validate if the designated constraints aren't met.
-type UserAvatarValidationError struct {
+// GetAvatarURLResponseValidationError is the validation error returned by
+// GetAvatarURLResponse.Validate if the designated constraints aren't met.
+type GetAvatarURLResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2878,22 +7042,24 @@ type UserAvatarValidationError struct {
 }
 
 // Field function returns field value.
-func (e UserAvatarValidationError) Field() string { return e.field }
+func (e GetAvatarURLResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UserAvatarValidationError) Reason() string { return e.reason }
+func (e GetAvatarURLResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UserAvatarValidationError) Cause() error { return e.cause }
+func (e GetAvatarURLResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UserAvatarValidationError) Key() bool { return e.key }
+func (e GetAvatarURLResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UserAvatarValidationError) ErrorName() string { return "UserAvatarValidationError" }
+func (e GetAvatarURLResponseValidationError) ErrorName() string {
+	return "GetAvatarURLResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e UserAvatarValidationError) Error() string {
+func (e GetAvatarURLResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2905,14 +7071,14 @@ func (e UserAvatarValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUserAvatar.%s: %s%s",
+		"invalid %sGetAvatarURLResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UserAvatarValidationError{}
+var _ error = GetAvatarURLResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2920,4 +7086,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UserAvatarValidationError{}
+} = GetAvatarURLResponseValidationError{}
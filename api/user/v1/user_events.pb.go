@@ -26,6 +26,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type LoginFailReason int32
+
+const (
+	LoginFailReason_LOGIN_FAIL_REASON_UNSPECIFIED LoginFailReason = 0
+	// There is no user with such email.
+	LoginFailReason_LOGIN_FAIL_REASON_UNKNOWN_EMAIL    LoginFailReason = 1
+	LoginFailReason_LOGIN_FAIL_REASON_INVALID_PASSWORD LoginFailReason = 2
+)
+
+// Enum value maps for LoginFailReason.
+var (
+	LoginFailReason_name = map[int32]string{
+		0: "LOGIN_FAIL_REASON_UNSPECIFIED",
+		1: "LOGIN_FAIL_REASON_UNKNOWN_EMAIL",
+		2: "LOGIN_FAIL_REASON_INVALID_PASSWORD",
+	}
+	LoginFailReason_value = map[string]int32{
+		"LOGIN_FAIL_REASON_UNSPECIFIED":      0,
+		"LOGIN_FAIL_REASON_UNKNOWN_EMAIL":    1,
+		"LOGIN_FAIL_REASON_INVALID_PASSWORD": 2,
+	}
+)
+
+func (x LoginFailReason) Enum() *LoginFailReason {
+	p := new(LoginFailReason)
+	*p = x
+	return p
+}
+
+func (x LoginFailReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LoginFailReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_user_v1_user_events_proto_enumTypes[0].Descriptor()
+}
+
+func (LoginFailReason) Type() protoreflect.EnumType {
+	return &file_api_user_v1_user_events_proto_enumTypes[0]
+}
+
+func (x LoginFailReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LoginFailReason.Descriptor instead.
+func (LoginFailReason) EnumDescriptor() ([]byte, []int) {
+	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{0}
+}
+
 type Add struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -167,6 +217,375 @@ func (x *Delete) GetUserId() string {
 	return ""
 }
 
+type PreferencesUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Changed preferences only.
+	Preferences []*Preference `protobuf:"bytes,2,rep,name=preferences,proto3" json:"preferences,omitempty"`
+}
+
+func (x *PreferencesUpdate) Reset() {
+	*x = PreferencesUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_user_v1_user_events_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreferencesUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreferencesUpdate) ProtoMessage() {}
+
+func (x *PreferencesUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_events_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreferencesUpdate.ProtoReflect.Descriptor instead.
+func (*PreferencesUpdate) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PreferencesUpdate) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PreferencesUpdate) GetPreferences() []*Preference {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// User logged in and got new session.
+type LoggedIn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Ip        string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent string `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+}
+
+func (x *LoggedIn) Reset() {
+	*x = LoggedIn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_user_v1_user_events_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoggedIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoggedIn) ProtoMessage() {}
+
+func (x *LoggedIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_events_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoggedIn.ProtoReflect.Descriptor instead.
+func (*LoggedIn) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LoggedIn) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoggedIn) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *LoggedIn) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+// User removed own session.
+type LoggedOut struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *LoggedOut) Reset() {
+	*x = LoggedOut{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_user_v1_user_events_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoggedOut) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoggedOut) ProtoMessage() {}
+
+func (x *LoggedOut) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_events_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoggedOut.ProtoReflect.Descriptor instead.
+func (*LoggedOut) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LoggedOut) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoggedOut) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// Somebody tried to log in with wrong credentials.
+type LoginFailed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Empty if user with such email doesn't exist.
+	UserId    string          `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email     string          `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Ip        string          `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent string          `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Reason    LoginFailReason `protobuf:"varint,5,opt,name=reason,proto3,enum=api.user.v1.LoginFailReason" json:"reason,omitempty"`
+}
+
+func (x *LoginFailed) Reset() {
+	*x = LoginFailed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_user_v1_user_events_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoginFailed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginFailed) ProtoMessage() {}
+
+func (x *LoginFailed) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_events_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginFailed.ProtoReflect.Descriptor instead.
+func (*LoginFailed) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LoginFailed) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginFailed) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginFailed) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *LoginFailed) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *LoginFailed) GetReason() LoginFailReason {
+	if x != nil {
+		return x.Reason
+	}
+	return LoginFailReason_LOGIN_FAIL_REASON_UNSPECIFIED
+}
+
+type PasswordChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *PasswordChanged) Reset() {
+	*x = PasswordChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_user_v1_user_events_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PasswordChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordChanged) ProtoMessage() {}
+
+func (x *PasswordChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_events_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordChanged.ProtoReflect.Descriptor instead.
+func (*PasswordChanged) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PasswordChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AvatarChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Empty if user has no avatar now.
+	AvatarId string `protobuf:"bytes,2,opt,name=avatar_id,json=avatarId,proto3" json:"avatar_id,omitempty"`
+	// Empty if user had no avatar.
+	PrevAvatarId string `protobuf:"bytes,3,opt,name=prev_avatar_id,json=prevAvatarId,proto3" json:"prev_avatar_id,omitempty"`
+}
+
+func (x *AvatarChanged) Reset() {
+	*x = AvatarChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_user_v1_user_events_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AvatarChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvatarChanged) ProtoMessage() {}
+
+func (x *AvatarChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_events_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvatarChanged.ProtoReflect.Descriptor instead.
+func (*AvatarChanged) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AvatarChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AvatarChanged) GetAvatarId() string {
+	if x != nil {
+		return x.AvatarId
+	}
+	return ""
+}
+
+func (x *AvatarChanged) GetPrevAvatarId() string {
+	if x != nil {
+		return x.PrevAvatarId
+	}
+	return ""
+}
+
 type Event struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -175,17 +594,22 @@ type Event struct {
 	// Contains event body.
 	//
 	// Types that are assignable to Body:
-	//
 	//	*Event_Add
 	//	*Event_Update
 	//	*Event_Delete
+	//	*Event_Preferences
+	//	*Event_LoggedIn
+	//	*Event_LoggedOut
+	//	*Event_LoginFailed
+	//	*Event_PasswordChanged
+	//	*Event_AvatarChanged
 	Body isEvent_Body `protobuf_oneof:"body"`
 }
 
 func (x *Event) Reset() {
 	*x = Event{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_user_v1_user_events_proto_msgTypes[3]
+		mi := &file_api_user_v1_user_events_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -198,7 +622,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_api_user_v1_user_events_proto_msgTypes[3]
+	mi := &file_api_user_v1_user_events_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,7 +635,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{3}
+	return file_api_user_v1_user_events_proto_rawDescGZIP(), []int{9}
 }
 
 func (m *Event) GetBody() isEvent_Body {
@@ -242,6 +666,48 @@ func (x *Event) GetDelete() *Delete {
 	return nil
 }
 
+func (x *Event) GetPreferences() *PreferencesUpdate {
+	if x, ok := x.GetBody().(*Event_Preferences); ok {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *Event) GetLoggedIn() *LoggedIn {
+	if x, ok := x.GetBody().(*Event_LoggedIn); ok {
+		return x.LoggedIn
+	}
+	return nil
+}
+
+func (x *Event) GetLoggedOut() *LoggedOut {
+	if x, ok := x.GetBody().(*Event_LoggedOut); ok {
+		return x.LoggedOut
+	}
+	return nil
+}
+
+func (x *Event) GetLoginFailed() *LoginFailed {
+	if x, ok := x.GetBody().(*Event_LoginFailed); ok {
+		return x.LoginFailed
+	}
+	return nil
+}
+
+func (x *Event) GetPasswordChanged() *PasswordChanged {
+	if x, ok := x.GetBody().(*Event_PasswordChanged); ok {
+		return x.PasswordChanged
+	}
+	return nil
+}
+
+func (x *Event) GetAvatarChanged() *AvatarChanged {
+	if x, ok := x.GetBody().(*Event_AvatarChanged); ok {
+		return x.AvatarChanged
+	}
+	return nil
+}
+
 type isEvent_Body interface {
 	isEvent_Body()
 }
@@ -258,12 +724,48 @@ type Event_Delete struct {
 	Delete *Delete `protobuf:"bytes,3,opt,name=delete,proto3,oneof"`
 }
 
+type Event_Preferences struct {
+	Preferences *PreferencesUpdate `protobuf:"bytes,4,opt,name=preferences,proto3,oneof"`
+}
+
+type Event_LoggedIn struct {
+	LoggedIn *LoggedIn `protobuf:"bytes,5,opt,name=logged_in,json=loggedIn,proto3,oneof"`
+}
+
+type Event_LoggedOut struct {
+	LoggedOut *LoggedOut `protobuf:"bytes,6,opt,name=logged_out,json=loggedOut,proto3,oneof"`
+}
+
+type Event_LoginFailed struct {
+	LoginFailed *LoginFailed `protobuf:"bytes,7,opt,name=login_failed,json=loginFailed,proto3,oneof"`
+}
+
+type Event_PasswordChanged struct {
+	PasswordChanged *PasswordChanged `protobuf:"bytes,8,opt,name=password_changed,json=passwordChanged,proto3,oneof"`
+}
+
+type Event_AvatarChanged struct {
+	AvatarChanged *AvatarChanged `protobuf:"bytes,9,opt,name=avatar_changed,json=avatarChanged,proto3,oneof"`
+}
+
 func (*Event_Add) isEvent_Body() {}
 
 func (*Event_Update) isEvent_Body() {}
 
 func (*Event_Delete) isEvent_Body() {}
 
+func (*Event_Preferences) isEvent_Body() {}
+
+func (*Event_LoggedIn) isEvent_Body() {}
+
+func (*Event_LoggedOut) isEvent_Body() {}
+
+func (*Event_LoginFailed) isEvent_Body() {}
+
+func (*Event_PasswordChanged) isEvent_Body() {}
+
+func (*Event_AvatarChanged) isEvent_Body() {}
+
 var File_api_user_v1_user_events_proto protoreflect.FileDescriptor
 
 var file_api_user_v1_user_events_proto_rawDesc = []byte{
@@ -295,20 +797,99 @@ var file_api_user_v1_user_events_proto_rawDesc = []byte{
 	0x73, 0x65, 0x72, 0x22, 0x2b, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x21, 0x0a,
 	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
 	0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
-	0x22, 0x9a, 0x01, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x03, 0x61, 0x64,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x48, 0x00, 0x52, 0x03, 0x61, 0x64, 0x64,
-	0x12, 0x2d, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
-	0x2d, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x48, 0x00, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x0d,
-	0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x05, 0xba, 0x48, 0x02, 0x08, 0x01, 0x42, 0x32, 0x5a,
-	0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x5a, 0x65, 0x72, 0x67,
-	0x73, 0x4c, 0x61, 0x77, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x2d, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x71, 0x0a, 0x11, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x22, 0x72, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x67, 0x65, 0x64, 0x49, 0x6e, 0x12,
+	0x21, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a,
+	0xba, 0x48, 0x07, 0xd0, 0x01, 0x01, 0x72, 0x02, 0x70, 0x01, 0x52, 0x02, 0x69, 0x70, 0x12, 0x27,
+	0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0x18, 0x80, 0x04, 0x52, 0x09, 0x75, 0x73,
+	0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x22, 0x57, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x67, 0x65,
+	0x64, 0x4f, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05,
+	0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0xdc, 0x01, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x12, 0x24, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0b, 0xba, 0x48, 0x08, 0xd0, 0x01, 0x01, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x09, 0xba, 0x48, 0x06, 0x72, 0x04, 0x10, 0x01, 0x18, 0x63,
+	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x0a, 0xba, 0x48, 0x07, 0xd0, 0x01, 0x01, 0x72, 0x02, 0x70, 0x01, 0x52,
+	0x02, 0x69, 0x70, 0x12, 0x27, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0x18, 0x80,
+	0x04, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x46, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x0b, 0xba, 0x48, 0x08, 0x82,
+	0x01, 0x05, 0x10, 0x01, 0x22, 0x01, 0x00, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x34, 0x0a, 0x0f, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x12, 0x21, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x8f, 0x01, 0x0a, 0x0d, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0xb0,
+	0x01, 0x01, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x09, 0x61, 0x76,
+	0x61, 0x74, 0x61, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xba,
+	0x48, 0x08, 0xd0, 0x01, 0x01, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x08, 0x61, 0x76, 0x61, 0x74,
+	0x61, 0x72, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x61, 0x76, 0x61,
+	0x74, 0x61, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xba, 0x48,
+	0x08, 0xd0, 0x01, 0x01, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x41,
+	0x76, 0x61, 0x74, 0x61, 0x72, 0x49, 0x64, 0x22, 0x9c, 0x04, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x24, 0x0a, 0x03, 0x61, 0x64, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64,
+	0x48, 0x00, 0x52, 0x03, 0x61, 0x64, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x06,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x48, 0x00, 0x52, 0x06, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x6c, 0x6f, 0x67,
+	0x67, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x65,
+	0x64, 0x49, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x64, 0x49, 0x6e, 0x12,
+	0x37, 0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x6c,
+	0x6f, 0x67, 0x67, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x69,
+	0x6e, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x6f, 0x67, 0x69,
+	0x6e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x49, 0x0a, 0x10, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x0f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x12, 0x43, 0x0a, 0x0e, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x5f, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x42, 0x0d, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12,
+	0x05, 0xba, 0x48, 0x02, 0x08, 0x01, 0x2a, 0x81, 0x01, 0x0a, 0x0f, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x46, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x4f,
+	0x47, 0x49, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a,
+	0x1f, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x45, 0x4d, 0x41, 0x49, 0x4c,
+	0x10, 0x01, 0x12, 0x26, 0x0a, 0x22, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f,
+	0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x10, 0x02, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x5a, 0x65, 0x72, 0x67, 0x73, 0x4c, 0x61,
+	0x77, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x2d, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -323,25 +904,42 @@ func file_api_user_v1_user_events_proto_rawDescGZIP() []byte {
 	return file_api_user_v1_user_events_proto_rawDescData
 }
 
-var file_api_user_v1_user_events_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_user_v1_user_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_user_v1_user_events_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_api_user_v1_user_events_proto_goTypes = []interface{}{
-	(*Add)(nil),    // 0: api.user.v1.Add
-	(*Update)(nil), // 1: api.user.v1.Update
-	(*Delete)(nil), // 2: api.user.v1.Delete
-	(*Event)(nil),  // 3: api.user.v1.Event
-	(*User)(nil),   // 4: api.user.v1.User
+	(LoginFailReason)(0),      // 0: api.user.v1.LoginFailReason
+	(*Add)(nil),               // 1: api.user.v1.Add
+	(*Update)(nil),            // 2: api.user.v1.Update
+	(*Delete)(nil),            // 3: api.user.v1.Delete
+	(*PreferencesUpdate)(nil), // 4: api.user.v1.PreferencesUpdate
+	(*LoggedIn)(nil),          // 5: api.user.v1.LoggedIn
+	(*LoggedOut)(nil),         // 6: api.user.v1.LoggedOut
+	(*LoginFailed)(nil),       // 7: api.user.v1.LoginFailed
+	(*PasswordChanged)(nil),   // 8: api.user.v1.PasswordChanged
+	(*AvatarChanged)(nil),     // 9: api.user.v1.AvatarChanged
+	(*Event)(nil),             // 10: api.user.v1.Event
+	(*User)(nil),              // 11: api.user.v1.User
+	(*Preference)(nil),        // 12: api.user.v1.Preference
 }
 var file_api_user_v1_user_events_proto_depIdxs = []int32{
-	4, // 0: api.user.v1.Add.user:type_name -> api.user.v1.User
-	4, // 1: api.user.v1.Update.user:type_name -> api.user.v1.User
-	0, // 2: api.user.v1.Event.add:type_name -> api.user.v1.Add
-	1, // 3: api.user.v1.Event.update:type_name -> api.user.v1.Update
-	2, // 4: api.user.v1.Event.delete:type_name -> api.user.v1.Delete
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	11, // 0: api.user.v1.Add.user:type_name -> api.user.v1.User
+	11, // 1: api.user.v1.Update.user:type_name -> api.user.v1.User
+	12, // 2: api.user.v1.PreferencesUpdate.preferences:type_name -> api.user.v1.Preference
+	0,  // 3: api.user.v1.LoginFailed.reason:type_name -> api.user.v1.LoginFailReason
+	1,  // 4: api.user.v1.Event.add:type_name -> api.user.v1.Add
+	2,  // 5: api.user.v1.Event.update:type_name -> api.user.v1.Update
+	3,  // 6: api.user.v1.Event.delete:type_name -> api.user.v1.Delete
+	4,  // 7: api.user.v1.Event.preferences:type_name -> api.user.v1.PreferencesUpdate
+	5,  // 8: api.user.v1.Event.logged_in:type_name -> api.user.v1.LoggedIn
+	6,  // 9: api.user.v1.Event.logged_out:type_name -> api.user.v1.LoggedOut
+	7,  // 10: api.user.v1.Event.login_failed:type_name -> api.user.v1.LoginFailed
+	8,  // 11: api.user.v1.Event.password_changed:type_name -> api.user.v1.PasswordChanged
+	9,  // 12: api.user.v1.Event.avatar_changed:type_name -> api.user.v1.AvatarChanged
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_api_user_v1_user_events_proto_init() }
@@ -388,6 +986,78 @@ func file_api_user_v1_user_events_proto_init() {
 			}
 		}
 		file_api_user_v1_user_events_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreferencesUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_user_v1_user_events_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoggedIn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_user_v1_user_events_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoggedOut); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_user_v1_user_events_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginFailed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_user_v1_user_events_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PasswordChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_user_v1_user_events_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AvatarChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_user_v1_user_events_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
@@ -400,23 +1070,30 @@ func file_api_user_v1_user_events_proto_init() {
 			}
 		}
 	}
-	file_api_user_v1_user_events_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_api_user_v1_user_events_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*Event_Add)(nil),
 		(*Event_Update)(nil),
 		(*Event_Delete)(nil),
+		(*Event_Preferences)(nil),
+		(*Event_LoggedIn)(nil),
+		(*Event_LoggedOut)(nil),
+		(*Event_LoginFailed)(nil),
+		(*Event_PasswordChanged)(nil),
+		(*Event_AvatarChanged)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_user_v1_user_events_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_api_user_v1_user_events_proto_goTypes,
 		DependencyIndexes: file_api_user_v1_user_events_proto_depIdxs,
+		EnumInfos:         file_api_user_v1_user_events_proto_enumTypes,
 		MessageInfos:      file_api_user_v1_user_events_proto_msgTypes,
 	}.Build()
 	File_api_user_v1_user_events_proto = out.File
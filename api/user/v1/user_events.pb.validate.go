@@ -389,6 +389,669 @@ var _ interface {
 	ErrorName() string
 } = DeleteValidationError{}
 
+// Validate checks the field values on PreferencesUpdate with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PreferencesUpdate) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PreferencesUpdate with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PreferencesUpdateMultiError, or nil if none found.
+func (m *PreferencesUpdate) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PreferencesUpdate) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	for idx, item := range m.GetPreferences() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PreferencesUpdateValidationError{
+						field:  fmt.Sprintf("Preferences[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PreferencesUpdateValidationError{
+						field:  fmt.Sprintf("Preferences[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PreferencesUpdateValidationError{
+					field:  fmt.Sprintf("Preferences[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return PreferencesUpdateMultiError(errors)
+	}
+
+	return nil
+}
+
+// PreferencesUpdateMultiError is an error wrapping multiple validation errors
+// returned by PreferencesUpdate.ValidateAll() if the designated constraints
+// aren't met.
+type PreferencesUpdateMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PreferencesUpdateMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PreferencesUpdateMultiError) AllErrors() []error { return m }
+
+// PreferencesUpdateValidationError is the validation error returned by
+// PreferencesUpdate.Validate if the designated constraints aren't met.
+type PreferencesUpdateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PreferencesUpdateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PreferencesUpdateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PreferencesUpdateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PreferencesUpdateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PreferencesUpdateValidationError) ErrorName() string {
+	return "PreferencesUpdateValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PreferencesUpdateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPreferencesUpdate.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PreferencesUpdateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PreferencesUpdateValidationError{}
+
+// Validate checks the field values on LoggedIn with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LoggedIn) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoggedIn with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LoggedInMultiError, or nil
+// if none found.
+func (m *LoggedIn) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoggedIn) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Ip
+
+	// no validation rules for UserAgent
+
+	if len(errors) > 0 {
+		return LoggedInMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoggedInMultiError is an error wrapping multiple validation errors returned
+// by LoggedIn.ValidateAll() if the designated constraints aren't met.
+type LoggedInMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoggedInMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoggedInMultiError) AllErrors() []error { return m }
+
+// LoggedInValidationError is the validation error returned by
+// LoggedIn.Validate if the designated constraints aren't met.
+type LoggedInValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoggedInValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoggedInValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoggedInValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoggedInValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoggedInValidationError) ErrorName() string { return "LoggedInValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoggedInValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoggedIn.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoggedInValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoggedInValidationError{}
+
+// Validate checks the field values on LoggedOut with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LoggedOut) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoggedOut with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LoggedOutMultiError, or nil
+// if none found.
+func (m *LoggedOut) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoggedOut) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for SessionId
+
+	if len(errors) > 0 {
+		return LoggedOutMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoggedOutMultiError is an error wrapping multiple validation errors returned
+// by LoggedOut.ValidateAll() if the designated constraints aren't met.
+type LoggedOutMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoggedOutMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoggedOutMultiError) AllErrors() []error { return m }
+
+// LoggedOutValidationError is the validation error returned by
+// LoggedOut.Validate if the designated constraints aren't met.
+type LoggedOutValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoggedOutValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoggedOutValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoggedOutValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoggedOutValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoggedOutValidationError) ErrorName() string { return "LoggedOutValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoggedOutValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoggedOut.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoggedOutValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoggedOutValidationError{}
+
+// Validate checks the field values on LoginFailed with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LoginFailed) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoginFailed with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LoginFailedMultiError, or
+// nil if none found.
+func (m *LoginFailed) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoginFailed) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Email
+
+	// no validation rules for Ip
+
+	// no validation rules for UserAgent
+
+	// no validation rules for Reason
+
+	if len(errors) > 0 {
+		return LoginFailedMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoginFailedMultiError is an error wrapping multiple validation errors
+// returned by LoginFailed.ValidateAll() if the designated constraints aren't met.
+type LoginFailedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoginFailedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoginFailedMultiError) AllErrors() []error { return m }
+
+// LoginFailedValidationError is the validation error returned by
+// LoginFailed.Validate if the designated constraints aren't met.
+type LoginFailedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoginFailedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoginFailedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoginFailedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoginFailedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoginFailedValidationError) ErrorName() string { return "LoginFailedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoginFailedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoginFailed.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoginFailedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoginFailedValidationError{}
+
+// Validate checks the field values on PasswordChanged with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PasswordChanged) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PasswordChanged with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PasswordChangedMultiError, or nil if none found.
+func (m *PasswordChanged) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PasswordChanged) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	if len(errors) > 0 {
+		return PasswordChangedMultiError(errors)
+	}
+
+	return nil
+}
+
+// PasswordChangedMultiError is an error wrapping multiple validation errors
+// returned by PasswordChanged.ValidateAll() if the designated constraints
+// aren't met.
+type PasswordChangedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PasswordChangedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PasswordChangedMultiError) AllErrors() []error { return m }
+
+// PasswordChangedValidationError is the validation error returned by
+// PasswordChanged.Validate if the designated constraints aren't met.
+type PasswordChangedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PasswordChangedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PasswordChangedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PasswordChangedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PasswordChangedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PasswordChangedValidationError) ErrorName() string { return "PasswordChangedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PasswordChangedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPasswordChanged.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PasswordChangedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PasswordChangedValidationError{}
+
+// Validate checks the field values on AvatarChanged with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AvatarChanged) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AvatarChanged with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AvatarChangedMultiError, or
+// nil if none found.
+func (m *AvatarChanged) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AvatarChanged) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for AvatarId
+
+	// no validation rules for PrevAvatarId
+
+	if len(errors) > 0 {
+		return AvatarChangedMultiError(errors)
+	}
+
+	return nil
+}
+
+// AvatarChangedMultiError is an error wrapping multiple validation errors
+// returned by AvatarChanged.ValidateAll() if the designated constraints
+// aren't met.
+type AvatarChangedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AvatarChangedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AvatarChangedMultiError) AllErrors() []error { return m }
+
+// AvatarChangedValidationError is the validation error returned by
+// AvatarChanged.Validate if the designated constraints aren't met.
+type AvatarChangedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AvatarChangedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AvatarChangedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AvatarChangedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AvatarChangedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AvatarChangedValidationError) ErrorName() string { return "AvatarChangedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AvatarChangedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAvatarChanged.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AvatarChangedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AvatarChangedValidationError{}
+
 // Validate checks the field values on Event with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -534,6 +1197,252 @@ func (m *Event) validate(all bool) error {
 			}
 		}
 
+	case *Event_Preferences:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetPreferences()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "Preferences",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "Preferences",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetPreferences()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "Preferences",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Event_LoggedIn:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetLoggedIn()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "LoggedIn",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "LoggedIn",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLoggedIn()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "LoggedIn",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Event_LoggedOut:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetLoggedOut()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "LoggedOut",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "LoggedOut",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLoggedOut()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "LoggedOut",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Event_LoginFailed:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetLoginFailed()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "LoginFailed",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "LoginFailed",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLoginFailed()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "LoginFailed",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Event_PasswordChanged:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetPasswordChanged()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "PasswordChanged",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "PasswordChanged",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetPasswordChanged()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "PasswordChanged",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Event_AvatarChanged:
+		if v == nil {
+			err := EventValidationError{
+				field:  "Body",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetAvatarChanged()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "AvatarChanged",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  "AvatarChanged",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetAvatarChanged()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  "AvatarChanged",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
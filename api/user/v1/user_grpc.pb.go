@@ -19,18 +19,33 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	UserExternalAPI_VerificationEmail_FullMethodName    = "/api.user.v1.UserExternalAPI/VerificationEmail"
-	UserExternalAPI_VerificationUsername_FullMethodName = "/api.user.v1.UserExternalAPI/VerificationUsername"
-	UserExternalAPI_CreateUser_FullMethodName           = "/api.user.v1.UserExternalAPI/CreateUser"
-	UserExternalAPI_Login_FullMethodName                = "/api.user.v1.UserExternalAPI/Login"
-	UserExternalAPI_Logout_FullMethodName               = "/api.user.v1.UserExternalAPI/Logout"
-	UserExternalAPI_GetUser_FullMethodName              = "/api.user.v1.UserExternalAPI/GetUser"
-	UserExternalAPI_SearchUsers_FullMethodName          = "/api.user.v1.UserExternalAPI/SearchUsers"
-	UserExternalAPI_UpdatePassword_FullMethodName       = "/api.user.v1.UserExternalAPI/UpdatePassword"
-	UserExternalAPI_UpdateUser_FullMethodName           = "/api.user.v1.UserExternalAPI/UpdateUser"
-	UserExternalAPI_RemoveAvatar_FullMethodName         = "/api.user.v1.UserExternalAPI/RemoveAvatar"
-	UserExternalAPI_ListUserAvatar_FullMethodName       = "/api.user.v1.UserExternalAPI/ListUserAvatar"
-	UserExternalAPI_GetUsersByIDs_FullMethodName        = "/api.user.v1.UserExternalAPI/GetUsersByIDs"
+	UserExternalAPI_VerificationEmail_FullMethodName     = "/api.user.v1.UserExternalAPI/VerificationEmail"
+	UserExternalAPI_VerificationUsername_FullMethodName  = "/api.user.v1.UserExternalAPI/VerificationUsername"
+	UserExternalAPI_CreateUser_FullMethodName            = "/api.user.v1.UserExternalAPI/CreateUser"
+	UserExternalAPI_Login_FullMethodName                 = "/api.user.v1.UserExternalAPI/Login"
+	UserExternalAPI_Logout_FullMethodName                = "/api.user.v1.UserExternalAPI/Logout"
+	UserExternalAPI_GetUser_FullMethodName               = "/api.user.v1.UserExternalAPI/GetUser"
+	UserExternalAPI_ResolveUsername_FullMethodName       = "/api.user.v1.UserExternalAPI/ResolveUsername"
+	UserExternalAPI_SearchUsers_FullMethodName           = "/api.user.v1.UserExternalAPI/SearchUsers"
+	UserExternalAPI_UpdatePassword_FullMethodName        = "/api.user.v1.UserExternalAPI/UpdatePassword"
+	UserExternalAPI_UpdateUser_FullMethodName            = "/api.user.v1.UserExternalAPI/UpdateUser"
+	UserExternalAPI_RemoveAvatar_FullMethodName          = "/api.user.v1.UserExternalAPI/RemoveAvatar"
+	UserExternalAPI_ListUserAvatar_FullMethodName        = "/api.user.v1.UserExternalAPI/ListUserAvatar"
+	UserExternalAPI_SetCurrentAvatar_FullMethodName      = "/api.user.v1.UserExternalAPI/SetCurrentAvatar"
+	UserExternalAPI_ReorderAvatars_FullMethodName        = "/api.user.v1.UserExternalAPI/ReorderAvatars"
+	UserExternalAPI_CreateAvatarUpload_FullMethodName    = "/api.user.v1.UserExternalAPI/CreateAvatarUpload"
+	UserExternalAPI_CompleteAvatarUpload_FullMethodName  = "/api.user.v1.UserExternalAPI/CompleteAvatarUpload"
+	UserExternalAPI_GetAvatarURL_FullMethodName          = "/api.user.v1.UserExternalAPI/GetAvatarURL"
+	UserExternalAPI_GetStorageUsage_FullMethodName       = "/api.user.v1.UserExternalAPI/GetStorageUsage"
+	UserExternalAPI_GetUsersByIDs_FullMethodName         = "/api.user.v1.UserExternalAPI/GetUsersByIDs"
+	UserExternalAPI_GetPrivacySettings_FullMethodName    = "/api.user.v1.UserExternalAPI/GetPrivacySettings"
+	UserExternalAPI_UpdatePrivacySettings_FullMethodName = "/api.user.v1.UserExternalAPI/UpdatePrivacySettings"
+	UserExternalAPI_GetPreferences_FullMethodName        = "/api.user.v1.UserExternalAPI/GetPreferences"
+	UserExternalAPI_SetPreferences_FullMethodName        = "/api.user.v1.UserExternalAPI/SetPreferences"
+	UserExternalAPI_BatchGetUsers_FullMethodName         = "/api.user.v1.UserExternalAPI/BatchGetUsers"
+	UserExternalAPI_ListDeadTasks_FullMethodName         = "/api.user.v1.UserExternalAPI/ListDeadTasks"
+	UserExternalAPI_RetryDeadTask_FullMethodName         = "/api.user.v1.UserExternalAPI/RetryDeadTask"
+	UserExternalAPI_DiscardDeadTask_FullMethodName       = "/api.user.v1.UserExternalAPI/DiscardDeadTask"
 )
 
 // UserExternalAPIClient is the client API for UserExternalAPI service.
@@ -41,7 +56,8 @@ type UserExternalAPIClient interface {
 	// It should be valid email.
 	VerificationEmail(ctx context.Context, in *VerificationEmailRequest, opts ...grpc.CallOption) (*VerificationEmailResponse, error)
 	// Verification and exist checking user's name.
-	// It should be valid email.
+	// Reserved usernames return INVALID_ARGUMENT,
+	// usernames recently released by other users return ALREADY_EXISTS.
 	VerificationUsername(ctx context.Context, in *VerificationUsernameRequest, opts ...grpc.CallOption) (*VerificationUsernameResponse, error)
 	// Create user by params.
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
@@ -51,19 +67,66 @@ type UserExternalAPIClient interface {
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
 	// Get user's information.
 	// If you not send user's id, we will return caller's profile by authorization token.
+	// Other users' profiles are reduced according to their privacy settings.
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	// Get user by username.
+	// If username was recently released by rename, we will return user which used it.
+	ResolveUsername(ctx context.Context, in *ResolveUsernameRequest, opts ...grpc.CallOption) (*ResolveUsernameResponse, error)
 	// Search users by username and full name.
+	// Users hidden from search are returned only for specialists.
 	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error)
 	// Set new password.
 	UpdatePassword(ctx context.Context, in *UpdatePasswordRequest, opts ...grpc.CallOption) (*UpdatePasswordResponse, error)
 	// Update profile.
+	// Previous username stays held by caller during cooldown period.
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	// Remove user avatar.
 	RemoveAvatar(ctx context.Context, in *RemoveAvatarRequest, opts ...grpc.CallOption) (*RemoveAvatarResponse, error)
-	// List user avatar.
+	// List user avatar gallery ordered by position.
+	// Gallery of session owner is returned if user_id is empty.
 	ListUserAvatar(ctx context.Context, in *ListUserAvatarRequest, opts ...grpc.CallOption) (*ListUserAvatarResponse, error)
+	// Make avatar from user's gallery current avatar of user.
+	SetCurrentAvatar(ctx context.Context, in *SetCurrentAvatarRequest, opts ...grpc.CallOption) (*SetCurrentAvatarResponse, error)
+	// Set order of user's avatar gallery.
+	// All avatars returned by ListUserAvatar must be passed exactly once.
+	ReorderAvatars(ctx context.Context, in *ReorderAvatarsRequest, opts ...grpc.CallOption) (*ReorderAvatarsResponse, error)
+	// Start avatar upload directly to file store.
+	// File must be uploaded by returned URL with returned headers and then completed by CompleteAvatarUpload.
+	// RESOURCE_EXHAUSTED is returned if file doesn't fit into user's storage quota.
+	// UNIMPLEMENTED is returned if file store doesn't support presigned URLs.
+	CreateAvatarUpload(ctx context.Context, in *CreateAvatarUploadRequest, opts ...grpc.CallOption) (*CreateAvatarUploadResponse, error)
+	// Make avatar from file uploaded by CreateAvatarUpload.
+	// RESOURCE_EXHAUSTED is returned if avatar doesn't fit into user's storage quota.
+	CompleteAvatarUpload(ctx context.Context, in *CompleteAvatarUploadRequest, opts ...grpc.CallOption) (*CompleteAvatarUploadResponse, error)
+	// Get URL for downloading avatar directly from file store.
+	// UNIMPLEMENTED is returned if file store doesn't support presigned URLs.
+	GetAvatarURL(ctx context.Context, in *GetAvatarURLRequest, opts ...grpc.CallOption) (*GetAvatarURLResponse, error)
+	// Get amount of bytes taken by user's avatars and user's storage quota.
+	GetStorageUsage(ctx context.Context, in *GetStorageUsageRequest, opts ...grpc.CallOption) (*GetStorageUsageResponse, error)
 	// Search users by ids.
 	GetUsersByIDs(ctx context.Context, in *GetUsersByIDsRequest, opts ...grpc.CallOption) (*GetUsersByIDsResponse, error)
+	// Get caller's privacy settings.
+	GetPrivacySettings(ctx context.Context, in *GetPrivacySettingsRequest, opts ...grpc.CallOption) (*GetPrivacySettingsResponse, error)
+	// Replace caller's privacy settings.
+	UpdatePrivacySettings(ctx context.Context, in *UpdatePrivacySettingsRequest, opts ...grpc.CallOption) (*UpdatePrivacySettingsResponse, error)
+	// Get caller's preferences ordered by key.
+	// Preferences which were never set are returned with default values.
+	GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*GetPreferencesResponse, error)
+	// Set caller's preferences. Only passed keys are changed.
+	// Keys and value types must be known by the preferences registry.
+	SetPreferences(ctx context.Context, in *SetPreferencesRequest, opts ...grpc.CallOption) (*SetPreferencesResponse, error)
+	// Get users by mix of ids, usernames and emails.
+	// Unknown keys are returned in missing list instead of NOT_FOUND.
+	BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error)
+	// List outbox tasks moved to dead-letter state after too many failed attempts.
+	// Available for admins only.
+	ListDeadTasks(ctx context.Context, in *ListDeadTasksRequest, opts ...grpc.CallOption) (*ListDeadTasksResponse, error)
+	// Return dead outbox task to processing, task gets all attempts again.
+	// Available for admins only.
+	RetryDeadTask(ctx context.Context, in *RetryDeadTaskRequest, opts ...grpc.CallOption) (*RetryDeadTaskResponse, error)
+	// Remove dead outbox task, its event is never published.
+	// Available for admins only.
+	DiscardDeadTask(ctx context.Context, in *DiscardDeadTaskRequest, opts ...grpc.CallOption) (*DiscardDeadTaskResponse, error)
 }
 
 type userExternalAPIClient struct {
@@ -128,6 +191,15 @@ func (c *userExternalAPIClient) GetUser(ctx context.Context, in *GetUserRequest,
 	return out, nil
 }
 
+func (c *userExternalAPIClient) ResolveUsername(ctx context.Context, in *ResolveUsernameRequest, opts ...grpc.CallOption) (*ResolveUsernameResponse, error) {
+	out := new(ResolveUsernameResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_ResolveUsername_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userExternalAPIClient) SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error) {
 	out := new(SearchUsersResponse)
 	err := c.cc.Invoke(ctx, UserExternalAPI_SearchUsers_FullMethodName, in, out, opts...)
@@ -173,6 +245,60 @@ func (c *userExternalAPIClient) ListUserAvatar(ctx context.Context, in *ListUser
 	return out, nil
 }
 
+func (c *userExternalAPIClient) SetCurrentAvatar(ctx context.Context, in *SetCurrentAvatarRequest, opts ...grpc.CallOption) (*SetCurrentAvatarResponse, error) {
+	out := new(SetCurrentAvatarResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_SetCurrentAvatar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) ReorderAvatars(ctx context.Context, in *ReorderAvatarsRequest, opts ...grpc.CallOption) (*ReorderAvatarsResponse, error) {
+	out := new(ReorderAvatarsResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_ReorderAvatars_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) CreateAvatarUpload(ctx context.Context, in *CreateAvatarUploadRequest, opts ...grpc.CallOption) (*CreateAvatarUploadResponse, error) {
+	out := new(CreateAvatarUploadResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_CreateAvatarUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) CompleteAvatarUpload(ctx context.Context, in *CompleteAvatarUploadRequest, opts ...grpc.CallOption) (*CompleteAvatarUploadResponse, error) {
+	out := new(CompleteAvatarUploadResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_CompleteAvatarUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) GetAvatarURL(ctx context.Context, in *GetAvatarURLRequest, opts ...grpc.CallOption) (*GetAvatarURLResponse, error) {
+	out := new(GetAvatarURLResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_GetAvatarURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) GetStorageUsage(ctx context.Context, in *GetStorageUsageRequest, opts ...grpc.CallOption) (*GetStorageUsageResponse, error) {
+	out := new(GetStorageUsageResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_GetStorageUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userExternalAPIClient) GetUsersByIDs(ctx context.Context, in *GetUsersByIDsRequest, opts ...grpc.CallOption) (*GetUsersByIDsResponse, error) {
 	out := new(GetUsersByIDsResponse)
 	err := c.cc.Invoke(ctx, UserExternalAPI_GetUsersByIDs_FullMethodName, in, out, opts...)
@@ -182,6 +308,78 @@ func (c *userExternalAPIClient) GetUsersByIDs(ctx context.Context, in *GetUsersB
 	return out, nil
 }
 
+func (c *userExternalAPIClient) GetPrivacySettings(ctx context.Context, in *GetPrivacySettingsRequest, opts ...grpc.CallOption) (*GetPrivacySettingsResponse, error) {
+	out := new(GetPrivacySettingsResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_GetPrivacySettings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) UpdatePrivacySettings(ctx context.Context, in *UpdatePrivacySettingsRequest, opts ...grpc.CallOption) (*UpdatePrivacySettingsResponse, error) {
+	out := new(UpdatePrivacySettingsResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_UpdatePrivacySettings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*GetPreferencesResponse, error) {
+	out := new(GetPreferencesResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_GetPreferences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) SetPreferences(ctx context.Context, in *SetPreferencesRequest, opts ...grpc.CallOption) (*SetPreferencesResponse, error) {
+	out := new(SetPreferencesResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_SetPreferences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error) {
+	out := new(BatchGetUsersResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_BatchGetUsers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) ListDeadTasks(ctx context.Context, in *ListDeadTasksRequest, opts ...grpc.CallOption) (*ListDeadTasksResponse, error) {
+	out := new(ListDeadTasksResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_ListDeadTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) RetryDeadTask(ctx context.Context, in *RetryDeadTaskRequest, opts ...grpc.CallOption) (*RetryDeadTaskResponse, error) {
+	out := new(RetryDeadTaskResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_RetryDeadTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExternalAPIClient) DiscardDeadTask(ctx context.Context, in *DiscardDeadTaskRequest, opts ...grpc.CallOption) (*DiscardDeadTaskResponse, error) {
+	out := new(DiscardDeadTaskResponse)
+	err := c.cc.Invoke(ctx, UserExternalAPI_DiscardDeadTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserExternalAPIServer is the server API for UserExternalAPI service.
 // All implementations should embed UnimplementedUserExternalAPIServer
 // for forward compatibility
@@ -190,7 +388,8 @@ type UserExternalAPIServer interface {
 	// It should be valid email.
 	VerificationEmail(context.Context, *VerificationEmailRequest) (*VerificationEmailResponse, error)
 	// Verification and exist checking user's name.
-	// It should be valid email.
+	// Reserved usernames return INVALID_ARGUMENT,
+	// usernames recently released by other users return ALREADY_EXISTS.
 	VerificationUsername(context.Context, *VerificationUsernameRequest) (*VerificationUsernameResponse, error)
 	// Create user by params.
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
@@ -200,19 +399,66 @@ type UserExternalAPIServer interface {
 	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
 	// Get user's information.
 	// If you not send user's id, we will return caller's profile by authorization token.
+	// Other users' profiles are reduced according to their privacy settings.
 	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	// Get user by username.
+	// If username was recently released by rename, we will return user which used it.
+	ResolveUsername(context.Context, *ResolveUsernameRequest) (*ResolveUsernameResponse, error)
 	// Search users by username and full name.
+	// Users hidden from search are returned only for specialists.
 	SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error)
 	// Set new password.
 	UpdatePassword(context.Context, *UpdatePasswordRequest) (*UpdatePasswordResponse, error)
 	// Update profile.
+	// Previous username stays held by caller during cooldown period.
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	// Remove user avatar.
 	RemoveAvatar(context.Context, *RemoveAvatarRequest) (*RemoveAvatarResponse, error)
-	// List user avatar.
+	// List user avatar gallery ordered by position.
+	// Gallery of session owner is returned if user_id is empty.
 	ListUserAvatar(context.Context, *ListUserAvatarRequest) (*ListUserAvatarResponse, error)
+	// Make avatar from user's gallery current avatar of user.
+	SetCurrentAvatar(context.Context, *SetCurrentAvatarRequest) (*SetCurrentAvatarResponse, error)
+	// Set order of user's avatar gallery.
+	// All avatars returned by ListUserAvatar must be passed exactly once.
+	ReorderAvatars(context.Context, *ReorderAvatarsRequest) (*ReorderAvatarsResponse, error)
+	// Start avatar upload directly to file store.
+	// File must be uploaded by returned URL with returned headers and then completed by CompleteAvatarUpload.
+	// RESOURCE_EXHAUSTED is returned if file doesn't fit into user's storage quota.
+	// UNIMPLEMENTED is returned if file store doesn't support presigned URLs.
+	CreateAvatarUpload(context.Context, *CreateAvatarUploadRequest) (*CreateAvatarUploadResponse, error)
+	// Make avatar from file uploaded by CreateAvatarUpload.
+	// RESOURCE_EXHAUSTED is returned if avatar doesn't fit into user's storage quota.
+	CompleteAvatarUpload(context.Context, *CompleteAvatarUploadRequest) (*CompleteAvatarUploadResponse, error)
+	// Get URL for downloading avatar directly from file store.
+	// UNIMPLEMENTED is returned if file store doesn't support presigned URLs.
+	GetAvatarURL(context.Context, *GetAvatarURLRequest) (*GetAvatarURLResponse, error)
+	// Get amount of bytes taken by user's avatars and user's storage quota.
+	GetStorageUsage(context.Context, *GetStorageUsageRequest) (*GetStorageUsageResponse, error)
 	// Search users by ids.
 	GetUsersByIDs(context.Context, *GetUsersByIDsRequest) (*GetUsersByIDsResponse, error)
+	// Get caller's privacy settings.
+	GetPrivacySettings(context.Context, *GetPrivacySettingsRequest) (*GetPrivacySettingsResponse, error)
+	// Replace caller's privacy settings.
+	UpdatePrivacySettings(context.Context, *UpdatePrivacySettingsRequest) (*UpdatePrivacySettingsResponse, error)
+	// Get caller's preferences ordered by key.
+	// Preferences which were never set are returned with default values.
+	GetPreferences(context.Context, *GetPreferencesRequest) (*GetPreferencesResponse, error)
+	// Set caller's preferences. Only passed keys are changed.
+	// Keys and value types must be known by the preferences registry.
+	SetPreferences(context.Context, *SetPreferencesRequest) (*SetPreferencesResponse, error)
+	// Get users by mix of ids, usernames and emails.
+	// Unknown keys are returned in missing list instead of NOT_FOUND.
+	BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error)
+	// List outbox tasks moved to dead-letter state after too many failed attempts.
+	// Available for admins only.
+	ListDeadTasks(context.Context, *ListDeadTasksRequest) (*ListDeadTasksResponse, error)
+	// Return dead outbox task to processing, task gets all attempts again.
+	// Available for admins only.
+	RetryDeadTask(context.Context, *RetryDeadTaskRequest) (*RetryDeadTaskResponse, error)
+	// Remove dead outbox task, its event is never published.
+	// Available for admins only.
+	DiscardDeadTask(context.Context, *DiscardDeadTaskRequest) (*DiscardDeadTaskResponse, error)
 }
 
 // UnimplementedUserExternalAPIServer should be embedded to have forward compatible implementations.
@@ -237,6 +483,9 @@ func (UnimplementedUserExternalAPIServer) Logout(context.Context, *LogoutRequest
 func (UnimplementedUserExternalAPIServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
 }
+func (UnimplementedUserExternalAPIServer) ResolveUsername(context.Context, *ResolveUsernameRequest) (*ResolveUsernameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveUsername not implemented")
+}
 func (UnimplementedUserExternalAPIServer) SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchUsers not implemented")
 }
@@ -252,9 +501,51 @@ func (UnimplementedUserExternalAPIServer) RemoveAvatar(context.Context, *RemoveA
 func (UnimplementedUserExternalAPIServer) ListUserAvatar(context.Context, *ListUserAvatarRequest) (*ListUserAvatarResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUserAvatar not implemented")
 }
+func (UnimplementedUserExternalAPIServer) SetCurrentAvatar(context.Context, *SetCurrentAvatarRequest) (*SetCurrentAvatarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCurrentAvatar not implemented")
+}
+func (UnimplementedUserExternalAPIServer) ReorderAvatars(context.Context, *ReorderAvatarsRequest) (*ReorderAvatarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderAvatars not implemented")
+}
+func (UnimplementedUserExternalAPIServer) CreateAvatarUpload(context.Context, *CreateAvatarUploadRequest) (*CreateAvatarUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAvatarUpload not implemented")
+}
+func (UnimplementedUserExternalAPIServer) CompleteAvatarUpload(context.Context, *CompleteAvatarUploadRequest) (*CompleteAvatarUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteAvatarUpload not implemented")
+}
+func (UnimplementedUserExternalAPIServer) GetAvatarURL(context.Context, *GetAvatarURLRequest) (*GetAvatarURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvatarURL not implemented")
+}
+func (UnimplementedUserExternalAPIServer) GetStorageUsage(context.Context, *GetStorageUsageRequest) (*GetStorageUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageUsage not implemented")
+}
 func (UnimplementedUserExternalAPIServer) GetUsersByIDs(context.Context, *GetUsersByIDsRequest) (*GetUsersByIDsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByIDs not implemented")
 }
+func (UnimplementedUserExternalAPIServer) GetPrivacySettings(context.Context, *GetPrivacySettingsRequest) (*GetPrivacySettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrivacySettings not implemented")
+}
+func (UnimplementedUserExternalAPIServer) UpdatePrivacySettings(context.Context, *UpdatePrivacySettingsRequest) (*UpdatePrivacySettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePrivacySettings not implemented")
+}
+func (UnimplementedUserExternalAPIServer) GetPreferences(context.Context, *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedUserExternalAPIServer) SetPreferences(context.Context, *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPreferences not implemented")
+}
+func (UnimplementedUserExternalAPIServer) BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetUsers not implemented")
+}
+func (UnimplementedUserExternalAPIServer) ListDeadTasks(context.Context, *ListDeadTasksRequest) (*ListDeadTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadTasks not implemented")
+}
+func (UnimplementedUserExternalAPIServer) RetryDeadTask(context.Context, *RetryDeadTaskRequest) (*RetryDeadTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryDeadTask not implemented")
+}
+func (UnimplementedUserExternalAPIServer) DiscardDeadTask(context.Context, *DiscardDeadTaskRequest) (*DiscardDeadTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardDeadTask not implemented")
+}
 
 // UnsafeUserExternalAPIServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to UserExternalAPIServer will
@@ -375,6 +666,24 @@ func _UserExternalAPI_GetUser_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserExternalAPI_ResolveUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).ResolveUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_ResolveUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).ResolveUsername(ctx, req.(*ResolveUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserExternalAPI_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchUsersRequest)
 	if err := dec(in); err != nil {
@@ -465,6 +774,114 @@ func _UserExternalAPI_ListUserAvatar_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserExternalAPI_SetCurrentAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCurrentAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).SetCurrentAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_SetCurrentAvatar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).SetCurrentAvatar(ctx, req.(*SetCurrentAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_ReorderAvatars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderAvatarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).ReorderAvatars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_ReorderAvatars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).ReorderAvatars(ctx, req.(*ReorderAvatarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_CreateAvatarUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAvatarUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).CreateAvatarUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_CreateAvatarUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).CreateAvatarUpload(ctx, req.(*CreateAvatarUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_CompleteAvatarUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteAvatarUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).CompleteAvatarUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_CompleteAvatarUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).CompleteAvatarUpload(ctx, req.(*CompleteAvatarUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_GetAvatarURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvatarURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).GetAvatarURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_GetAvatarURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).GetAvatarURL(ctx, req.(*GetAvatarURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_GetStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).GetStorageUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_GetStorageUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).GetStorageUsage(ctx, req.(*GetStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserExternalAPI_GetUsersByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUsersByIDsRequest)
 	if err := dec(in); err != nil {
@@ -483,6 +900,150 @@ func _UserExternalAPI_GetUsersByIDs_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserExternalAPI_GetPrivacySettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPrivacySettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).GetPrivacySettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_GetPrivacySettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).GetPrivacySettings(ctx, req.(*GetPrivacySettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_UpdatePrivacySettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePrivacySettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).UpdatePrivacySettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_UpdatePrivacySettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).UpdatePrivacySettings(ctx, req.(*UpdatePrivacySettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).GetPreferences(ctx, req.(*GetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_SetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).SetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_SetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).SetPreferences(ctx, req.(*SetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_BatchGetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).BatchGetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_BatchGetUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).BatchGetUsers(ctx, req.(*BatchGetUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_ListDeadTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).ListDeadTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_ListDeadTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).ListDeadTasks(ctx, req.(*ListDeadTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_RetryDeadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryDeadTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).RetryDeadTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_RetryDeadTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).RetryDeadTask(ctx, req.(*RetryDeadTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExternalAPI_DiscardDeadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardDeadTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExternalAPIServer).DiscardDeadTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserExternalAPI_DiscardDeadTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExternalAPIServer).DiscardDeadTask(ctx, req.(*DiscardDeadTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserExternalAPI_ServiceDesc is the grpc.ServiceDesc for UserExternalAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -514,6 +1075,10 @@ var UserExternalAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUser",
 			Handler:    _UserExternalAPI_GetUser_Handler,
 		},
+		{
+			MethodName: "ResolveUsername",
+			Handler:    _UserExternalAPI_ResolveUsername_Handler,
+		},
 		{
 			MethodName: "SearchUsers",
 			Handler:    _UserExternalAPI_SearchUsers_Handler,
@@ -534,10 +1099,66 @@ var UserExternalAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUserAvatar",
 			Handler:    _UserExternalAPI_ListUserAvatar_Handler,
 		},
+		{
+			MethodName: "SetCurrentAvatar",
+			Handler:    _UserExternalAPI_SetCurrentAvatar_Handler,
+		},
+		{
+			MethodName: "ReorderAvatars",
+			Handler:    _UserExternalAPI_ReorderAvatars_Handler,
+		},
+		{
+			MethodName: "CreateAvatarUpload",
+			Handler:    _UserExternalAPI_CreateAvatarUpload_Handler,
+		},
+		{
+			MethodName: "CompleteAvatarUpload",
+			Handler:    _UserExternalAPI_CompleteAvatarUpload_Handler,
+		},
+		{
+			MethodName: "GetAvatarURL",
+			Handler:    _UserExternalAPI_GetAvatarURL_Handler,
+		},
+		{
+			MethodName: "GetStorageUsage",
+			Handler:    _UserExternalAPI_GetStorageUsage_Handler,
+		},
 		{
 			MethodName: "GetUsersByIDs",
 			Handler:    _UserExternalAPI_GetUsersByIDs_Handler,
 		},
+		{
+			MethodName: "GetPrivacySettings",
+			Handler:    _UserExternalAPI_GetPrivacySettings_Handler,
+		},
+		{
+			MethodName: "UpdatePrivacySettings",
+			Handler:    _UserExternalAPI_UpdatePrivacySettings_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _UserExternalAPI_GetPreferences_Handler,
+		},
+		{
+			MethodName: "SetPreferences",
+			Handler:    _UserExternalAPI_SetPreferences_Handler,
+		},
+		{
+			MethodName: "BatchGetUsers",
+			Handler:    _UserExternalAPI_BatchGetUsers_Handler,
+		},
+		{
+			MethodName: "ListDeadTasks",
+			Handler:    _UserExternalAPI_ListDeadTasks_Handler,
+		},
+		{
+			MethodName: "RetryDeadTask",
+			Handler:    _UserExternalAPI_RetryDeadTask_Handler,
+		},
+		{
+			MethodName: "DiscardDeadTask",
+			Handler:    _UserExternalAPI_DiscardDeadTask_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/user/v1/user.proto",
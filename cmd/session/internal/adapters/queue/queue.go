@@ -12,13 +12,20 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 
+	events_pb "github.com/ZergsLaw/back-template1/api/events/v1"
+	session_pb "github.com/ZergsLaw/back-template1/api/session/v1"
 	user_pb "github.com/ZergsLaw/back-template1/api/user/v1"
 	"github.com/ZergsLaw/back-template1/cmd/session/internal/app"
 	"github.com/ZergsLaw/back-template1/internal/dom"
+	"github.com/ZergsLaw/back-template1/internal/events"
+	"github.com/ZergsLaw/back-template1/internal/outbox"
 	"github.com/ZergsLaw/back-template1/internal/queue"
 )
 
-var _ app.Queue = &Client{}
+var (
+	_ app.Queue        = &Client{}
+	_ outbox.Publisher = &Client{}
+)
 
 type (
 	// Config provide connection info for message broker.
@@ -31,6 +38,7 @@ type (
 	Client struct {
 		consumerName string
 		queue        *queue.Queue
+		registry     *events.Registry
 		m            Metrics
 		chUpStatus   chan dom.Event[app.UpdateStatus]
 	}
@@ -54,6 +62,7 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 	return &Client{
 		consumerName: namespace,
 		queue:        client,
+		registry:     user_pb.Registry(),
 		m:            m,
 		chUpStatus:   make(chan dom.Event[app.UpdateStatus]),
 	}, nil
@@ -117,14 +126,13 @@ func (c *Client) handleEvent(ctx context.Context, msg queue.Message) error {
 }
 
 func (c *Client) handleUpStatus(ctx context.Context, ack chan dom.AcknowledgeKind, msgID uuid.UUID, msg queue.Message) error {
-	event := &user_pb.Event{}
-	err := msg.Unmarshal(event)
+	event, err := c.decode(msg)
 	if err != nil {
-		return fmt.Errorf("msg.Unmarshal: %w", err)
+		return err
 	}
 
 	updateEvent := event.GetUpdate()
-	if err != nil {
+	if updateEvent == nil {
 		return fmt.Errorf("%w: event.GetUpdate: %+v", queue.ErrIncorrectMessage, event.GetBody())
 	}
 
@@ -142,6 +150,45 @@ func (c *Client) handleUpStatus(ctx context.Context, ack chan dom.AcknowledgeKin
 	return nil
 }
 
+// decode returns user event from envelope of message.
+// Messages published before envelopes were introduced contain raw event,
+// they are recognized by absent schema version, which is set in every envelope.
+func (c *Client) decode(msg queue.Message) (*user_pb.Event, error) {
+	env := &events_pb.Envelope{}
+	err := msg.Unmarshal(env)
+	if err != nil || env.SchemaVersion == 0 {
+		event := &user_pb.Event{}
+		err = msg.Unmarshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("msg.Unmarshal: %w", err)
+		}
+
+		err = events.Validate(event)
+		if err != nil {
+			return nil, fmt.Errorf("%w: events.Validate: %w", queue.ErrIncorrectMessage, err)
+		}
+
+		return event, nil
+	}
+
+	decoded, err := c.registry.Decode(env)
+	if err != nil {
+		return nil, fmt.Errorf("%w: c.registry.Decode: %w", queue.ErrIncorrectMessage, err)
+	}
+
+	event, ok := decoded.(*user_pb.Event)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected event %T", queue.ErrIncorrectMessage, decoded)
+	}
+
+	return event, nil
+}
+
+// Publish implements outbox.Publisher.
+func (c *Client) Publish(ctx context.Context, topic string, msgID uuid.UUID, event any) error {
+	return c.queue.Publish(ctx, topic, msgID, event)
+}
+
 // Close implements io.Closer.
 func (c *Client) Close() error {
 	return c.queue.Drain()
@@ -165,6 +212,11 @@ func migrate(namespace string) func(manager nats.JetStreamManager) error {
 			return fmt.Errorf("user.Migrate: %w", err)
 		}
 
+		err = session_pb.Migrate(manager)
+		if err != nil {
+			return fmt.Errorf("session.Migrate: %w", err)
+		}
+
 		_, err = manager.AddConsumer(user_pb.Stream, &nats.ConsumerConfig{
 			Durable:       namespace,
 			Description:   "Consumer for updating user's session status.",
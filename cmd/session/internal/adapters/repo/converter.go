@@ -12,7 +12,6 @@ import (
 )
 
 const (
-	duplPKey    = "deduplication_pkey"
 	duplPrimary = "primary"
 )
 
@@ -31,8 +30,6 @@ func convertErr(err error) error {
 
 func constraint(pqErr *pq.Error) error {
 	switch {
-	case strings.HasSuffix(pqErr.Message, fmt.Sprintf("unique constraint %q", duplPKey)):
-		return app.ErrDuplicate
 	case strings.HasSuffix(pqErr.Message, fmt.Sprintf("unique constraint %q", duplPrimary)):
 		return app.ErrDuplicate
 	default:
@@ -0,0 +1,64 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ZergsLaw/back-template/internal/inbox"
+	"github.com/ZergsLaw/back-template/internal/queue"
+)
+
+func TestRepo_Inbox(t *testing.T) {
+	t.Parallel()
+
+	ctx, r, assert := start(t)
+	in := inbox.New(r.Inbox(), inbox.Config{})
+
+	effects := 0
+	handler := in.Wrap("consumer", func(ctx context.Context, tx *sqlx.Tx, msg queue.Message) error {
+		// Message is recorded in the same transaction.
+		var count int
+		err := tx.GetContext(ctx, &count, `select count(*) from inbox where message_id = $1`, msg.ID())
+		assert.NoError(err)
+		assert.Equal(1, count)
+		effects++
+
+		return nil
+	})
+
+	// Redelivered message is acknowledged without running handler again.
+	msg := &message{id: uuid.Must(uuid.NewV4())}
+	for i := 0; i < 2; i++ {
+		msg.acked = false
+		assert.NoError(handler(ctx, msg))
+		assert.True(msg.acked)
+	}
+	assert.Equal(1, effects)
+
+	// The same message is processed once by every consumer.
+	other := in.Wrap("other", func(context.Context, *sqlx.Tx, queue.Message) error {
+		effects++
+
+		return nil
+	})
+	assert.NoError(other(ctx, msg))
+	assert.Equal(2, effects)
+
+	assert.NoError(in.RemoveExpired(ctx))
+}
+
+type message struct {
+	id    uuid.UUID
+	acked bool
+}
+
+func (m *message) ID() uuid.UUID             { return m.id }
+func (*message) Subject() string             { return "subject" }
+func (*message) Unmarshal(any) error         { return nil }
+func (m *message) Ack(context.Context) error { m.acked = true; return nil }
+func (*message) Nack(context.Context) error  { return nil }
@@ -0,0 +1,98 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	session_pb "github.com/ZergsLaw/back-template/api/session/v1"
+	"github.com/ZergsLaw/back-template/cmd/session/internal/app"
+	"github.com/ZergsLaw/back-template/internal/dom"
+	"github.com/ZergsLaw/back-template/internal/outbox"
+)
+
+func TestRepo_Outbox(t *testing.T) {
+	t.Parallel()
+
+	ctx, r, assert := start(t)
+	o := r.Outbox()
+
+	// Every saved session writes one message.
+	for i := 0; i < 4; i++ {
+		err := r.Save(ctx, app.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			Origin: app.Origin{IP: net.ParseIP("192.100.10.4"), UserAgent: "Mozilla/5.0"},
+			Token:  app.Token{Value: uuid.Must(uuid.NewV4()).String()},
+			Status: dom.UserStatusDefault,
+			UserID: uuid.Must(uuid.NewV4()),
+		})
+		assert.NoError(err)
+	}
+
+	ids := func(msgs []outbox.Message) []uuid.UUID {
+		res := make([]uuid.UUID, len(msgs))
+		for i := range msgs {
+			res[i] = msgs[i].ID
+		}
+
+		return res
+	}
+
+	all, err := o.Claim(ctx, "worker1", time.Millisecond, 10)
+	assert.NoError(err)
+	assert.Len(all, 4)
+	for i := range all {
+		assert.Equal(session_pb.TopicCreated, all[i].Topic)
+		assert.Zero(all[i].Attempts)
+		assert.NotEmpty(all[i].Payload)
+		if i > 0 {
+			assert.False(all[i].CreatedAt.Before(all[i-1].CreatedAt))
+		}
+	}
+	time.Sleep(time.Millisecond * 10)
+
+	// Expired lease is taken by other worker, claimed messages are skipped until their lease is over.
+	claimed, err := o.Claim(ctx, "worker2", time.Minute, 2)
+	assert.NoError(err)
+	assert.Equal(ids(all[:2]), ids(claimed))
+
+	claimed, err = o.Claim(ctx, "worker3", time.Minute, 10)
+	assert.NoError(err)
+	assert.Equal(ids(all[2:]), ids(claimed))
+
+	claimed, err = o.Claim(ctx, "worker4", time.Minute, 10)
+	assert.NoError(err)
+	assert.Empty(claimed)
+
+	// Worker with expired lease doesn't change messages leased by other workers.
+	assert.NoError(o.Fail(ctx, "worker1", all[0].ID, "first error", 0))
+	assert.NoError(o.Kill(ctx, "worker1", all[2].ID, "third error"))
+
+	claimed, err = o.Claim(ctx, "worker4", time.Minute, 10)
+	assert.NoError(err)
+	assert.Empty(claimed)
+
+	// Failed message is released and claimed again after delay.
+	assert.NoError(o.Fail(ctx, "worker2", all[0].ID, "first error", 0))
+	assert.NoError(o.Fail(ctx, "worker2", all[1].ID, "second error", time.Hour))
+	// Dead message isn't claimed.
+	assert.NoError(o.Kill(ctx, "worker3", all[2].ID, "third error"))
+	// Deleted message isn't claimed.
+	assert.NoError(o.Delete(ctx, []uuid.UUID{all[3].ID}))
+
+	claimed, err = o.Claim(ctx, "worker4", time.Minute, 10)
+	assert.NoError(err)
+	assert.Equal(ids(all[:1]), ids(claimed))
+	assert.Equal(1, claimed[0].Attempts)
+
+	assert.NoError(o.Kill(ctx, "worker4", all[0].ID, "first error"))
+	assert.NoError(o.Delete(ctx, []uuid.UUID{all[1].ID, all[2].ID}))
+
+	claimed, err = o.Claim(ctx, "worker4", time.Millisecond, 10)
+	assert.NoError(err)
+	assert.Empty(claimed)
+}
@@ -4,6 +4,7 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"time"
@@ -16,8 +17,12 @@ import (
 	"github.com/sipki-tech/database/connectors"
 	"github.com/sipki-tech/database/migrations"
 
+	session_pb "github.com/ZergsLaw/back-template1/api/session/v1"
 	"github.com/ZergsLaw/back-template1/cmd/session/internal/app"
 	"github.com/ZergsLaw/back-template1/internal/dom"
+	"github.com/ZergsLaw/back-template1/internal/events"
+	"github.com/ZergsLaw/back-template1/internal/inbox"
+	"github.com/ZergsLaw/back-template1/internal/outbox"
 )
 
 var _ app.Repo = &Repo{}
@@ -89,6 +94,33 @@ func (r *Repo) Close() error {
 	return r.sql.Close()
 }
 
+// Inbox returns repository of processed messages, it shares connection with Repo.
+func (r *Repo) Inbox() *inbox.SQL {
+	return inbox.NewSQL(r.sql)
+}
+
+// Outbox returns repository of events waiting for publishing, it shares connection with Repo.
+func (r *Repo) Outbox() *outbox.SQL {
+	return outbox.NewSQL(r.sql)
+}
+
+// writeEvent saves event to outbox inside tx, so event is published only if changes are committed.
+func writeEvent(ctx context.Context, tx *sqlx.Tx, topic string, event *session_pb.Event, meta events.Meta) error {
+	meta.Producer = session_pb.Producer
+
+	env, err := events.Wrap(ctx, topic, session_pb.SchemaVersion, event, meta)
+	if err != nil {
+		return fmt.Errorf("events.Wrap: %w", err)
+	}
+
+	_, err = outbox.Write(ctx, tx, topic, env)
+	if err != nil {
+		return fmt.Errorf("outbox.Write: %w", err)
+	}
+
+	return nil
+}
+
 func convert(s app.Session) *session {
 	return &session{
 		ID:        s.ID,
@@ -140,7 +172,7 @@ func toUserStatus(text string) dom.UserStatus {
 
 // Save for implements app.Repo.
 func (r *Repo) Save(ctx context.Context, session app.Session) error {
-	return r.sql.NoTx(func(db *sqlx.DB) error {
+	return r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
 		newSession := convert(session)
 
 		const query = `
@@ -150,9 +182,29 @@ func (r *Repo) Save(ctx context.Context, session app.Session) error {
 		values 
 			($1, $2, $3, $4, $5, $6)`
 
-		_, err := db.ExecContext(ctx, query, newSession.ID, newSession.Token, newSession.IP, newSession.UserAgent, newSession.UserID, newSession.Status)
+		_, err := tx.ExecContext(ctx, query, newSession.ID, newSession.Token, newSession.IP, newSession.UserAgent, newSession.UserID, newSession.Status)
+		if err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+
+		var ip string
+		if session.Origin.IP != nil {
+			ip = newSession.IP
+		}
+
+		err = writeEvent(ctx, tx, session_pb.TopicCreated, &session_pb.Event{
+			Body: &session_pb.Event_Created{
+				Created: &session_pb.Created{
+					SessionId: session.ID.String(),
+					UserId:    session.UserID.String(),
+					Kind:      dom.UserStatusToAPI(session.Status),
+					Ip:        ip,
+					UserAgent: session.Origin.UserAgent,
+				},
+			},
+		}, events.Meta{})
 		if err != nil {
-			return fmt.Errorf("db.ExecContext: %w", err)
+			return fmt.Errorf("writeEvent: %w", err)
 		}
 
 		return nil
@@ -183,41 +235,77 @@ func (r *Repo) ByID(ctx context.Context, sessionID uuid.UUID) (s *app.Session, e
 
 // Delete for implements app.Repo.
 func (r *Repo) Delete(ctx context.Context, sessionID uuid.UUID) error {
-	return r.sql.NoTx(func(db *sqlx.DB) error {
+	return r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
 		const query = `
 		delete
 		from sessions
 		where id = $1 returning *`
 
-		err := db.GetContext(ctx, &session{}, query, sessionID)
+		res := session{}
+		err := tx.GetContext(ctx, &res, query, sessionID)
 		if err != nil {
-			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+			return fmt.Errorf("tx.GetContext: %w", convertErr(err))
+		}
+
+		err = writeEvent(ctx, tx, session_pb.TopicRemoved, &session_pb.Event{
+			Body: &session_pb.Event_Removed{
+				Removed: &session_pb.Removed{
+					SessionId: res.ID.String(),
+					UserId:    res.UserID.String(),
+				},
+			},
+		}, events.Meta{})
+		if err != nil {
+			return fmt.Errorf("writeEvent: %w", err)
 		}
 
 		return nil
 	})
 }
 
+// UpdateStatus for implements app.Repo.
+// Event about changed status is correlated with processed message by reqID.
 func (r *Repo) UpdateStatus(ctx context.Context, reqID, userID uuid.UUID, status dom.UserStatus) error {
 	return r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
-		err := insertToDeduplication(ctx, tx, reqID, requestUpdateStatus)
+		err := inbox.Record(ctx, tx, requestUpdateStatus, reqID)
+		if errors.Is(err, inbox.ErrDuplicate) {
+			return app.ErrDuplicate
+		}
 		if err != nil {
-			return fmt.Errorf("r.insertToDeduplication: %w", convertErr(err))
+			return fmt.Errorf("inbox.Record: %w", convertErr(err))
 		}
 
-		const query = `update sessions set status = $1 where user_id = $2`
+		const query = `update sessions set status = $1 where user_id = $2 returning id`
 
-		_, err = tx.ExecContext(ctx, query, status.String(), userID)
+		var ids []uuid.UUID
+		err = tx.SelectContext(ctx, &ids, query, status.String(), userID)
 		if err != nil {
-			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+			return fmt.Errorf("tx.SelectContext: %w", convertErr(err))
 		}
 
-		return nil
-	})
-}
+		// User without sessions has nothing to notify about.
+		if len(ids) == 0 {
+			return nil
+		}
 
-func insertToDeduplication(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, kind string) error {
-	const query = `insert into deduplication (id, kind) values ($1, $2) returning id`
+		sessionIDs := make([]string, len(ids))
+		for i := range ids {
+			sessionIDs[i] = ids[i].String()
+		}
 
-	return tx.GetContext(ctx, &uuid.UUID{}, query, id, kind)
+		err = writeEvent(ctx, tx, session_pb.TopicStatusChanged, &session_pb.Event{
+			Body: &session_pb.Event_StatusChanged{
+				StatusChanged: &session_pb.StatusChanged{
+					UserId:     userID.String(),
+					SessionIds: sessionIDs,
+					Kind:       dom.UserStatusToAPI(status),
+				},
+			},
+		}, events.Meta{CorrelationID: reqID.String()})
+		if err != nil {
+			return fmt.Errorf("writeEvent: %w", err)
+		}
+
+		return nil
+	})
 }
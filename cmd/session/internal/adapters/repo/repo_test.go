@@ -9,6 +9,7 @@ import (
 
 	"github.com/gofrs/uuid"
 
+	session_pb "github.com/ZergsLaw/back-template/api/session/v1"
 	"github.com/ZergsLaw/back-template/cmd/session/internal/app"
 	"github.com/ZergsLaw/back-template/internal/dom"
 )
@@ -63,4 +64,12 @@ func TestRepo_Smoke(t *testing.T) {
 	res, err = r.ByID(ctx, session.ID)
 	assert.Nil(res)
 	assert.ErrorIs(err, app.ErrNotFound)
+
+	msgs, err := r.Outbox().Claim(ctx, t.Name(), time.Minute, 10)
+	assert.NoError(err)
+	topics := make([]string, len(msgs))
+	for i := range msgs {
+		topics[i] = msgs[i].Topic
+	}
+	assert.Equal([]string{session_pb.TopicCreated, session_pb.TopicStatusChanged, session_pb.TopicRemoved}, topics)
 }
@@ -11,17 +11,17 @@ import (
 type (
 	// Repo interface for session data repository.
 	Repo interface {
-		// Save saves the new user session in a database.
+		// Save saves the new user session in a database with event about creating session.
 		// Errors: unknown.
 		Save(context.Context, Session) error
 		// ByID returns user session by session id.
 		// Errors: ErrNotFound, unknown.
 		ByID(context.Context, uuid.UUID) (*Session, error)
-		// Delete removes user session.
+		// Delete removes user session and saves event about removing it.
 		// Errors: ErrNotFound, unknown.
 		Delete(context.Context, uuid.UUID) error
-		// UpdateStatus change user session status.
-		// Errors: unknown.
+		// UpdateStatus change user session status and saves event about changing it.
+		// Errors: ErrDuplicate, unknown.
 		UpdateStatus(ctx context.Context, reqID, userID uuid.UUID, status dom.UserStatus) error
 	}
 
@@ -25,17 +25,21 @@ import (
 	"github.com/ZergsLaw/back-template1/cmd/session/internal/auth"
 	"github.com/ZergsLaw/back-template1/internal/flags"
 	"github.com/ZergsLaw/back-template1/internal/grpchelper"
+	"github.com/ZergsLaw/back-template1/internal/inbox"
 	"github.com/ZergsLaw/back-template1/internal/logger"
 	"github.com/ZergsLaw/back-template1/internal/metrics"
+	"github.com/ZergsLaw/back-template1/internal/outbox"
 	"github.com/ZergsLaw/back-template1/internal/serve"
 )
 
 type (
 	config struct {
-		AuthKey string      `yaml:"auth_key"`
-		Server  server      `yaml:"server"`
-		DB      dbConfig    `yaml:"db"`
-		Queue   queueConfig `yaml:"queue"`
+		AuthKey string       `yaml:"auth_key"`
+		Server  server       `yaml:"server"`
+		DB      dbConfig     `yaml:"db"`
+		Queue   queueConfig  `yaml:"queue"`
+		Inbox   inboxConfig  `yaml:"inbox"`
+		Outbox  outboxConfig `yaml:"outbox"`
 	}
 	server struct {
 		Host string `yaml:"host"`
@@ -55,6 +59,17 @@ type (
 		Username string   `yaml:"username"`
 		Password string   `yaml:"password"`
 	}
+	inboxConfig struct {
+		Retention time.Duration `yaml:"retention"`
+	}
+	outboxConfig struct {
+		PollInterval    time.Duration `yaml:"poll_interval"`
+		Lease           time.Duration `yaml:"lease"`
+		BatchSize       int           `yaml:"batch_size"`
+		MaxAttempts     int           `yaml:"max_attempts"`
+		RetryBackoff    time.Duration `yaml:"retry_backoff"`
+		RetryMaxBackoff time.Duration `yaml:"retry_max_backoff"`
+	}
 )
 
 var (
@@ -133,6 +148,19 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 		}
 	}()
 
+	in := inbox.New(r.Inbox(), inbox.Config{
+		Retention: cfg.Inbox.Retention,
+	})
+
+	relay := outbox.NewRelay(r.Outbox(), q, outbox.Config{
+		PollInterval:    cfg.Outbox.PollInterval,
+		Lease:           cfg.Outbox.Lease,
+		BatchSize:       cfg.Outbox.BatchSize,
+		MaxAttempts:     cfg.Outbox.MaxAttempts,
+		RetryBackoff:    cfg.Outbox.RetryBackoff,
+		RetryMaxBackoff: cfg.Outbox.RetryMaxBackoff,
+	})
+
 	authModule := auth.New(cfg.AuthKey)
 	module := app.New(r, authModule, idGenerator{}, q)
 	grpcAPI := api.New(ctx, m, module, reg, namespace)
@@ -144,6 +172,8 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 		module.Process,
 		q.Monitor,
 		q.Process,
+		in.Process,
+		relay.Process,
 	)
 	if err != nil {
 		return fmt.Errorf("serve.Start: %w", err)
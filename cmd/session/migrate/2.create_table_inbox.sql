@@ -0,0 +1,37 @@
+-- up
+create table inbox
+(
+    consumer   text      not null,
+    message_id uuid      not null,
+    created_at timestamp not null default now(),
+
+    primary key (consumer, message_id)
+);
+
+create index inbox_created_at_idx on inbox (created_at);
+
+insert into inbox (consumer, message_id, created_at)
+select kind::text, id, created_at
+from deduplication;
+
+drop table deduplication;
+
+drop type deduplication_kind;
+
+-- down
+create type deduplication_kind as enum ('StatusUpdate');
+
+create table deduplication
+(
+    id         uuid               not null,
+    kind       deduplication_kind not null,
+    created_at timestamp          not null default now(),
+    primary key (id, kind)
+);
+
+insert into deduplication (id, kind, created_at)
+select message_id, consumer::deduplication_kind, created_at
+from inbox
+where consumer = 'StatusUpdate';
+
+drop table inbox;
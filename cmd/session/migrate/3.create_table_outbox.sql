@@ -0,0 +1,23 @@
+-- up
+create table outbox
+(
+    id              uuid      not null,
+    topic           text      not null,
+    message_type    text      not null,
+    payload         bytea     not null,
+    created_at      timestamp not null default now(),
+    attempts        int4      not null default 0,
+    next_attempt_at timestamp not null default now(),
+    locked_by       text,
+    locked_until    timestamp,
+    last_error      text      not null default '',
+    dead_at         timestamp,
+
+    primary key (id)
+);
+
+create index outbox_pending_created_at_idx on outbox (created_at)
+    where dead_at is null;
+
+-- down
+drop table outbox;
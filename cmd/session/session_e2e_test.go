@@ -13,6 +13,7 @@ import (
 	user_pb "github.com/ZergsLaw/back-template/api/user/v1"
 	user_status_pb "github.com/ZergsLaw/back-template/api/user_status/v1"
 	"github.com/ZergsLaw/back-template/internal/dom"
+	"github.com/ZergsLaw/back-template/internal/events"
 	"github.com/ZergsLaw/back-template/internal/testhelper"
 )
 
@@ -66,7 +67,12 @@ func TestE2E(t *testing.T) {
 		},
 	}
 
-	err = consumer.Publish(ctx, user_pb.TopicUpdate, eventAddUserUpStatusTaskID, eventUpdateStatus)
+	env, err := events.Wrap(ctx, user_pb.TopicUpdate, user_pb.SchemaVersion, eventUpdateStatus, events.Meta{
+		Producer: user_pb.Producer,
+	})
+	assert.NoError(err)
+
+	err = consumer.Publish(ctx, user_pb.TopicUpdate, eventAddUserUpStatusTaskID, env)
 	assert.NoError(err)
 
 	for {
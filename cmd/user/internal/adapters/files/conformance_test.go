@@ -0,0 +1,243 @@
+package files_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/files"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+// testConformance checks behaviour which app expects from every file store backend.
+func testConformance(ctx context.Context, t *testing.T, fileStore files.FileStore) {
+	t.Helper()
+
+	var (
+		content   = []byte("avatar content")
+		thumbnail = []byte("thumbnail content")
+		size      = app.AvatarThumbnailSizes[0]
+	)
+
+	t.Run("file", func(t *testing.T) {
+		assert := require.New(t)
+
+		id, err := fileStore.UploadFile(ctx, app.Avatar{
+			Name:           "avatar.png",
+			ContentType:    "image/png",
+			Size:           int64(len(content)),
+			ReadSeekCloser: readSeekCloser(content),
+		})
+		assert.NoError(err)
+		assert.NotEqual(uuid.Nil, id)
+
+		file, err := fileStore.DownloadFile(ctx, id)
+		assert.NoError(err)
+		assert.Equal(id, file.ID)
+		assert.Equal("avatar.png", file.Name)
+		assert.Equal("image/png", file.ContentType)
+		assert.Equal(int64(len(content)), file.Size)
+		assert.False(file.ModTime.IsZero())
+		assert.Equal(content, readAll(assert, file))
+
+		_, err = fileStore.DownloadThumbnail(ctx, id, size)
+		assert.ErrorIs(err, app.ErrNotFound)
+
+		err = fileStore.UploadThumbnail(ctx, id, app.Thumbnail{
+			Size: size,
+			Avatar: app.Avatar{
+				Name:           "avatar.png",
+				ContentType:    "image/png",
+				Size:           int64(len(thumbnail)),
+				ReadSeekCloser: readSeekCloser(thumbnail),
+			},
+		})
+		assert.NoError(err)
+
+		file, err = fileStore.DownloadThumbnail(ctx, id, size)
+		assert.NoError(err)
+		assert.Equal("image/png", file.ContentType)
+		assert.Equal(thumbnail, readAll(assert, file))
+
+		assert.Contains(listFiles(ctx, assert, fileStore), id)
+
+		err = fileStore.DeleteFile(ctx, id)
+		assert.NoError(err)
+
+		_, err = fileStore.DownloadFile(ctx, id)
+		assert.ErrorIs(err, app.ErrNotFound)
+		_, err = fileStore.DownloadThumbnail(ctx, id, size)
+		assert.ErrorIs(err, app.ErrNotFound)
+
+		assert.NotContains(listFiles(ctx, assert, fileStore), id)
+
+		err = fileStore.DeleteFile(ctx, id)
+		assert.NoError(err)
+	})
+
+	t.Run("multipart_upload", func(t *testing.T) {
+		assert := require.New(t)
+
+		uploadID := uuid.Must(uuid.NewV4())
+		_, err := fileStore.DownloadUpload(ctx, uploadID)
+		assert.ErrorIs(err, app.ErrNotFound)
+
+		multipartID, err := fileStore.CreateMultipartUpload(ctx, uploadID, "image/png")
+		assert.NoError(err)
+
+		n, err := fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 0, bytes.NewReader(content[:5]))
+		assert.NoError(err)
+		assert.Equal(int64(5), n)
+		// Repeated write from the same offset replaces data instead of duplicating it.
+		n, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 5, bytes.NewReader(content[5:]))
+		assert.NoError(err)
+		assert.Equal(int64(len(content)-5), n)
+		n, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 5, bytes.NewReader(content[5:]))
+		assert.NoError(err)
+		assert.Equal(int64(len(content)-5), n)
+		_, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, int64(len(content)+1), bytes.NewReader(content))
+		assert.ErrorIs(err, app.ErrInvalidOffset)
+
+		err = fileStore.CompleteMultipartUpload(ctx, uploadID, multipartID, int64(len(content)))
+		assert.NoError(err)
+
+		upload, err := fileStore.DownloadUpload(ctx, uploadID)
+		assert.NoError(err)
+		assert.Equal("image/png", upload.ContentType)
+		assert.Equal(int64(len(content)), upload.Size)
+		assert.Equal(content, readAll(assert, upload))
+
+		assert.NotContains(listFiles(ctx, assert, fileStore), uploadID)
+
+		err = fileStore.DeleteUpload(ctx, uploadID)
+		assert.NoError(err)
+
+		_, err = fileStore.DownloadUpload(ctx, uploadID)
+		assert.ErrorIs(err, app.ErrNotFound)
+	})
+
+	t.Run("abort_multipart_upload", func(t *testing.T) {
+		assert := require.New(t)
+
+		uploadID := uuid.Must(uuid.NewV4())
+		multipartID, err := fileStore.CreateMultipartUpload(ctx, uploadID, "image/png")
+		assert.NoError(err)
+
+		_, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 0, bytes.NewReader(content))
+		assert.NoError(err)
+
+		err = fileStore.AbortMultipartUpload(ctx, uploadID, multipartID)
+		assert.NoError(err)
+		err = fileStore.AbortMultipartUpload(ctx, uploadID, multipartID)
+		assert.NoError(err)
+
+		_, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 0, bytes.NewReader(content))
+		assert.ErrorIs(err, app.ErrNotFound)
+		_, err = fileStore.DownloadUpload(ctx, uploadID)
+		assert.ErrorIs(err, app.ErrNotFound)
+	})
+
+	t.Run("list_files", func(t *testing.T) {
+		assert := require.New(t)
+
+		ids := make([]uuid.UUID, 3)
+		for i := range ids {
+			id, err := fileStore.UploadFile(ctx, app.Avatar{
+				Name:           "avatar.png",
+				ContentType:    "image/png",
+				Size:           int64(len(content)),
+				ReadSeekCloser: readSeekCloser(content),
+			})
+			assert.NoError(err)
+			ids[i] = id
+		}
+		t.Cleanup(func() {
+			for _, id := range ids {
+				assert.NoError(fileStore.DeleteFile(ctx, id))
+			}
+		})
+
+		assert.Subset(listFiles(ctx, assert, fileStore), ids)
+
+		files, err := fileStore.ListFiles(ctx, uuid.Nil, 2)
+		assert.NoError(err)
+		assert.Len(files, 2)
+		assert.Negative(bytes.Compare(files[0].ID.Bytes(), files[1].ID.Bytes()))
+	})
+
+	t.Run("presigned_url", func(t *testing.T) {
+		assert := require.New(t)
+
+		id, err := fileStore.UploadFile(ctx, app.Avatar{
+			Name:           "avatar.png",
+			ContentType:    "image/png",
+			Size:           int64(len(content)),
+			ReadSeekCloser: readSeekCloser(content),
+		})
+		assert.NoError(err)
+		t.Cleanup(func() {
+			assert.NoError(fileStore.DeleteFile(ctx, id))
+		})
+
+		// Presigned URLs are optional, app reports such backends to clients.
+		u, err := fileStore.DownloadURL(ctx, id, 0, time.Minute)
+		if errors.Is(err, app.ErrNotSupported) {
+			_, err = fileStore.UploadURL(ctx, uuid.Must(uuid.NewV4()), "image/png", int64(len(content)), time.Minute)
+			assert.ErrorIs(err, app.ErrNotSupported)
+
+			return
+		}
+		assert.NoError(err)
+		assert.NotNil(u)
+
+		_, err = fileStore.DownloadURL(ctx, id, size, time.Minute)
+		assert.ErrorIs(err, app.ErrNotFound)
+
+		u, err = fileStore.UploadURL(ctx, uuid.Must(uuid.NewV4()), "image/png", int64(len(content)), time.Minute)
+		assert.NoError(err)
+		assert.NotNil(u)
+	})
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+func readSeekCloser(buf []byte) io.ReadSeekCloser {
+	return nopCloser{ReadSeeker: bytes.NewReader(buf)}
+}
+
+func readAll(assert *require.Assertions, file *app.Avatar) []byte {
+	buf, err := io.ReadAll(file)
+	assert.NoError(err)
+	assert.NoError(file.Close())
+
+	return buf
+}
+
+// listFiles lists all files by pages of one file and checks that files are ordered by id.
+func listFiles(ctx context.Context, assert *require.Assertions, fileStore files.FileStore) []uuid.UUID {
+	var ids []uuid.UUID
+	after := uuid.Nil
+	for {
+		files, err := fileStore.ListFiles(ctx, after, 1)
+		assert.NoError(err)
+		assert.LessOrEqual(len(files), 1)
+		if len(files) == 0 {
+			return ids
+		}
+
+		assert.Positive(bytes.Compare(files[0].ID.Bytes(), after.Bytes()))
+		after = files[0].ID
+		assert.False(files[0].ModTime.IsZero())
+		ids = append(ids, after)
+	}
+}
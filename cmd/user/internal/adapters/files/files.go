@@ -0,0 +1,63 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+// Backend is a kind of file store.
+type Backend string
+
+// Backends.
+const (
+	BackendS3     Backend = "s3"
+	BackendLocal  Backend = "local"
+	BackendMemory Backend = "memory"
+)
+
+var errUnknownBackend = errors.New("unknown file store backend")
+
+type (
+	// Config selects file store backend and provides settings for it.
+	// S3 backend is used by default.
+	Config struct {
+		Backend Backend
+		S3      S3Config
+		Local   LocalConfig
+	}
+	// FileStore is a file store backend.
+	FileStore interface {
+		app.FileStore
+		io.Closer
+	}
+)
+
+// New build and returns file store instance of selected backend.
+func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Config) (FileStore, error) {
+	switch cfg.Backend {
+	case BackendS3, "":
+		client, err := NewS3(ctx, reg, namespace, cfg.S3)
+		if err != nil {
+			return nil, fmt.Errorf("NewS3: %w", err)
+		}
+
+		return client, nil
+	case BackendLocal:
+		store, err := NewLocal(cfg.Local)
+		if err != nil {
+			return nil, fmt.Errorf("NewLocal: %w", err)
+		}
+
+		return store, nil
+	case BackendMemory:
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownBackend, cfg.Backend)
+	}
+}
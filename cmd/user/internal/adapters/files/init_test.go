@@ -11,9 +11,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
-	"github.com/ZergsLaw/back-template/cmd/user/internal/adapters/files"
-	"github.com/ZergsLaw/back-template/internal/logger"
-	"github.com/ZergsLaw/back-template/internal/testhelper"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/files"
+	"github.com/ZergsLaw/back-template1/internal/logger"
+	"github.com/ZergsLaw/back-template1/internal/testhelper"
 )
 
 func start(t *testing.T) (context.Context, *files.Client, *require.Assertions) {
@@ -34,7 +34,7 @@ func start(t *testing.T) (context.Context, *files.Client, *require.Assertions) {
 	)
 
 	reg := prometheus.NewPedanticRegistry()
-	fileStorage, err := files.New(ctx, reg, namespace, files.Config{
+	fileStorage, err := files.NewS3(ctx, reg, namespace, files.S3Config{
 		Secure:    false,
 		Endpoint:  endpoint,
 		AccessKey: username,
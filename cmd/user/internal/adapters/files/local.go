@@ -0,0 +1,261 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+var _ bucket = &localBucket{}
+
+const (
+	objectsDir = `objects`
+	metaDir    = `meta`
+	tmpPattern = `.tmp-*`
+	dirPerm    = 0o750
+	filePerm   = 0o640
+)
+
+type (
+	// LocalConfig provide settings for file store on local file system.
+	LocalConfig struct {
+		// Root is a directory for all files, it's created if it doesn't exist.
+		Root string
+	}
+	// localBucket keeps objects data and metadata in separate directories.
+	// Keys are escaped, so all objects are kept in flat directories.
+	localBucket struct {
+		root string
+	}
+)
+
+// NewLocal build and returns new file store instance on local file system.
+func NewLocal(cfg LocalConfig) (*Store, error) {
+	for _, dir := range []string{objectsDir, metaDir} {
+		err := os.MkdirAll(filepath.Join(cfg.Root, dir), dirPerm)
+		if err != nil {
+			return nil, fmt.Errorf("os.MkdirAll: %w", err)
+		}
+	}
+
+	return &Store{bucket: &localBucket{root: cfg.Root}}, nil
+}
+
+func (b *localBucket) put(_ context.Context, key string, info objectInfo, r io.Reader) error {
+	meta, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	err = b.write(b.metaPath(key), bytes.NewReader(meta))
+	if err != nil {
+		return fmt.Errorf("b.write: %w", err)
+	}
+
+	err = b.write(b.objectPath(key), r)
+	if err != nil {
+		return fmt.Errorf("b.write: %w", err)
+	}
+
+	return nil
+}
+
+func (b *localBucket) append(_ context.Context, key string, offset int64, r io.Reader) (int64, error) {
+	file, err := os.OpenFile(b.objectPath(key), os.O_WRONLY, filePerm)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, app.ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("os.OpenFile: %w", err)
+	}
+
+	n, err := writeAt(file, offset, r)
+	if err != nil {
+		_ = file.Close()
+
+		return n, fmt.Errorf("writeAt: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return n, fmt.Errorf("file.Close: %w", err)
+	}
+
+	return n, nil
+}
+
+// writeAt replaces file content after offset by data from r.
+func writeAt(file *os.File, offset int64, r io.Reader) (int64, error) {
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("file.Stat: %w", err)
+	}
+
+	if stat.Size() < offset {
+		return 0, fmt.Errorf("file of %d bytes: %w", stat.Size(), app.ErrInvalidOffset)
+	}
+
+	err = file.Truncate(offset)
+	if err != nil {
+		return 0, fmt.Errorf("file.Truncate: %w", err)
+	}
+
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return 0, fmt.Errorf("file.Seek: %w", err)
+	}
+
+	n, err := io.Copy(file, r)
+	if err != nil {
+		return n, fmt.Errorf("io.Copy: %w", err)
+	}
+
+	return n, nil
+}
+
+func (b *localBucket) get(_ context.Context, key string) (io.ReadSeekCloser, *objectInfo, error) {
+	file, err := os.Open(b.objectPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, app.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("os.Open: %w", err)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return nil, nil, fmt.Errorf("file.Stat: %w", err)
+	}
+
+	info, err := b.readMeta(key, stat)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, nil, fmt.Errorf("b.readMeta: %w", err)
+	}
+
+	return file, info, nil
+}
+
+func (b *localBucket) remove(_ context.Context, key string) error {
+	for _, path := range []string{b.objectPath(key), b.metaPath(key)} {
+		err := os.Remove(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("os.Remove: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (b *localBucket) list(context.Context) ([]objectInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(b.root, objectsDir))
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadDir: %w", err)
+	}
+
+	objects := make([]objectInfo, 0, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") { // Temporary files.
+			continue
+		}
+
+		key, err := url.PathUnescape(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("url.PathUnescape: %w", err)
+		}
+
+		stat, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) { // Removed while listing.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("entry.Info: %w", err)
+		}
+
+		objects = append(objects, objectInfo{
+			Key:     key,
+			Size:    stat.Size(),
+			ModTime: stat.ModTime(),
+		})
+	}
+
+	return objects, nil
+}
+
+// Close implements io.Closer.
+func (*localBucket) Close() error {
+	return nil
+}
+
+func (b *localBucket) readMeta(key string, stat fs.FileInfo) (*objectInfo, error) {
+	meta, err := os.ReadFile(b.metaPath(key))
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	info := &objectInfo{}
+	err = json.Unmarshal(meta, info)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	info.Key = key
+	info.Size = stat.Size()
+	info.ModTime = stat.ModTime()
+
+	return info, nil
+}
+
+// write replaces file atomically, so readers never see partially written file.
+func (*localBucket) write(path string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), tmpPattern)
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("io.Copy: %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("tmp.Close: %w", err)
+	}
+
+	err = os.Chmod(tmp.Name(), filePerm)
+	if err != nil {
+		return fmt.Errorf("os.Chmod: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("os.Rename: %w", err)
+	}
+
+	return nil
+}
+
+func (b *localBucket) objectPath(key string) string {
+	return filepath.Join(b.root, objectsDir, url.PathEscape(key))
+}
+
+func (b *localBucket) metaPath(key string) string {
+	return filepath.Join(b.root, metaDir, url.PathEscape(key))
+}
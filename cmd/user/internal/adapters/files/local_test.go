@@ -0,0 +1,44 @@
+package files_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/files"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/testhelper"
+)
+
+func TestLocal_Conformance(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	root := filepath.Join(t.TempDir(), "avatars")
+	fileStore, err := files.NewLocal(files.LocalConfig{Root: root})
+	assert.NoError(err)
+	t.Cleanup(func() {
+		assert.NoError(fileStore.Close())
+	})
+
+	testConformance(testhelper.Context(t), t, fileStore)
+
+	// Files are kept after reopening store.
+	ctx := testhelper.Context(t)
+	id, err := fileStore.UploadFile(ctx, app.Avatar{
+		Name:           "avatar.png",
+		ContentType:    "image/png",
+		Size:           int64(len("avatar")),
+		ReadSeekCloser: readSeekCloser([]byte("avatar")),
+	})
+	assert.NoError(err)
+
+	reopened, err := files.NewLocal(files.LocalConfig{Root: root})
+	assert.NoError(err)
+	file, err := reopened.DownloadFile(ctx, id)
+	assert.NoError(err)
+	assert.Equal("avatar.png", file.Name)
+	assert.Equal([]byte("avatar"), readAll(assert, file))
+}
@@ -0,0 +1,132 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+var _ bucket = &memoryBucket{}
+
+type (
+	// memoryBucket keeps objects in memory, it's useful for tests and local development.
+	memoryBucket struct {
+		mu      sync.Mutex
+		objects map[string]memoryObject
+	}
+	memoryObject struct {
+		info objectInfo
+		data []byte
+	}
+	readSeekNopCloser struct {
+		io.ReadSeeker
+	}
+)
+
+// NewMemory build and returns new in-memory file store instance.
+// All files are lost after closing.
+func NewMemory() *Store {
+	return &Store{
+		bucket: &memoryBucket{
+			objects: make(map[string]memoryObject),
+		},
+	}
+}
+
+func (b *memoryBucket) put(_ context.Context, key string, info objectInfo, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	info.Key = key
+	info.Size = int64(len(data))
+	info.ModTime = time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.objects[key] = memoryObject{info: info, data: data}
+
+	return nil
+}
+
+func (b *memoryBucket) append(_ context.Context, key string, offset int64, r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	object, ok := b.objects[key]
+	if !ok {
+		return 0, app.ErrNotFound
+	}
+
+	if int64(len(object.data)) < offset {
+		return 0, fmt.Errorf("object of %d bytes: %w", len(object.data), app.ErrInvalidOffset)
+	}
+
+	// Full slice expression makes append copy data, so readers of previous version aren't affected.
+	object.data = append(object.data[:offset:offset], data...)
+	object.info.Size = int64(len(object.data))
+	object.info.ModTime = time.Now()
+	b.objects[key] = object
+
+	return int64(len(data)), nil
+}
+
+func (b *memoryBucket) get(_ context.Context, key string) (io.ReadSeekCloser, *objectInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	object, ok := b.objects[key]
+	if !ok {
+		return nil, nil, app.ErrNotFound
+	}
+
+	return readSeekNopCloser{ReadSeeker: bytes.NewReader(object.data)}, &object.info, nil
+}
+
+func (b *memoryBucket) remove(_ context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.objects, key)
+
+	return nil
+}
+
+func (b *memoryBucket) list(context.Context) ([]objectInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	objects := make([]objectInfo, 0, len(b.objects))
+	for _, object := range b.objects {
+		objects = append(objects, object.info)
+	}
+
+	return objects, nil
+}
+
+// Close implements io.Closer.
+func (b *memoryBucket) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	clear(b.objects)
+
+	return nil
+}
+
+// Close implements io.Closer.
+func (readSeekNopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,21 @@
+package files_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/files"
+	"github.com/ZergsLaw/back-template1/internal/testhelper"
+)
+
+func TestMemory_Conformance(t *testing.T) {
+	t.Parallel()
+
+	fileStore := files.NewMemory()
+	t.Cleanup(func() {
+		require.NoError(t, fileStore.Close())
+	})
+
+	testConformance(testhelper.Context(t), t, fileStore)
+}
@@ -3,6 +3,10 @@ package files
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/minio/minio-go/v7"
@@ -15,7 +19,7 @@ func (c *Client) UploadFile(ctx context.Context, f app.Avatar) (uuid.UUID, error
 	id := uuid.Must(uuid.NewV4())
 
 	const partSize = 1024 * 1024 / 2
-	_, err := c.store.PutObject(ctx, bucketName, id.String(), f, f.Size, minio.PutObjectOptions{
+	_, err := c.store.PutObject(ctx, c.bucket, id.String(), f, f.Size, minio.PutObjectOptions{
 		UserMetadata: map[string]string{
 			headerSrcName: f.Name,
 		},
@@ -31,19 +35,60 @@ func (c *Client) UploadFile(ctx context.Context, f app.Avatar) (uuid.UUID, error
 
 // DownloadFile implements app.FileStore.
 func (c *Client) DownloadFile(ctx context.Context, id uuid.UUID) (*app.Avatar, error) {
-	file, err := c.store.GetObject(ctx, bucketName, id.String(), minio.GetObjectOptions{})
+	file, err := c.store.GetObject(ctx, c.bucket, id.String(), minio.GetObjectOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("c.store.GetObject: %w", err)
 	}
 
 	stat, err := file.Stat()
+	if minio.ToErrorResponse(err).Code == codeNoSuchKey || stat.IsDeleteMarker {
+		return nil, app.ErrNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("file.stat: %w", err)
 	}
 
-	if stat.IsDeleteMarker {
+	f := &app.Avatar{
+		ReadSeekCloser: file,
+		ID:             id,
+		Name:           stat.Metadata.Get(headerSrcName),
+		Size:           stat.Size,
+		ModTime:        stat.LastModified,
+		ContentType:    stat.ContentType,
+	}
+
+	return f, nil
+}
+
+// UploadThumbnail implements app.FileStore.
+func (c *Client) UploadThumbnail(ctx context.Context, id uuid.UUID, t app.Thumbnail) error {
+	_, err := c.store.PutObject(ctx, c.bucket, thumbnailName(id, t.Size), t, t.Avatar.Size, minio.PutObjectOptions{
+		UserMetadata: map[string]string{
+			headerSrcName: t.Name,
+		},
+		ContentType: t.ContentType,
+	})
+	if err != nil {
+		return fmt.Errorf("c.store.PutObject: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadThumbnail implements app.FileStore.
+func (c *Client) DownloadThumbnail(ctx context.Context, id uuid.UUID, size int) (*app.Avatar, error) {
+	file, err := c.store.GetObject(ctx, c.bucket, thumbnailName(id, size), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("c.store.GetObject: %w", err)
+	}
+
+	stat, err := file.Stat()
+	if minio.ToErrorResponse(err).Code == codeNoSuchKey || stat.IsDeleteMarker {
 		return nil, app.ErrNotFound
 	}
+	if err != nil {
+		return nil, fmt.Errorf("file.stat: %w", err)
+	}
 
 	f := &app.Avatar{
 		ReadSeekCloser: file,
@@ -59,14 +104,135 @@ func (c *Client) DownloadFile(ctx context.Context, id uuid.UUID) (*app.Avatar, e
 
 // DeleteFile implements app.FileStore.
 func (c *Client) DeleteFile(ctx context.Context, id uuid.UUID) error {
-	err := c.store.RemoveObject(ctx, bucketName, id.String(), minio.RemoveObjectOptions{})
+	err := c.store.RemoveObject(ctx, c.bucket, id.String(), minio.RemoveObjectOptions{})
 	if err != nil {
 		return fmt.Errorf("c.store.RemoveObject: %w", err)
 	}
 
+	for _, size := range app.AvatarThumbnailSizes {
+		err = c.store.RemoveObject(ctx, c.bucket, thumbnailName(id, size), minio.RemoveObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("c.store.RemoveObject: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// UploadURL implements app.FileStore.
+// Content type and size are signed, so file store rejects upload with other values.
+func (c *Client) UploadURL(ctx context.Context, id uuid.UUID, contentType string, size int64, expires time.Duration) (*url.URL, error) {
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	headers.Set("Content-Length", strconv.FormatInt(size, 10))
+
+	u, err := c.presign.PresignHeader(ctx, http.MethodPut, c.bucket, uploadName(id), expires, nil, headers)
+	if err != nil {
+		return nil, fmt.Errorf("c.presign.PresignHeader: %w", err)
+	}
+
+	return u, nil
+}
+
+// DownloadUpload implements app.FileStore.
+func (c *Client) DownloadUpload(ctx context.Context, id uuid.UUID) (*app.Avatar, error) {
+	file, err := c.store.GetObject(ctx, c.bucket, uploadName(id), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("c.store.GetObject: %w", err)
+	}
+
+	stat, err := file.Stat()
+	if minio.ToErrorResponse(err).Code == codeNoSuchKey || stat.IsDeleteMarker {
+		return nil, app.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("file.stat: %w", err)
+	}
+
+	f := &app.Avatar{
+		ReadSeekCloser: file,
+		ID:             id,
+		Size:           stat.Size,
+		ModTime:        stat.LastModified,
+		ContentType:    stat.ContentType,
+	}
+
+	return f, nil
+}
+
+// DeleteUpload implements app.FileStore.
+func (c *Client) DeleteUpload(ctx context.Context, id uuid.UUID) error {
+	err := c.store.RemoveObject(ctx, c.bucket, uploadName(id), minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("c.store.RemoveObject: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadURL implements app.FileStore.
+func (c *Client) DownloadURL(ctx context.Context, id uuid.UUID, size int, expires time.Duration) (*url.URL, error) {
+	name := id.String()
+	if size != 0 {
+		name = thumbnailName(id, size)
+	}
+
+	stat, err := c.store.StatObject(ctx, c.bucket, name, minio.StatObjectOptions{})
+	if minio.ToErrorResponse(err).Code == codeNoSuchKey || stat.IsDeleteMarker {
+		return nil, app.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("c.store.StatObject: %w", err)
+	}
+
+	u, err := c.presign.PresignedGetObject(ctx, c.bucket, name, expires, nil)
+	if err != nil {
+		return nil, fmt.Errorf("c.presign.PresignedGetObject: %w", err)
+	}
+
+	return u, nil
+}
+
+// ListFiles implements app.FileStore.
+// Thumbnails and uploads are stored under prefixes, so only files in bucket root are listed.
+// Objects are listed in lexical order of keys, it's the same as order of ids.
+func (c *Client) ListFiles(ctx context.Context, after uuid.UUID, limit int) ([]app.StoredFile, error) {
+	// Listing is stopped by ctx when limit is reached.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	files := make([]app.StoredFile, 0, limit)
+	opts := minio.ListObjectsOptions{StartAfter: after.String()}
+	for object := range c.store.ListObjects(ctx, c.bucket, opts) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("c.store.ListObjects: %w", object.Err)
+		}
+
+		id, err := uuid.FromString(object.Key)
+		if err != nil { // Prefixes of thumbnails and uploads.
+			continue
+		}
+
+		files = append(files, app.StoredFile{
+			ID:      id,
+			ModTime: object.LastModified,
+		})
+		if len(files) == limit {
+			break
+		}
+	}
+
+	return files, nil
+}
+
+func uploadName(id uuid.UUID) string {
+	return fmt.Sprintf("%s/%s", uploadsPrefix, id)
+}
+
+func thumbnailName(id uuid.UUID, size int) string {
+	return fmt.Sprintf("%s/%d", id, size)
+}
+
 // Close implements io.Closer.
 func (*Client) Close() error {
 	return nil
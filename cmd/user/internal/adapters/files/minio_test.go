@@ -3,21 +3,32 @@
 package files_test
 
 import (
+	"bytes"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/require"
 
-	"github.com/ZergsLaw/back-template/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
 )
 
 const (
 	avatarFilePath = `testdata/test.jpg`
 )
 
+func TestClient_Conformance(t *testing.T) {
+	t.Parallel()
+
+	ctx, fileStore, _ := start(t)
+
+	testConformance(ctx, t, fileStore)
+}
+
 func TestClient_Smoke(t *testing.T) {
 	t.Parallel()
 
@@ -53,6 +64,134 @@ func TestClient_Smoke(t *testing.T) {
 	imgFromStoreBuf, err := io.ReadAll(fImgFromStore)
 	assert.NoError(err)
 	assert.Equal(imgBuf, imgFromStoreBuf)
+
+	_, err = fileStore.DownloadThumbnail(ctx, id, app.AvatarThumbnailSizes[0])
+	assert.ErrorIs(err, app.ErrNotFound)
+
+	_, err = fImg.Seek(0, io.SeekStart)
+	assert.NoError(err)
+	err = fileStore.UploadThumbnail(ctx, id, app.Thumbnail{
+		Size: app.AvatarThumbnailSizes[0],
+		Avatar: app.Avatar{
+			Name:           fImg.Name(),
+			ContentType:    getContentType(t, assert, fImg),
+			Size:           st.Size(),
+			ReadSeekCloser: fImg,
+		},
+	})
+	assert.NoError(err)
+
+	thumbnail, err := fileStore.DownloadThumbnail(ctx, id, app.AvatarThumbnailSizes[0])
+	assert.NoError(err)
+	thumbnailBuf, err := io.ReadAll(thumbnail)
+	assert.NoError(err)
+	assert.NoError(thumbnail.Close())
+	assert.Equal(imgBuf, thumbnailBuf)
+
+	downloadURL, err := fileStore.DownloadURL(ctx, id, app.AvatarThumbnailSizes[0], time.Minute)
+	assert.NoError(err)
+	assert.Equal(imgBuf, httpGet(t, assert, downloadURL))
+
+	assert.Contains(listFiles(ctx, assert, fileStore), id)
+
+	err = fileStore.DeleteFile(ctx, id)
+	assert.NoError(err)
+
+	_, err = fileStore.DownloadThumbnail(ctx, id, app.AvatarThumbnailSizes[0])
+	assert.ErrorIs(err, app.ErrNotFound)
+
+	_, err = fileStore.DownloadURL(ctx, id, 0, time.Minute)
+	assert.ErrorIs(err, app.ErrNotFound)
+
+	uploadID := uuid.Must(uuid.NewV4())
+	_, err = fileStore.DownloadUpload(ctx, uploadID)
+	assert.ErrorIs(err, app.ErrNotFound)
+
+	uploadURL, err := fileStore.UploadURL(ctx, uploadID, "image/jpeg", int64(len(imgBuf)), time.Minute)
+	assert.NoError(err)
+	assert.Equal(http.StatusForbidden, httpPut(t, assert, uploadURL, "image/png", imgBuf))
+	assert.Equal(http.StatusOK, httpPut(t, assert, uploadURL, "image/jpeg", imgBuf))
+
+	upload, err := fileStore.DownloadUpload(ctx, uploadID)
+	assert.NoError(err)
+	assert.Equal("image/jpeg", upload.ContentType)
+	assert.Equal(int64(len(imgBuf)), upload.Size)
+	uploadBuf, err := io.ReadAll(upload)
+	assert.NoError(err)
+	assert.NoError(upload.Close())
+	assert.Equal(imgBuf, uploadBuf)
+
+	err = fileStore.DeleteUpload(ctx, uploadID)
+	assert.NoError(err)
+
+	_, err = fileStore.DownloadUpload(ctx, uploadID)
+	assert.ErrorIs(err, app.ErrNotFound)
+
+	multipartID, err := fileStore.CreateMultipartUpload(ctx, uploadID, "image/jpeg")
+	assert.NoError(err)
+	chunk := bytes.Repeat([]byte{1}, 6<<20)
+	n, err := fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 0, bytes.NewReader(chunk[:1<<20]))
+	assert.NoError(err)
+	assert.Equal(int64(1<<20), n)
+	n, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 1<<20, bytes.NewReader(chunk[1<<20:]))
+	assert.NoError(err)
+	assert.Equal(int64(5<<20), n)
+	// Retry of write which wasn't confirmed replaces part and tail written by the first try.
+	n, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 1<<20, bytes.NewReader(chunk[1<<20:]))
+	assert.NoError(err)
+	assert.Equal(int64(5<<20), n)
+	n, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 6<<20, bytes.NewReader(imgBuf))
+	assert.NoError(err)
+	assert.Equal(int64(len(imgBuf)), n)
+	err = fileStore.CompleteMultipartUpload(ctx, uploadID, multipartID, int64(6<<20+len(imgBuf)))
+	assert.NoError(err)
+
+	upload, err = fileStore.DownloadUpload(ctx, uploadID)
+	assert.NoError(err)
+	assert.Equal("image/jpeg", upload.ContentType)
+	uploadBuf, err = io.ReadAll(upload)
+	assert.NoError(err)
+	assert.Equal(append(chunk, imgBuf...), uploadBuf)
+
+	err = fileStore.DeleteUpload(ctx, uploadID)
+	assert.NoError(err)
+
+	multipartID, err = fileStore.CreateMultipartUpload(ctx, uploadID, "image/jpeg")
+	assert.NoError(err)
+	_, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 0, bytes.NewReader(imgBuf))
+	assert.NoError(err)
+	err = fileStore.AbortMultipartUpload(ctx, uploadID, multipartID)
+	assert.NoError(err)
+	_, err = fileStore.AppendMultipartUpload(ctx, uploadID, multipartID, 0, bytes.NewReader(imgBuf))
+	assert.ErrorIs(err, app.ErrNotFound)
+}
+
+func httpGet(t *testing.T, assert *require.Assertions, u *url.URL) []byte {
+	t.Helper()
+
+	resp, err := http.Get(u.String())
+	assert.NoError(err)
+	defer resp.Body.Close()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+
+	buf, err := io.ReadAll(resp.Body)
+	assert.NoError(err)
+
+	return buf
+}
+
+func httpPut(t *testing.T, assert *require.Assertions, u *url.URL, contentType string, body []byte) int {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPut, u.String(), bytes.NewReader(body))
+	assert.NoError(err)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(err)
+	assert.NoError(resp.Body.Close())
+
+	return resp.StatusCode
 }
 
 func getContentType(t *testing.T, assert *require.Assertions, r io.ReadSeeker) string {
@@ -0,0 +1,254 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/minio/minio-go/v7"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+const (
+	// minPartSize is minimal size of every multipart upload part except the last one.
+	minPartSize       = 5 << 20
+	codeNoSuchUpload  = `NoSuchUpload`
+	maxPartsInListing = 1000
+	tailSuffix        = `.tail.`
+)
+
+// CreateMultipartUpload implements app.FileStore.
+func (c *Client) CreateMultipartUpload(ctx context.Context, id uuid.UUID, contentType string) (string, error) {
+	multipartID, err := c.core().NewMultipartUpload(ctx, c.bucket, uploadName(id), minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("c.core.NewMultipartUpload: %w", err)
+	}
+
+	return multipartID, nil
+}
+
+// AppendMultipartUpload implements app.FileStore.
+// Parts must be at least minPartSize, so upload of offset bytes consists of offset/minPartSize parts
+// and tail object with the rest of data, which is prepended to the next appended data.
+// Part numbers and tail are defined by offset, so repeated call with the same offset
+// replaces parts and tail written by previous call instead of duplicating data.
+func (c *Client) AppendMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string, offset int64, r io.Reader) (int64, error) {
+	err := c.checkMultipartUpload(ctx, id, multipartID)
+	if err != nil {
+		return 0, fmt.Errorf("c.checkMultipartUpload: %w", err)
+	}
+
+	tail, err := c.downloadTail(ctx, id, offset)
+	if err != nil {
+		return 0, fmt.Errorf("c.downloadTail: %w", err)
+	}
+
+	if int64(len(tail)) != offset%minPartSize {
+		return 0, fmt.Errorf("tail of %d bytes for offset %d: %w", len(tail), offset, app.ErrInvalidOffset)
+	}
+
+	partNumber := int(offset/minPartSize) + 1
+	buf := make([]byte, minPartSize)
+	n := copy(buf, tail)
+	written := int64(0)
+
+	for {
+		read, readErr := io.ReadFull(r, buf[n:])
+		n += read
+
+		if n < minPartSize {
+			err = c.saveTail(ctx, id, offset+written+int64(read), buf[:n])
+			if err != nil {
+				return written, fmt.Errorf("c.saveTail: %w", err)
+			}
+			written += int64(read)
+
+			if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+				return written, nil
+			}
+
+			return written, fmt.Errorf("io.ReadFull: %w", readErr)
+		}
+
+		_, err = c.core().PutObjectPart(ctx, c.bucket, uploadName(id), multipartID, partNumber,
+			bytes.NewReader(buf), minPartSize, minio.PutObjectPartOptions{})
+		if err != nil {
+			return written, fmt.Errorf("c.core.PutObjectPart: %w", err)
+		}
+		partNumber++
+		written += int64(read)
+		n = 0
+	}
+}
+
+// CompleteMultipartUpload implements app.FileStore.
+// Parts after size are left by calls which stored data, but weren't confirmed, they are skipped.
+func (c *Client) CompleteMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string, size int64) error {
+	tail, err := c.downloadTail(ctx, id, size)
+	if err != nil {
+		return fmt.Errorf("c.downloadTail: %w", err)
+	}
+
+	if int64(len(tail)) != size%minPartSize {
+		return fmt.Errorf("tail of %d bytes for size %d: %w", len(tail), size, app.ErrInvalidOffset)
+	}
+
+	allParts, err := c.listParts(ctx, id, multipartID)
+	if err != nil {
+		return fmt.Errorf("c.listParts: %w", err)
+	}
+
+	fullParts := int(size / minPartSize)
+	parts := make([]minio.CompletePart, 0, fullParts+1)
+	for _, part := range allParts {
+		if part.PartNumber <= fullParts {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) != fullParts {
+		return fmt.Errorf("%d parts for size %d: %w", len(parts), size, app.ErrInvalidOffset)
+	}
+
+	if len(tail) > 0 {
+		part, err := c.core().PutObjectPart(ctx, c.bucket, uploadName(id), multipartID, fullParts+1,
+			bytes.NewReader(tail), int64(len(tail)), minio.PutObjectPartOptions{})
+		if err != nil {
+			return fmt.Errorf("c.core.PutObjectPart: %w", err)
+		}
+
+		parts = append(parts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+
+	_, err = c.core().CompleteMultipartUpload(ctx, c.bucket, uploadName(id), multipartID, parts, minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("c.core.CompleteMultipartUpload: %w", err)
+	}
+
+	err = c.removeTails(ctx, id)
+	if err != nil {
+		return fmt.Errorf("c.removeTails: %w", err)
+	}
+
+	return nil
+}
+
+// AbortMultipartUpload implements app.FileStore.
+func (c *Client) AbortMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string) error {
+	err := c.core().AbortMultipartUpload(ctx, c.bucket, uploadName(id), multipartID)
+	if err != nil && minio.ToErrorResponse(err).Code != codeNoSuchUpload {
+		return fmt.Errorf("c.core.AbortMultipartUpload: %w", err)
+	}
+
+	err = c.removeTails(ctx, id)
+	if err != nil {
+		return fmt.Errorf("c.removeTails: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) core() minio.Core {
+	return minio.Core{Client: c.store}
+}
+
+func (c *Client) listParts(ctx context.Context, id uuid.UUID, multipartID string) ([]minio.CompletePart, error) {
+	var (
+		parts  []minio.CompletePart
+		marker = 0
+	)
+
+	for {
+		res, err := c.core().ListObjectParts(ctx, c.bucket, uploadName(id), multipartID, marker, maxPartsInListing)
+		switch {
+		case minio.ToErrorResponse(err).Code == codeNoSuchUpload:
+			return nil, app.ErrNotFound
+		case err != nil:
+			return nil, fmt.Errorf("c.core.ListObjectParts: %w", err)
+		}
+
+		for _, part := range res.ObjectParts {
+			parts = append(parts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+		}
+
+		if !res.IsTruncated {
+			return parts, nil
+		}
+		marker = res.NextPartNumberMarker
+	}
+}
+
+// checkMultipartUpload returns app.ErrNotFound if multipart upload doesn't exist.
+func (c *Client) checkMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string) error {
+	_, err := c.core().ListObjectParts(ctx, c.bucket, uploadName(id), multipartID, 0, 1)
+	switch {
+	case minio.ToErrorResponse(err).Code == codeNoSuchUpload:
+		return app.ErrNotFound
+	case err != nil:
+		return fmt.Errorf("c.core.ListObjectParts: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) downloadTail(ctx context.Context, id uuid.UUID, offset int64) ([]byte, error) {
+	file, err := c.store.GetObject(ctx, c.bucket, tailName(id, offset), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("c.store.GetObject: %w", err)
+	}
+	defer file.Close()
+
+	tail, err := io.ReadAll(file)
+	if minio.ToErrorResponse(err).Code == codeNoSuchKey {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	return tail, nil
+}
+
+// saveTail saves the rest of data which doesn't fill part, offset is count of bytes in upload including tail.
+func (c *Client) saveTail(ctx context.Context, id uuid.UUID, offset int64, tail []byte) error {
+	if len(tail) == 0 {
+		return nil
+	}
+
+	_, err := c.store.PutObject(ctx, c.bucket, tailName(id, offset), bytes.NewReader(tail), int64(len(tail)), minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("c.store.PutObject: %w", err)
+	}
+
+	return nil
+}
+
+// removeTails removes tails of all offsets.
+func (c *Client) removeTails(ctx context.Context, id uuid.UUID) error {
+	opts := minio.ListObjectsOptions{Prefix: uploadName(id) + tailSuffix, Recursive: true}
+	for object := range c.store.ListObjects(ctx, c.bucket, opts) {
+		if object.Err != nil {
+			return fmt.Errorf("c.store.ListObjects: %w", object.Err)
+		}
+
+		err := c.store.RemoveObject(ctx, c.bucket, object.Key, minio.RemoveObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("c.store.RemoveObject: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// tailName returns name of tail saved when upload had offset bytes,
+// tails of previous offsets are kept, so repeated append can read them.
+func tailName(id uuid.UUID, offset int64) string {
+	return uploadName(id) + tailSuffix + strconv.FormatInt(offset, 10)
+}
@@ -18,28 +18,36 @@ var _ app.FileStore = &Client{}
 
 const (
 	headerSrcName = `src_name`
-	bucketName    = `user.avatars`
+	defaultBucket = `user.avatars`
+	uploadsPrefix = `uploads`
+	codeNoSuchKey = `NoSuchKey`
 )
 
 type (
-	// Config provide connection info for message broker.
-	Config struct {
+	// S3Config provide connection info for S3 compatible file store.
+	S3Config struct {
 		Secure       bool
 		Endpoint     string
 		AccessKey    string
 		SecretKey    string
 		SessionToken string
 		Region       string
+		// PublicEndpoint is used in presigned URLs if file store is reachable by clients on another address.
+		PublicEndpoint string
+		// Bucket keeps all files, user.avatars is used by default.
+		Bucket string
 	}
-	// Client provided data from and to message broker.
+	// Client provided data from and to S3 compatible file store.
 	Client struct {
-		store *minio.Client
-		m     Metrics
+		store   *minio.Client
+		presign *minio.Client
+		bucket  string
+		m       Metrics
 	}
 )
 
-// New build and returns new file store instance.
-func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Config) (*Client, error) {
+// NewS3 build and returns new S3 compatible file store instance.
+func NewS3(ctx context.Context, reg *prometheus.Registry, namespace string, cfg S3Config) (*Client, error) {
 	const subsystem = "file_store"
 	m := NewMetrics(reg, namespace, subsystem, []string{})
 
@@ -60,6 +68,19 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 		return nil, fmt.Errorf("minio.New: %w, opts: %+v", err, cfg)
 	}
 
+	presign := client
+	if cfg.PublicEndpoint != "" {
+		presign, err = minio.New(cfg.PublicEndpoint, opts)
+		if err != nil {
+			return nil, fmt.Errorf("minio.New: %w, opts: %+v", err, cfg)
+		}
+	}
+
+	bucketName := cfg.Bucket
+	if bucketName == "" {
+		bucketName = defaultBucket
+	}
+
 	var lastErr error
 	exist, err := client.BucketExists(ctx, bucketName)
 	for err != nil {
@@ -88,7 +109,9 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 	}
 
 	return &Client{
-		store: client,
-		m:     m,
+		store:   client,
+		presign: presign,
+		bucket:  bucketName,
+		m:       m,
 	}, nil
 }
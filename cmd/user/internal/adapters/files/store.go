@@ -0,0 +1,239 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+var _ app.FileStore = &Store{}
+
+type (
+	// bucket is a flat object storage used by Store.
+	// Missing objects are reported by app.ErrNotFound.
+	bucket interface {
+		// put creates or replaces object.
+		put(ctx context.Context, key string, info objectInfo, r io.Reader) error
+		// append replaces data of existing object after offset and returns amount of written bytes.
+		// Errors: app.ErrNotFound, app.ErrInvalidOffset if object is shorter than offset, unknown.
+		append(ctx context.Context, key string, offset int64, r io.Reader) (int64, error)
+		// get returns object data and info.
+		get(ctx context.Context, key string) (io.ReadSeekCloser, *objectInfo, error)
+		// remove removes object, missing object isn't an error.
+		remove(ctx context.Context, key string) error
+		// list returns info about all objects.
+		list(ctx context.Context) ([]objectInfo, error)
+		io.Closer
+	}
+	// objectInfo contains object metadata.
+	objectInfo struct {
+		Key         string    `json:"-"`
+		Name        string    `json:"name"`
+		ContentType string    `json:"content_type"`
+		Size        int64     `json:"-"`
+		ModTime     time.Time `json:"-"`
+	}
+	// Store provides file store on top of local file system or memory.
+	// Presigned URLs aren't supported.
+	Store struct {
+		bucket bucket
+	}
+)
+
+// UploadFile implements app.FileStore.
+func (s *Store) UploadFile(ctx context.Context, f app.Avatar) (uuid.UUID, error) {
+	id := uuid.Must(uuid.NewV4())
+
+	err := s.bucket.put(ctx, id.String(), objectInfo{Name: f.Name, ContentType: f.ContentType}, f)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("s.bucket.put: %w", err)
+	}
+
+	return id, nil
+}
+
+// DownloadFile implements app.FileStore.
+func (s *Store) DownloadFile(ctx context.Context, id uuid.UUID) (*app.Avatar, error) {
+	return s.download(ctx, id, id.String())
+}
+
+// UploadThumbnail implements app.FileStore.
+func (s *Store) UploadThumbnail(ctx context.Context, id uuid.UUID, t app.Thumbnail) error {
+	err := s.bucket.put(ctx, thumbnailName(id, t.Size), objectInfo{Name: t.Name, ContentType: t.ContentType}, t)
+	if err != nil {
+		return fmt.Errorf("s.bucket.put: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadThumbnail implements app.FileStore.
+func (s *Store) DownloadThumbnail(ctx context.Context, id uuid.UUID, size int) (*app.Avatar, error) {
+	return s.download(ctx, id, thumbnailName(id, size))
+}
+
+// DeleteFile implements app.FileStore.
+func (s *Store) DeleteFile(ctx context.Context, id uuid.UUID) error {
+	err := s.bucket.remove(ctx, id.String())
+	if err != nil {
+		return fmt.Errorf("s.bucket.remove: %w", err)
+	}
+
+	for _, size := range app.AvatarThumbnailSizes {
+		err = s.bucket.remove(ctx, thumbnailName(id, size))
+		if err != nil {
+			return fmt.Errorf("s.bucket.remove: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UploadURL implements app.FileStore.
+func (*Store) UploadURL(context.Context, uuid.UUID, string, int64, time.Duration) (*url.URL, error) {
+	return nil, fmt.Errorf("presigned upload: %w", app.ErrNotSupported)
+}
+
+// DownloadUpload implements app.FileStore.
+func (s *Store) DownloadUpload(ctx context.Context, id uuid.UUID) (*app.Avatar, error) {
+	return s.download(ctx, id, uploadName(id))
+}
+
+// DeleteUpload implements app.FileStore.
+func (s *Store) DeleteUpload(ctx context.Context, id uuid.UUID) error {
+	err := s.bucket.remove(ctx, uploadName(id))
+	if err != nil {
+		return fmt.Errorf("s.bucket.remove: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadURL implements app.FileStore.
+func (*Store) DownloadURL(context.Context, uuid.UUID, int, time.Duration) (*url.URL, error) {
+	return nil, fmt.Errorf("presigned download: %w", app.ErrNotSupported)
+}
+
+// ListFiles implements app.FileStore.
+func (s *Store) ListFiles(ctx context.Context, after uuid.UUID, limit int) ([]app.StoredFile, error) {
+	objects, err := s.bucket.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("s.bucket.list: %w", err)
+	}
+
+	var files []app.StoredFile
+	for _, object := range objects {
+		id, err := uuid.FromString(object.Key)
+		if err != nil || bytes.Compare(id.Bytes(), after.Bytes()) <= 0 { // Thumbnails, uploads and listed files.
+			continue
+		}
+
+		files = append(files, app.StoredFile{
+			ID:      id,
+			ModTime: object.ModTime,
+		})
+	}
+
+	slices.SortFunc(files, func(a, b app.StoredFile) int {
+		return bytes.Compare(a.ID.Bytes(), b.ID.Bytes())
+	})
+
+	return files[:min(len(files), limit)], nil
+}
+
+// CreateMultipartUpload implements app.FileStore.
+// Data is appended to separate object, which is moved to upload by CompleteMultipartUpload.
+func (s *Store) CreateMultipartUpload(ctx context.Context, id uuid.UUID, contentType string) (string, error) {
+	multipartID := uuid.Must(uuid.NewV4()).String()
+
+	err := s.bucket.put(ctx, multipartName(id, multipartID), objectInfo{ContentType: contentType}, bytes.NewReader(nil))
+	if err != nil {
+		return "", fmt.Errorf("s.bucket.put: %w", err)
+	}
+
+	return multipartID, nil
+}
+
+// AppendMultipartUpload implements app.FileStore.
+func (s *Store) AppendMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string, offset int64, r io.Reader) (int64, error) {
+	n, err := s.bucket.append(ctx, multipartName(id, multipartID), offset, r)
+	if err != nil {
+		return n, fmt.Errorf("s.bucket.append: %w", err)
+	}
+
+	return n, nil
+}
+
+// CompleteMultipartUpload implements app.FileStore.
+func (s *Store) CompleteMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string, size int64) error {
+	part, info, err := s.bucket.get(ctx, multipartName(id, multipartID))
+	if err != nil {
+		return fmt.Errorf("s.bucket.get: %w", err)
+	}
+	defer part.Close()
+
+	if info.Size < size {
+		return fmt.Errorf("part of %d bytes for size %d: %w", info.Size, size, app.ErrInvalidOffset)
+	}
+
+	err = s.bucket.put(ctx, uploadName(id), objectInfo{ContentType: info.ContentType}, io.LimitReader(part, size))
+	if err != nil {
+		return fmt.Errorf("s.bucket.put: %w", err)
+	}
+
+	err = s.bucket.remove(ctx, multipartName(id, multipartID))
+	if err != nil {
+		return fmt.Errorf("s.bucket.remove: %w", err)
+	}
+
+	return nil
+}
+
+// AbortMultipartUpload implements app.FileStore.
+func (s *Store) AbortMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string) error {
+	err := s.bucket.remove(ctx, multipartName(id, multipartID))
+	if err != nil {
+		return fmt.Errorf("s.bucket.remove: %w", err)
+	}
+
+	return nil
+}
+
+// Close implements io.Closer.
+func (s *Store) Close() error {
+	return s.bucket.Close()
+}
+
+func (s *Store) download(ctx context.Context, id uuid.UUID, key string) (*app.Avatar, error) {
+	file, info, err := s.bucket.get(ctx, key)
+	if errors.Is(err, app.ErrNotFound) {
+		return nil, app.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("s.bucket.get: %w", err)
+	}
+
+	f := &app.Avatar{
+		ReadSeekCloser: file,
+		ID:             id,
+		Name:           info.Name,
+		Size:           info.Size,
+		ModTime:        info.ModTime,
+		ContentType:    info.ContentType,
+	}
+
+	return f, nil
+}
+
+func multipartName(id uuid.UUID, multipartID string) string {
+	return fmt.Sprintf("%s.%s", uploadName(id), multipartID)
+}
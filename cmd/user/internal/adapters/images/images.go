@@ -0,0 +1,197 @@
+// Package images decodes, crops and scales uploaded images.
+package images
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif" // Register GIF decoder.
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Register WebP decoder.
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+var _ app.ImageProcessor = &Processor{}
+
+const (
+	formatJPEG = "jpeg"
+
+	defaultJPEGQuality = 90
+	defaultMaxPixels   = 50_000_000
+	defaultMaxSide     = 8192
+)
+
+type (
+	// Processor prepares images for storing.
+	Processor struct {
+		jpegQuality int
+		maxPixels   int
+		maxSide     int
+	}
+	// Option for building Processor struct.
+	Option func(*Processor)
+
+	nopCloser struct {
+		io.ReadSeeker
+	}
+)
+
+// JPEGQuality option for sets quality of encoded jpeg images.
+func JPEGQuality(quality int) Option {
+	return func(p *Processor) {
+		p.jpegQuality = quality
+	}
+}
+
+// MaxPixels option for sets maximum count of pixels in decoded image.
+func MaxPixels(pixels int) Option {
+	return func(p *Processor) {
+		p.maxPixels = pixels
+	}
+}
+
+// MaxSide option for sets maximum width and height of decoded image.
+func MaxSide(pixels int) Option {
+	return func(p *Processor) {
+		p.maxSide = pixels
+	}
+}
+
+// New creates and returns new Processor.
+func New(options ...Option) *Processor {
+	p := &Processor{
+		jpegQuality: defaultJPEGQuality,
+		maxPixels:   defaultMaxPixels,
+		maxSide:     defaultMaxSide,
+	}
+
+	for i := range options {
+		options[i](p)
+	}
+
+	return p
+}
+
+// Avatar implements app.ImageProcessor.
+// Dimensions are checked from image header before decoding pixels, so decompression bombs aren't decoded.
+// Metadata like EXIF is dropped because image is re-encoded from decoded pixels,
+// EXIF orientation is applied to pixels before, so image isn't shown rotated.
+// JPEG images stay JPEG, other formats are encoded to PNG to keep transparency.
+func (p *Processor) Avatar(_ context.Context, f app.Avatar, sizes []int) (*app.Avatar, []app.Thumbnail, error) {
+	src, format, err := p.decode(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("p.decode: %w", err)
+	}
+
+	orientation, err := readOrientation(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("readOrientation: %w", err)
+	}
+
+	// Centre square stays in centre after orientation, so scaled squares are oriented only.
+	square := centreSquare(src.Bounds())
+
+	avatar, err := p.encode(f.Name, format, orient(scale(src, square, square.Dx()), orientation))
+	if err != nil {
+		return nil, nil, fmt.Errorf("p.encode: %w", err)
+	}
+
+	thumbnails := make([]app.Thumbnail, 0, len(sizes))
+	for _, size := range sizes {
+		side := min(size, square.Dx()) // Don't upscale small images.
+
+		thumbnail, err := p.encode(f.Name, format, orient(scale(src, square, side), orientation))
+		if err != nil {
+			return nil, nil, fmt.Errorf("p.encode: %w", err)
+		}
+
+		thumbnails = append(thumbnails, app.Thumbnail{
+			Size:   size,
+			Avatar: *thumbnail,
+		})
+	}
+
+	return avatar, thumbnails, nil
+}
+
+func (p *Processor) decode(f app.Avatar) (image.Image, string, error) {
+	_, err := f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, "", fmt.Errorf("f.Seek: %w", err)
+	}
+
+	cfg, format, err := image.DecodeConfig(f)
+	if err != nil {
+		return nil, "", fmt.Errorf("image.DecodeConfig: %w", app.ErrInvalidImageFormat)
+	}
+
+	if "image/"+format != f.ContentType {
+		return nil, "", fmt.Errorf("image format %s for %s: %w", format, f.ContentType, app.ErrInvalidImageFormat)
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > p.maxSide || cfg.Height > p.maxSide ||
+		cfg.Width*cfg.Height > p.maxPixels {
+		return nil, "", fmt.Errorf("image size %dx%d: %w", cfg.Width, cfg.Height, app.ErrInvalidImageFormat)
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, "", fmt.Errorf("f.Seek: %w", err)
+	}
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, "", fmt.Errorf("image.Decode: %w", app.ErrInvalidImageFormat)
+	}
+
+	return img, format, nil
+}
+
+func (p *Processor) encode(name, format string, img image.Image) (*app.Avatar, error) {
+	buf := &bytes.Buffer{}
+	contentType := "image/png"
+
+	var err error
+	switch format {
+	case formatJPEG:
+		contentType = "image/jpeg"
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: p.jpegQuality})
+	default:
+		err = png.Encode(buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", format, err)
+	}
+
+	return &app.Avatar{
+		Name:           name,
+		ContentType:    contentType,
+		Size:           int64(buf.Len()),
+		ReadSeekCloser: nopCloser{bytes.NewReader(buf.Bytes())},
+	}, nil
+}
+
+// centreSquare returns the biggest square in the centre of rect.
+func centreSquare(rect image.Rectangle) image.Rectangle {
+	side := min(rect.Dx(), rect.Dy())
+	x := rect.Min.X + (rect.Dx()-side)/2
+	y := rect.Min.Y + (rect.Dy()-side)/2
+
+	return image.Rect(x, y, x+side, y+side)
+}
+
+// scale returns part of src in rect scaled to square with side pixels.
+func scale(src image.Image, rect image.Rectangle, side int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, rect, draw.Src, nil)
+
+	return dst
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,233 @@
+package images_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/images"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestProcessor_Avatar(t *testing.T) {
+	t.Parallel()
+
+	var (
+		src = newImage(300, 200)
+		// APP1 segment with EXIF data placed after SOI marker.
+		exif = []byte("\xff\xe1\x00\x10Exif\x00\x00secret!!")
+	)
+
+	testCases := map[string]struct {
+		data            []byte
+		contentType     string
+		wantContentType string
+		wantSide        int
+		wantErr         error
+	}{
+		"png":         {encode(t, src, png.Encode), "image/png", "image/png", 200, nil},
+		"gif":         {encode(t, src, gifEncode), "image/gif", "image/png", 200, nil},
+		"jpeg":        {encode(t, src, jpegEncode), "image/jpeg", "image/jpeg", 200, nil},
+		"jpeg_exif":   {withEXIF(encode(t, src, jpegEncode), exif), "image/jpeg", "image/jpeg", 200, nil},
+		"small":       {encode(t, newImage(50, 80), png.Encode), "image/png", "image/png", 50, nil},
+		"too_big":     {encode(t, newImage(2000, 400), png.Encode), "image/png", "", 0, app.ErrInvalidImageFormat},
+		"too_many_px": {encode(t, newImage(1100, 1000), png.Encode), "image/png", "", 0, app.ErrInvalidImageFormat},
+		"wrong_type":  {encode(t, src, png.Encode), "image/jpeg", "", 0, app.ErrInvalidImageFormat},
+		"not_image":   {[]byte("not an image"), "image/png", "", 0, app.ErrInvalidImageFormat},
+		"svg":         {[]byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), "image/svg+xml", "", 0, app.ErrInvalidImageFormat},
+		"broken_body": {encode(t, src, png.Encode)[:100], "image/png", "", 0, app.ErrInvalidImageFormat},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			p := images.New(images.MaxPixels(1_000_000), images.MaxSide(1500))
+			sizes := []int{64, 128}
+
+			avatar, thumbnails, err := p.Avatar(context.Background(), app.Avatar{
+				Name:           "avatar",
+				ContentType:    tc.contentType,
+				Size:           int64(len(tc.data)),
+				ReadSeekCloser: nopCloser{bytes.NewReader(tc.data)},
+			}, sizes)
+			assert.ErrorIs(err, tc.wantErr)
+			if tc.wantErr != nil {
+				return
+			}
+
+			assert.Equal(tc.wantContentType, avatar.ContentType)
+			data := decode(t, avatar)
+			assert.NotContains(string(data.raw), "Exif")
+			assert.Equal(image.Rect(0, 0, tc.wantSide, tc.wantSide), data.img.Bounds())
+
+			assert.Len(thumbnails, len(sizes))
+			for i, size := range sizes {
+				assert.Equal(size, thumbnails[i].Size)
+				assert.Equal(tc.wantContentType, thumbnails[i].ContentType)
+
+				side := min(size, tc.wantSide)
+				assert.Equal(image.Rect(0, 0, side, side), decode(t, &thumbnails[i].Avatar).img.Bounds())
+			}
+		})
+	}
+}
+
+func TestProcessor_AvatarOrientation(t *testing.T) {
+	t.Parallel()
+
+	src := newImage(200, 200)
+
+	// Pixel (30, 120) of result is taken from source pixel depending on orientation.
+	testCases := map[string]struct {
+		data      []byte
+		tolerance int
+		wantX     int
+		wantY     int
+	}{
+		"absent":          {encode(t, src, png.Encode), 0, 30, 120},
+		"normal":          {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 1)), 0, 30, 120},
+		"flip_horizontal": {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 2)), 0, 169, 120},
+		"rotate_180":      {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 3)), 0, 169, 79},
+		"flip_vertical":   {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 4)), 0, 30, 79},
+		"transpose":       {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 5)), 0, 120, 30},
+		"rotate_90":       {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 6)), 0, 120, 169},
+		"transverse":      {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 7)), 0, 79, 169},
+		"rotate_270":      {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 8)), 0, 79, 30},
+		"invalid":         {withPNGEXIF(encode(t, src, png.Encode), tiff(binary.LittleEndian, 9)), 0, 30, 120},
+		"jpeg":            {withEXIF(encode(t, src, jpegEncode), jpegAPP1(tiff(binary.BigEndian, 6))), 8, 120, 169},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			p := images.New()
+
+			avatar, _, err := p.Avatar(context.Background(), app.Avatar{
+				Name:           "avatar",
+				ContentType:    http.DetectContentType(tc.data),
+				Size:           int64(len(tc.data)),
+				ReadSeekCloser: nopCloser{bytes.NewReader(tc.data)},
+			}, nil)
+			assert.NoError(err)
+
+			r, g, _, _ := decode(t, avatar).img.At(30, 120).RGBA()
+			assert.InDelta(tc.wantX, int(r>>8), float64(tc.tolerance))
+			assert.InDelta(tc.wantY, int(g>>8), float64(tc.tolerance))
+		})
+	}
+}
+
+type decoded struct {
+	raw []byte
+	img image.Image
+}
+
+func decode(t *testing.T, f *app.Avatar) decoded {
+	t.Helper()
+
+	raw, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(raw)), f.Size)
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	return decoded{raw: raw, img: img}
+}
+
+func newImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+
+	return img
+}
+
+func encode(t *testing.T, img image.Image, enc func(io.Writer, image.Image) error) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, enc(buf, img))
+
+	return buf.Bytes()
+}
+
+func jpegEncode(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, nil)
+}
+
+func gifEncode(w io.Writer, img image.Image) error {
+	return gif.Encode(w, img, nil)
+}
+
+func withEXIF(data, exif []byte) []byte {
+	res := append([]byte{}, data[:2]...)
+	res = append(res, exif...)
+
+	return append(res, data[2:]...)
+}
+
+// tiff returns TIFF data with the only orientation tag in IFD.
+func tiff(order binary.AppendByteOrder, orientation uint16) []byte {
+	res := []byte("II*\x00")
+	if order == binary.BigEndian {
+		res = []byte("MM\x00*")
+	}
+	res = order.AppendUint32(res, 8)
+	res = order.AppendUint16(res, 1)
+	res = order.AppendUint16(res, 0x0112) // Tag, type SHORT, count and value padded to 4 bytes.
+	res = order.AppendUint16(res, 3)
+	res = order.AppendUint32(res, 1)
+	res = order.AppendUint16(res, orientation)
+	res = order.AppendUint16(res, 0)
+
+	return order.AppendUint32(res, 0)
+}
+
+func jpegAPP1(tiff []byte) []byte {
+	res := binary.BigEndian.AppendUint16([]byte{0xff, 0xe1}, uint16(2+6+len(tiff)))
+	res = append(res, "Exif\x00\x00"...)
+
+	return append(res, tiff...)
+}
+
+// withPNGEXIF inserts eXIf chunk after IHDR chunk.
+func withPNGEXIF(data, exif []byte) []byte {
+	const ihdrEnd = 8 + 25
+
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(exif)))
+	chunk = append(chunk, "eXIf"...)
+	chunk = append(chunk, exif...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	res := append([]byte{}, data[:ihdrEnd]...)
+	res = append(res, chunk...)
+
+	return append(res, data[ihdrEnd:]...)
+}
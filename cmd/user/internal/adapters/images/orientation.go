@@ -0,0 +1,193 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+const (
+	// maxMetadataSize limits beginning of file searched for EXIF data.
+	maxMetadataSize = 1 << 20
+	tagOrientation  = 0x0112
+
+	orientationNormal     = 1
+	orientationFlipH      = 2
+	orientationRotate180  = 3
+	orientationFlipV      = 4
+	orientationTranspose  = 5
+	orientationRotate90   = 6
+	orientationTransverse = 7
+	orientationRotate270  = 8
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// readOrientation returns EXIF orientation of image, orientationNormal is returned if it's absent.
+// EXIF is searched in JPEG application segments, PNG eXIf chunk and WebP EXIF chunk.
+func readOrientation(f app.Avatar) (int, error) {
+	_, err := f.Seek(0, io.SeekStart)
+	if err != nil {
+		return 0, fmt.Errorf("f.Seek: %w", err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(f, maxMetadataSize))
+	if err != nil {
+		return 0, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	var exif []byte
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8}):
+		exif = jpegEXIF(data[2:])
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		exif = pngEXIF(data[8:])
+	case len(data) >= 12 && bytes.Equal(data[:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		exif = bytes.TrimPrefix(webpEXIF(data[12:]), exifHeader)
+	}
+
+	return tiffOrientation(exif), nil
+}
+
+// jpegEXIF returns TIFF data of the first EXIF segment before image data.
+func jpegEXIF(data []byte) []byte {
+	for len(data) >= 4 && data[0] == 0xff {
+		marker, size := data[1], int(binary.BigEndian.Uint16(data[2:]))
+		if marker == 0xda || marker == 0xd9 || size < 2 || size+2 > len(data) { // Image data or malformed segment.
+			return nil
+		}
+
+		segment := data[4 : size+2]
+		if marker == 0xe1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment[len(exifHeader):]
+		}
+		data = data[size+2:]
+	}
+
+	return nil
+}
+
+// pngEXIF returns data of eXIf chunk before image data.
+func pngEXIF(data []byte) []byte {
+	for len(data) >= 12 {
+		size := int(binary.BigEndian.Uint32(data))
+		if size < 0 || size > len(data)-12 {
+			return nil
+		}
+
+		switch string(data[4:8]) {
+		case "eXIf":
+			return data[8 : 8+size]
+		case "IDAT":
+			return nil
+		}
+		data = data[12+size:]
+	}
+
+	return nil
+}
+
+// webpEXIF returns data of EXIF chunk.
+func webpEXIF(data []byte) []byte {
+	for len(data) >= 8 {
+		size := int(binary.LittleEndian.Uint32(data[4:]))
+		if size < 0 || size > len(data)-8 {
+			return nil
+		}
+
+		if string(data[:4]) == "EXIF" {
+			return data[8 : 8+size]
+		}
+		data = data[min(8+size+size%2, len(data)):]
+	}
+
+	return nil
+}
+
+// tiffOrientation returns orientation tag of the first IFD, orientationNormal is returned if it's absent or invalid.
+func tiffOrientation(data []byte) int {
+	if len(data) < 8 {
+		return orientationNormal
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:4]) {
+	case "II*\x00":
+		order = binary.LittleEndian
+	case "MM\x00*":
+		order = binary.BigEndian
+	default:
+		return orientationNormal
+	}
+
+	offset := int(order.Uint32(data[4:]))
+	if offset < 8 || offset+2 > len(data) {
+		return orientationNormal
+	}
+
+	count := int(order.Uint16(data[offset:]))
+	entries := data[offset+2:]
+	for i := 0; i < count && len(entries) >= 12; i++ {
+		entry := entries[:12]
+		entries = entries[12:]
+
+		if order.Uint16(entry) != tagOrientation {
+			continue
+		}
+
+		value := int(order.Uint16(entry[8:]))
+		if value < orientationNormal || value > orientationRotate270 {
+			return orientationNormal
+		}
+
+		return value
+	}
+
+	return orientationNormal
+}
+
+// orient returns image transformed by EXIF orientation, so it's shown in the same way without metadata.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation == orientationNormal {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= orientationTranspose {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case orientationFlipH:
+				sx, sy = w-1-x, y
+			case orientationRotate180:
+				sx, sy = w-1-x, h-1-y
+			case orientationFlipV:
+				sx, sy = x, h-1-y
+			case orientationTranspose:
+				sx, sy = y, x
+			case orientationRotate90:
+				sx, sy = y, h-1-x
+			case orientationTransverse:
+				sx, sy = w-1-y, h-1-x
+			case orientationRotate270:
+				sx, sy = w-1-y, x
+			}
+
+			s := src.PixOffset(src.Rect.Min.X+sx, src.Rect.Min.Y+sy)
+			d := dst.PixOffset(x, y)
+			copy(dst.Pix[d:d+4], src.Pix[s:s+4])
+		}
+	}
+
+	return dst
+}
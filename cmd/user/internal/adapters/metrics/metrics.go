@@ -0,0 +1,109 @@
+// Package metrics contains business metrics of user service.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+var _ app.Metrics = &Metrics{}
+
+// Metrics implements app.Metrics by prometheus.
+type Metrics struct {
+	avatarsChecked        prometheus.Counter
+	avatarsOrphans        prometheus.Counter
+	avatarsMissing        prometheus.Counter
+	avatarsReconciledTime prometheus.Gauge
+	tasksUnfinished       prometheus.Gauge
+	tasksDead             prometheus.Gauge
+	tasksOldestAge        prometheus.Gauge
+	tasksRemoved          prometheus.Counter
+}
+
+// New registers and returns business metrics.
+func New(reg *prometheus.Registry, namespace string) *Metrics {
+	const subsystem = "app"
+
+	m := &Metrics{
+		avatarsChecked: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "avatars_checked_total",
+			Help:      "Amount of avatars checked by reconciliation.",
+		}),
+		avatarsOrphans: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "avatars_orphans_removed_total",
+			Help:      "Amount of files removed by reconciliation because they have no avatars.",
+		}),
+		avatarsMissing: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "avatars_missing_total",
+			Help:      "Amount of avatars flagged by reconciliation because their files are absent.",
+		}),
+		avatarsReconciledTime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "avatars_reconciled_timestamp_seconds",
+			Help:      "Time of the last successful avatars reconciliation.",
+		}),
+		tasksUnfinished: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "outbox_unfinished_tasks",
+			Help:      "Amount of outbox tasks waiting for publishing.",
+		}),
+		tasksDead: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "outbox_dead_tasks",
+			Help:      "Amount of outbox tasks in dead-letter state.",
+		}),
+		tasksOldestAge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "outbox_oldest_unfinished_task_age_seconds",
+			Help:      "Age of the oldest outbox task waiting for publishing, zero if there are none.",
+		}),
+		tasksRemoved: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "outbox_tasks_removed_total",
+			Help:      "Amount of finished outbox tasks removed or archived by retention.",
+		}),
+	}
+	reg.MustRegister(m.avatarsChecked, m.avatarsOrphans, m.avatarsMissing, m.avatarsReconciledTime,
+		m.tasksUnfinished, m.tasksDead, m.tasksOldestAge, m.tasksRemoved)
+
+	return m
+}
+
+// AvatarsReconciled implements app.Metrics.
+func (m *Metrics) AvatarsReconciled(report app.AvatarsReconciliation) {
+	m.avatarsChecked.Add(float64(report.Checked))
+	m.avatarsOrphans.Add(float64(report.Orphans))
+	m.avatarsMissing.Add(float64(report.Missing))
+	m.avatarsReconciledTime.SetToCurrentTime()
+}
+
+// TaskBacklog implements app.Metrics.
+func (m *Metrics) TaskBacklog(backlog app.TaskBacklog) {
+	m.tasksUnfinished.Set(float64(backlog.Unfinished))
+	m.tasksDead.Set(float64(backlog.Dead))
+
+	var age time.Duration
+	if !backlog.OldestUnfinished.IsZero() {
+		age = time.Since(backlog.OldestUnfinished)
+	}
+	m.tasksOldestAge.Set(age.Seconds())
+}
+
+// TasksRemoved implements app.Metrics.
+func (m *Metrics) TasksRemoved(count int) {
+	m.tasksRemoved.Add(float64(count))
+}
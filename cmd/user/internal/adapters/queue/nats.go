@@ -3,15 +3,17 @@ package queue
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/prometheus/client_golang/prometheus"
-	"google.golang.org/protobuf/types/known/timestamppb"
 
 	user_pb "github.com/ZergsLaw/back-template1/api/user/v1"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
-	"github.com/ZergsLaw/back-template1/internal/dom"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/convert"
+	"github.com/ZergsLaw/back-template1/internal/events"
 	"github.com/ZergsLaw/back-template1/internal/queue"
 )
 
@@ -54,23 +56,15 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 }
 
 // AddUser implements app.Queue.
-func (c *Client) AddUser(ctx context.Context, id uuid.UUID, user app.User) error {
-	return c.queue.Publish(ctx,
+func (c *Client) AddUser(ctx context.Context, id uuid.UUID, occurredAt time.Time, user app.User) (app.PublishAck, error) {
+	return c.publish(ctx,
 		user_pb.TopicAdd,
 		id,
+		occurredAt,
 		&user_pb.Event{
 			Body: &user_pb.Event_Add{
 				Add: &user_pb.Add{
-					User: &user_pb.User{
-						Id:        user.ID.String(),
-						Username:  user.Name,
-						Email:     user.Email,
-						AvatarId:  user.AvatarID.String(),
-						Kind:      dom.UserStatusToAPI(user.Status),
-						FullName:  user.FullName,
-						CreatedAt: timestamppb.New(user.CreatedAt),
-						UpdatedAt: timestamppb.New(user.UpdatedAt),
-					},
+					User: convert.User(user),
 				},
 			},
 		},
@@ -78,10 +72,11 @@ func (c *Client) AddUser(ctx context.Context, id uuid.UUID, user app.User) error
 }
 
 // DeleteUser implements app.Queue.
-func (c *Client) DeleteUser(ctx context.Context, id uuid.UUID, user app.User) error {
-	return c.queue.Publish(ctx,
+func (c *Client) DeleteUser(ctx context.Context, id uuid.UUID, occurredAt time.Time, user app.User) (app.PublishAck, error) {
+	return c.publish(ctx,
 		user_pb.TopicDel,
 		id,
+		occurredAt,
 		&user_pb.Event{
 			Body: &user_pb.Event_Delete{
 				Delete: &user_pb.Delete{
@@ -93,29 +88,166 @@ func (c *Client) DeleteUser(ctx context.Context, id uuid.UUID, user app.User) er
 }
 
 // UpdateUser implements app.Queue.
-func (c *Client) UpdateUser(ctx context.Context, id uuid.UUID, user app.User) error {
-	return c.queue.Publish(ctx,
+func (c *Client) UpdateUser(ctx context.Context, id uuid.UUID, occurredAt time.Time, user app.User) (app.PublishAck, error) {
+	return c.publish(ctx,
 		user_pb.TopicUpdate,
 		id,
+		occurredAt,
 		&user_pb.Event{
 			Body: &user_pb.Event_Update{
 				Update: &user_pb.Update{
-					User: &user_pb.User{
-						Id:        user.ID.String(),
-						Username:  user.Name,
-						Email:     user.Email,
-						AvatarId:  user.AvatarID.String(),
-						Kind:      dom.UserStatusToAPI(user.Status),
-						FullName:  user.FullName,
-						CreatedAt: timestamppb.New(user.CreatedAt),
-						UpdatedAt: timestamppb.New(user.UpdatedAt),
-					},
+					User: convert.User(user),
 				},
 			},
 		},
 	)
 }
 
+// UpdatePreferences implements app.Queue.
+func (c *Client) UpdatePreferences(ctx context.Context, id uuid.UUID, occurredAt time.Time, userID uuid.UUID, prefs []app.Preference) (app.PublishAck, error) {
+	return c.publish(ctx,
+		user_pb.TopicPreferences,
+		id,
+		occurredAt,
+		&user_pb.Event{
+			Body: &user_pb.Event_Preferences{
+				Preferences: &user_pb.PreferencesUpdate{
+					UserId:      userID.String(),
+					Preferences: convert.Preferences(prefs),
+				},
+			},
+		},
+	)
+}
+
+// LoggedIn implements app.Queue.
+func (c *Client) LoggedIn(ctx context.Context, id uuid.UUID, occurredAt time.Time, user app.User, activity app.Activity) (app.PublishAck, error) {
+	return c.publish(ctx,
+		user_pb.TopicLoggedIn,
+		id,
+		occurredAt,
+		&user_pb.Event{
+			Body: &user_pb.Event_LoggedIn{
+				LoggedIn: &user_pb.LoggedIn{
+					UserId:    user.ID.String(),
+					Ip:        toIP(activity.Origin.IP),
+					UserAgent: activity.Origin.UserAgent,
+				},
+			},
+		},
+	)
+}
+
+// LoggedOut implements app.Queue.
+func (c *Client) LoggedOut(ctx context.Context, id uuid.UUID, occurredAt time.Time, user app.User, activity app.Activity) (app.PublishAck, error) {
+	return c.publish(ctx,
+		user_pb.TopicLoggedOut,
+		id,
+		occurredAt,
+		&user_pb.Event{
+			Body: &user_pb.Event_LoggedOut{
+				LoggedOut: &user_pb.LoggedOut{
+					UserId:    user.ID.String(),
+					SessionId: activity.SessionID.String(),
+				},
+			},
+		},
+	)
+}
+
+// LoginFailed implements app.Queue.
+func (c *Client) LoginFailed(ctx context.Context, id uuid.UUID, occurredAt time.Time, user app.User, activity app.Activity) (app.PublishAck, error) {
+	reason := user_pb.LoginFailReason_LOGIN_FAIL_REASON_INVALID_PASSWORD
+	if user.ID == uuid.Nil {
+		reason = user_pb.LoginFailReason_LOGIN_FAIL_REASON_UNKNOWN_EMAIL
+	}
+
+	return c.publish(ctx,
+		user_pb.TopicLoginFailed,
+		id,
+		occurredAt,
+		&user_pb.Event{
+			Body: &user_pb.Event_LoginFailed{
+				LoginFailed: &user_pb.LoginFailed{
+					UserId:    toID(user.ID),
+					Email:     user.Email,
+					Ip:        toIP(activity.Origin.IP),
+					UserAgent: activity.Origin.UserAgent,
+					Reason:    reason,
+				},
+			},
+		},
+	)
+}
+
+// PasswordChanged implements app.Queue.
+func (c *Client) PasswordChanged(ctx context.Context, id uuid.UUID, occurredAt time.Time, user app.User) (app.PublishAck, error) {
+	return c.publish(ctx,
+		user_pb.TopicPasswordChanged,
+		id,
+		occurredAt,
+		&user_pb.Event{
+			Body: &user_pb.Event_PasswordChanged{
+				PasswordChanged: &user_pb.PasswordChanged{
+					UserId: user.ID.String(),
+				},
+			},
+		},
+	)
+}
+
+// AvatarChanged implements app.Queue.
+func (c *Client) AvatarChanged(ctx context.Context, id uuid.UUID, occurredAt time.Time, user app.User, activity app.Activity) (app.PublishAck, error) {
+	return c.publish(ctx,
+		user_pb.TopicAvatarChanged,
+		id,
+		occurredAt,
+		&user_pb.Event{
+			Body: &user_pb.Event_AvatarChanged{
+				AvatarChanged: &user_pb.AvatarChanged{
+					UserId:       user.ID.String(),
+					AvatarId:     toID(user.AvatarID),
+					PrevAvatarId: toID(activity.PrevAvatarID),
+				},
+			},
+		},
+	)
+}
+
+func (c *Client) publish(ctx context.Context, topic string, id uuid.UUID, occurredAt time.Time, event *user_pb.Event) (app.PublishAck, error) {
+	env, err := events.Wrap(ctx, topic, user_pb.SchemaVersion, event, events.Meta{
+		Producer:   user_pb.Producer,
+		OccurredAt: occurredAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("events.Wrap: %w", err)
+	}
+
+	ack, err := c.queue.PublishAsync(ctx, topic, id, env)
+	if err != nil {
+		return nil, fmt.Errorf("c.queue.PublishAsync: %w", err)
+	}
+
+	return ack, nil
+}
+
+// toID returns empty string for uuid.Nil, so optional ids are left unset in events.
+func toID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+
+	return id.String()
+}
+
+func toIP(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+
+	return ip.String()
+}
+
 // Close implements io.Closer.
 func (c *Client) Close() error {
 	return c.queue.Drain()
@@ -3,6 +3,7 @@ package queue_test
 import (
 	"context"
 	"fmt"
+	events_pb "github.com/ZergsLaw/back-template1/api/events/v1"
 	user_pb "github.com/ZergsLaw/back-template1/api/user/v1"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
 	"github.com/ZergsLaw/back-template1/internal/dom"
@@ -39,17 +40,20 @@ func TestQueue_Smoke(t *testing.T) {
 
 	user2 := user
 
-	err = client.AddUser(ctx, msgId, user)
+	ack, err := client.AddUser(ctx, msgId, time.Now(), user)
 	assert.NoError(err)
+	assert.NoError(ack.Wait(ctx))
 
 	user2.FullName = "username2"
 	user2.Email = "email2@gmail.com"
 
-	err = client.UpdateUser(ctx, msgId, user2)
+	ack, err = client.UpdateUser(ctx, msgId, time.Now(), user2)
 	assert.NoError(err)
+	assert.NoError(ack.Wait(ctx))
 
-	err = client.DeleteUser(ctx, msgId, user2)
+	ack, err = client.DeleteUser(ctx, msgId, time.Now(), user2)
 	assert.NoError(err)
+	assert.NoError(ack.Wait(ctx))
 
 	subscribeCtx, subscribeCtxCancel := context.WithTimeout(ctx, time.Second*2)
 	t.Cleanup(subscribeCtxCancel)
@@ -61,9 +65,16 @@ func TestQueue_Smoke(t *testing.T) {
 		}
 
 		assert.Equal(user_pb.TopicAdd, message.Subject())
-		eventAdd := user_pb.Event{}
-		err = message.Unmarshal(&eventAdd)
+		env := events_pb.Envelope{}
+		err = message.Unmarshal(&env)
 		assert.NoError(err)
+		assert.Equal(user_pb.TopicAdd, env.EventType)
+		assert.Equal(user_pb.Producer, env.Producer)
+		assert.EqualValues(user_pb.SchemaVersion, env.SchemaVersion)
+		decoded, err := user_pb.Registry().Decode(&env)
+		assert.NoError(err)
+		eventAdd, ok := decoded.(*user_pb.Event)
+		assert.True(ok)
 		assert.Equal(user.ID, eventAdd.GetAdd().User.Id)
 		assert.Equal(user.Email, eventAdd.GetAdd().User.Email)
 		assert.Equal(user2.ID, eventAdd.GetUpdate().User.Id)
@@ -14,6 +14,7 @@ import (
 const (
 	duplEmail            = "users_email_key"
 	duplUsername         = "users_name_key"
+	duplNameNormalized   = "users_name_normalized_key"
 	duplOwnerIDAndFileID = "files_owner_id_file_id_key"
 	fkUserID             = "fk_user_id_ref_users"
 )
@@ -37,6 +38,8 @@ func constraint(pqErr *pq.Error) error {
 		return app.ErrEmailExist
 	case strings.HasSuffix(pqErr.Message, fmt.Sprintf("unique constraint \"%s\"", duplUsername)):
 		return app.ErrUsernameExist
+	case strings.HasSuffix(pqErr.Message, fmt.Sprintf("unique constraint \"%s\"", duplNameNormalized)):
+		return app.ErrUsernameExist
 	case strings.HasSuffix(pqErr.Message, fmt.Sprintf("unique constraint \"%s\"", duplOwnerIDAndFileID)):
 		return app.ErrUserIDAndFileIDExist
 	case strings.HasSuffix(pqErr.Message, fmt.Sprintf("violates foreign key constraint \"%s\"", fkUserID)):
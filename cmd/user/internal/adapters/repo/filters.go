@@ -2,6 +2,7 @@ package repo
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -16,6 +17,8 @@ type searchUsers struct {
 	email          string
 	startCreatedAt time.Time
 	endCreatedAt   time.Time
+	fuzzy          bool
+	includeHidden  bool
 }
 
 func newSearchUsers(params app.SearchParams) searchUsers {
@@ -31,6 +34,8 @@ func newSearchUsers(params app.SearchParams) searchUsers {
 		email:          params.Email,
 		startCreatedAt: params.StartCreatedAt,
 		endCreatedAt:   params.EndCreatedAt,
+		fuzzy:          params.Mode == app.SearchModeFuzzy,
+		includeHidden:  params.IncludeHidden,
 	}
 }
 
@@ -44,6 +49,9 @@ func getUsers(params app.SearchParams) (string, []interface{}, error) {
 	filters := newSearchUsers(params)
 
 	sql = filters.getFilters(sql)
+	if filters.fuzzy {
+		sql = filters.orderByRelevance(sql)
+	}
 
 	query, args, err := sql.ToSql()
 	if err != nil {
@@ -75,6 +83,29 @@ func (s *searchUsers) getFilters(sql sq.SelectBuilder) sq.SelectBuilder {
 		sql = sql.Where(sq.Eq{"status": s.statuses})
 	}
 
+	switch {
+	case s.fuzzy:
+		sql = s.getFuzzyFilters(sql)
+	default:
+		sql = s.getSubstringFilters(sql)
+	}
+
+	if !s.startCreatedAt.IsZero() {
+		sql = sql.Where("created_at >= ?", s.startCreatedAt)
+	}
+
+	if !s.endCreatedAt.IsZero() {
+		sql = sql.Where("created_at <= ?", s.endCreatedAt)
+	}
+
+	if !s.includeHidden {
+		sql = sql.Where("not exists (select 1 from privacy_settings ps where ps.user_id = users.id and ps.hide_from_search)")
+	}
+
+	return sql.PlaceholderFormat(sq.Dollar)
+}
+
+func (s *searchUsers) getSubstringFilters(sql sq.SelectBuilder) sq.SelectBuilder {
 	if s.userName != "" || s.fullName != "" {
 		sql = sql.Where(sq.Or{
 			sq.Expr("full_name ilike ?", fmt.Sprintf("%%%s%%", s.fullName)),
@@ -86,13 +117,45 @@ func (s *searchUsers) getFilters(sql sq.SelectBuilder) sq.SelectBuilder {
 		sql = sql.Where("email ilike ?", fmt.Sprintf("%%%s%%", s.email))
 	}
 
-	if !s.startCreatedAt.IsZero() {
-		sql = sql.Where("created_at >= ?", s.startCreatedAt)
+	return sql
+}
+
+// getFuzzyFilters uses trigram similarity operator, so the query can use trigram indexes
+// and tolerate typos.
+func (s *searchUsers) getFuzzyFilters(sql sq.SelectBuilder) sq.SelectBuilder {
+	if s.userName != "" || s.fullName != "" {
+		sql = sql.Where(sq.Or{
+			sq.Expr("full_name % ?", s.fullName),
+			sq.Expr("name % ?", s.userName),
+		})
 	}
 
-	if !s.endCreatedAt.IsZero() {
-		sql = sql.Where("created_at <= ?", s.endCreatedAt)
+	if s.email != "" {
+		sql = sql.Where("email % ?", s.email)
 	}
 
-	return sql.PlaceholderFormat(sq.Dollar)
+	return sql
+}
+
+func (s *searchUsers) orderByRelevance(sql sq.SelectBuilder) sq.SelectBuilder {
+	var (
+		ranks []string
+		args  []interface{}
+	)
+
+	if s.userName != "" || s.fullName != "" {
+		ranks = append(ranks, "similarity(full_name, ?)", "similarity(name, ?)")
+		args = append(args, s.fullName, s.userName)
+	}
+
+	if s.email != "" {
+		ranks = append(ranks, "similarity(email, ?)")
+		args = append(args, s.email)
+	}
+
+	if len(ranks) == 0 {
+		return sql
+	}
+
+	return sql.OrderByClause(fmt.Sprintf("greatest(%s) desc, id", strings.Join(ranks, ", ")), args...)
 }
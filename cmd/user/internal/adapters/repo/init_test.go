@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sipki-tech/database/connectors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ZergsLaw/back-template/cmd/user/internal/adapters/repo"
@@ -28,6 +29,27 @@ const (
 )
 
 func start(t *testing.T) (context.Context, *repo.Repo, *require.Assertions) {
+	t.Helper()
+	ctx, cockroachCfg, assert := startDB(t)
+
+	namespace := testhelper.Namespace(t)
+
+	reg := prometheus.NewPedanticRegistry()
+	r, err := repo.New(ctx, reg, namespace, repo.Config{
+		Cockroach:  *cockroachCfg,
+		MigrateDir: migrateDir,
+		Driver:     "postgres",
+	})
+	assert.NoError(err)
+	t.Cleanup(func() {
+		assert.NoError(r.Close())
+	})
+
+	return ctx, r, assert
+}
+
+// startDB runs database without migrations.
+func startDB(t *testing.T) (context.Context, *connectors.CockroachDB, *require.Assertions) {
 	t.Helper()
 	ctx := testhelper.Context(t)
 	assert := require.New(t)
@@ -35,8 +57,6 @@ func start(t *testing.T) (context.Context, *repo.Repo, *require.Assertions) {
 	pwd, err := os.Getwd()
 	assert.NoError(err)
 
-	namespace := testhelper.Namespace(t)
-
 	cockroachCfg := testhelper.CockroachDB(
 		ctx,
 		t,
@@ -46,17 +66,6 @@ func start(t *testing.T) (context.Context, *repo.Repo, *require.Assertions) {
 		filepath.Join(pwd, clientCrtPath), filepath.Join(pwd, clientKeyPath),
 	)
 
-	reg := prometheus.NewPedanticRegistry()
-	r, err := repo.New(ctx, reg, namespace, repo.Config{
-		Cockroach:  *cockroachCfg,
-		MigrateDir: migrateDir,
-		Driver:     "postgres",
-	})
-	assert.NoError(err)
-	t.Cleanup(func() {
-		assert.NoError(r.Close())
-	})
-
 	log := slog.New(
 		slog.NewJSONHandler(
 			os.Stdout,
@@ -67,5 +76,5 @@ func start(t *testing.T) (context.Context, *repo.Repo, *require.Assertions) {
 		),
 	)
 
-	return logger.NewContext(ctx, log), r, assert
+	return logger.NewContext(ctx, log), cockroachCfg, assert
 }
@@ -0,0 +1,150 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Outbox drivers, they define how publisher learns about new tasks.
+const (
+	// OutboxPolling doesn't notify publisher, it finds new tasks by polling only.
+	OutboxPolling = "polling"
+	// OutboxNotify notifies publisher by Postgres LISTEN/NOTIFY, CockroachDB doesn't support it.
+	OutboxNotify = "notify"
+	// OutboxChangefeed notifies publisher by CockroachDB core changefeed on tasks table.
+	OutboxChangefeed = "changefeed"
+)
+
+const (
+	tasksChannel     = `tasks`
+	notifyTasksQuery = `select pg_notify('` + tasksChannel + `', '')`
+	// Existing tasks are found by polling, so initial scan is skipped.
+	// Diff tells new tasks from updates made by publisher.
+	changefeedTasksQuery = `experimental changefeed for tasks with no_initial_scan, diff`
+
+	listenerMinReconnect = time.Second
+	listenerMaxReconnect = time.Minute
+)
+
+// ListenTasks implements app.Repo.
+func (r *Repo) ListenTasks(ctx context.Context, notify chan<- struct{}) error {
+	switch r.outbox {
+	case OutboxNotify:
+		return r.listenNotify(ctx, notify)
+	case OutboxChangefeed:
+		return r.listenChangefeed(ctx, notify)
+	default:
+		<-ctx.Done()
+
+		return nil
+	}
+}
+
+func (r *Repo) listenNotify(ctx context.Context, notify chan<- struct{}) error {
+	listener := pq.NewListener(r.dsn, listenerMinReconnect, listenerMaxReconnect, nil)
+	defer listener.Close()
+
+	err := listener.Listen(tasksChannel)
+	if err != nil {
+		return fmt.Errorf("listener.Listen: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		// Nil notification is sent after reconnect, tasks could be saved while connection was lost.
+		case <-listener.Notify:
+			wake(notify)
+		}
+	}
+}
+
+func (r *Repo) listenChangefeed(ctx context.Context, notify chan<- struct{}) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		rows, err := db.QueryContext(ctx, changefeedTasksQuery)
+		if err != nil {
+			return fmt.Errorf("db.QueryContext: %w", convertErr(err))
+		}
+		defer rows.Close()
+
+		// Changefeed streams row per every change of tasks until query is canceled.
+		// Publisher wakes up on new tasks only, otherwise its own updates of tasks wake it up again.
+		for rows.Next() {
+			var (
+				table    string
+				key, val []byte
+			)
+			err = rows.Scan(&table, &key, &val)
+			if err != nil {
+				return fmt.Errorf("rows.Scan: %w", err)
+			}
+
+			inserted, err := isInsert(val)
+			if err != nil {
+				return fmt.Errorf("isInsert: %w", err)
+			}
+
+			if inserted {
+				wake(notify)
+			}
+		}
+
+		err = rows.Err()
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("rows.Err: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// isInsert returns true if changefeed value with diff describes new row.
+func isInsert(val []byte) (bool, error) {
+	var change struct {
+		Before *json.RawMessage `json:"before"`
+		After  *json.RawMessage `json:"after"`
+	}
+	err := json.Unmarshal(val, &change)
+	if err != nil {
+		return false, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	return change.Before == nil && change.After != nil, nil
+}
+
+// checkOutbox returns error if outbox driver isn't supported by database.
+func (r *Repo) checkOutbox(ctx context.Context) error {
+	if r.outbox != OutboxNotify {
+		return nil
+	}
+
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		var version string
+		err := db.GetContext(ctx, &version, `select version()`)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		// CockroachDB doesn't support LISTEN/NOTIFY, so publisher would never be notified.
+		if strings.Contains(version, "CockroachDB") {
+			return fmt.Errorf("outbox driver %s isn't supported by CockroachDB, use %s", OutboxNotify, OutboxChangefeed)
+		}
+
+		return nil
+	})
+}
+
+// wake notifies publisher without blocking, notifications are merged while publisher is busy.
+func wake(notify chan<- struct{}) {
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+}
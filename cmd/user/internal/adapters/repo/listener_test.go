@@ -0,0 +1,89 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ZergsLaw/back-template/cmd/user/internal/adapters/repo"
+	"github.com/ZergsLaw/back-template/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template/internal/testhelper"
+)
+
+func TestRepo_ListenTasks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cfg, assert := startDB(t)
+	db := connect(ctx, t, assert, cfg)
+	_, err := db.ExecContext(ctx, `set cluster setting kv.rangefeed.enabled = true`)
+	assert.NoError(err)
+
+	_, err = repo.New(ctx, prometheus.NewPedanticRegistry(), testhelper.Namespace(t), repo.Config{
+		Cockroach:  *cfg,
+		MigrateDir: migrateDir,
+		Driver:     "postgres",
+		Outbox:     repo.OutboxNotify,
+	})
+	assert.Error(err)
+
+	r, err := repo.New(ctx, prometheus.NewPedanticRegistry(), testhelper.Namespace(t), repo.Config{
+		Cockroach:  *cfg,
+		MigrateDir: migrateDir,
+		Driver:     "postgres",
+		Outbox:     repo.OutboxChangefeed,
+	})
+	assert.NoError(err)
+	t.Cleanup(func() {
+		assert.NoError(r.Close())
+	})
+
+	task := app.Task{
+		User: app.User{ID: uuid.Must(uuid.NewV4()), Email: "email@mail.com", Name: "username"},
+		Kind: app.TaskKindEventAdd,
+	}
+	task.ID, err = r.SaveTask(ctx, task)
+	assert.NoError(err)
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	notify := make(chan struct{}, 1)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- r.ListenTasks(listenCtx, notify)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(<-errc)
+	})
+
+	// Neither existing tasks nor updates made by publisher wake it up.
+	claimed, err := r.ClaimTasks(ctx, "worker", time.Minute, 1)
+	assert.NoError(err)
+	assert.Len(claimed, 1)
+	assert.NoError(r.FinishTasks(ctx, "worker", []uuid.UUID{task.ID}))
+	assert.Never(func() bool {
+		select {
+		case <-notify:
+			return true
+		default:
+			return false
+		}
+	}, time.Second*3, time.Millisecond*100)
+
+	// New tasks are saved until changefeed is started.
+	assert.Eventually(func() bool {
+		_, err := r.SaveTask(ctx, task)
+		assert.NoError(err)
+
+		select {
+		case <-notify:
+			return true
+		case <-time.After(time.Millisecond * 500):
+			return false
+		}
+	}, time.Second*30, time.Millisecond*100)
+}
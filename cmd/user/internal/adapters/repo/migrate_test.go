@@ -0,0 +1,184 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sipki-tech/database/connectors"
+	"github.com/sipki-tech/database/migrations"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZergsLaw/back-template/cmd/user/internal/adapters/repo"
+	"github.com/ZergsLaw/back-template/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template/internal/testhelper"
+)
+
+// migrate applies migrations with version in range (from, to].
+func migrate(ctx context.Context, t *testing.T, assert *require.Assertions, cfg *connectors.CockroachDB, from, to uint) {
+	t.Helper()
+
+	migrates, err := migrations.Parse(migrateDir)
+	assert.NoError(err)
+
+	var part migrations.Migrations
+	for _, m := range migrates {
+		if from < m.Version && m.Version <= to {
+			part = append(part, m)
+		}
+	}
+
+	assert.NoError(migrations.Run(ctx, "postgres", cfg, migrations.Up, part))
+}
+
+func connect(ctx context.Context, t *testing.T, assert *require.Assertions, cfg *connectors.CockroachDB) *sqlx.DB {
+	t.Helper()
+
+	dsn, err := cfg.DSN()
+	assert.NoError(err)
+	db, err := sqlx.ConnectContext(ctx, "postgres", dsn)
+	assert.NoError(err)
+	t.Cleanup(func() {
+		assert.NoError(db.Close())
+	})
+
+	return db
+}
+
+func TestMigrate_UserIDOfTasks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cfg, assert := startDB(t)
+	migrate(ctx, t, assert, cfg, 0, 20)
+	db := connect(ctx, t, assert, cfg)
+
+	var (
+		userID       = uuid.Must(uuid.NewV4())
+		unfinishedID = uuid.Must(uuid.NewV4())
+		finishedID   = uuid.Must(uuid.NewV4())
+	)
+
+	const insert = `
+	insert into tasks
+		(id, user_bytes, kind, finished_at)
+	values
+		($1, $2, 'add', null),
+		($3, $2, 'add', now())`
+	userBytes := []byte(`{"id":"` + userID.String() + `","email":"email@mail.com","name":"username"}`)
+	_, err := db.ExecContext(ctx, insert, unfinishedID, userBytes, finishedID)
+	assert.NoError(err)
+
+	migrate(ctx, t, assert, cfg, 20, 21)
+
+	const query = `select user_id from tasks where id = $1`
+	var res uuid.NullUUID
+	assert.NoError(db.GetContext(ctx, &res, query, unfinishedID))
+	assert.Equal(uuid.NullUUID{UUID: userID, Valid: true}, res)
+	assert.NoError(db.GetContext(ctx, &res, query, finishedID))
+	assert.False(res.Valid)
+}
+
+func TestMigrate_NormalizedUsernames(t *testing.T) {
+	t.Parallel()
+
+	ctx, cfg, assert := startDB(t)
+	migrate(ctx, t, assert, cfg, 0, 9)
+	db := connect(ctx, t, assert, cfg)
+
+	const insert = `
+	insert into users
+		(email, name, full_name, pass_hash, status, created_at)
+	values
+		('1@mail.com', 'Bob', 'Bob', 'hash', 'default', now() - interval '3 hour'),
+		('2@mail.com', 'bob', 'Bob', 'hash', 'default', now() - interval '2 hour'),
+		('3@mail.com', 'ＢＯＢ', 'Bob', 'hash', 'default', now() - interval '1 hour')`
+	_, err := db.ExecContext(ctx, insert)
+	assert.NoError(err)
+
+	_, err = db.ExecContext(ctx, `insert into username_history (user_id, username) select id, 'BOB' from users limit 1`)
+	assert.NoError(err)
+
+	r, err := repo.New(ctx, prometheus.NewPedanticRegistry(), testhelper.Namespace(t), repo.Config{
+		Cockroach:  *cfg,
+		MigrateDir: migrateDir,
+		Driver:     "postgres",
+	})
+	assert.NoError(err)
+	t.Cleanup(func() {
+		assert.NoError(r.Close())
+	})
+
+	// Users are left as is until they're backfilled by app.
+	users, err := r.ListUnnormalizedUsers(ctx, 2)
+	assert.NoError(err)
+	assert.Len(users, 2)
+	assert.Equal("Bob", users[0].Name)
+	assert.Equal("bob", users[1].Name)
+
+	_, err = r.Update(ctx, users[0])
+	assert.NoError(err)
+	_, err = r.Update(ctx, users[1])
+	assert.ErrorIs(err, app.ErrUsernameExist)
+
+	users, err = r.ListUnnormalizedUsers(ctx, 10)
+	assert.NoError(err)
+	assert.Len(users, 2)
+	assert.Equal("bob", users[0].Name)
+	assert.Equal("ＢＯＢ", users[1].Name)
+
+	assert.NoError(r.NormalizeUsernameHistory(ctx))
+	change, err := r.LastUsernameChange(ctx, "bob", time.Now().Add(-time.Hour))
+	assert.NoError(err)
+	assert.Equal("BOB", change.Username)
+}
+
+func TestMigrate_StorageUsage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cfg, assert := startDB(t)
+	migrate(ctx, t, assert, cfg, 0, 24)
+	db := connect(ctx, t, assert, cfg)
+
+	var (
+		userID  = uuid.Must(uuid.NewV4())
+		emptyID = uuid.Must(uuid.NewV4())
+	)
+
+	const insertUsers = `
+	insert into users
+		(id, email, name, name_normalized, full_name, pass_hash, status)
+	values
+		($1, '1@mail.com', 'first', 'first', 'First', 'hash', 'default'),
+		($2, '2@mail.com', 'second', 'second', 'Second', 'hash', 'default')`
+	_, err := db.ExecContext(ctx, insertUsers, userID, emptyID)
+	assert.NoError(err)
+
+	// Legacy avatar has size 0, quarantined avatar doesn't take quota, usage was counted partially.
+	const insertAvatars = `
+	insert into avatars
+		(id, owner_id, status, size, position)
+	values
+		(gen_random_uuid(), $1, 'Clean', 0, 1),
+		(gen_random_uuid(), $1, 'Clean', 10, 2),
+		(gen_random_uuid(), $1, 'Clean', 20, 3),
+		(gen_random_uuid(), $1, 'Quarantined', 5, 4)`
+	_, err = db.ExecContext(ctx, insertAvatars, userID)
+	assert.NoError(err)
+
+	_, err = db.ExecContext(ctx, `insert into storage_usage (user_id, used_bytes) values ($1, 10)`, userID)
+	assert.NoError(err)
+
+	migrate(ctx, t, assert, cfg, 24, 25)
+
+	const query = `select used_bytes from storage_usage where user_id = $1`
+	var used int64
+	assert.NoError(db.GetContext(ctx, &used, query, userID))
+	assert.Equal(int64(30), used)
+	assert.ErrorIs(db.GetContext(ctx, &used, query, emptyID), sql.ErrNoRows)
+}
@@ -4,9 +4,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net"
+	"slices"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
 
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
 	"github.com/ZergsLaw/back-template1/internal/dom"
@@ -14,31 +17,104 @@ import (
 
 type (
 	user struct {
-		ID              uuid.UUID     `db:"id" json:"id"`
-		Email           string        `db:"email" json:"email"`
-		Name            string        `db:"name" json:"name"`
-		FullName        string        `db:"full_name" json:"full_name"`
-		CurrentAvatarID uuid.NullUUID `db:"current_avatar_id" json:"current_avatar_id,omitempty"`
-		PassHash        []byte        `db:"pass_hash" json:"-"`
-		Status          string        `db:"status" json:"status"`
-		CreatedAt       time.Time     `db:"created_at" json:"created_at"`
-		UpdatedAt       time.Time     `db:"updated_at" json:"updated_at"`
+		ID              uuid.UUID      `db:"id" json:"id"`
+		Email           string         `db:"email" json:"email"`
+		Name            string         `db:"name" json:"name"`
+		NameNormalized  sql.NullString `db:"name_normalized" json:"-"`
+		FullName        string         `db:"full_name" json:"full_name"`
+		CurrentAvatarID uuid.NullUUID  `db:"current_avatar_id" json:"current_avatar_id,omitempty"`
+		PassHash        []byte         `db:"pass_hash" json:"-"`
+		Status          string         `db:"status" json:"status"`
+		Bio             string         `db:"bio" json:"bio"`
+		Locale          string         `db:"locale" json:"locale"`
+		Timezone        string         `db:"timezone" json:"timezone"`
+		Birthday        sql.NullTime   `db:"birthday" json:"birthday"`
+		Links           pq.StringArray `db:"links" json:"links"`
+		CreatedAt       time.Time      `db:"created_at" json:"created_at"`
+		UpdatedAt       time.Time      `db:"updated_at" json:"updated_at"`
 	}
 
 	avatar struct {
 		ID        uuid.UUID `db:"id"`
 		OwnerID   uuid.UUID `db:"owner_id"`
+		Status    string    `db:"status"`
+		Position  int       `db:"position"`
+		Size      int64     `db:"size"`
 		CreatedAt time.Time `db:"created_at"`
 		UpdatedAt time.Time `db:"updated_at"`
 	}
 
 	task struct {
-		ID         uuid.UUID       `db:"id"`
-		UserBytes  json.RawMessage `db:"user_bytes"`
-		Kind       string          `db:"kind"`
-		CreatedAt  time.Time       `db:"created_at"`
-		UpdatedAt  time.Time       `db:"updated_at"`
-		FinishedAt sql.NullTime    `db:"finished_at"`
+		ID               uuid.UUID       `db:"id"`
+		UserBytes        json.RawMessage `db:"user_bytes"`
+		Kind             string          `db:"kind"`
+		PreferencesBytes json.RawMessage `db:"preferences_bytes"`
+		ActivityBytes    json.RawMessage `db:"activity_bytes"`
+		CreatedAt        time.Time       `db:"created_at"`
+		UpdatedAt        time.Time       `db:"updated_at"`
+		FinishedAt       sql.NullTime    `db:"finished_at"`
+		LockedBy         sql.NullString  `db:"locked_by"`
+		LockedUntil      sql.NullTime    `db:"locked_until"`
+		Attempts         int             `db:"attempts"`
+		NextAttemptAt    time.Time       `db:"next_attempt_at"`
+		LastError        string          `db:"last_error"`
+		DeadAt           sql.NullTime    `db:"dead_at"`
+		UserID           uuid.NullUUID   `db:"user_id"`
+	}
+
+	activity struct {
+		IP           string    `json:"ip,omitempty"`
+		UserAgent    string    `json:"user_agent,omitempty"`
+		SessionID    uuid.UUID `json:"session_id"`
+		PrevAvatarID uuid.UUID `json:"prev_avatar_id"`
+	}
+
+	taskBacklog struct {
+		Unfinished       int          `db:"unfinished"`
+		Dead             int          `db:"dead"`
+		OldestUnfinished sql.NullTime `db:"oldest_unfinished"`
+	}
+
+	preference struct {
+		UserID    uuid.UUID `db:"user_id" json:"user_id"`
+		Key       string    `db:"key" json:"key"`
+		Kind      string    `db:"kind" json:"kind"`
+		Value     string    `db:"value" json:"value"`
+		CreatedAt time.Time `db:"created_at" json:"created_at"`
+		UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	}
+
+	privacySettings struct {
+		UserID         uuid.UUID `db:"user_id"`
+		HideEmail      bool      `db:"hide_email"`
+		HideStatus     bool      `db:"hide_status"`
+		HideAvatar     bool      `db:"hide_avatar"`
+		HideFromSearch bool      `db:"hide_from_search"`
+		CreatedAt      time.Time `db:"created_at"`
+		UpdatedAt      time.Time `db:"updated_at"`
+	}
+
+	usernameChange struct {
+		ID                 uuid.UUID `db:"id"`
+		UserID             uuid.UUID `db:"user_id"`
+		Username           string    `db:"username"`
+		UsernameNormalized string    `db:"username_normalized"`
+		CreatedAt          time.Time `db:"created_at"`
+	}
+
+	avatarUpload struct {
+		ID          uuid.UUID      `db:"id"`
+		OwnerID     uuid.UUID      `db:"owner_id"`
+		Name        string         `db:"name"`
+		ContentType string         `db:"content_type"`
+		Size        int64          `db:"size"`
+		ExpiresAt   time.Time      `db:"expires_at"`
+		CreatedAt   time.Time      `db:"created_at"`
+		MultipartID string         `db:"multipart_id"`
+		Offset      int64          `db:"uploaded_size"`
+		AvatarID    uuid.NullUUID  `db:"avatar_id"`
+		LockedBy    sql.NullString `db:"locked_by"`
+		LockedUntil sql.NullTime   `db:"locked_until"`
 	}
 
 	statusUpdateRequest struct {
@@ -51,31 +127,60 @@ type (
 )
 
 func convert(u app.User) *user {
+	links := pq.StringArray(u.Profile.Links)
+	if links == nil {
+		links = pq.StringArray{} // Column is not null.
+	}
+
 	return &user{
 		ID:       u.ID,
 		Email:    u.Email,
 		Name:     u.Name,
 		FullName: u.FullName,
+		NameNormalized: sql.NullString{
+			String: app.NormalizeUsername(u.Name),
+			Valid:  true,
+		},
 		CurrentAvatarID: uuid.NullUUID{
 			UUID:  u.AvatarID,
 			Valid: u.AvatarID != uuid.Nil,
 		},
-		PassHash:  u.PassHash,
-		Status:    u.Status.String(),
+		PassHash: u.PassHash,
+		Status:   u.Status.String(),
+		Bio:      u.Profile.Bio,
+		Locale:   u.Profile.Locale,
+		Timezone: u.Profile.Timezone,
+		Birthday: sql.NullTime{
+			Time:  u.Profile.Birthday,
+			Valid: !u.Profile.Birthday.IsZero(),
+		},
+		Links:     links,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
 }
 
 func (u user) convert() *app.User {
+	var links []string
+	if len(u.Links) != 0 {
+		links = u.Links
+	}
+
 	return &app.User{
-		ID:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		FullName:  u.FullName,
-		AvatarID:  u.CurrentAvatarID.UUID,
-		PassHash:  u.PassHash,
-		Status:    appUserStatus(u.Status),
+		ID:       u.ID,
+		Email:    u.Email,
+		Name:     u.Name,
+		FullName: u.FullName,
+		AvatarID: u.CurrentAvatarID.UUID,
+		PassHash: u.PassHash,
+		Status:   appUserStatus(u.Status),
+		Profile: app.Profile{
+			Bio:      u.Bio,
+			Locale:   u.Locale,
+			Timezone: u.Timezone,
+			Birthday: u.Birthday.Time,
+			Links:    links,
+		},
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
@@ -85,6 +190,9 @@ func convertUserFile(f app.AvatarInfo) *avatar {
 	return &avatar{
 		ID:        f.FileID,
 		OwnerID:   f.OwnerID,
+		Status:    f.Status.String(),
+		Position:  f.Position,
+		Size:      f.Size,
 		CreatedAt: f.CreatedAt,
 		UpdatedAt: f.UpdatedAt,
 	}
@@ -94,27 +202,112 @@ func (f avatar) convert() *app.AvatarInfo {
 	return &app.AvatarInfo{
 		OwnerID:   f.OwnerID,
 		FileID:    f.ID,
+		Status:    appAvatarStatus(f.Status),
+		Position:  f.Position,
+		Size:      f.Size,
 		CreatedAt: f.CreatedAt,
 		UpdatedAt: f.UpdatedAt,
 	}
 }
 
+func convertPrivacySettings(s app.PrivacySettings) *privacySettings {
+	return &privacySettings{
+		UserID:         s.UserID,
+		HideEmail:      s.HideEmail,
+		HideStatus:     s.HideStatus,
+		HideAvatar:     s.HideAvatar,
+		HideFromSearch: s.HideFromSearch,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+func (s privacySettings) convert() *app.PrivacySettings {
+	return &app.PrivacySettings{
+		UserID:         s.UserID,
+		HideEmail:      s.HideEmail,
+		HideStatus:     s.HideStatus,
+		HideAvatar:     s.HideAvatar,
+		HideFromSearch: s.HideFromSearch,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+func (c usernameChange) convert() *app.UsernameChange {
+	return &app.UsernameChange{
+		ID:        c.ID,
+		UserID:    c.UserID,
+		Username:  c.Username,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func (u avatarUpload) convert() *app.AvatarUpload {
+	return &app.AvatarUpload{
+		ID:          u.ID,
+		OwnerID:     u.OwnerID,
+		Name:        u.Name,
+		ContentType: u.ContentType,
+		Size:        u.Size,
+		MultipartID: u.MultipartID,
+		Offset:      u.Offset,
+		AvatarID:    u.AvatarID.UUID,
+		ExpiresAt:   u.ExpiresAt,
+		CreatedAt:   u.CreatedAt,
+	}
+}
+
 func convertTask(s app.Task) (*task, error) {
 	userBytes, err := json.Marshal(convert(s.User))
 	if err != nil {
 		return nil, fmt.Errorf("json.Marshal: %w", err)
 	}
 
+	var preferencesBytes json.RawMessage
+	if len(s.Preferences) != 0 {
+		prefs := make([]preference, len(s.Preferences))
+		for i := range s.Preferences {
+			prefs[i] = *convertPreference(s.Preferences[i])
+		}
+
+		preferencesBytes, err = json.Marshal(prefs)
+		if err != nil {
+			return nil, fmt.Errorf("json.Marshal: %w", err)
+		}
+	}
+
+	var activityBytes json.RawMessage
+	if !s.Activity.IsEmpty() {
+		activityBytes, err = json.Marshal(convertActivity(s.Activity))
+		if err != nil {
+			return nil, fmt.Errorf("json.Marshal: %w", err)
+		}
+	}
+
 	return &task{
-		ID:        s.ID,
-		UserBytes: userBytes,
-		Kind:      s.Kind.String(),
-		CreatedAt: s.CreatedAt,
-		UpdatedAt: s.UpdatedAt,
+		ID:               s.ID,
+		UserBytes:        userBytes,
+		Kind:             s.Kind.String(),
+		PreferencesBytes: preferencesBytes,
+		ActivityBytes:    activityBytes,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
 		FinishedAt: sql.NullTime{
 			Time:  s.FinishedAt,
 			Valid: !s.FinishedAt.IsZero(),
 		},
+		Attempts:      s.Attempts,
+		NextAttemptAt: s.NextAttempt,
+		LastError:     s.LastError,
+		DeadAt: sql.NullTime{
+			Time:  s.DeadAt,
+			Valid: !s.DeadAt.IsZero(),
+		},
+		UserID: uuid.NullUUID{
+			UUID:  s.User.ID,
+			Valid: s.User.ID != uuid.Nil,
+		},
 	}, nil
 }
 
@@ -125,16 +318,176 @@ func (t task) convert() (*app.Task, error) {
 		return nil, fmt.Errorf("json.Unmarshal: %w", err)
 	}
 
+	var prefs []app.Preference
+	if len(t.PreferencesBytes) != 0 {
+		var res []preference
+		err = json.Unmarshal(t.PreferencesBytes, &res)
+		if err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+
+		prefs = make([]app.Preference, len(res))
+		for i := range res {
+			prefs[i] = *res[i].convert()
+		}
+	}
+
+	var act activity
+	if len(t.ActivityBytes) != 0 {
+		err = json.Unmarshal(t.ActivityBytes, &act)
+		if err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+	}
+
+	// Task of failed login with unknown email has user with email only.
+	taskUser := app.User{Email: u.Email}
+	if u.ID != uuid.Nil {
+		taskUser = *u.convert()
+	}
+
 	return &app.Task{
-		ID:         t.ID,
-		User:       *u.convert(),
-		Kind:       appTaskKind(t.Kind),
-		CreatedAt:  t.CreatedAt,
-		UpdatedAt:  t.UpdatedAt,
-		FinishedAt: t.FinishedAt.Time,
+		ID:          t.ID,
+		User:        taskUser,
+		Kind:        appTaskKind(t.Kind),
+		Preferences: prefs,
+		Activity:    act.convert(),
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		FinishedAt:  t.FinishedAt.Time,
+		Attempts:    t.Attempts,
+		NextAttempt: t.NextAttemptAt,
+		LastError:   t.LastError,
+		DeadAt:      t.DeadAt.Time,
 	}, nil
 }
 
+// convertTasks converts tasks and orders them by creation time,
+// because update statements don't keep order of rows.
+func convertTasks(res []task) ([]app.Task, error) {
+	tasks := make([]app.Task, len(res))
+	for i := range res {
+		t, err := res[i].convert()
+		if err != nil {
+			return nil, fmt.Errorf("convert: %w", err)
+		}
+
+		tasks[i] = *t
+	}
+
+	slices.SortStableFunc(tasks, func(a, b app.Task) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return tasks, nil
+}
+
+func convertActivity(a app.Activity) activity {
+	res := activity{
+		UserAgent:    a.Origin.UserAgent,
+		SessionID:    a.SessionID,
+		PrevAvatarID: a.PrevAvatarID,
+	}
+	if a.Origin.IP != nil {
+		res.IP = a.Origin.IP.String()
+	}
+
+	return res
+}
+
+func (a activity) convert() app.Activity {
+	return app.Activity{
+		Origin: dom.Origin{
+			IP:        net.ParseIP(a.IP),
+			UserAgent: a.UserAgent,
+		},
+		SessionID:    a.SessionID,
+		PrevAvatarID: a.PrevAvatarID,
+	}
+}
+
+func (b taskBacklog) convert() *app.TaskBacklog {
+	return &app.TaskBacklog{
+		Unfinished:       b.Unfinished,
+		Dead:             b.Dead,
+		OldestUnfinished: b.OldestUnfinished.Time,
+	}
+}
+
+func convertPreference(p app.Preference) *preference {
+	return &preference{
+		UserID:    p.UserID,
+		Key:       p.Key,
+		Kind:      p.Kind.String(),
+		Value:     p.Value,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func (p preference) convert() *app.Preference {
+	return &app.Preference{
+		UserID:    p.UserID,
+		Key:       p.Key,
+		Kind:      appPreferenceKind(p.Kind),
+		Value:     p.Value,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// preferencesColumns splits preferences by columns for batch insert.
+func preferencesColumns(prefs []app.Preference) (userIDs []uuid.UUID, keys, kinds, values []string) {
+	userIDs = make([]uuid.UUID, len(prefs))
+	keys = make([]string, len(prefs))
+	kinds = make([]string, len(prefs))
+	values = make([]string, len(prefs))
+	for i := range prefs {
+		p := convertPreference(prefs[i])
+		userIDs[i] = p.UserID
+		keys[i] = p.Key
+		kinds[i] = p.Kind
+		values[i] = p.Value
+	}
+
+	return userIDs, keys, kinds, values
+}
+
+func normalizeUsernames(usernames []string) []string {
+	res := make([]string, len(usernames))
+	for i := range usernames {
+		res[i] = app.NormalizeUsername(usernames[i])
+	}
+
+	return res
+}
+
+func appPreferenceKind(txt string) app.PreferenceKind {
+	switch txt {
+	case app.PreferenceKindBool.String():
+		return app.PreferenceKindBool
+	case app.PreferenceKindInt.String():
+		return app.PreferenceKindInt
+	case app.PreferenceKindString.String():
+		return app.PreferenceKindString
+	default:
+		panic(fmt.Sprintf("unknown txt: %s", txt))
+	}
+}
+
+func appAvatarStatus(txt string) app.AvatarStatus {
+	switch txt {
+	case app.AvatarStatusClean.String():
+		return app.AvatarStatusClean
+	case app.AvatarStatusQuarantined.String():
+		return app.AvatarStatusQuarantined
+	case app.AvatarStatusMissing.String():
+		return app.AvatarStatusMissing
+	default:
+		panic(fmt.Sprintf("unknown txt: %s", txt))
+	}
+}
+
 func appTaskKind(txt string) app.TaskKind {
 	switch txt {
 	case app.TaskKindEventAdd.String():
@@ -143,6 +496,18 @@ func appTaskKind(txt string) app.TaskKind {
 		return app.TaskKindEventDel
 	case app.TaskKindEventUpdate.String():
 		return app.TaskKindEventUpdate
+	case app.TaskKindEventPreferences.String():
+		return app.TaskKindEventPreferences
+	case app.TaskKindEventLoggedIn.String():
+		return app.TaskKindEventLoggedIn
+	case app.TaskKindEventLoggedOut.String():
+		return app.TaskKindEventLoggedOut
+	case app.TaskKindEventLoginFailed.String():
+		return app.TaskKindEventLoginFailed
+	case app.TaskKindEventPasswordChanged.String():
+		return app.TaskKindEventPasswordChanged
+	case app.TaskKindEventAvatarChanged.String():
+		return app.TaskKindEventAvatarChanged
 	default:
 		panic(fmt.Sprintf("unknown txt: %s", txt))
 	}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
@@ -20,16 +21,124 @@ import (
 
 var _ app.Repo = &Repo{}
 
+// addStorageUsageQuery changes amount of bytes taken by user's avatars, amount never becomes negative.
+const addStorageUsageQuery = `
+	insert into storage_usage
+		(user_id, used_bytes)
+	values
+		($1, greatest($2::int8, 0))
+	on conflict (user_id) do update
+	set used_bytes = greatest(storage_usage.used_bytes + $2::int8, 0), updated_at = now()
+	returning used_bytes`
+
+// claimTasksQuery leases unfinished tasks ready for next attempt to worker,
+// tasks leased by other workers are skipped until their lease is over.
+// Task isn't claimed while previous task of the same user is leased or waits for retry,
+// so tasks of user are published in order of creation.
+const claimTasksQuery = `
+	update tasks
+	set locked_by = $1, locked_until = now() + $2 * interval '1 microsecond', updated_at = now()
+	where id in (
+		select id from tasks
+		where finished_at is null and dead_at is null and next_attempt_at <= now()
+			and (locked_until is null or locked_until < now())
+			and not exists (
+				select 1 from tasks prev
+				where prev.user_id = tasks.user_id and prev.created_at < tasks.created_at
+					and prev.finished_at is null and prev.dead_at is null
+					and (prev.next_attempt_at > now() or prev.locked_until >= now())
+			)
+		order by created_at asc
+		limit $3
+		for update skip locked
+	)
+	returning *`
+
+// usersByKeysQuery matches usernames by normalized form like other lookups by username.
+const usersByKeysQuery = `select * from users where id = any($1) or name_normalized = any($2) or email = any($3)`
+
+// Normalized usernames are left empty by migrations, because normalization is implemented by app.NormalizeUsername only.
+const (
+	unnormalizedUsersQuery   = `select * from users where name_normalized is null order by created_at, id limit $1`
+	unnormalizedHistoryQuery = `select distinct username from username_history where username_normalized is null`
+	normalizeHistoryQuery    = `update username_history set username_normalized = $1 where username = $2 and username_normalized is null`
+)
+
+const taskBacklogQuery = `
+	select
+		count(*) filter (where dead_at is null) as unfinished,
+		count(*) filter (where dead_at is not null) as dead,
+		min(created_at) filter (where dead_at is null) as oldest_unfinished
+	from tasks
+	where finished_at is null`
+
+const deleteFinishedTasksQuery = `
+	delete from tasks
+	where id in (
+		select id from tasks
+		where finished_at < $1
+		order by finished_at asc
+		limit $2
+	)
+	returning id`
+
+// archiveFinishedTasksQuery moves tasks to archive by one statement, so task can't be lost or archived twice.
+const archiveFinishedTasksQuery = `
+	with moved as (
+		delete from tasks
+		where id in (
+			select id from tasks
+			where finished_at < $1
+			order by finished_at asc
+			limit $2
+		)
+		returning id, user_id, kind, user_bytes, preferences_bytes, activity_bytes, attempts, created_at, finished_at
+	)
+	insert into tasks_archive
+		(id, user_id, kind, user_bytes, preferences_bytes, activity_bytes, attempts, created_at, finished_at)
+	select id, user_id, kind, user_bytes, preferences_bytes, activity_bytes, attempts, created_at, finished_at
+	from moved
+	returning id`
+
+// leaseJobQuery takes lease of job if it's free, expired or held by the same worker.
+const leaseJobQuery = `
+	insert into job_leases
+		(job, locked_by, locked_until)
+	values
+		($1, $2, now() + $3 * interval '1 microsecond')
+	on conflict (job) do update
+	set locked_by = excluded.locked_by, locked_until = excluded.locked_until
+	where job_leases.locked_until < now() or job_leases.locked_by = excluded.locked_by
+	returning job`
+
+// leaseAvatarUploadQuery takes lease of avatar upload if it's received up to offset and isn't leased by other writer.
+const leaseAvatarUploadQuery = `
+	update avatar_uploads
+	set locked_by = $3, locked_until = now() + $4 * interval '1 microsecond'
+	where id = $1 and uploaded_size = $2 and (locked_until is null or locked_until < now())
+	returning id`
+
+// setAvatarUploadOffsetQuery updates offset of avatar upload and releases lease if it's held by writer.
+const setAvatarUploadOffsetQuery = `
+	update avatar_uploads
+	set uploaded_size = $3, locked_by = null, locked_until = null
+	where id = $1 and locked_by = $2
+	returning id`
+
 type (
 	// Config provide connection info for database.
 	Config struct {
 		Cockroach  connectors.CockroachDB
 		MigrateDir string
 		Driver     string
+		// Outbox is one of Outbox… drivers, OutboxPolling is used by default.
+		Outbox string
 	}
 	// Repo provided data from and to database.
 	Repo struct {
-		sql *database.SQL
+		sql    *database.SQL
+		outbox string
+		dsn    string
 	}
 )
 
@@ -45,6 +154,19 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 		app.ErrUserIDAndFileIDExist,
 	}
 
+	switch cfg.Outbox {
+	case "":
+		cfg.Outbox = OutboxPolling
+	case OutboxPolling, OutboxNotify, OutboxChangefeed:
+	default:
+		return nil, fmt.Errorf("unknown outbox driver: %s", cfg.Outbox)
+	}
+
+	dsn, err := cfg.Cockroach.DSN()
+	if err != nil {
+		return nil, fmt.Errorf("cfg.Cockroach.DSN: %w", err)
+	}
+
 	migrates, err := migrations.Parse(cfg.MigrateDir)
 	if err != nil {
 		return nil, fmt.Errorf("migrations.Parse: %w", err)
@@ -63,9 +185,18 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 		return nil, fmt.Errorf("librepo.NewCockroach: %w", err)
 	}
 
-	return &Repo{
-		sql: conn,
-	}, nil
+	r := &Repo{
+		sql:    conn,
+		outbox: cfg.Outbox,
+		dsn:    dsn,
+	}
+
+	err = r.checkOutbox(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("r.checkOutbox: %w", err)
+	}
+
+	return r, nil
 }
 
 // Close implements io.Closer.
@@ -80,13 +211,14 @@ func (r *Repo) Save(ctx context.Context, u app.User) (id uuid.UUID, err error) {
 		const query = `
 		insert into 
 		users 
-		    (email, name, full_name, pass_hash, status) 
+		    (email, name, name_normalized, full_name, pass_hash, status) 
 		values 
-			($1, $2, $3, $4, $5)
+			($1, $2, $3, $4, $5, $6)
 		returning id
 		`
 
-		err := db.GetContext(ctx, &id, query, newUser.Email, newUser.Name, newUser.FullName, newUser.PassHash, newUser.Status)
+		err := db.GetContext(ctx, &id, query, newUser.Email, newUser.Name, newUser.NameNormalized, newUser.FullName, newUser.PassHash,
+			newUser.Status)
 		if err != nil {
 			return fmt.Errorf("db.GetContext: %w", convertErr(err))
 		}
@@ -113,13 +245,20 @@ func (r *Repo) Update(ctx context.Context, u app.User) (upUser *app.User, err er
 			pass_hash   	  = $4,
 			current_avatar_id = $5,
 			status 			  = $6,
+			bio               = $7,
+			locale            = $8,
+			timezone          = $9,
+			birthday          = $10,
+			links             = $11,
+			name_normalized   = $12,
 			updated_at = now()
-		where id = $7
+		where id = $13
 		returning *`
 
 		var res user
 		err := db.GetContext(ctx, &res, query, updateUser.Email, updateUser.Name, updateUser.FullName, updateUser.PassHash,
-			updateUser.CurrentAvatarID, updateUser.Status, updateUser.ID)
+			updateUser.CurrentAvatarID, updateUser.Status, updateUser.Bio, updateUser.Locale, updateUser.Timezone, updateUser.Birthday,
+			updateUser.Links, updateUser.NameNormalized, updateUser.ID)
 		if err != nil {
 			return fmt.Errorf("db.GetContext: %w", convertErr(err))
 		}
@@ -199,10 +338,10 @@ func (r *Repo) ByEmail(ctx context.Context, email string) (u *app.User, err erro
 // ByUsername for implements app.Repo.
 func (r *Repo) ByUsername(ctx context.Context, username string) (u *app.User, err error) {
 	err = r.sql.NoTx(func(db *sqlx.DB) error {
-		const query = `select * from users where name = $1`
+		const query = `select * from users where name = $1 or name_normalized = $2 order by name = $1 desc limit 1`
 
 		res := user{}
-		err = db.GetContext(ctx, &res, query, username)
+		err = db.GetContext(ctx, &res, query, username, app.NormalizeUsername(username))
 		if err != nil {
 			return fmt.Errorf("db.GetContext: %w", convertErr(err))
 		}
@@ -264,22 +403,8 @@ func (r *Repo) SearchUsers(ctx context.Context, params app.SearchParams) (users
 
 // SaveAvatar for implements app.Repo.
 func (r *Repo) SaveAvatar(ctx context.Context, userFile app.AvatarInfo) (err error) {
-	return r.sql.NoTx(func(db *sqlx.DB) error {
-		avatarCache := convertUserFile(userFile)
-		const query = `
-		insert into 
-		avatars 
-		    (owner_id, id) 
-		values 
-			($1, $2)
-		`
-
-		_, err := db.ExecContext(ctx, query, avatarCache.OwnerID, avatarCache.ID)
-		if err != nil {
-			return fmt.Errorf("db.GetContext: %w", convertErr(err))
-		}
-
-		return nil
+	return r.Tx(ctx, func(repo app.Repo) error {
+		return repo.SaveAvatar(ctx, userFile)
 	})
 }
 
@@ -326,7 +451,7 @@ func (r *Repo) GetAvatar(ctx context.Context, avatarID uuid.UUID) (f *app.Avatar
 // ListAvatarByUserID for implements app.Repo.
 func (r *Repo) ListAvatarByUserID(ctx context.Context, userID uuid.UUID) (userAvatars []app.AvatarInfo, err error) {
 	err = r.sql.NoTx(func(db *sqlx.DB) error {
-		const query = `select * from avatars where owner_id = $1 order by created_at desc`
+		const query = `select * from avatars where owner_id = $1 order by position asc, created_at desc`
 
 		var res []avatar
 		err = db.SelectContext(ctx, &res, query, userID)
@@ -351,9 +476,9 @@ func (r *Repo) ListAvatarByUserID(ctx context.Context, userID uuid.UUID) (userAv
 // GetCountAvatars for implements app.Repo.
 func (r *Repo) GetCountAvatars(ctx context.Context, ownerID uuid.UUID) (total int, err error) {
 	err = r.sql.NoTx(func(db *sqlx.DB) error {
-		const getTotal = `select count(*) over() as total from avatars where owner_id = $1`
+		const getTotal = `select count(*) over() as total from avatars where owner_id = $1 and status = $2`
 
-		err = db.GetContext(ctx, &total, getTotal, ownerID)
+		err = db.GetContext(ctx, &total, getTotal, ownerID, app.AvatarStatusClean.String())
 		if err != nil {
 			return fmt.Errorf("db.GetContext: %w", convertErr(err))
 		}
@@ -367,6 +492,95 @@ func (r *Repo) GetCountAvatars(ctx context.Context, ownerID uuid.UUID) (total in
 	return total, nil
 }
 
+// ListAvatars implements app.Repo.
+func (r *Repo) ListAvatars(ctx context.Context, after uuid.UUID, limit int) (avatars []app.AvatarInfo, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `select * from avatars where id > $1 order by id asc limit $2`
+
+		res := make([]avatar, 0, limit)
+		err = db.SelectContext(ctx, &res, query, after, limit)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		avatars = make([]app.AvatarInfo, len(res))
+		for i := range res {
+			avatars[i] = *res[i].convert()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return avatars, nil
+}
+
+// SetAvatarStatus implements app.Repo.
+func (r *Repo) SetAvatarStatus(ctx context.Context, fileID uuid.UUID, status app.AvatarStatus) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `update avatars set status = $2, updated_at = now() where id = $1`
+
+		_, err := db.ExecContext(ctx, query, fileID, status.String())
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// SetAvatarPosition implements app.Repo.
+func (r *Repo) SetAvatarPosition(ctx context.Context, fileID uuid.UUID, position int) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `update avatars set position = $2, updated_at = now() where id = $1`
+
+		_, err := db.ExecContext(ctx, query, fileID, position)
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// GetStorageUsage implements app.Repo.
+func (r *Repo) GetStorageUsage(ctx context.Context, userID uuid.UUID) (usedBytes int64, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `select coalesce(max(used_bytes), 0) from storage_usage where user_id = $1`
+
+		err = db.GetContext(ctx, &usedBytes, query, userID)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return usedBytes, nil
+}
+
+// AddStorageUsage implements app.Repo.
+func (r *Repo) AddStorageUsage(ctx context.Context, userID uuid.UUID, delta int64) (usedBytes int64, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		err = db.GetContext(ctx, &usedBytes, addStorageUsageQuery, userID, delta)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return usedBytes, nil
+}
+
 // SaveTask implements app.Repo.
 func (r *Repo) SaveTask(ctx context.Context, task app.Task) (id uuid.UUID, err error) {
 	err = r.sql.NoTx(func(db *sqlx.DB) error {
@@ -378,17 +592,24 @@ func (r *Repo) SaveTask(ctx context.Context, task app.Task) (id uuid.UUID, err e
 		const query = `
 		insert into 
 		tasks 
-		    (user_bytes, kind) 
+		    (user_bytes, kind, preferences_bytes, user_id, activity_bytes) 
 		values
-			($1, $2)
+			($1, $2, $3, $4, $5)
 		returning id
 		`
 
-		err = db.GetContext(ctx, &id, query, newTask.UserBytes, newTask.Kind)
+		err = db.GetContext(ctx, &id, query, newTask.UserBytes, newTask.Kind, newTask.PreferencesBytes, newTask.UserID, newTask.ActivityBytes)
 		if err != nil {
 			return fmt.Errorf("db.GetContext: %w", convertErr(err))
 		}
 
+		if r.outbox == OutboxNotify {
+			_, err = db.ExecContext(ctx, notifyTasksQuery)
+			if err != nil {
+				return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -398,16 +619,16 @@ func (r *Repo) SaveTask(ctx context.Context, task app.Task) (id uuid.UUID, err e
 	return id, nil
 }
 
-// FinishTask implements app.Repo.
-func (r *Repo) FinishTask(ctx context.Context, id uuid.UUID) error {
+// FinishTasks implements app.Repo.
+func (r *Repo) FinishTasks(ctx context.Context, workerID string, ids []uuid.UUID) error {
 	return r.sql.NoTx(func(db *sqlx.DB) error {
 		const query = `
 		update tasks set 
 		updated_at = now(),
 		finished_at = now()
-		where id = $1`
+		where id = any($1) and locked_by = $2`
 
-		_, err := db.ExecContext(ctx, query, id)
+		_, err := db.ExecContext(ctx, query, pq.Array(ids), workerID)
 		if err != nil {
 			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
 		}
@@ -416,17 +637,175 @@ func (r *Repo) FinishTask(ctx context.Context, id uuid.UUID) error {
 	})
 }
 
-// ListActualTask implements app.Repo.
-func (r *Repo) ListActualTask(ctx context.Context, limit int) (tasks []app.Task, err error) {
+// ClaimTasks implements app.Repo.
+func (r *Repo) ClaimTasks(ctx context.Context, workerID string, lease time.Duration, limit int) (tasks []app.Task, err error) {
 	err = r.sql.NoTx(func(db *sqlx.DB) error {
-		const query = `select * from tasks where finished_at is null order by created_at asc limit $1`
+		res := make([]task, 0, limit)
+		err = db.SelectContext(ctx, &res, claimTasksQuery, workerID, lease.Microseconds(), limit)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		tasks, err = convertTasks(res)
+		if err != nil {
+			return fmt.Errorf("convertTasks: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// LeaseJob implements app.Repo.
+func (r *Repo) LeaseJob(ctx context.Context, job, workerID string, lease time.Duration) (ok bool, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		var jobs []string
+		err = db.SelectContext(ctx, &jobs, leaseJobQuery, job, workerID, lease.Microseconds())
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		ok = len(jobs) > 0
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+// GetTaskBacklog implements app.Repo.
+func (r *Repo) GetTaskBacklog(ctx context.Context) (backlog *app.TaskBacklog, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		var res taskBacklog
+		err = db.GetContext(ctx, &res, taskBacklogQuery)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		backlog = res.convert()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return backlog, nil
+}
+
+// DeleteFinishedTasks implements app.Repo.
+func (r *Repo) DeleteFinishedTasks(ctx context.Context, before time.Time, limit int) (count int, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		var ids []uuid.UUID
+		err = db.SelectContext(ctx, &ids, deleteFinishedTasksQuery, before, limit)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		count = len(ids)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ArchiveFinishedTasks implements app.Repo.
+func (r *Repo) ArchiveFinishedTasks(ctx context.Context, before time.Time, limit int) (count int, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		var ids []uuid.UUID
+		err = db.SelectContext(ctx, &ids, archiveFinishedTasksQuery, before, limit)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		count = len(ids)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// FailTask implements app.Repo.
+func (r *Repo) FailTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string, delay time.Duration) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		update tasks set
+		attempts = attempts + 1,
+		last_error = $2,
+		next_attempt_at = now() + $3 * interval '1 microsecond',
+		locked_by = null,
+		locked_until = null,
+		updated_at = now()
+		where id = $1 and locked_by = $4`
+
+		_, err := db.ExecContext(ctx, query, id, lastErr, delay.Microseconds(), workerID)
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// KillTask implements app.Repo.
+func (r *Repo) KillTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		update tasks set
+		attempts = attempts + 1,
+		last_error = $2,
+		dead_at = now(),
+		locked_by = null,
+		locked_until = null,
+		updated_at = now()
+		where id = $1 and locked_by = $3`
+
+		_, err := db.ExecContext(ctx, query, id, lastErr, workerID)
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// ListDeadTasks implements app.Repo.
+func (r *Repo) ListDeadTasks(ctx context.Context, limit, offset int) (tasks []app.Task, total int, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		select * from tasks 
+		where finished_at is null and dead_at is not null 
+		order by dead_at desc, id asc 
+		limit $1 offset $2`
 
 		res := make([]task, 0, limit)
-		err = db.SelectContext(ctx, &res, query, limit)
+		err = db.SelectContext(ctx, &res, query, limit, offset)
 		if err != nil {
 			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
 		}
 
+		const totalQuery = `select count(*) from tasks where finished_at is null and dead_at is not null`
+
+		err = db.GetContext(ctx, &total, totalQuery)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
 		tasks = make([]app.Task, len(res))
 		for i := range res {
 			t, err := res[i].convert()
@@ -440,10 +819,50 @@ func (r *Repo) ListActualTask(ctx context.Context, limit int) (tasks []app.Task,
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return tasks, nil
+	return tasks, total, nil
+}
+
+// RetryDeadTask implements app.Repo.
+func (r *Repo) RetryDeadTask(ctx context.Context, id uuid.UUID) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		update tasks set
+		attempts = 0,
+		next_attempt_at = now(),
+		dead_at = null,
+		updated_at = now()
+		where id = $1 and finished_at is null and dead_at is not null
+		returning id`
+
+		var res uuid.UUID
+		err := db.GetContext(ctx, &res, query, id)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// DiscardDeadTask implements app.Repo.
+func (r *Repo) DiscardDeadTask(ctx context.Context, id uuid.UUID) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		delete from tasks
+		where id = $1 and finished_at is null and dead_at is not null
+		returning id`
+
+		var res uuid.UUID
+		err := db.GetContext(ctx, &res, query, id)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
 }
 
 func (r *Repo) UsersByIDs(ctx context.Context, ids []uuid.UUID) (users []app.User, err error) {
@@ -471,6 +890,350 @@ func (r *Repo) UsersByIDs(ctx context.Context, ids []uuid.UUID) (users []app.Use
 	return users, nil
 }
 
+// UsersByKeys implements app.Repo.
+func (r *Repo) UsersByKeys(ctx context.Context, keys app.BatchKeys) (users []app.User, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		res := make([]user, 0, keys.Len())
+
+		err = db.SelectContext(ctx, &res, usersByKeysQuery, pq.Array(keys.IDs), pq.Array(normalizeUsernames(keys.Usernames)), pq.Array(keys.Emails))
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		users = make([]app.User, len(res))
+		for i := range res {
+			users[i] = *res[i].convert()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// SavePrivacySettings implements app.Repo.
+func (r *Repo) SavePrivacySettings(ctx context.Context, s app.PrivacySettings) (upSettings *app.PrivacySettings, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		settings := convertPrivacySettings(s)
+		const query = `
+		insert into privacy_settings
+			(user_id, hide_email, hide_status, hide_avatar, hide_from_search)
+		values
+			($1, $2, $3, $4, $5)
+		on conflict (user_id) do update
+		set
+			hide_email       = excluded.hide_email,
+			hide_status      = excluded.hide_status,
+			hide_avatar      = excluded.hide_avatar,
+			hide_from_search = excluded.hide_from_search,
+			updated_at       = now()
+		returning *`
+
+		var res privacySettings
+		err := db.GetContext(ctx, &res, query, settings.UserID, settings.HideEmail, settings.HideStatus,
+			settings.HideAvatar, settings.HideFromSearch)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		upSettings = res.convert()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return upSettings, nil
+}
+
+// ListPrivacySettings implements app.Repo.
+func (r *Repo) ListPrivacySettings(ctx context.Context, userIDs []uuid.UUID) (settings []app.PrivacySettings, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `select * from privacy_settings where user_id = any($1)`
+
+		res := make([]privacySettings, 0, len(userIDs))
+
+		err = db.SelectContext(ctx, &res, query, pq.Array(userIDs))
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		settings = make([]app.PrivacySettings, len(res))
+		for i := range res {
+			settings[i] = *res[i].convert()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// SavePreferences implements app.Repo.
+func (r *Repo) SavePreferences(ctx context.Context, prefs []app.Preference) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		userIDs, keys, kinds, values := preferencesColumns(prefs)
+		const query = `
+		insert into preferences
+			(user_id, key, kind, value)
+		select * from unnest($1::uuid[], $2::text[], $3::text[], $4::text[])
+		on conflict (user_id, key) do update
+		set
+			kind       = excluded.kind,
+			value      = excluded.value,
+			updated_at = now()`
+
+		_, err := db.ExecContext(ctx, query, pq.Array(userIDs), pq.Array(keys), pq.Array(kinds), pq.Array(values))
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// ListPreferences implements app.Repo.
+func (r *Repo) ListPreferences(ctx context.Context, userID uuid.UUID) (prefs []app.Preference, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `select * from preferences where user_id = $1 order by key`
+
+		var res []preference
+		err = db.SelectContext(ctx, &res, query, userID)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		prefs = make([]app.Preference, len(res))
+		for i := range res {
+			prefs[i] = *res[i].convert()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+// SaveUsernameChange implements app.Repo.
+func (r *Repo) SaveUsernameChange(ctx context.Context, change app.UsernameChange) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `insert into username_history (user_id, username, username_normalized) values ($1, $2, $3)`
+
+		_, err := db.ExecContext(ctx, query, change.UserID, change.Username, app.NormalizeUsername(change.Username))
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// LastUsernameChange implements app.Repo.
+func (r *Repo) LastUsernameChange(ctx context.Context, username string, since time.Time) (change *app.UsernameChange, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		select * from username_history
+		where username_normalized = $1 and created_at > $2
+		order by created_at desc
+		limit 1`
+
+		res := usernameChange{}
+		err = db.GetContext(ctx, &res, query, app.NormalizeUsername(username), since)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		change = res.convert()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// ListUnnormalizedUsers implements app.Repo.
+func (r *Repo) ListUnnormalizedUsers(ctx context.Context, limit int) (users []app.User, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		res := make([]user, 0, limit)
+
+		err = db.SelectContext(ctx, &res, unnormalizedUsersQuery, limit)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		users = make([]app.User, len(res))
+		for i := range res {
+			users[i] = *res[i].convert()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// NormalizeUsernameHistory implements app.Repo.
+func (r *Repo) NormalizeUsernameHistory(ctx context.Context) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		var usernames []string
+		err := db.SelectContext(ctx, &usernames, unnormalizedHistoryQuery)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		for _, username := range usernames {
+			_, err = db.ExecContext(ctx, normalizeHistoryQuery, app.NormalizeUsername(username), username)
+			if err != nil {
+				return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+			}
+		}
+
+		return nil
+	})
+}
+
+// SaveAvatarUpload implements app.Repo.
+func (r *Repo) SaveAvatarUpload(ctx context.Context, upload app.AvatarUpload) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		insert into avatar_uploads
+			(id, owner_id, name, content_type, size, multipart_id, expires_at)
+		values
+			($1, $2, $3, $4, $5, $6, $7)`
+
+		_, err := db.ExecContext(ctx, query,
+			upload.ID, upload.OwnerID, upload.Name, upload.ContentType, upload.Size, upload.MultipartID, upload.ExpiresAt.UTC())
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// GetAvatarUpload implements app.Repo.
+func (r *Repo) GetAvatarUpload(ctx context.Context, id uuid.UUID) (upload *app.AvatarUpload, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `select * from avatar_uploads where id = $1`
+
+		res := avatarUpload{}
+		err = db.GetContext(ctx, &res, query, id)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		upload = res.convert()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// DeleteAvatarUpload implements app.Repo.
+func (r *Repo) DeleteAvatarUpload(ctx context.Context, id uuid.UUID) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `delete from avatar_uploads where id = $1`
+
+		_, err := db.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// LeaseAvatarUpload implements app.Repo.
+func (r *Repo) LeaseAvatarUpload(ctx context.Context, id uuid.UUID, offset int64, writerID string, lease time.Duration) (ok bool, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		var ids []uuid.UUID
+		err = db.SelectContext(ctx, &ids, leaseAvatarUploadQuery, id, offset, writerID, lease.Microseconds())
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		ok = len(ids) > 0
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+// SetAvatarUploadOffset implements app.Repo.
+func (r *Repo) SetAvatarUploadOffset(ctx context.Context, id uuid.UUID, writerID string, offset int64) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		err := db.GetContext(ctx, &uuid.UUID{}, setAvatarUploadOffsetQuery, id, writerID, offset)
+		if err != nil {
+			return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// CompleteAvatarUpload implements app.Repo.
+func (r *Repo) CompleteAvatarUpload(ctx context.Context, id, avatarID uuid.UUID) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `update avatar_uploads set avatar_id = $2 where id = $1`
+
+		_, err := db.ExecContext(ctx, query, id, avatarID)
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", convertErr(err))
+		}
+
+		return nil
+	})
+}
+
+// ListExpiredAvatarUploads implements app.Repo.
+func (r *Repo) ListExpiredAvatarUploads(ctx context.Context, before time.Time, limit int) (uploads []app.AvatarUpload, err error) {
+	err = r.sql.NoTx(func(db *sqlx.DB) error {
+		const query = `select * from avatar_uploads where expires_at < $1 order by expires_at asc limit $2`
+
+		res := make([]avatarUpload, 0, limit)
+		err = db.SelectContext(ctx, &res, query, before.UTC(), limit)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", convertErr(err))
+		}
+
+		uploads = make([]app.AvatarUpload, len(res))
+		for i := range res {
+			uploads[i] = *res[i].convert()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
 // Tx implements app.Repo.
 func (r *Repo) Tx(ctx context.Context, f func(app.Repo) error) error {
 	opt := &sql.TxOptions{
@@ -479,6 +1242,6 @@ func (r *Repo) Tx(ctx context.Context, f func(app.Repo) error) error {
 	}
 
 	return r.sql.Tx(ctx, opt, func(tx *sqlx.Tx) error {
-		return f(&txRepo{tx: tx})
+		return f(&txRepo{tx: tx, outbox: r.outbox})
 	})
 }
@@ -5,11 +5,13 @@ package repo_test
 import (
 	"context"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ZergsLaw/back-template/cmd/user/internal/app"
 	"github.com/ZergsLaw/back-template/internal/dom"
@@ -47,6 +49,12 @@ func TestRepo_Smoke(t *testing.T) {
 
 	user.Name = "new_username"
 	user.FullName = "Elon Musk2"
+	user.Profile = app.Profile{
+		Bio:      "bio",
+		Locale:   "en-US",
+		Timezone: "Europe/Moscow",
+		Links:    []string{"https://example.com"},
+	}
 	_, err = r.Update(ctx, user)
 	assert.NoError(err)
 
@@ -84,6 +92,174 @@ func TestRepo_Smoke(t *testing.T) {
 	assert.NoError(err)
 	assert.Len(users, 0)
 
+	users, err = r.UsersByKeys(ctx, app.BatchKeys{
+		IDs:       []uuid.UUID{user.ID, uuid.Must(uuid.NewV4())},
+		Usernames: []string{user3.Name, "unknown"},
+		Emails:    []string{user.Email},
+	})
+	assert.NoError(err)
+	assert.Len(users, 2)
+
+	// Usernames are matched by normalized form.
+	users, err = r.UsersByKeys(ctx, app.BatchKeys{Usernames: []string{strings.ToUpper(user3.Name)}})
+	assert.NoError(err)
+	assert.Len(users, 1)
+	assert.Equal(user3ID, users[0].ID)
+
+	privacy, err := r.SavePrivacySettings(ctx, app.PrivacySettings{UserID: user3ID, HideFromSearch: true})
+	assert.NoError(err)
+	assert.True(privacy.HideFromSearch)
+
+	_, err = r.SavePrivacySettings(ctx, app.PrivacySettings{UserID: uuid.Must(uuid.NewV4())})
+	assert.ErrorIs(err, app.ErrNotFound)
+
+	settings, err := r.ListPrivacySettings(ctx, []uuid.UUID{user.ID, user3ID})
+	assert.NoError(err)
+	assert.Equal([]app.PrivacySettings{*privacy}, settings)
+
+	byNormalized, err := r.ByUsername(ctx, strings.ToUpper(user.Name))
+	assert.NoError(err)
+	assert.Equal(user.ID, byNormalized.ID)
+
+	_, err = r.Save(ctx, app.User{Email: "confusable@gmail.com", Name: strings.ToUpper(user.Name), FullName: user.FullName, PassHash: user.PassHash, Status: user.Status})
+	assert.ErrorIs(err, app.ErrUsernameExist)
+
+	err = r.SaveUsernameChange(ctx, app.UsernameChange{UserID: user3ID, Username: "old_name"})
+	assert.NoError(err)
+
+	change, err := r.LastUsernameChange(ctx, "old_name", time.Now().Add(-time.Hour))
+	assert.NoError(err)
+	assert.Equal(user3ID, change.UserID)
+
+	_, err = r.LastUsernameChange(ctx, "old_name", time.Now().Add(time.Hour))
+	assert.ErrorIs(err, app.ErrNotFound)
+
+	avatarID := uuid.Must(uuid.NewV4())
+	err = r.SaveAvatar(ctx, app.AvatarInfo{FileID: avatarID, OwnerID: user3ID, Status: app.AvatarStatusClean})
+	assert.NoError(err)
+
+	err = r.SetAvatarStatus(ctx, avatarID, app.AvatarStatusMissing)
+	assert.NoError(err)
+
+	avatars, err := r.ListAvatars(ctx, uuid.Nil, 10)
+	assert.NoError(err)
+	assert.Len(avatars, 1)
+	assert.Equal(avatarID, avatars[0].FileID)
+	assert.Equal(app.AvatarStatusMissing, avatars[0].Status)
+
+	avatars, err = r.ListAvatars(ctx, avatarID, 10)
+	assert.NoError(err)
+	assert.Empty(avatars)
+
+	avatar2ID := uuid.Must(uuid.NewV4())
+	err = r.SaveAvatar(ctx, app.AvatarInfo{FileID: avatar2ID, OwnerID: user3ID, Status: app.AvatarStatusClean, Size: 9})
+	assert.NoError(err)
+
+	avatars, err = r.ListAvatarByUserID(ctx, user3ID)
+	assert.NoError(err)
+	assert.Len(avatars, 2)
+	assert.Equal(avatar2ID, avatars[0].FileID)
+	assert.Equal(-1, avatars[0].Position)
+	assert.Equal(avatarID, avatars[1].FileID)
+	assert.Equal(0, avatars[1].Position)
+
+	err = r.SetAvatarPosition(ctx, avatarID, 2)
+	assert.NoError(err)
+
+	avatars, err = r.ListAvatarByUserID(ctx, user3ID)
+	assert.NoError(err)
+	assert.Len(avatars, 2)
+	assert.Equal(avatar2ID, avatars[0].FileID)
+	assert.Equal(-1, avatars[0].Position)
+	assert.Equal(avatarID, avatars[1].FileID)
+	assert.Equal(2, avatars[1].Position)
+	assert.Equal(int64(9), avatars[0].Size)
+
+	// Only clean avatars are counted.
+	err = r.SaveAvatar(ctx, app.AvatarInfo{FileID: uuid.Must(uuid.NewV4()), OwnerID: user3ID, Status: app.AvatarStatusQuarantined})
+	assert.NoError(err)
+
+	count, err := r.GetCountAvatars(ctx, user3ID)
+	assert.NoError(err)
+	assert.Equal(1, count)
+
+	used, err := r.GetStorageUsage(ctx, user3ID)
+	assert.NoError(err)
+	assert.Zero(used)
+
+	used, err = r.AddStorageUsage(ctx, user3ID, 9)
+	assert.NoError(err)
+	assert.Equal(int64(9), used)
+
+	used, err = r.AddStorageUsage(ctx, user3ID, -10)
+	assert.NoError(err)
+	assert.Zero(used)
+
+	used, err = r.GetStorageUsage(ctx, user3ID)
+	assert.NoError(err)
+	assert.Zero(used)
+
+	upload := app.AvatarUpload{
+		ID:          uuid.Must(uuid.NewV4()),
+		OwnerID:     user3ID,
+		Name:        "avatar.png",
+		ContentType: "image/png",
+		Size:        1024,
+		ExpiresAt:   time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond),
+	}
+	err = r.SaveAvatarUpload(ctx, upload)
+	assert.NoError(err)
+
+	uploadRes, err := r.GetAvatarUpload(ctx, upload.ID)
+	assert.NoError(err)
+	upload.CreatedAt = uploadRes.CreatedAt
+	assert.Equal(upload, *uploadRes)
+
+	// Lease is taken by writer of current offset only, until it's released or over.
+	ok, err := r.LeaseAvatarUpload(ctx, upload.ID, 1, "writer1", time.Minute)
+	assert.NoError(err)
+	assert.False(ok)
+	ok, err = r.LeaseAvatarUpload(ctx, upload.ID, 0, "writer1", time.Millisecond)
+	assert.NoError(err)
+	assert.True(ok)
+	time.Sleep(time.Millisecond * 10)
+	ok, err = r.LeaseAvatarUpload(ctx, upload.ID, 0, "writer2", time.Minute)
+	assert.NoError(err)
+	assert.True(ok)
+	ok, err = r.LeaseAvatarUpload(ctx, upload.ID, 0, "writer3", time.Minute)
+	assert.NoError(err)
+	assert.False(ok)
+
+	err = r.SetAvatarUploadOffset(ctx, upload.ID, "writer1", 256)
+	assert.ErrorIs(err, app.ErrNotFound)
+	err = r.SetAvatarUploadOffset(ctx, upload.ID, "writer2", 512)
+	assert.NoError(err)
+	upload.Offset = 512
+
+	ok, err = r.LeaseAvatarUpload(ctx, upload.ID, upload.Offset, "writer3", time.Minute)
+	assert.NoError(err)
+	assert.True(ok)
+	err = r.SetAvatarUploadOffset(ctx, upload.ID, "writer3", upload.Offset)
+	assert.NoError(err)
+
+	upload.AvatarID = uuid.Must(uuid.NewV4())
+	err = r.CompleteAvatarUpload(ctx, upload.ID, upload.AvatarID)
+	assert.NoError(err)
+
+	expiredRes, err := r.ListExpiredAvatarUploads(ctx, upload.ExpiresAt.Add(time.Second), 10)
+	assert.NoError(err)
+	assert.Equal([]app.AvatarUpload{upload}, expiredRes)
+
+	expiredRes, err = r.ListExpiredAvatarUploads(ctx, upload.ExpiresAt, 10)
+	assert.NoError(err)
+	assert.Empty(expiredRes)
+
+	err = r.DeleteAvatarUpload(ctx, upload.ID)
+	assert.NoError(err)
+
+	_, err = r.GetAvatarUpload(ctx, upload.ID)
+	assert.ErrorIs(err, app.ErrNotFound)
+
 	listRes, total, err := r.SearchUsers(ctx, app.SearchParams{OwnerID: user3ID, Username: user.Name, FullName: user.FullName, Limit: 5})
 	assert.NoError(err)
 	assert.Equal(1, total)
@@ -116,7 +292,7 @@ func TestRepo_Smoke(t *testing.T) {
 	assert.NotEmpty(taskIDDel)
 	taskDel.ID = taskIDDel
 
-	tasks, err := r.ListActualTask(ctx, 5)
+	tasks, err := r.ClaimTasks(ctx, "worker1", time.Minute, 5)
 	assert.NoError(err)
 	assert.Len(tasks, 2)
 
@@ -125,52 +301,111 @@ func TestRepo_Smoke(t *testing.T) {
 		tasks[i].CreatedAt = time.Time{}
 		assert.NotEmpty(tasks[i].UpdatedAt)
 		tasks[i].UpdatedAt = time.Time{}
+		assert.NotEmpty(tasks[i].NextAttempt)
+		tasks[i].NextAttempt = time.Time{}
 		assert.Empty(tasks[i].FinishedAt)
 		assert.NotEmpty(tasks[i].User.CreatedAt)
 		tasks[i].User.CreatedAt = time.Time{}
 		assert.NotEmpty(tasks[i].User.UpdatedAt)
 		tasks[i].User.UpdatedAt = time.Time{}
 	}
-	expListActualTask := lo.Map([]app.Task{taskAdd, taskDel}, func(item app.Task, _ int) app.Task {
+	expClaimedTasks := lo.Map([]app.Task{taskAdd, taskDel}, func(item app.Task, _ int) app.Task {
 		item.User.PassHash = nil
 		item.User.CreatedAt = time.Time{}
 		item.User.UpdatedAt = time.Time{}
 		return item
 	})
-	sort.Slice(expListActualTask, func(i, j int) bool {
-		return expListActualTask[i].ID.String() > expListActualTask[j].ID.String()
+	sort.Slice(expClaimedTasks, func(i, j int) bool {
+		return expClaimedTasks[i].ID.String() > expClaimedTasks[j].ID.String()
 	})
 	sort.Slice(tasks, func(i, j int) bool {
 		return tasks[i].ID.String() > tasks[j].ID.String()
 	})
-	assert.Equal(expListActualTask, tasks)
+	assert.Equal(expClaimedTasks, tasks)
 
-	err = r.FinishTask(ctx, taskAdd.ID)
+	claimed, err := r.ClaimTasks(ctx, "worker2", time.Minute, 5)
 	assert.NoError(err)
+	assert.Empty(claimed)
 
-	tasks, err = r.ListActualTask(ctx, 5)
+	// Tasks leased by other worker aren't changed and stay leased.
+	err = r.FinishTasks(ctx, "worker2", []uuid.UUID{taskAdd.ID})
+	assert.NoError(err)
+	err = r.FailTask(ctx, "worker2", taskAdd.ID, "any error", 0)
+	assert.NoError(err)
+	err = r.KillTask(ctx, "worker2", taskDel.ID, "any error")
 	assert.NoError(err)
-	assert.Len(tasks, 1)
 
-	err = r.FinishTask(ctx, taskDel.ID)
+	backlog, err := r.GetTaskBacklog(ctx)
 	assert.NoError(err)
+	assert.Equal(2, backlog.Unfinished)
+	assert.Zero(backlog.Dead)
 
-	for i := range tasks {
-		assert.NotEmpty(tasks[i].CreatedAt)
-		tasks[i].CreatedAt = time.Time{}
-		assert.NotEmpty(tasks[i].UpdatedAt)
-		tasks[i].UpdatedAt = time.Time{}
-		assert.Empty(tasks[i].FinishedAt)
+	claimed, err = r.ClaimTasks(ctx, "worker2", time.Minute, 5)
+	assert.NoError(err)
+	assert.Empty(claimed)
 
-		assert.NotEmpty(tasks[i].User.CreatedAt)
-		tasks[i].User.CreatedAt = time.Time{}
-		assert.NotEmpty(tasks[i].User.UpdatedAt)
-		tasks[i].User.UpdatedAt = time.Time{}
-	}
+	err = r.FailTask(ctx, "worker1", taskAdd.ID, "any error", 0)
+	assert.NoError(err)
+
+	claimed, err = r.ClaimTasks(ctx, "worker1", time.Minute, 5)
+	assert.NoError(err)
+	assert.Len(claimed, 1)
+	assert.Equal(taskAdd.ID, claimed[0].ID)
+	assert.Equal(1, claimed[0].Attempts)
+	assert.Equal("any error", claimed[0].LastError)
+
+	err = r.FinishTasks(ctx, "worker1", []uuid.UUID{taskAdd.ID})
+	assert.NoError(err)
+	err = r.KillTask(ctx, "worker1", taskDel.ID, "any error")
+	assert.NoError(err)
+
+	deadTasks, total, err := r.ListDeadTasks(ctx, 5, 0)
+	assert.NoError(err)
+	assert.Equal(1, total)
+	assert.Len(deadTasks, 1)
+	assert.Equal(taskDel.ID, deadTasks[0].ID)
+	assert.Equal(1, deadTasks[0].Attempts)
+	assert.Equal("any error", deadTasks[0].LastError)
+	assert.NotEmpty(deadTasks[0].DeadAt)
+
+	err = r.RetryDeadTask(ctx, taskDel.ID)
+	assert.NoError(err)
+	err = r.RetryDeadTask(ctx, taskDel.ID)
+	assert.ErrorIs(err, app.ErrNotFound)
+	err = r.DiscardDeadTask(ctx, taskDel.ID)
+	assert.ErrorIs(err, app.ErrNotFound)
+
+	claimed, err = r.ClaimTasks(ctx, "worker1", time.Minute, 5)
+	assert.NoError(err)
+	assert.Len(claimed, 1)
+	assert.Equal(taskDel.ID, claimed[0].ID)
+	assert.Zero(claimed[0].Attempts)
 
-	taskDel.User.CreatedAt = time.Time{}
-	taskDel.User.UpdatedAt = time.Time{}
-	assert.Equal([]app.Task{taskDel}, tasks)
+	err = r.FinishTasks(ctx, "worker1", []uuid.UUID{taskDel.ID})
+	assert.NoError(err)
+
+	backlog, err = r.GetTaskBacklog(ctx)
+	assert.NoError(err)
+	assert.Equal(&app.TaskBacklog{}, backlog)
+
+	count, err = r.ArchiveFinishedTasks(ctx, time.Now().Add(time.Hour), 1)
+	assert.NoError(err)
+	assert.Equal(1, count)
+	count, err = r.DeleteFinishedTasks(ctx, time.Now().Add(time.Hour), 5)
+	assert.NoError(err)
+	assert.Equal(1, count)
+
+	// Failed task isn't claimed until the next attempt.
+	taskAdd.ID, err = r.SaveTask(ctx, taskAdd)
+	assert.NoError(err)
+	claimed, err = r.ClaimTasks(ctx, "worker1", time.Minute, 5)
+	assert.NoError(err)
+	assert.Len(claimed, 1)
+	err = r.FailTask(ctx, "worker1", taskAdd.ID, "any error", time.Hour)
+	assert.NoError(err)
+	claimed, err = r.ClaimTasks(ctx, "worker1", time.Minute, 5)
+	assert.NoError(err)
+	assert.Empty(claimed)
 
 	err = r.Tx(ctx, func(r app.Repo) error {
 		return nil
@@ -274,7 +509,7 @@ func TestRepo_Tx(t *testing.T) {
 		assert.NotEmpty(taskIDDel)
 		taskDel.ID = taskIDDel
 
-		tasks, err := r.ListActualTask(ctx, 5)
+		tasks, err := r.ClaimTasks(ctx, "worker", time.Minute, 5)
 		assert.NoError(err)
 		assert.Len(tasks, 2)
 
@@ -283,52 +518,34 @@ func TestRepo_Tx(t *testing.T) {
 			tasks[i].CreatedAt = time.Time{}
 			assert.NotEmpty(tasks[i].UpdatedAt)
 			tasks[i].UpdatedAt = time.Time{}
+			assert.NotEmpty(tasks[i].NextAttempt)
+			tasks[i].NextAttempt = time.Time{}
 			assert.Empty(tasks[i].FinishedAt)
 			assert.NotEmpty(tasks[i].User.CreatedAt)
 			tasks[i].User.CreatedAt = time.Time{}
 			assert.NotEmpty(tasks[i].User.UpdatedAt)
 			tasks[i].User.UpdatedAt = time.Time{}
 		}
-		expListActualTask := lo.Map([]app.Task{taskAdd, taskDel}, func(item app.Task, _ int) app.Task {
+		expClaimedTasks := lo.Map([]app.Task{taskAdd, taskDel}, func(item app.Task, _ int) app.Task {
 			item.User.PassHash = nil
 			item.User.CreatedAt = time.Time{}
 			item.User.UpdatedAt = time.Time{}
 			return item
 		})
-		sort.Slice(expListActualTask, func(i, j int) bool {
-			return expListActualTask[i].ID.String() > expListActualTask[j].ID.String()
+		sort.Slice(expClaimedTasks, func(i, j int) bool {
+			return expClaimedTasks[i].ID.String() > expClaimedTasks[j].ID.String()
 		})
 		sort.Slice(tasks, func(i, j int) bool {
 			return tasks[i].ID.String() > tasks[j].ID.String()
 		})
-		assert.Equal(expListActualTask, tasks)
+		assert.Equal(expClaimedTasks, tasks)
 
-		err = r.FinishTask(ctx, taskAdd.ID)
+		err = r.FinishTasks(ctx, "worker", []uuid.UUID{taskAdd.ID, taskDel.ID})
 		assert.NoError(err)
 
-		tasks, err = r.ListActualTask(ctx, 5)
+		backlog, err := r.GetTaskBacklog(ctx)
 		assert.NoError(err)
-		assert.Len(tasks, 1)
-
-		err = r.FinishTask(ctx, taskDel.ID)
-		assert.NoError(err)
-
-		for i := range tasks {
-			assert.NotEmpty(tasks[i].CreatedAt)
-			tasks[i].CreatedAt = time.Time{}
-			assert.NotEmpty(tasks[i].UpdatedAt)
-			tasks[i].UpdatedAt = time.Time{}
-			assert.Empty(tasks[i].FinishedAt)
-
-			assert.NotEmpty(tasks[i].User.CreatedAt)
-			tasks[i].User.CreatedAt = time.Time{}
-			assert.NotEmpty(tasks[i].User.UpdatedAt)
-			tasks[i].User.UpdatedAt = time.Time{}
-		}
-
-		taskDel.User.CreatedAt = time.Time{}
-		taskDel.User.UpdatedAt = time.Time{}
-		assert.Equal([]app.Task{taskDel}, tasks)
+		assert.Equal(&app.TaskBacklog{}, backlog)
 
 		return nil
 	})
@@ -339,3 +556,93 @@ func TestRepo_Tx(t *testing.T) {
 	})
 	assert.ErrorIs(err, app.ErrNotFound)
 }
+
+func TestRepo_LeaseJob(t *testing.T) {
+	t.Parallel()
+
+	ctx, r, assert := start(t)
+
+	const job = "job"
+
+	ok, err := r.LeaseJob(ctx, job, "worker1", time.Second)
+	assert.NoError(err)
+	assert.True(ok)
+
+	// Lease is extended by the same worker only.
+	ok, err = r.LeaseJob(ctx, job, "worker1", time.Second)
+	assert.NoError(err)
+	assert.True(ok)
+	ok, err = r.LeaseJob(ctx, job, "worker2", time.Second)
+	assert.NoError(err)
+	assert.False(ok)
+
+	// Other jobs are leased separately.
+	ok, err = r.LeaseJob(ctx, "other_job", "worker2", time.Second)
+	assert.NoError(err)
+	assert.True(ok)
+
+	assert.Eventually(func() bool {
+		ok, err := r.LeaseJob(ctx, job, "worker2", time.Minute)
+		assert.NoError(err)
+
+		return ok
+	}, time.Second*5, time.Millisecond*100)
+
+	ok, err = r.LeaseJob(ctx, job, "worker1", time.Minute)
+	assert.NoError(err)
+	assert.False(ok)
+}
+
+func TestRepo_SearchUsersFuzzy(t *testing.T) {
+	t.Parallel()
+
+	ctx, r, assert := start(t)
+
+	for _, u := range []app.User{
+		{Email: "alexander@mail.com", Name: "alexander", FullName: "Alexander Pushkin"},
+		{Email: "aleksandr@mail.com", Name: "aleksandr", FullName: "Aleksandr Blok"},
+		{Email: "boris@mail.com", Name: "boris", FullName: "Boris Pasternak"},
+	} {
+		u.Status = dom.UserStatusDefault
+		u.PassHash = []byte("pass")
+		_, err := r.Save(ctx, u)
+		assert.NoError(err)
+	}
+
+	// Users similar to any of given fields are returned from the most similar one.
+	testCases := map[string]struct {
+		params    app.SearchParams
+		want      []string
+		wantTotal int
+	}{
+		"username_typo":      {app.SearchParams{Username: "alexandr", Limit: 5}, []string{"alexander", "aleksandr"}, 2},
+		"full_name_word":     {app.SearchParams{FullName: "Pushkin", Limit: 5}, []string{"alexander"}, 1},
+		"email":              {app.SearchParams{Email: "aleksandr@mail.com", Limit: 5}, []string{"aleksandr", "alexander", "boris"}, 3},
+		"username_full_name": {app.SearchParams{Username: "boris", FullName: "Aleksandr", Limit: 5}, []string{"boris", "aleksandr"}, 2},
+		"not_similar":        {app.SearchParams{Username: "zzz", Limit: 5}, []string{}, 0},
+		"limit":              {app.SearchParams{Email: "aleksandr@mail.com", Limit: 1}, []string{"aleksandr"}, 3},
+		"offset":             {app.SearchParams{Email: "aleksandr@mail.com", Limit: 5, Offset: 1}, []string{"alexander", "boris"}, 3},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			params := tc.params
+			params.Mode = app.SearchModeFuzzy
+
+			res, total, err := r.SearchUsers(ctx, params)
+			assert.NoError(err)
+			assert.Equal(tc.wantTotal, total)
+			assert.Equal(tc.want, lo.Map(res, func(u app.User, _ int) string { return u.Name }))
+		})
+	}
+
+	// Substring search doesn't tolerate typos.
+	res, total, err := r.SearchUsers(ctx, app.SearchParams{Username: "alexandr", Mode: app.SearchModeSubstring, Limit: 5})
+	assert.NoError(err)
+	assert.Zero(total)
+	assert.Empty(res)
+}
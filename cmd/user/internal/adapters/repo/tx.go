@@ -2,7 +2,9 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
@@ -14,7 +16,8 @@ import (
 var _ app.Repo = &txRepo{}
 
 type txRepo struct {
-	tx *sqlx.Tx
+	tx     *sqlx.Tx
+	outbox string
 }
 
 // Save for implements app.Repo.
@@ -23,13 +26,14 @@ func (t *txRepo) Save(ctx context.Context, u app.User) (id uuid.UUID, err error)
 	const query = `
 		insert into 
 		users 
-		    (email, name, full_name, pass_hash, status) 
+		    (email, name, name_normalized, full_name, pass_hash, status) 
 		values 
-			($1, $2, $3, $4, $5)
+			($1, $2, $3, $4, $5, $6)
 		returning id
 		`
 
-	err = t.tx.GetContext(ctx, &id, query, newUser.Email, newUser.Name, newUser.FullName, newUser.PassHash, newUser.Status)
+	err = t.tx.GetContext(ctx, &id, query, newUser.Email, newUser.Name, newUser.NameNormalized, newUser.FullName, newUser.PassHash,
+		newUser.Status)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("db.GetContext: %w", convertErr(err))
 	}
@@ -49,13 +53,20 @@ func (t *txRepo) Update(ctx context.Context, u app.User) (upUser *app.User, err
 			pass_hash   	  = $4,
 			current_avatar_id = $5,
 			status 			  = $6,
+			bio               = $7,
+			locale            = $8,
+			timezone          = $9,
+			birthday          = $10,
+			links             = $11,
+			name_normalized   = $12,
 			updated_at = now()
-		where id = $7
+		where id = $13
 		returning *`
 
 	var res user
 	err = t.tx.GetContext(ctx, &res, query, updateUser.Email, updateUser.Name, updateUser.FullName, updateUser.PassHash,
-		updateUser.CurrentAvatarID, updateUser.Status, updateUser.ID)
+		updateUser.CurrentAvatarID, updateUser.Status, updateUser.Bio, updateUser.Locale, updateUser.Timezone, updateUser.Birthday,
+		updateUser.Links, updateUser.NameNormalized, updateUser.ID)
 	if err != nil {
 		return nil, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
 	}
@@ -105,10 +116,10 @@ func (t *txRepo) ByEmail(ctx context.Context, email string) (u *app.User, err er
 
 // ByUsername for implements app.Repo.
 func (t *txRepo) ByUsername(ctx context.Context, username string) (u *app.User, err error) {
-	const query = `select * from users where name = $1`
+	const query = `select * from users where name = $1 or name_normalized = $2 order by name = $1 desc limit 1`
 
 	res := user{}
-	err = t.tx.GetContext(ctx, &res, query, username)
+	err = t.tx.GetContext(ctx, &res, query, username, app.NormalizeUsername(username))
 	if err != nil {
 		return nil, fmt.Errorf("db.GetContext: %w", convertErr(err))
 	}
@@ -150,13 +161,27 @@ func (t *txRepo) SearchUsers(ctx context.Context, params app.SearchParams) (user
 }
 
 // SaveAvatar for implements app.Repo.
+// New avatar goes first in gallery as avatars ordered before positions were added.
+// Owner is locked, so concurrent avatars of the same user get different positions.
 func (t *txRepo) SaveAvatar(ctx context.Context, userFile app.AvatarInfo) (err error) {
 	avatarCache := convertUserFile(userFile)
-	const query = `insert into avatars (owner_id, id) values ($1, $2)`
+	const lock = `select id from users where id = $1 for update`
 
-	_, err = t.tx.ExecContext(ctx, query, avatarCache.OwnerID, avatarCache.ID)
+	var ownerID uuid.UUID
+	err = t.tx.GetContext(ctx, &ownerID, lock, avatarCache.OwnerID)
 	if err != nil {
-		return fmt.Errorf("db.GetContext: %w", convertErr(err))
+		return fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	const query = `
+	insert into avatars
+		(owner_id, id, status, size, position)
+	values
+		($1, $2, $3, $4, (select coalesce(min(position) - 1, 0) from avatars where owner_id = $1))`
+
+	_, err = t.tx.ExecContext(ctx, query, avatarCache.OwnerID, avatarCache.ID, avatarCache.Status, avatarCache.Size)
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
 	}
 
 	return nil
@@ -191,7 +216,7 @@ func (t *txRepo) GetAvatar(ctx context.Context, avatarID uuid.UUID) (f *app.Avat
 
 // ListAvatarByUserID for implements app.Repo.
 func (t *txRepo) ListAvatarByUserID(ctx context.Context, userID uuid.UUID) (userAvatars []app.AvatarInfo, err error) {
-	const query = `select * from avatars where owner_id = $1 order by created_at desc`
+	const query = `select * from avatars where owner_id = $1 order by position asc, created_at desc`
 
 	var res []avatar
 	err = t.tx.SelectContext(ctx, &res, query, userID)
@@ -209,9 +234,9 @@ func (t *txRepo) ListAvatarByUserID(ctx context.Context, userID uuid.UUID) (user
 
 // GetCountAvatars for implements app.Repo.
 func (t *txRepo) GetCountAvatars(ctx context.Context, ownerID uuid.UUID) (total int, err error) {
-	const getTotal = `select count(*) over() as total from avatars where owner_id = $1`
+	const getTotal = `select count(*) over() as total from avatars where owner_id = $1 and status = $2`
 
-	err = t.tx.GetContext(ctx, &total, getTotal, ownerID)
+	err = t.tx.GetContext(ctx, &total, getTotal, ownerID, app.AvatarStatusClean.String())
 	if err != nil {
 		return 0, fmt.Errorf("db.GetContext: %w", convertErr(err))
 	}
@@ -231,6 +256,70 @@ func (t *txRepo) UpdateCurrentAvatar(ctx context.Context, userID, fileID uuid.UU
 	return nil
 }
 
+// ListAvatars implements app.Repo.
+func (t *txRepo) ListAvatars(ctx context.Context, after uuid.UUID, limit int) ([]app.AvatarInfo, error) {
+	const query = `select * from avatars where id > $1 order by id asc limit $2 for update`
+
+	res := make([]avatar, 0, limit)
+	err := t.tx.SelectContext(ctx, &res, query, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	avatars := make([]app.AvatarInfo, len(res))
+	for i := range res {
+		avatars[i] = *res[i].convert()
+	}
+
+	return avatars, nil
+}
+
+// SetAvatarStatus implements app.Repo.
+func (t *txRepo) SetAvatarStatus(ctx context.Context, fileID uuid.UUID, status app.AvatarStatus) error {
+	const query = `update avatars set status = $2, updated_at = now() where id = $1`
+
+	_, err := t.tx.ExecContext(ctx, query, fileID, status.String())
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// SetAvatarPosition implements app.Repo.
+func (t *txRepo) SetAvatarPosition(ctx context.Context, fileID uuid.UUID, position int) error {
+	const query = `update avatars set position = $2, updated_at = now() where id = $1`
+
+	_, err := t.tx.ExecContext(ctx, query, fileID, position)
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// GetStorageUsage implements app.Repo.
+func (t *txRepo) GetStorageUsage(ctx context.Context, userID uuid.UUID) (usedBytes int64, err error) {
+	const query = `select coalesce(max(used_bytes), 0) from storage_usage where user_id = $1`
+
+	err = t.tx.GetContext(ctx, &usedBytes, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return usedBytes, nil
+}
+
+// AddStorageUsage implements app.Repo.
+func (t *txRepo) AddStorageUsage(ctx context.Context, userID uuid.UUID, delta int64) (usedBytes int64, err error) {
+	err = t.tx.GetContext(ctx, &usedBytes, addStorageUsageQuery, userID, delta)
+	if err != nil {
+		return 0, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return usedBytes, nil
+}
+
 // SaveTask implements app.Repo.
 func (t *txRepo) SaveTask(ctx context.Context, task app.Task) (id uuid.UUID, err error) {
 	newTask, err := convertTask(task)
@@ -241,29 +330,37 @@ func (t *txRepo) SaveTask(ctx context.Context, task app.Task) (id uuid.UUID, err
 	const query = `
 		insert into 
 		tasks 
-		    (user_bytes, kind) 
+		    (user_bytes, kind, preferences_bytes, user_id, activity_bytes) 
 		values
-			($1, $2)
+			($1, $2, $3, $4, $5)
 		returning id
 		`
 
-	err = t.tx.GetContext(ctx, &id, query, newTask.UserBytes, newTask.Kind)
+	err = t.tx.GetContext(ctx, &id, query, newTask.UserBytes, newTask.Kind, newTask.PreferencesBytes, newTask.UserID, newTask.ActivityBytes)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
 	}
 
+	// Notification is delivered after commit only.
+	if t.outbox == OutboxNotify {
+		_, err = t.tx.ExecContext(ctx, notifyTasksQuery)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+		}
+	}
+
 	return id, nil
 }
 
-// FinishTask implements app.Repo.
-func (t *txRepo) FinishTask(ctx context.Context, id uuid.UUID) error {
+// FinishTasks implements app.Repo.
+func (t *txRepo) FinishTasks(ctx context.Context, workerID string, ids []uuid.UUID) error {
 	const query = `
 		update tasks set 
 		updated_at = now(),
 		finished_at = now()
-		where id = $1`
+		where id = any($1) and locked_by = $2`
 
-	_, err := t.tx.ExecContext(ctx, query, id)
+	_, err := t.tx.ExecContext(ctx, query, pq.Array(ids), workerID)
 	if err != nil {
 		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
 	}
@@ -271,27 +368,181 @@ func (t *txRepo) FinishTask(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// ListActualTask implements app.Repo.
-func (t *txRepo) ListActualTask(ctx context.Context, limit int) ([]app.Task, error) {
-	const query = `select * from tasks where finished_at is null order by created_at asc limit $1 for update`
-
+// ClaimTasks implements app.Repo.
+func (t *txRepo) ClaimTasks(ctx context.Context, workerID string, lease time.Duration, limit int) ([]app.Task, error) {
 	res := make([]task, 0, limit)
-	err := t.tx.SelectContext(ctx, &res, query, limit)
+	err := t.tx.SelectContext(ctx, &res, claimTasksQuery, workerID, lease.Microseconds(), limit)
 	if err != nil {
 		return nil, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
 	}
 
+	tasks, err := convertTasks(res)
+	if err != nil {
+		return nil, fmt.Errorf("convertTasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// ListenTasks implements app.Repo.
+// Listening holds connection until ctx is canceled, so it isn't available inside transaction.
+func (*txRepo) ListenTasks(context.Context, chan<- struct{}) error {
+	return fmt.Errorf("listen tasks in transaction: %w", errors.ErrUnsupported)
+}
+
+// LeaseJob implements app.Repo.
+func (t *txRepo) LeaseJob(ctx context.Context, job, workerID string, lease time.Duration) (bool, error) {
+	var jobs []string
+	err := t.tx.SelectContext(ctx, &jobs, leaseJobQuery, job, workerID, lease.Microseconds())
+	if err != nil {
+		return false, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	return len(jobs) > 0, nil
+}
+
+// GetTaskBacklog implements app.Repo.
+func (t *txRepo) GetTaskBacklog(ctx context.Context) (*app.TaskBacklog, error) {
+	var res taskBacklog
+	err := t.tx.GetContext(ctx, &res, taskBacklogQuery)
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return res.convert(), nil
+}
+
+// DeleteFinishedTasks implements app.Repo.
+func (t *txRepo) DeleteFinishedTasks(ctx context.Context, before time.Time, limit int) (int, error) {
+	var ids []uuid.UUID
+	err := t.tx.SelectContext(ctx, &ids, deleteFinishedTasksQuery, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	return len(ids), nil
+}
+
+// ArchiveFinishedTasks implements app.Repo.
+func (t *txRepo) ArchiveFinishedTasks(ctx context.Context, before time.Time, limit int) (int, error) {
+	var ids []uuid.UUID
+	err := t.tx.SelectContext(ctx, &ids, archiveFinishedTasksQuery, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	return len(ids), nil
+}
+
+// FailTask implements app.Repo.
+func (t *txRepo) FailTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string, delay time.Duration) error {
+	const query = `
+		update tasks set
+		attempts = attempts + 1,
+		last_error = $2,
+		next_attempt_at = now() + $3 * interval '1 microsecond',
+		locked_by = null,
+		locked_until = null,
+		updated_at = now()
+		where id = $1 and locked_by = $4`
+
+	_, err := t.tx.ExecContext(ctx, query, id, lastErr, delay.Microseconds(), workerID)
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// KillTask implements app.Repo.
+func (t *txRepo) KillTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string) error {
+	const query = `
+		update tasks set
+		attempts = attempts + 1,
+		last_error = $2,
+		dead_at = now(),
+		locked_by = null,
+		locked_until = null,
+		updated_at = now()
+		where id = $1 and locked_by = $3`
+
+	_, err := t.tx.ExecContext(ctx, query, id, lastErr, workerID)
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// ListDeadTasks implements app.Repo.
+func (t *txRepo) ListDeadTasks(ctx context.Context, limit, offset int) ([]app.Task, int, error) {
+	const query = `
+		select * from tasks 
+		where finished_at is null and dead_at is not null 
+		order by dead_at desc, id asc 
+		limit $1 offset $2`
+
+	res := make([]task, 0, limit)
+	err := t.tx.SelectContext(ctx, &res, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	const totalQuery = `select count(*) from tasks where finished_at is null and dead_at is not null`
+
+	total := 0
+	err = t.tx.GetContext(ctx, &total, totalQuery)
+	if err != nil {
+		return nil, 0, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
 	tasks := make([]app.Task, len(res))
 	for i := range res {
 		t, err := res[i].convert()
 		if err != nil {
-			return nil, fmt.Errorf("convert: %w", err)
+			return nil, 0, fmt.Errorf("convert: %w", err)
 		}
 
 		tasks[i] = *t
 	}
 
-	return tasks, nil
+	return tasks, total, nil
+}
+
+// RetryDeadTask implements app.Repo.
+func (t *txRepo) RetryDeadTask(ctx context.Context, id uuid.UUID) error {
+	const query = `
+		update tasks set
+		attempts = 0,
+		next_attempt_at = now(),
+		dead_at = null,
+		updated_at = now()
+		where id = $1 and finished_at is null and dead_at is not null
+		returning id`
+
+	var res uuid.UUID
+	err := t.tx.GetContext(ctx, &res, query, id)
+	if err != nil {
+		return fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// DiscardDeadTask implements app.Repo.
+func (t *txRepo) DiscardDeadTask(ctx context.Context, id uuid.UUID) error {
+	const query = `
+		delete from tasks
+		where id = $1 and finished_at is null and dead_at is not null
+		returning id`
+
+	var res uuid.UUID
+	err := t.tx.GetContext(ctx, &res, query, id)
+	if err != nil {
+		return fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return nil
 }
 
 // SaveStatusUpdateRequest implements app.Repo.
@@ -385,6 +636,266 @@ func (t *txRepo) UsersByIDs(ctx context.Context, ids []uuid.UUID) (users []app.U
 	return users, nil
 }
 
+// UsersByKeys implements app.Repo.
+func (t *txRepo) UsersByKeys(ctx context.Context, keys app.BatchKeys) (users []app.User, err error) {
+	res := make([]user, 0, keys.Len())
+
+	err = t.tx.SelectContext(ctx, &res, usersByKeysQuery, pq.Array(keys.IDs), pq.Array(normalizeUsernames(keys.Usernames)), pq.Array(keys.Emails))
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	users = make([]app.User, len(res))
+	for i := range res {
+		users[i] = *res[i].convert()
+	}
+
+	return users, nil
+}
+
+// SavePrivacySettings implements app.Repo.
+func (t *txRepo) SavePrivacySettings(ctx context.Context, s app.PrivacySettings) (*app.PrivacySettings, error) {
+	settings := convertPrivacySettings(s)
+	const query = `
+		insert into privacy_settings
+			(user_id, hide_email, hide_status, hide_avatar, hide_from_search)
+		values
+			($1, $2, $3, $4, $5)
+		on conflict (user_id) do update
+		set
+			hide_email       = excluded.hide_email,
+			hide_status      = excluded.hide_status,
+			hide_avatar      = excluded.hide_avatar,
+			hide_from_search = excluded.hide_from_search,
+			updated_at       = now()
+		returning *`
+
+	var res privacySettings
+	err := t.tx.GetContext(ctx, &res, query, settings.UserID, settings.HideEmail, settings.HideStatus,
+		settings.HideAvatar, settings.HideFromSearch)
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return res.convert(), nil
+}
+
+// ListPrivacySettings implements app.Repo.
+func (t *txRepo) ListPrivacySettings(ctx context.Context, userIDs []uuid.UUID) ([]app.PrivacySettings, error) {
+	const query = `select * from privacy_settings where user_id = any($1) for update`
+
+	res := make([]privacySettings, 0, len(userIDs))
+
+	err := t.tx.SelectContext(ctx, &res, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	settings := make([]app.PrivacySettings, len(res))
+	for i := range res {
+		settings[i] = *res[i].convert()
+	}
+
+	return settings, nil
+}
+
+// SavePreferences implements app.Repo.
+func (t *txRepo) SavePreferences(ctx context.Context, prefs []app.Preference) error {
+	userIDs, keys, kinds, values := preferencesColumns(prefs)
+	const query = `
+		insert into preferences
+			(user_id, key, kind, value)
+		select * from unnest($1::uuid[], $2::text[], $3::text[], $4::text[])
+		on conflict (user_id, key) do update
+		set
+			kind       = excluded.kind,
+			value      = excluded.value,
+			updated_at = now()`
+
+	_, err := t.tx.ExecContext(ctx, query, pq.Array(userIDs), pq.Array(keys), pq.Array(kinds), pq.Array(values))
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// ListPreferences implements app.Repo.
+func (t *txRepo) ListPreferences(ctx context.Context, userID uuid.UUID) ([]app.Preference, error) {
+	const query = `select * from preferences where user_id = $1 order by key for update`
+
+	var res []preference
+	err := t.tx.SelectContext(ctx, &res, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	prefs := make([]app.Preference, len(res))
+	for i := range res {
+		prefs[i] = *res[i].convert()
+	}
+
+	return prefs, nil
+}
+
+// SaveUsernameChange implements app.Repo.
+func (t *txRepo) SaveUsernameChange(ctx context.Context, change app.UsernameChange) error {
+	const query = `insert into username_history (user_id, username, username_normalized) values ($1, $2, $3)`
+
+	_, err := t.tx.ExecContext(ctx, query, change.UserID, change.Username, app.NormalizeUsername(change.Username))
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// LastUsernameChange implements app.Repo.
+func (t *txRepo) LastUsernameChange(ctx context.Context, username string, since time.Time) (*app.UsernameChange, error) {
+	const query = `
+	select * from username_history
+	where username_normalized = $1 and created_at > $2
+	order by created_at desc
+	limit 1
+	for update`
+
+	res := usernameChange{}
+	err := t.tx.GetContext(ctx, &res, query, app.NormalizeUsername(username), since)
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return res.convert(), nil
+}
+
+// ListUnnormalizedUsers implements app.Repo.
+func (t *txRepo) ListUnnormalizedUsers(ctx context.Context, limit int) ([]app.User, error) {
+	res := make([]user, 0, limit)
+
+	err := t.tx.SelectContext(ctx, &res, unnormalizedUsersQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	users := make([]app.User, len(res))
+	for i := range res {
+		users[i] = *res[i].convert()
+	}
+
+	return users, nil
+}
+
+// NormalizeUsernameHistory implements app.Repo.
+func (t *txRepo) NormalizeUsernameHistory(ctx context.Context) error {
+	var usernames []string
+	err := t.tx.SelectContext(ctx, &usernames, unnormalizedHistoryQuery)
+	if err != nil {
+		return fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	for _, username := range usernames {
+		_, err = t.tx.ExecContext(ctx, normalizeHistoryQuery, app.NormalizeUsername(username), username)
+		if err != nil {
+			return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+		}
+	}
+
+	return nil
+}
+
+// SaveAvatarUpload implements app.Repo.
+func (t *txRepo) SaveAvatarUpload(ctx context.Context, upload app.AvatarUpload) error {
+	const query = `
+	insert into avatar_uploads
+		(id, owner_id, name, content_type, size, multipart_id, expires_at)
+	values
+		($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := t.tx.ExecContext(ctx, query,
+		upload.ID, upload.OwnerID, upload.Name, upload.ContentType, upload.Size, upload.MultipartID, upload.ExpiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// GetAvatarUpload implements app.Repo.
+func (t *txRepo) GetAvatarUpload(ctx context.Context, id uuid.UUID) (*app.AvatarUpload, error) {
+	const query = `select * from avatar_uploads where id = $1 for update`
+
+	res := avatarUpload{}
+	err := t.tx.GetContext(ctx, &res, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return res.convert(), nil
+}
+
+// DeleteAvatarUpload implements app.Repo.
+func (t *txRepo) DeleteAvatarUpload(ctx context.Context, id uuid.UUID) error {
+	const query = `delete from avatar_uploads where id = $1`
+
+	_, err := t.tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// LeaseAvatarUpload implements app.Repo.
+func (t *txRepo) LeaseAvatarUpload(ctx context.Context, id uuid.UUID, offset int64, writerID string, lease time.Duration) (bool, error) {
+	var ids []uuid.UUID
+	err := t.tx.SelectContext(ctx, &ids, leaseAvatarUploadQuery, id, offset, writerID, lease.Microseconds())
+	if err != nil {
+		return false, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	return len(ids) > 0, nil
+}
+
+// SetAvatarUploadOffset implements app.Repo.
+func (t *txRepo) SetAvatarUploadOffset(ctx context.Context, id uuid.UUID, writerID string, offset int64) error {
+	err := t.tx.GetContext(ctx, &uuid.UUID{}, setAvatarUploadOffsetQuery, id, writerID, offset)
+	if err != nil {
+		return fmt.Errorf("t.tx.GetContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// CompleteAvatarUpload implements app.Repo.
+func (t *txRepo) CompleteAvatarUpload(ctx context.Context, id, avatarID uuid.UUID) error {
+	const query = `update avatar_uploads set avatar_id = $2 where id = $1`
+
+	_, err := t.tx.ExecContext(ctx, query, id, avatarID)
+	if err != nil {
+		return fmt.Errorf("t.tx.ExecContext: %w", convertErr(err))
+	}
+
+	return nil
+}
+
+// ListExpiredAvatarUploads implements app.Repo.
+func (t *txRepo) ListExpiredAvatarUploads(ctx context.Context, before time.Time, limit int) ([]app.AvatarUpload, error) {
+	const query = `select * from avatar_uploads where expires_at < $1 order by expires_at asc limit $2 for update`
+
+	res := make([]avatarUpload, 0, limit)
+	err := t.tx.SelectContext(ctx, &res, query, before.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("t.tx.SelectContext: %w", convertErr(err))
+	}
+
+	uploads := make([]app.AvatarUpload, len(res))
+	for i := range res {
+		uploads[i] = *res[i].convert()
+	}
+
+	return uploads, nil
+}
+
 // Tx implements app.Repo.
 func (*txRepo) Tx(_ context.Context, _ func(app.Repo) error) error {
 	panic("you can't start new transaction in current transaction")
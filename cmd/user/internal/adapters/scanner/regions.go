@@ -0,0 +1,268 @@
+package scanner
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+)
+
+// maxInflatedText limits decompressed size of compressed text chunk of PNG.
+const maxInflatedText = 1 << 20
+
+var (
+	pngSignature  = []byte("\x89PNG\r\n\x1a\n")
+	jpegSignature = []byte{0xff, 0xd8}
+	gif87a        = []byte("GIF87a")
+	gif89a        = []byte("GIF89a")
+	riffSignature = []byte("RIFF")
+	webpSignature = []byte("WEBP")
+)
+
+// regions returns parts of file which may contain active content: metadata, decoded text
+// and data after the end of image. Compressed pixels aren't returned, because random bytes
+// match signatures by chance. Whole file is returned if format isn't known or file is malformed.
+func regions(data []byte) [][]byte {
+	var (
+		res [][]byte
+		ok  bool
+	)
+	switch {
+	case bytes.HasPrefix(data, pngSignature):
+		res, ok = pngRegions(data[len(pngSignature):])
+	case bytes.HasPrefix(data, jpegSignature):
+		res, ok = jpegRegions(data[len(jpegSignature):])
+	case bytes.HasPrefix(data, gif87a), bytes.HasPrefix(data, gif89a):
+		res, ok = gifRegions(data[len(gif89a):])
+	case len(data) >= 12 && bytes.Equal(data[:4], riffSignature) && bytes.Equal(data[8:12], webpSignature):
+		res, ok = webpRegions(data)
+	}
+	if !ok {
+		return [][]byte{data}
+	}
+
+	return res
+}
+
+// pngRegions returns chunks except image data, compressed text is inflated.
+func pngRegions(data []byte) (res [][]byte, ok bool) {
+	for len(data) >= 12 {
+		size := int(binary.BigEndian.Uint32(data))
+		if size < 0 || size > len(data)-12 {
+			return nil, false
+		}
+
+		typ, chunk := string(data[4:8]), data[8:8+size]
+		data = data[12+size:] // Chunk ends with checksum.
+
+		switch typ {
+		case "IDAT":
+		case "IEND":
+			return append(res, data), true
+		case "zTXt": // Keyword, compression method and compressed text.
+			res = append(res, chunk)
+			_, text, _ := bytes.Cut(chunk, []byte{0})
+			if len(text) > 0 {
+				res = append(res, inflate(text[1:]))
+			}
+		case "iTXt": // Keyword, compression flag and method, language, translated keyword and text.
+			res = append(res, chunk)
+			_, text, _ := bytes.Cut(chunk, []byte{0})
+			if len(text) > 2 && text[0] == 1 {
+				_, text, _ = bytes.Cut(text[2:], []byte{0})
+				_, text, _ = bytes.Cut(text, []byte{0})
+				res = append(res, inflate(text))
+			}
+		default:
+			res = append(res, chunk)
+		}
+	}
+
+	return nil, false
+}
+
+// inflate decompresses zlib data, text decompressed before error is returned in case of invalid data.
+func inflate(data []byte) []byte {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+
+	text, _ := io.ReadAll(io.LimitReader(r, maxInflatedText))
+
+	return text
+}
+
+// jpegRegions returns application and comment segments, entropy-coded data is skipped.
+func jpegRegions(data []byte) (res [][]byte, ok bool) {
+	for len(data) >= 2 && data[0] == 0xff {
+		if data[1] == 0xff { // Fill bytes before marker.
+			data = data[1:]
+
+			continue
+		}
+
+		marker := data[1]
+		data = data[2:]
+
+		switch {
+		case marker == 0xd9: // End of image.
+			return append(res, data), true
+		case marker == 0x01 || marker >= 0xd0 && marker <= 0xd8: // Markers without data.
+			continue
+		}
+
+		if len(data) < 2 {
+			return nil, false
+		}
+		size := int(binary.BigEndian.Uint16(data))
+		if size < 2 || size > len(data) {
+			return nil, false
+		}
+		segment := data[2:size]
+		data = data[size:]
+
+		switch {
+		case marker >= 0xe0 && marker <= 0xef, marker == 0xfe: // Application data and comments.
+			res = append(res, segment)
+		case marker == 0xda: // Start of scan, entropy-coded data lasts till next marker.
+			data = data[scanLen(data):]
+		}
+	}
+
+	return nil, false
+}
+
+// scanLen returns length of entropy-coded data, stuffed zero bytes and restart markers belong to it.
+func scanLen(data []byte) int {
+	for i := 0; i+1 < len(data); i++ {
+		if data[i] == 0xff && data[i+1] != 0 && (data[i+1] < 0xd0 || data[i+1] > 0xd7) {
+			return i
+		}
+	}
+
+	return len(data)
+}
+
+// gifRegions returns extensions except graphic control, image data is skipped.
+func gifRegions(data []byte) (res [][]byte, ok bool) {
+	// Logical screen descriptor is followed by optional global color table.
+	if len(data) < 7 {
+		return nil, false
+	}
+	flags := data[4]
+	data = data[7:]
+	if flags&0x80 != 0 {
+		data, ok = skip(data, 3<<(flags&0x07+1))
+		if !ok {
+			return nil, false
+		}
+	}
+
+	for len(data) > 0 {
+		block := data[0]
+		data = data[1:]
+
+		switch block {
+		case 0x3b: // Trailer.
+			return append(res, data), true
+		case 0x21: // Extension label and sub-blocks.
+			if len(data) == 0 {
+				return nil, false
+			}
+			label := data[0]
+
+			var ext []byte
+			ext, data, ok = subBlocks(data[1:])
+			if !ok {
+				return nil, false
+			}
+			if label != 0xf9 { // Graphic control.
+				res = append(res, ext)
+			}
+		case 0x2c: // Image descriptor, optional local color table, LZW minimum code size and sub-blocks.
+			if len(data) < 9 {
+				return nil, false
+			}
+			flags = data[8]
+			size := 9 + 1
+			if flags&0x80 != 0 {
+				size += 3 << (flags&0x07 + 1)
+			}
+
+			data, ok = skip(data, size)
+			if !ok {
+				return nil, false
+			}
+			_, data, ok = subBlocks(data)
+			if !ok {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+// subBlocks returns joined data of GIF sub-blocks and the rest of data.
+func subBlocks(data []byte) (joined, rest []byte, ok bool) {
+	for len(data) > 0 {
+		size := int(data[0])
+		data = data[1:]
+		if size == 0 {
+			return joined, data, true
+		}
+		if size > len(data) {
+			return nil, nil, false
+		}
+
+		joined = append(joined, data[:size]...)
+		data = data[size:]
+	}
+
+	return nil, nil, false
+}
+
+// webpRegions returns chunks except image data and data after RIFF container.
+func webpRegions(data []byte) (res [][]byte, ok bool) {
+	size := int(binary.LittleEndian.Uint32(data[4:]))
+	if size < 4 || size > len(data)-8 {
+		return nil, false
+	}
+	res = append(res, data[8+size:])
+	data = data[12 : 8+size]
+
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, false
+		}
+		typ := string(data[:4])
+		size = int(binary.LittleEndian.Uint32(data[4:]))
+		if size < 0 || size > len(data)-8 {
+			return nil, false
+		}
+
+		switch typ {
+		case "VP8 ", "VP8L", "ALPH", "ANMF":
+		default:
+			res = append(res, data[8:8+size])
+		}
+
+		// Chunks are padded to even size, padding of the last chunk may be missed.
+		data, _ = skip(data, 8+size+size%2)
+	}
+
+	return res, true
+}
+
+// skip returns data without n bytes, ok is false if data is shorter.
+func skip(data []byte, n int) (rest []byte, ok bool) {
+	if n > len(data) {
+		return nil, false
+	}
+
+	return data[n:], true
+}
@@ -0,0 +1,82 @@
+// Package scanner checks uploaded files for embedded active content.
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+var _ app.Scanner = &Scanner{}
+
+// defaultSignatures contains markers of scripts and markup which must never be served as avatar.
+var defaultSignatures = []string{
+	"<script",
+	"<svg",
+	"<html",
+	"<?php",
+	"javascript:",
+}
+
+type (
+	// Scanner looks for known signatures in metadata of images and in data appended to them.
+	// Compressed pixels aren't scanned, so images aren't quarantined by chance matches.
+	Scanner struct {
+		signatures [][]byte
+	}
+	// Option for building Scanner struct.
+	Option func(*Scanner)
+)
+
+// Signatures option for adds case-insensitive signatures to defaults.
+func Signatures(signatures ...string) Option {
+	return func(s *Scanner) {
+		for _, signature := range signatures {
+			s.signatures = append(s.signatures, bytes.ToLower([]byte(signature)))
+		}
+	}
+}
+
+// New creates and returns new Scanner.
+func New(options ...Option) *Scanner {
+	s := &Scanner{}
+	Signatures(defaultSignatures...)(s)
+
+	for i := range options {
+		options[i](s)
+	}
+
+	return s
+}
+
+// Scan implements app.Scanner.
+func (s *Scanner) Scan(_ context.Context, f app.Avatar) error {
+	_, err := f.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("f.Seek: %w", err)
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	for _, region := range regions(data) {
+		region = bytes.ToLower(region)
+		for _, signature := range s.signatures {
+			if bytes.Contains(region, signature) {
+				return fmt.Errorf("signature %q: %w", signature, app.ErrQuarantined)
+			}
+		}
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("f.Seek: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package scanner_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/scanner"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestScanner_Scan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		data    string
+		wantErr error
+	}{
+		"success":     {"\x89PNG\r\n\x1a\nIHDR", nil},
+		"script":      {"\x89PNG<ScRiPt>alert(1)</script>", app.ErrQuarantined},
+		"svg":         {`<svg xmlns="http://www.w3.org/2000/svg"/>`, app.ErrQuarantined},
+		"php":         {"GIF89a<?php system($_GET['c']); ?>", app.ErrQuarantined},
+		"javascript":  {"\xff\xd8JAVASCRIPT:alert(1)", app.ErrQuarantined},
+		"custom":      {"\xff\xd8EICAR", app.ErrQuarantined},
+		"custom_case": {"\xff\xd8eicar", app.ErrQuarantined},
+
+		"png_pixels":             {pngFile(pngChunk("IDAT", "<script")), nil},
+		"png_text":               {pngFile(pngChunk("tEXt", "Comment\x00<script>")), app.ErrQuarantined},
+		"png_compressed_text":    {pngFile(pngChunk("zTXt", "Comment\x00\x00"+deflate("<svg>"))), app.ErrQuarantined},
+		"png_international_text": {pngFile(pngChunk("iTXt", "Comment\x00\x01\x00en\x00\x00"+deflate("<html>"))), app.ErrQuarantined},
+		"png_appended":           {pngFile() + "<?php", app.ErrQuarantined},
+		"png_malformed":          {"\x89PNG\r\n\x1a\n<script", app.ErrQuarantined},
+		"jpeg_pixels":            {jpegFile("<svg\xff\x00\xff\xd0<html"), nil},
+		"jpeg_comment":           {jpegFile("", jpegSegment(0xfe, "<script>")), app.ErrQuarantined},
+		"jpeg_metadata":          {jpegFile("", jpegSegment(0xe1, "Exif\x00\x00javascript:")), app.ErrQuarantined},
+		"jpeg_appended":          {jpegFile("") + "<?php", app.ErrQuarantined},
+		"gif_pixels":             {gifFile(",\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x05<svg>\x00"), nil},
+		"gif_comment":            {gifFile("!\xfe\x08<script>\x00"), app.ErrQuarantined},
+		"gif_appended":           {gifFile("") + "<?php", app.ErrQuarantined},
+		"webp_pixels":            {webpFile(webpChunk("VP8 ", "<script")), nil},
+		"webp_metadata":          {webpFile(webpChunk("EXIF", "<script")), app.ErrQuarantined},
+		"webp_appended":          {webpFile() + "<?php", app.ErrQuarantined},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			s := scanner.New(scanner.Signatures("EICAR"))
+			f := app.Avatar{ReadSeekCloser: nopCloser{bytes.NewReader([]byte(tc.data))}}
+
+			err := s.Scan(context.Background(), f)
+			assert.ErrorIs(err, tc.wantErr)
+			if tc.wantErr != nil {
+				return
+			}
+
+			data, err := io.ReadAll(f)
+			assert.NoError(err)
+			assert.Equal(tc.data, string(data))
+		})
+	}
+}
+
+func pngFile(chunks ...string) string {
+	return "\x89PNG\r\n\x1a\n" + pngChunk("IHDR", strings.Repeat("\x00", 13)) +
+		strings.Join(chunks, "") + pngChunk("IEND", "")
+}
+
+func pngChunk(typ, data string) string {
+	return string(binary.BigEndian.AppendUint32(nil, uint32(len(data)))) + typ + data + "\x00\x00\x00\x00"
+}
+
+func jpegFile(scan string, segments ...string) string {
+	return "\xff\xd8" + strings.Join(segments, "") + jpegSegment(0xda, "\x01\x01\x00\x00\x3f\x00") + scan + "\xff\xd9"
+}
+
+func jpegSegment(marker byte, data string) string {
+	return string([]byte{0xff, marker}) + string(binary.BigEndian.AppendUint16(nil, uint16(len(data)+2))) + data
+}
+
+func gifFile(blocks string) string {
+	return "GIF89a\x01\x00\x01\x00\x00\x00\x00" + blocks + ";"
+}
+
+func webpFile(chunks ...string) string {
+	data := "WEBP" + strings.Join(chunks, "")
+
+	return "RIFF" + string(binary.LittleEndian.AppendUint32(nil, uint32(len(data)))) + data
+}
+
+func webpChunk(typ, data string) string {
+	chunk := typ + string(binary.LittleEndian.AppendUint32(nil, uint32(len(data)))) + data
+	if len(data)%2 != 0 {
+		chunk += "\x00"
+	}
+
+	return chunk
+}
+
+func deflate(text string) string {
+	buf := &bytes.Buffer{}
+	w := zlib.NewWriter(buf)
+	_, _ = w.Write([]byte(text))
+	_ = w.Close()
+
+	return buf.String()
+}
@@ -1,8 +1,14 @@
 package grpc
 
 import (
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	user_pb "github.com/ZergsLaw/back-template1/api/user/v1"
+	user_status_pb "github.com/ZergsLaw/back-template1/api/user_status/v1"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/convert"
 	"github.com/ZergsLaw/back-template1/internal/dom"
 )
 
@@ -14,12 +20,128 @@ func toUser(u app.User) *user_pb.User {
 		FullName: u.FullName,
 		AvatarId: u.AvatarID.String(),
 		Kind:     dom.UserStatusToAPI(u.Status),
+		Profile:  convert.Profile(u.Profile),
+	}
+}
+
+func fromProfile(p *user_pb.Profile) *app.Profile {
+	if p == nil {
+		return nil
+	}
+
+	res := &app.Profile{
+		Bio:      p.Bio,
+		Locale:   p.Locale,
+		Timezone: p.Timezone,
+		Links:    p.Links,
+	}
+	if p.Birthday != nil {
+		res.Birthday = p.Birthday.AsTime()
+	}
+
+	return res
+}
+
+// toUserView returns full profile for the owner and specialists,
+// otherwise returns profile reduced according to user's privacy settings.
+func toUserView(session dom.Session, u app.User) *user_pb.User {
+	res := toUser(u)
+	if session.UserID == u.ID || session.Status.IsSpecialist() {
+		return res
+	}
+
+	if u.Privacy.HideEmail {
+		res.Email = ""
+	}
+
+	if u.Privacy.HideStatus {
+		res.Kind = user_status_pb.StatusKind_STATUS_KIND_NONE
+	}
+
+	if u.Privacy.HideAvatar {
+		res.AvatarId = ""
+	}
+
+	return res
+}
+
+func toPrivacySettings(s app.PrivacySettings) *user_pb.PrivacySettings {
+	return &user_pb.PrivacySettings{
+		HideEmail:      s.HideEmail,
+		HideStatus:     s.HideStatus,
+		HideAvatar:     s.HideAvatar,
+		HideFromSearch: s.HideFromSearch,
+	}
+}
+
+func fromPrivacySettings(s *user_pb.PrivacySettings) app.PrivacySettings {
+	return app.PrivacySettings{
+		HideEmail:      s.GetHideEmail(),
+		HideStatus:     s.GetHideStatus(),
+		HideAvatar:     s.GetHideAvatar(),
+		HideFromSearch: s.GetHideFromSearch(),
 	}
 }
 
 func toUserFile(f app.AvatarInfo) *user_pb.UserAvatar {
 	return &user_pb.UserAvatar{
-		UserId: f.OwnerID.String(),
-		FileId: f.FileID.String(),
+		UserId:    f.OwnerID.String(),
+		FileId:    f.FileID.String(),
+		Position:  int32(f.Position),
+		CreatedAt: timestamppb.New(f.CreatedAt),
+		UpdatedAt: timestamppb.New(f.UpdatedAt),
+	}
+}
+
+func toUserFiles(files []app.AvatarInfo) []*user_pb.UserAvatar {
+	res := make([]*user_pb.UserAvatar, len(files))
+	for i := range files {
+		res[i] = toUserFile(files[i])
+	}
+
+	return res
+}
+
+func toDeadTasks(tasks []app.Task) []*user_pb.DeadTask {
+	res := make([]*user_pb.DeadTask, len(tasks))
+	for i := range tasks {
+		res[i] = &user_pb.DeadTask{
+			Id:        tasks[i].ID.String(),
+			Kind:      tasks[i].Kind.String(),
+			UserId:    tasks[i].User.ID.String(),
+			Attempts:  int32(tasks[i].Attempts),
+			LastError: tasks[i].LastError,
+			CreatedAt: timestamppb.New(tasks[i].CreatedAt),
+			DeadAt:    timestamppb.New(tasks[i].DeadAt),
+		}
+	}
+
+	return res
+}
+
+func toSearchMode(mode user_pb.SearchMode) app.SearchMode {
+	switch mode {
+	case user_pb.SearchMode_SEARCH_MODE_FUZZY:
+		return app.SearchModeFuzzy
+	default:
+		return app.SearchModeSubstring
 	}
 }
+
+func fromPreference(p *user_pb.Preference) app.Preference {
+	res := app.Preference{Key: p.Key}
+
+	switch v := p.Value.(type) {
+	case *user_pb.Preference_BoolValue:
+		res.Kind = app.PreferenceKindBool
+		res.Value = strconv.FormatBool(v.BoolValue)
+	case *user_pb.Preference_IntValue:
+		res.Kind = app.PreferenceKindInt
+		res.Value = strconv.FormatInt(v.IntValue, 10)
+	case *user_pb.Preference_StringValue:
+		res.Kind = app.PreferenceKindString
+		res.Value = v.StringValue
+	}
+
+	return res
+}
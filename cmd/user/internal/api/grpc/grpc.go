@@ -33,14 +33,29 @@ type application interface {
 	CreateUser(ctx context.Context, email, username, fullName, password string) (uuid.UUID, error)
 	Login(ctx context.Context, email, password string, origin dom.Origin) (uuid.UUID, *dom.Token, error)
 	UserByID(ctx context.Context, session dom.Session, userID uuid.UUID) (*app.User, error)
+	ResolveUsername(ctx context.Context, session dom.Session, username string) (*app.User, error)
 	ListUserByFilters(ctx context.Context, _ dom.Session, filters app.SearchParams) ([]app.User, int, error)
 	Logout(ctx context.Context, session dom.Session) error
 	UpdatePassword(ctx context.Context, session dom.Session, oldPass, newPass string) error
 	Auth(ctx context.Context, token string) (*dom.Session, error)
-	UpdateUser(ctx context.Context, session dom.Session, username string, avatarID uuid.UUID) error
+	UpdateUser(ctx context.Context, session dom.Session, username string, avatarID uuid.UUID, profile *app.Profile) error
 	RemoveAvatar(ctx context.Context, session dom.Session, fileID uuid.UUID) error
-	ListUserAvatars(ctx context.Context, session dom.Session) ([]app.AvatarInfo, error)
+	ListUserAvatars(ctx context.Context, session dom.Session, userID uuid.UUID) ([]app.AvatarInfo, error)
+	SetCurrentAvatar(ctx context.Context, session dom.Session, fileID uuid.UUID) error
+	ReorderAvatars(ctx context.Context, session dom.Session, fileIDs []uuid.UUID) ([]app.AvatarInfo, error)
+	CreateAvatarUpload(ctx context.Context, session dom.Session, name, contentType string, size int64) (*app.AvatarUpload, *app.PresignedURL, error)
+	CompleteAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) (uuid.UUID, error)
+	GetFileURL(ctx context.Context, session dom.Session, fileID uuid.UUID, size int) (*app.PresignedURL, error)
+	GetStorageUsage(ctx context.Context, session dom.Session) (*app.StorageUsage, error)
 	GetUsersByIDs(ctx context.Context, session dom.Session, ids []uuid.UUID) ([]app.User, error)
+	BatchGetUsers(ctx context.Context, session dom.Session, keys app.BatchKeys) (*app.BatchResult, error)
+	GetPrivacySettings(ctx context.Context, session dom.Session) (*app.PrivacySettings, error)
+	UpdatePrivacySettings(ctx context.Context, session dom.Session, settings app.PrivacySettings) (*app.PrivacySettings, error)
+	GetPreferences(ctx context.Context, session dom.Session) ([]app.Preference, error)
+	SetPreferences(ctx context.Context, session dom.Session, prefs []app.Preference) ([]app.Preference, error)
+	ListDeadTasks(ctx context.Context, session dom.Session, limit, offset int) ([]app.Task, int, error)
+	RetryDeadTask(ctx context.Context, session dom.Session, id uuid.UUID) error
+	DiscardDeadTask(ctx context.Context, session dom.Session, id uuid.UUID) error
 }
 
 type api struct {
@@ -64,18 +79,33 @@ func New(ctx context.Context, m metrics.Metrics, applications application, reg *
 	user_pb.RegisterUserExternalAPIServer(srv, &api{
 		app: applications,
 		auth: map[string]bool{
-			"VerificationEmail":    false,
-			"VerificationUsername": false,
-			"CreateUser":           false,
-			"Login":                false,
-			"GetUser":              true,
-			"SearchUsers":          true,
-			"Logout":               true,
-			"UpdatePassword":       true,
-			"UpdateUser":           true,
-			"RemoveAvatar":         true,
-			"ListUserAvatar":       true,
-			"GetUsersByIDs":        true,
+			"VerificationEmail":     false,
+			"VerificationUsername":  false,
+			"CreateUser":            false,
+			"Login":                 false,
+			"GetUser":               true,
+			"ResolveUsername":       true,
+			"SearchUsers":           true,
+			"Logout":                true,
+			"UpdatePassword":        true,
+			"UpdateUser":            true,
+			"RemoveAvatar":          true,
+			"ListUserAvatar":        true,
+			"SetCurrentAvatar":      true,
+			"ReorderAvatars":        true,
+			"CreateAvatarUpload":    true,
+			"CompleteAvatarUpload":  true,
+			"GetAvatarURL":          true,
+			"GetStorageUsage":       true,
+			"GetUsersByIDs":         true,
+			"BatchGetUsers":         true,
+			"GetPrivacySettings":    true,
+			"UpdatePrivacySettings": true,
+			"GetPreferences":        true,
+			"SetPreferences":        true,
+			"ListDeadTasks":         true,
+			"RetryDeadTask":         true,
+			"DiscardDeadTask":       true,
 		},
 	})
 
@@ -122,6 +152,8 @@ func apiError(err error) *status.Status {
 		code = codes.AlreadyExists
 	case errors.Is(err, app.ErrUsernameExist):
 		code = codes.AlreadyExists
+	case errors.Is(err, app.ErrUsernameReserved):
+		code = codes.InvalidArgument
 	case errors.Is(err, app.ErrAccessDenied):
 		code = codes.PermissionDenied
 	case errors.Is(err, app.ErrNotFound):
@@ -130,6 +162,20 @@ func apiError(err error) *status.Status {
 		code = codes.InvalidArgument
 	case errors.Is(err, app.ErrInvalidPassword):
 		code = codes.InvalidArgument
+	case errors.Is(err, app.ErrInvalidArgument):
+		code = codes.InvalidArgument
+	case errors.Is(err, app.ErrBatchTooLarge):
+		code = codes.InvalidArgument
+	case errors.Is(err, app.ErrInvalidImageFormat):
+		code = codes.InvalidArgument
+	case errors.Is(err, app.ErrQuarantined):
+		code = codes.InvalidArgument
+	case errors.Is(err, app.ErrMaxFiles):
+		code = codes.FailedPrecondition
+	case errors.Is(err, app.ErrNotSupported):
+		code = codes.Unimplemented
+	case errors.Is(err, app.ErrQuotaExceeded):
+		code = codes.ResourceExhausted
 	case errors.Is(err, context.DeadlineExceeded):
 		code = codes.DeadlineExceeded
 	case errors.Is(err, context.Canceled):
@@ -3,15 +3,18 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/gofrs/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	user_pb "github.com/ZergsLaw/back-template1/api/user/v1"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/convert"
 	"github.com/ZergsLaw/back-template1/internal/adapters/session"
 )
 
@@ -102,7 +105,25 @@ func (a *api) GetUser(ctx context.Context, request *user_pb.GetUserRequest) (*us
 		return nil, fmt.Errorf("a.app.UserByID: %w", err)
 	}
 
-	return &user_pb.GetUserResponse{User: toUser(*user)}, nil
+	return &user_pb.GetUserResponse{User: toUserView(*userSession, *user)}, nil
+}
+
+// ResolveUsername implements pb.UserExternalAPIServer.
+func (a *api) ResolveUsername(ctx context.Context, request *user_pb.ResolveUsernameRequest) (*user_pb.ResolveUsernameResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	user, err := a.app.ResolveUsername(ctx, *userSession, request.Username)
+	if err != nil {
+		return nil, fmt.Errorf("a.app.ResolveUsername: %w", err)
+	}
+
+	return &user_pb.ResolveUsernameResponse{
+		User:    toUserView(*userSession, *user),
+		Renamed: user.Name != request.Username,
+	}, nil
 }
 
 // SearchUsers implements pb.UserExternalAPIServer.
@@ -117,6 +138,7 @@ func (a *api) SearchUsers(ctx context.Context, request *user_pb.SearchUsersReque
 			OwnerID:  userSession.UserID,
 			Username: request.Name,
 			FullName: request.Name,
+			Mode:     toSearchMode(request.Mode),
 			Limit:    uint64(request.Limit),
 			Offset:   uint64(request.Offset),
 		},
@@ -127,7 +149,7 @@ func (a *api) SearchUsers(ctx context.Context, request *user_pb.SearchUsersReque
 
 	pbUsers := make([]*user_pb.User, len(users))
 	for i := range users {
-		pbUsers[i] = toUser(users[i])
+		pbUsers[i] = toUserView(*userSession, users[i])
 	}
 
 	return &user_pb.SearchUsersResponse{Users: pbUsers, Total: int32(total)}, nil
@@ -155,7 +177,7 @@ func (a *api) UpdateUser(ctx context.Context, request *user_pb.UpdateUserRequest
 		return nil, ErrUnauthenticated
 	}
 
-	err := a.app.UpdateUser(ctx, *userSession, request.Username, uuid.FromStringOrNil(request.AvatarId))
+	err := a.app.UpdateUser(ctx, *userSession, request.Username, uuid.FromStringOrNil(request.AvatarId), fromProfile(request.Profile))
 	if err != nil {
 		return nil, fmt.Errorf("a.app.UpdateUser: %w", err)
 	}
@@ -179,23 +201,127 @@ func (a *api) RemoveAvatar(ctx context.Context, request *user_pb.RemoveAvatarReq
 }
 
 // ListUserAvatar implements pb.UserExternalAPIServer.
-func (a *api) ListUserAvatar(ctx context.Context, _ *user_pb.ListUserAvatarRequest) (*user_pb.ListUserAvatarResponse, error) {
+func (a *api) ListUserAvatar(ctx context.Context, request *user_pb.ListUserAvatarRequest) (*user_pb.ListUserAvatarResponse, error) {
 	userSession := session.FromContext(ctx)
 	if userSession == nil {
 		return nil, ErrUnauthenticated
 	}
 
-	filesCache, err := a.app.ListUserAvatars(ctx, *userSession)
+	filesCache, err := a.app.ListUserAvatars(ctx, *userSession, uuid.FromStringOrNil(request.UserId))
 	if err != nil {
 		return nil, fmt.Errorf("a.app.ListUserAvatars: %w", err)
 	}
 
-	pbAvatars := make([]*user_pb.UserAvatar, len(filesCache))
-	for i := range filesCache {
-		pbAvatars[i] = toUserFile(filesCache[i])
+	return &user_pb.ListUserAvatarResponse{Avatars: toUserFiles(filesCache)}, nil
+}
+
+// SetCurrentAvatar implements pb.UserExternalAPIServer.
+func (a *api) SetCurrentAvatar(ctx context.Context, request *user_pb.SetCurrentAvatarRequest) (*user_pb.SetCurrentAvatarResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
 	}
 
-	return &user_pb.ListUserAvatarResponse{Avatars: pbAvatars}, nil
+	err := a.app.SetCurrentAvatar(ctx, *userSession, uuid.FromStringOrNil(request.FileId))
+	if err != nil {
+		return nil, fmt.Errorf("a.app.SetCurrentAvatar: %w", err)
+	}
+
+	return &user_pb.SetCurrentAvatarResponse{}, nil
+}
+
+// ReorderAvatars implements pb.UserExternalAPIServer.
+func (a *api) ReorderAvatars(ctx context.Context, request *user_pb.ReorderAvatarsRequest) (*user_pb.ReorderAvatarsResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	fileIDs := make([]uuid.UUID, len(request.FileIds))
+	for i := range request.FileIds {
+		fileIDs[i] = uuid.FromStringOrNil(request.FileIds[i])
+	}
+
+	gallery, err := a.app.ReorderAvatars(ctx, *userSession, fileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("a.app.ReorderAvatars: %w", err)
+	}
+
+	return &user_pb.ReorderAvatarsResponse{Avatars: toUserFiles(gallery)}, nil
+}
+
+// CreateAvatarUpload implements pb.UserExternalAPIServer.
+func (a *api) CreateAvatarUpload(ctx context.Context, request *user_pb.CreateAvatarUploadRequest) (*user_pb.CreateAvatarUploadResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	upload, u, err := a.app.CreateAvatarUpload(ctx, *userSession, request.Name, request.ContentType, request.Size)
+	if err != nil {
+		return nil, fmt.Errorf("a.app.CreateAvatarUpload: %w", err)
+	}
+
+	return &user_pb.CreateAvatarUploadResponse{
+		UploadId: upload.ID.String(),
+		Url:      u.URL.String(),
+		Headers: map[string]string{
+			"Content-Type":   upload.ContentType,
+			"Content-Length": strconv.FormatInt(upload.Size, 10),
+		},
+		ExpiresAt: timestamppb.New(u.ExpiresAt),
+	}, nil
+}
+
+// CompleteAvatarUpload implements pb.UserExternalAPIServer.
+func (a *api) CompleteAvatarUpload(ctx context.Context, request *user_pb.CompleteAvatarUploadRequest) (*user_pb.CompleteAvatarUploadResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	fileID, err := a.app.CompleteAvatarUpload(ctx, *userSession, uuid.FromStringOrNil(request.UploadId))
+	if err != nil {
+		return nil, fmt.Errorf("a.app.CompleteAvatarUpload: %w", err)
+	}
+
+	return &user_pb.CompleteAvatarUploadResponse{FileId: fileID.String()}, nil
+}
+
+// GetAvatarURL implements pb.UserExternalAPIServer.
+func (a *api) GetAvatarURL(ctx context.Context, request *user_pb.GetAvatarURLRequest) (*user_pb.GetAvatarURLResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	u, err := a.app.GetFileURL(ctx, *userSession, uuid.FromStringOrNil(request.FileId), int(request.Size))
+	if err != nil {
+		return nil, fmt.Errorf("a.app.GetFileURL: %w", err)
+	}
+
+	return &user_pb.GetAvatarURLResponse{
+		Url:       u.URL.String(),
+		ExpiresAt: timestamppb.New(u.ExpiresAt),
+	}, nil
+}
+
+// GetStorageUsage implements pb.UserExternalAPIServer.
+func (a *api) GetStorageUsage(ctx context.Context, _ *user_pb.GetStorageUsageRequest) (*user_pb.GetStorageUsageResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	usage, err := a.app.GetStorageUsage(ctx, *userSession)
+	if err != nil {
+		return nil, fmt.Errorf("a.app.GetStorageUsage: %w", err)
+	}
+
+	return &user_pb.GetStorageUsageResponse{
+		UsedBytes:  usage.UsedBytes,
+		QuotaBytes: usage.QuotaBytes,
+	}, nil
 }
 
 func (a *api) GetUsersByIDs(ctx context.Context, request *user_pb.GetUsersByIDsRequest) (*user_pb.GetUsersByIDsResponse, error) {
@@ -222,10 +348,157 @@ func (a *api) GetUsersByIDs(ctx context.Context, request *user_pb.GetUsersByIDsR
 
 	pbUsers := make([]*user_pb.User, len(users))
 	for i := range users {
-		pbUsers[i] = toUser(users[i])
+		pbUsers[i] = toUserView(*userSession, users[i])
 	}
 
 	return &user_pb.GetUsersByIDsResponse{
 		Result: pbUsers,
 	}, nil
 }
+
+// BatchGetUsers implements pb.UserExternalAPIServer.
+func (a *api) BatchGetUsers(ctx context.Context, request *user_pb.BatchGetUsersRequest) (*user_pb.BatchGetUsersResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	ids := make([]uuid.UUID, len(request.Ids))
+	for i := range request.Ids {
+		id, err := uuid.FromString(request.Ids[i])
+		if err != nil {
+			return nil, fmt.Errorf("uuid.FromString: %w", app.ErrInvalidArgument)
+		}
+
+		ids[i] = id
+	}
+
+	res, err := a.app.BatchGetUsers(ctx, *userSession, app.BatchKeys{
+		IDs:       ids,
+		Usernames: request.Usernames,
+		Emails:    request.Emails,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("a.app.BatchGetUsers: %w", err)
+	}
+
+	pbUsers := make(map[string]*user_pb.User, len(res.Users))
+	for key := range res.Users {
+		pbUsers[key] = toUserView(*userSession, res.Users[key])
+	}
+
+	return &user_pb.BatchGetUsersResponse{
+		Users:   pbUsers,
+		Missing: res.Missing,
+	}, nil
+}
+
+// GetPrivacySettings implements pb.UserExternalAPIServer.
+func (a *api) GetPrivacySettings(ctx context.Context, _ *user_pb.GetPrivacySettingsRequest) (*user_pb.GetPrivacySettingsResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	settings, err := a.app.GetPrivacySettings(ctx, *userSession)
+	if err != nil {
+		return nil, fmt.Errorf("a.app.GetPrivacySettings: %w", err)
+	}
+
+	return &user_pb.GetPrivacySettingsResponse{Settings: toPrivacySettings(*settings)}, nil
+}
+
+// UpdatePrivacySettings implements pb.UserExternalAPIServer.
+func (a *api) UpdatePrivacySettings(ctx context.Context, request *user_pb.UpdatePrivacySettingsRequest) (*user_pb.UpdatePrivacySettingsResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	settings, err := a.app.UpdatePrivacySettings(ctx, *userSession, fromPrivacySettings(request.Settings))
+	if err != nil {
+		return nil, fmt.Errorf("a.app.UpdatePrivacySettings: %w", err)
+	}
+
+	return &user_pb.UpdatePrivacySettingsResponse{Settings: toPrivacySettings(*settings)}, nil
+}
+
+// GetPreferences implements pb.UserExternalAPIServer.
+func (a *api) GetPreferences(ctx context.Context, _ *user_pb.GetPreferencesRequest) (*user_pb.GetPreferencesResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	prefs, err := a.app.GetPreferences(ctx, *userSession)
+	if err != nil {
+		return nil, fmt.Errorf("a.app.GetPreferences: %w", err)
+	}
+
+	return &user_pb.GetPreferencesResponse{Preferences: convert.Preferences(prefs)}, nil
+}
+
+// SetPreferences implements pb.UserExternalAPIServer.
+func (a *api) SetPreferences(ctx context.Context, request *user_pb.SetPreferencesRequest) (*user_pb.SetPreferencesResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	prefs := make([]app.Preference, len(request.Preferences))
+	for i := range request.Preferences {
+		prefs[i] = fromPreference(request.Preferences[i])
+	}
+
+	res, err := a.app.SetPreferences(ctx, *userSession, prefs)
+	if err != nil {
+		return nil, fmt.Errorf("a.app.SetPreferences: %w", err)
+	}
+
+	return &user_pb.SetPreferencesResponse{Preferences: convert.Preferences(res)}, nil
+}
+
+// ListDeadTasks implements pb.UserExternalAPIServer.
+func (a *api) ListDeadTasks(ctx context.Context, request *user_pb.ListDeadTasksRequest) (*user_pb.ListDeadTasksResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	tasks, total, err := a.app.ListDeadTasks(ctx, *userSession, int(request.Limit), int(request.Offset))
+	if err != nil {
+		return nil, fmt.Errorf("a.app.ListDeadTasks: %w", err)
+	}
+
+	return &user_pb.ListDeadTasksResponse{Tasks: toDeadTasks(tasks), Total: int32(total)}, nil
+}
+
+// RetryDeadTask implements pb.UserExternalAPIServer.
+func (a *api) RetryDeadTask(ctx context.Context, request *user_pb.RetryDeadTaskRequest) (*user_pb.RetryDeadTaskResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	err := a.app.RetryDeadTask(ctx, *userSession, uuid.FromStringOrNil(request.TaskId))
+	if err != nil {
+		return nil, fmt.Errorf("a.app.RetryDeadTask: %w", err)
+	}
+
+	return &user_pb.RetryDeadTaskResponse{}, nil
+}
+
+// DiscardDeadTask implements pb.UserExternalAPIServer.
+func (a *api) DiscardDeadTask(ctx context.Context, request *user_pb.DiscardDeadTaskRequest) (*user_pb.DiscardDeadTaskResponse, error) {
+	userSession := session.FromContext(ctx)
+	if userSession == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	err := a.app.DiscardDeadTask(ctx, *userSession, uuid.FromStringOrNil(request.TaskId))
+	if err != nil {
+		return nil, fmt.Errorf("a.app.DiscardDeadTask: %w", err)
+	}
+
+	return &user_pb.DiscardDeadTaskResponse{}, nil
+}
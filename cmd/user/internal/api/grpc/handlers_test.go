@@ -2,6 +2,7 @@ package grpc_test
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	user_pb "github.com/ZergsLaw/back-template1/api/user/v1"
 	user_status_pb "github.com/ZergsLaw/back-template1/api/user_status/v1"
@@ -62,6 +64,8 @@ func TestApi_VerificationUsername(t *testing.T) {
 	var (
 		username    = "username"
 		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.VerificationUsername: %s", errAny))
+		errReserved = status.Error(codes.InvalidArgument, fmt.Sprintf("a.app.VerificationUsername: %s", app.ErrUsernameReserved))
+		errExist    = status.Error(codes.AlreadyExists, fmt.Sprintf("a.app.VerificationUsername: %s", app.ErrUsernameExist))
 	)
 
 	testCases := map[string]struct {
@@ -69,8 +73,10 @@ func TestApi_VerificationUsername(t *testing.T) {
 		appErr   error
 		wantErr  error
 	}{
-		"success":                    {username, nil, nil},
-		"a.app.VerificationUsername": {username, errAny, errInternal},
+		"success":                             {username, nil, nil},
+		"a.app.VerificationUsername_reserved": {username, app.ErrUsernameReserved, errReserved},
+		"a.app.VerificationUsername_exist":    {username, app.ErrUsernameExist, errExist},
+		"a.app.VerificationUsername":          {username, errAny, errInternal},
 	}
 
 	for name, tc := range testCases {
@@ -224,19 +230,35 @@ func TestApi_GetUser(t *testing.T) {
 				Kind:     user_status_pb.StatusKind_STATUS_KIND_DEFAULT,
 			},
 		}
+		privateUser = withPrivacy(user, app.PrivacySettings{
+			UserID:     user.ID,
+			HideEmail:  true,
+			HideStatus: true,
+			HideAvatar: true,
+		})
+		wantPrivate = &user_pb.GetUserResponse{
+			User: &user_pb.User{
+				Id:       user.ID.String(),
+				Username: user.Name,
+			},
+		}
 		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.UserByID: %s", errAny))
 	)
 
 	testCases := map[string]struct {
-		userID  string
-		want    *user_pb.GetUserResponse
-		appRes  *app.User
-		appErr  error
-		wantErr error
+		userStatus dom.UserStatus
+		session    dom.Session
+		userID     string
+		want       *user_pb.GetUserResponse
+		appRes     *app.User
+		appErr     error
+		wantErr    error
 	}{
-		"success":          {userID.String(), want, &user, nil, nil},
-		"success_empty_id": {"", want, &user, nil, nil},
-		"a.app.GetUser":    {userID.String(), nil, nil, errAny, errInternal},
+		"success":                    {dom.UserStatusDefault, session, userID.String(), want, &user, nil, nil},
+		"success_empty_id":           {dom.UserStatusDefault, session, "", want, &user, nil, nil},
+		"success_private":            {dom.UserStatusDefault, session, userID.String(), wantPrivate, &privateUser, nil, nil},
+		"success_private_specialist": {dom.UserStatusAdmin, adminSession, userID.String(), want, &privateUser, nil, nil},
+		"a.app.GetUser":              {dom.UserStatusDefault, session, userID.String(), nil, nil, errAny, errInternal},
 	}
 
 	for name, tc := range testCases {
@@ -244,14 +266,14 @@ func TestApi_GetUser(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			ctx, c, mockApp, assert := start(t, tc.userStatus)
 
 			if tc.appRes != nil || tc.appErr != nil {
 				id := uuid.Nil
 				if tc.userID != "" {
 					id = uuid.Must(uuid.FromString(tc.userID))
 				}
-				mockApp.EXPECT().UserByID(gomock.Any(), session, id).Return(tc.appRes, tc.appErr)
+				mockApp.EXPECT().UserByID(gomock.Any(), tc.session, id).Return(tc.appRes, tc.appErr)
 			}
 
 			res, err := c.GetUser(auth(ctx), &user_pb.GetUserRequest{
@@ -263,6 +285,57 @@ func TestApi_GetUser(t *testing.T) {
 	}
 }
 
+func TestApi_ResolveUsername(t *testing.T) {
+	t.Parallel()
+
+	var (
+		want = &user_pb.ResolveUsernameResponse{
+			User: &user_pb.User{
+				Id:       user.ID.String(),
+				Username: user.Name,
+				Email:    user.Email,
+				AvatarId: user.AvatarID.String(),
+				Kind:     user_status_pb.StatusKind_STATUS_KIND_DEFAULT,
+			},
+		}
+		wantRenamed = &user_pb.ResolveUsernameResponse{
+			User:    want.User,
+			Renamed: true,
+		}
+		errNotFound = status.Error(codes.NotFound, fmt.Sprintf("a.app.ResolveUsername: %s", app.ErrNotFound))
+		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.ResolveUsername: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		username string
+		appRes   *app.User
+		appErr   error
+		want     *user_pb.ResolveUsernameResponse
+		wantErr  error
+	}{
+		"success":                   {user.Name, &user, nil, want, nil},
+		"success_renamed":           {"old_name", &user, nil, wantRenamed, nil},
+		"a.app.ResolveUsername":     {user.Name, nil, errAny, nil, errInternal},
+		"a.app.ResolveUsername_404": {"old_name", nil, app.ErrNotFound, nil, errNotFound},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			mockApp.EXPECT().ResolveUsername(gomock.Any(), session, tc.username).Return(tc.appRes, tc.appErr)
+
+			res, err := c.ResolveUsername(auth(ctx), &user_pb.ResolveUsernameRequest{
+				Username: tc.username,
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
 func TestApi_SearchUser(t *testing.T) {
 	t.Parallel()
 
@@ -280,15 +353,19 @@ func TestApi_SearchUser(t *testing.T) {
 		name        string
 		limit       int
 		offset      int
+		mode        user_pb.SearchMode
+		appMode     app.SearchMode
 		want        *user_pb.SearchUsersResponse
 		appRes      []app.User
 		appResTotal int
 		appErr      error
 		wantErr     error
 	}{
-		"success_pagination_min": {user.Name, 1, 0, want, []app.User{user}, 1, nil, nil},
-		"success_pagination_max": {user.Name, 500, 100, want, []app.User{user}, 1, nil, nil},
-		"a.app.SearchUsers":      {user.Name, 1, 1, nil, nil, 0, errAny, errInternal},
+		"success_pagination_min": {user.Name, 1, 0, user_pb.SearchMode_SEARCH_MODE_NONE, app.SearchModeSubstring, want, []app.User{user}, 1, nil, nil},
+		"success_pagination_max": {user.Name, 500, 100, user_pb.SearchMode_SEARCH_MODE_NONE, app.SearchModeSubstring, want, []app.User{user}, 1, nil, nil},
+		"success_substring":      {user.Name, 1, 0, user_pb.SearchMode_SEARCH_MODE_SUBSTRING, app.SearchModeSubstring, want, []app.User{user}, 1, nil, nil},
+		"success_fuzzy":          {user.Name, 1, 0, user_pb.SearchMode_SEARCH_MODE_FUZZY, app.SearchModeFuzzy, want, []app.User{user}, 1, nil, nil},
+		"a.app.SearchUsers":      {user.Name, 1, 1, user_pb.SearchMode_SEARCH_MODE_NONE, app.SearchModeSubstring, nil, nil, 0, errAny, errInternal},
 	}
 
 	for name, tc := range testCases {
@@ -303,6 +380,7 @@ func TestApi_SearchUser(t *testing.T) {
 					OwnerID:  session.UserID,
 					Username: tc.name,
 					FullName: tc.name,
+					Mode:     tc.appMode,
 					Limit:    uint64(tc.limit),
 					Offset:   uint64(tc.offset),
 				}).Return(tc.appRes, tc.appResTotal, tc.appErr)
@@ -312,6 +390,7 @@ func TestApi_SearchUser(t *testing.T) {
 				Name:   tc.name,
 				Limit:  int32(int64(tc.limit)),
 				Offset: int32(tc.offset),
+				Mode:   tc.mode,
 			})
 			assert.ErrorIs(err, tc.wantErr)
 			assert.True(proto.Equal(res, tc.want))
@@ -367,20 +446,40 @@ func TestAPI_UpdateUser(t *testing.T) {
 	var (
 		newUsername = "new username"
 		newAvatarID = uuid.Must(uuid.NewV4())
-		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.UpdateUser: %s", errAny))
-		errNotFound = status.Error(codes.NotFound, fmt.Sprintf("a.app.UpdateUser: %s", app.ErrNotFound))
+		birthday    = time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+		pbProfile   = &user_pb.Profile{
+			Bio:      "bio",
+			Locale:   "en-US",
+			Timezone: "Europe/Moscow",
+			Birthday: timestamppb.New(birthday),
+			Links:    []string{"https://example.com"},
+		}
+		profile = &app.Profile{
+			Bio:      "bio",
+			Locale:   "en-US",
+			Timezone: "Europe/Moscow",
+			Birthday: birthday,
+			Links:    []string{"https://example.com"},
+		}
+		errInternal        = status.Error(codes.Internal, fmt.Sprintf("a.app.UpdateUser: %s", errAny))
+		errNotFound        = status.Error(codes.NotFound, fmt.Sprintf("a.app.UpdateUser: %s", app.ErrNotFound))
+		errInvalidArgument = status.Error(codes.InvalidArgument, fmt.Sprintf("a.app.UpdateUser: %s", app.ErrInvalidArgument))
 	)
 
 	testCases := map[string]struct {
-		username string
-		avatarID uuid.UUID
-		appErr   error
-		want     *user_pb.UpdateUserResponse
-		wantErr  error
+		username  string
+		avatarID  uuid.UUID
+		pbProfile *user_pb.Profile
+		profile   *app.Profile
+		appErr    error
+		want      *user_pb.UpdateUserResponse
+		wantErr   error
 	}{
-		"success":       {newUsername, newAvatarID, nil, &user_pb.UpdateUserResponse{}, nil},
-		"err_not_found": {newUsername, newAvatarID, app.ErrNotFound, nil, errNotFound},
-		"err_any":       {newUsername, newAvatarID, errAny, nil, errInternal},
+		"success":              {newUsername, newAvatarID, nil, nil, nil, &user_pb.UpdateUserResponse{}, nil},
+		"success_profile":      {newUsername, newAvatarID, pbProfile, profile, nil, &user_pb.UpdateUserResponse{}, nil},
+		"err_invalid_argument": {newUsername, newAvatarID, pbProfile, profile, app.ErrInvalidArgument, nil, errInvalidArgument},
+		"err_not_found":        {newUsername, newAvatarID, nil, nil, app.ErrNotFound, nil, errNotFound},
+		"err_any":              {newUsername, newAvatarID, nil, nil, errAny, nil, errInternal},
 	}
 
 	for name, tc := range testCases {
@@ -391,12 +490,13 @@ func TestAPI_UpdateUser(t *testing.T) {
 			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
 
 			if tc.username == newUsername && tc.avatarID == newAvatarID {
-				mockApp.EXPECT().UpdateUser(gomock.Any(), session, tc.username, tc.avatarID).Return(tc.appErr)
+				mockApp.EXPECT().UpdateUser(gomock.Any(), session, tc.username, tc.avatarID, tc.profile).Return(tc.appErr)
 			}
 
 			res, err := c.UpdateUser(auth(ctx), &user_pb.UpdateUserRequest{
 				Username: tc.username,
 				AvatarId: tc.avatarID.String(),
+				Profile:  tc.pbProfile,
 			})
 			assert.ErrorIs(err, tc.wantErr)
 			assert.True(proto.Equal(res, tc.want))
@@ -444,6 +544,341 @@ func TestAPI_RemoveAvatar(t *testing.T) {
 	}
 }
 
+func TestApi_ListUserAvatar(t *testing.T) {
+	t.Parallel()
+
+	var (
+		userID = uuid.Must(uuid.NewV4())
+		avatar = app.AvatarInfo{
+			FileID:    uuid.Must(uuid.NewV4()),
+			OwnerID:   userID,
+			Position:  1,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		want = &user_pb.ListUserAvatarResponse{Avatars: []*user_pb.UserAvatar{{
+			UserId:    userID.String(),
+			FileId:    avatar.FileID.String(),
+			Position:  1,
+			CreatedAt: timestamppb.New(avatar.CreatedAt),
+			UpdatedAt: timestamppb.New(avatar.UpdatedAt),
+		}}}
+		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.ListUserAvatars: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		userID  string
+		appRes  []app.AvatarInfo
+		appErr  error
+		want    *user_pb.ListUserAvatarResponse
+		wantErr error
+	}{
+		"success":         {userID.String(), []app.AvatarInfo{avatar}, nil, want, nil},
+		"success_session": {"", []app.AvatarInfo{}, nil, &user_pb.ListUserAvatarResponse{}, nil},
+		"err_any":         {userID.String(), nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			mockApp.EXPECT().ListUserAvatars(gomock.Any(), session, uuid.FromStringOrNil(tc.userID)).Return(tc.appRes, tc.appErr)
+
+			res, err := c.ListUserAvatar(auth(ctx), &user_pb.ListUserAvatarRequest{
+				UserId: tc.userID,
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_SetCurrentAvatar(t *testing.T) {
+	t.Parallel()
+
+	var (
+		fileID          = uuid.Must(uuid.NewV4())
+		errNotFound     = status.Error(codes.NotFound, fmt.Sprintf("a.app.SetCurrentAvatar: %s", app.ErrNotFound))
+		errAccessDenied = status.Error(codes.PermissionDenied, fmt.Sprintf("a.app.SetCurrentAvatar: %s", app.ErrAccessDenied))
+		errInternal     = status.Error(codes.Internal, fmt.Sprintf("a.app.SetCurrentAvatar: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appErr  error
+		want    *user_pb.SetCurrentAvatarResponse
+		wantErr error
+	}{
+		"success":           {nil, &user_pb.SetCurrentAvatarResponse{}, nil},
+		"err_not_found":     {app.ErrNotFound, nil, errNotFound},
+		"err_access_denied": {app.ErrAccessDenied, nil, errAccessDenied},
+		"err_any":           {errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			mockApp.EXPECT().SetCurrentAvatar(gomock.Any(), session, fileID).Return(tc.appErr)
+
+			res, err := c.SetCurrentAvatar(auth(ctx), &user_pb.SetCurrentAvatarRequest{
+				FileId: fileID.String(),
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_ReorderAvatars(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first  = app.AvatarInfo{FileID: uuid.Must(uuid.NewV4()), OwnerID: session.UserID, Position: 0}
+		second = app.AvatarInfo{FileID: uuid.Must(uuid.NewV4()), OwnerID: session.UserID, Position: 1}
+		want   = &user_pb.ReorderAvatarsResponse{Avatars: []*user_pb.UserAvatar{
+			{
+				UserId:    session.UserID.String(),
+				FileId:    first.FileID.String(),
+				Position:  0,
+				CreatedAt: timestamppb.New(time.Time{}),
+				UpdatedAt: timestamppb.New(time.Time{}),
+			},
+			{
+				UserId:    session.UserID.String(),
+				FileId:    second.FileID.String(),
+				Position:  1,
+				CreatedAt: timestamppb.New(time.Time{}),
+				UpdatedAt: timestamppb.New(time.Time{}),
+			},
+		}}
+		errInvalidArgument = status.Error(codes.InvalidArgument, fmt.Sprintf("a.app.ReorderAvatars: %s", app.ErrInvalidArgument))
+		errInternal        = status.Error(codes.Internal, fmt.Sprintf("a.app.ReorderAvatars: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes  []app.AvatarInfo
+		appErr  error
+		want    *user_pb.ReorderAvatarsResponse
+		wantErr error
+	}{
+		"success":              {[]app.AvatarInfo{first, second}, nil, want, nil},
+		"err_invalid_argument": {nil, app.ErrInvalidArgument, nil, errInvalidArgument},
+		"err_any":              {nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			mockApp.EXPECT().ReorderAvatars(gomock.Any(), session, []uuid.UUID{first.FileID, second.FileID}).Return(tc.appRes, tc.appErr)
+
+			res, err := c.ReorderAvatars(auth(ctx), &user_pb.ReorderAvatarsRequest{
+				FileIds: []string{first.FileID.String(), second.FileID.String()},
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_CreateAvatarUpload(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upload = &app.AvatarUpload{
+			ID:          uuid.Must(uuid.NewV4()),
+			OwnerID:     session.UserID,
+			Name:        "avatar.png",
+			ContentType: "image/png",
+			Size:        1024,
+		}
+		presigned = &app.PresignedURL{
+			URL:       &url.URL{Scheme: "http", Host: "minio", Path: "/user.avatars/uploads/" + upload.ID.String()},
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+		want = &user_pb.CreateAvatarUploadResponse{
+			UploadId: upload.ID.String(),
+			Url:      presigned.URL.String(),
+			Headers: map[string]string{
+				"Content-Type":   "image/png",
+				"Content-Length": "1024",
+			},
+			ExpiresAt: timestamppb.New(presigned.ExpiresAt),
+		}
+		errInvalidArgument = status.Error(codes.InvalidArgument, fmt.Sprintf("a.app.CreateAvatarUpload: %s", app.ErrInvalidImageFormat))
+		errMaxFiles        = status.Error(codes.FailedPrecondition, fmt.Sprintf("a.app.CreateAvatarUpload: %s", app.ErrMaxFiles))
+		errQuotaExceeded   = status.Error(codes.ResourceExhausted, fmt.Sprintf("a.app.CreateAvatarUpload: %s", app.ErrQuotaExceeded))
+		errInternal        = status.Error(codes.Internal, fmt.Sprintf("a.app.CreateAvatarUpload: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appUpload *app.AvatarUpload
+		appURL    *app.PresignedURL
+		appErr    error
+		want      *user_pb.CreateAvatarUploadResponse
+		wantErr   error
+	}{
+		"success":              {upload, presigned, nil, want, nil},
+		"err_invalid_argument": {nil, nil, app.ErrInvalidImageFormat, nil, errInvalidArgument},
+		"err_max_files":        {nil, nil, app.ErrMaxFiles, nil, errMaxFiles},
+		"err_quota_exceeded":   {nil, nil, app.ErrQuotaExceeded, nil, errQuotaExceeded},
+		"err_any":              {nil, nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			mockApp.EXPECT().CreateAvatarUpload(gomock.Any(), session, upload.Name, upload.ContentType, upload.Size).
+				Return(tc.appUpload, tc.appURL, tc.appErr)
+
+			res, err := c.CreateAvatarUpload(auth(ctx), &user_pb.CreateAvatarUploadRequest{
+				Name:        upload.Name,
+				ContentType: upload.ContentType,
+				Size:        upload.Size,
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_CompleteAvatarUpload(t *testing.T) {
+	t.Parallel()
+
+	var (
+		uploadID       = uuid.Must(uuid.NewV4())
+		fileID         = uuid.Must(uuid.NewV4())
+		errNotFound    = status.Error(codes.NotFound, fmt.Sprintf("a.app.CompleteAvatarUpload: %s", app.ErrNotFound))
+		errQuarantined = status.Error(codes.InvalidArgument, fmt.Sprintf("a.app.CompleteAvatarUpload: %s", app.ErrQuarantined))
+		errInternal    = status.Error(codes.Internal, fmt.Sprintf("a.app.CompleteAvatarUpload: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes  uuid.UUID
+		appErr  error
+		want    *user_pb.CompleteAvatarUploadResponse
+		wantErr error
+	}{
+		"success":         {fileID, nil, &user_pb.CompleteAvatarUploadResponse{FileId: fileID.String()}, nil},
+		"err_not_found":   {uuid.Nil, app.ErrNotFound, nil, errNotFound},
+		"err_quarantined": {uuid.Nil, app.ErrQuarantined, nil, errQuarantined},
+		"err_any":         {uuid.Nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			mockApp.EXPECT().CompleteAvatarUpload(gomock.Any(), session, uploadID).Return(tc.appRes, tc.appErr)
+
+			res, err := c.CompleteAvatarUpload(auth(ctx), &user_pb.CompleteAvatarUploadRequest{
+				UploadId: uploadID.String(),
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_GetAvatarURL(t *testing.T) {
+	t.Parallel()
+
+	var (
+		fileID    = uuid.Must(uuid.NewV4())
+		presigned = &app.PresignedURL{
+			URL:       &url.URL{Scheme: "http", Host: "minio", Path: "/user.avatars/" + fileID.String() + "/64"},
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+		want = &user_pb.GetAvatarURLResponse{
+			Url:       presigned.URL.String(),
+			ExpiresAt: timestamppb.New(presigned.ExpiresAt),
+		}
+		errNotFound       = status.Error(codes.NotFound, fmt.Sprintf("a.app.GetFileURL: %s", app.ErrNotFound))
+		errNotImplemented = status.Error(codes.Unimplemented, fmt.Sprintf("a.app.GetFileURL: %s", app.ErrNotSupported))
+		errInternal       = status.Error(codes.Internal, fmt.Sprintf("a.app.GetFileURL: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes  *app.PresignedURL
+		appErr  error
+		want    *user_pb.GetAvatarURLResponse
+		wantErr error
+	}{
+		"success":           {presigned, nil, want, nil},
+		"err_not_found":     {nil, app.ErrNotFound, nil, errNotFound},
+		"err_not_supported": {nil, app.ErrNotSupported, nil, errNotImplemented},
+		"err_any":           {nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			mockApp.EXPECT().GetFileURL(gomock.Any(), session, fileID, 64).Return(tc.appRes, tc.appErr)
+
+			res, err := c.GetAvatarURL(auth(ctx), &user_pb.GetAvatarURLRequest{
+				FileId: fileID.String(),
+				Size:   64,
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_GetStorageUsage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		usage = &app.StorageUsage{
+			UsedBytes:  42,
+			QuotaBytes: 1 << 20,
+		}
+		want = &user_pb.GetStorageUsageResponse{
+			UsedBytes:  42,
+			QuotaBytes: 1 << 20,
+		}
+		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.GetStorageUsage: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes  *app.StorageUsage
+		appErr  error
+		want    *user_pb.GetStorageUsageResponse
+		wantErr error
+	}{
+		"success": {usage, nil, want, nil},
+		"err_any": {nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+			mockApp.EXPECT().GetStorageUsage(gomock.Any(), session).Return(tc.appRes, tc.appErr)
+
+			res, err := c.GetStorageUsage(auth(ctx), &user_pb.GetStorageUsageRequest{})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
 func TestApi_GetUsersByIDs(t *testing.T) {
 	t.Parallel()
 
@@ -535,3 +970,408 @@ func TestApi_GetUsersByIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestApi_BatchGetUsers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		user1 = app.User{
+			ID:       uuid.Must(uuid.NewV4()),
+			Email:    email,
+			Name:     username,
+			FullName: fullName,
+			AvatarID: uuid.Must(uuid.NewV4()),
+			Status:   dom.UserStatusDefault,
+		}
+		pbUser1 = &user_pb.User{
+			Id:       user1.ID.String(),
+			Username: user1.Name,
+			Email:    user1.Email,
+			AvatarId: user1.AvatarID.String(),
+			Kind:     user_status_pb.StatusKind_STATUS_KIND_DEFAULT,
+			FullName: user1.FullName,
+		}
+		missing = "unknown"
+		keys    = app.BatchKeys{
+			IDs:       []uuid.UUID{user1.ID},
+			Usernames: []string{missing},
+			Emails:    []string{user1.Email},
+		}
+		appRes = &app.BatchResult{
+			Users: map[string]app.User{
+				user1.ID.String(): user1,
+				user1.Email:       user1,
+			},
+			Missing: []string{missing},
+		}
+		want = &user_pb.BatchGetUsersResponse{
+			Users: map[string]*user_pb.User{
+				user1.ID.String(): pbUser1,
+				user1.Email:       pbUser1,
+			},
+			Missing: []string{missing},
+		}
+		errInvalidArgument = status.Error(codes.InvalidArgument, fmt.Sprintf("uuid.FromString: %s", app.ErrInvalidArgument))
+		errBatchTooLarge   = status.Error(codes.InvalidArgument, fmt.Sprintf("a.app.BatchGetUsers: %s", app.ErrBatchTooLarge))
+		errInternal        = status.Error(codes.Internal, fmt.Sprintf("a.app.BatchGetUsers: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		ids     []string
+		appRes  *app.BatchResult
+		appErr  error
+		want    *user_pb.BatchGetUsersResponse
+		wantErr error
+	}{
+		"success":             {[]string{user1.ID.String()}, appRes, nil, want, nil},
+		"err_invalid_id":      {[]string{"invalid"}, nil, nil, nil, errInvalidArgument},
+		"err_batch_too_large": {[]string{user1.ID.String()}, nil, app.ErrBatchTooLarge, nil, errBatchTooLarge},
+		"a.app.BatchGetUsers": {[]string{user1.ID.String()}, nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+
+			if tc.wantErr != errInvalidArgument {
+				mockApp.EXPECT().BatchGetUsers(gomock.Any(), session, keys).Return(tc.appRes, tc.appErr)
+			}
+
+			resp, err := c.BatchGetUsers(auth(ctx), &user_pb.BatchGetUsersRequest{
+				Ids:       tc.ids,
+				Usernames: keys.Usernames,
+				Emails:    keys.Emails,
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(resp, tc.want))
+		})
+	}
+}
+
+func TestApi_GetPrivacySettings(t *testing.T) {
+	t.Parallel()
+
+	var (
+		settings = app.PrivacySettings{
+			UserID:         session.UserID,
+			HideEmail:      true,
+			HideFromSearch: true,
+		}
+		want = &user_pb.GetPrivacySettingsResponse{
+			Settings: &user_pb.PrivacySettings{
+				HideEmail:      true,
+				HideFromSearch: true,
+			},
+		}
+		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.GetPrivacySettings: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes  *app.PrivacySettings
+		appErr  error
+		want    *user_pb.GetPrivacySettingsResponse
+		wantErr error
+	}{
+		"success":                  {&settings, nil, want, nil},
+		"a.app.GetPrivacySettings": {nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+
+			mockApp.EXPECT().GetPrivacySettings(gomock.Any(), session).Return(tc.appRes, tc.appErr)
+
+			res, err := c.GetPrivacySettings(auth(ctx), &user_pb.GetPrivacySettingsRequest{})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_UpdatePrivacySettings(t *testing.T) {
+	t.Parallel()
+
+	var (
+		settings = app.PrivacySettings{
+			HideStatus: true,
+			HideAvatar: true,
+		}
+		appRes = app.PrivacySettings{
+			UserID:     session.UserID,
+			HideStatus: true,
+			HideAvatar: true,
+		}
+		pbSettings = &user_pb.PrivacySettings{
+			HideStatus: true,
+			HideAvatar: true,
+		}
+		want        = &user_pb.UpdatePrivacySettingsResponse{Settings: pbSettings}
+		errNotFound = status.Error(codes.NotFound, fmt.Sprintf("a.app.UpdatePrivacySettings: %s", app.ErrNotFound))
+		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.UpdatePrivacySettings: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes  *app.PrivacySettings
+		appErr  error
+		want    *user_pb.UpdatePrivacySettingsResponse
+		wantErr error
+	}{
+		"success":                     {&appRes, nil, want, nil},
+		"err_not_found":               {nil, app.ErrNotFound, nil, errNotFound},
+		"a.app.UpdatePrivacySettings": {nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+
+			mockApp.EXPECT().UpdatePrivacySettings(gomock.Any(), session, settings).Return(tc.appRes, tc.appErr)
+
+			res, err := c.UpdatePrivacySettings(auth(ctx), &user_pb.UpdatePrivacySettingsRequest{
+				Settings: pbSettings,
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func withPrivacy(u app.User, settings app.PrivacySettings) app.User {
+	u.Privacy = settings
+
+	return u
+}
+
+func TestApi_GetPreferences(t *testing.T) {
+	t.Parallel()
+
+	var (
+		prefs = []app.Preference{
+			{UserID: session.UserID, Key: "ui.compact_mode", Kind: app.PreferenceKindBool, Value: "true"},
+			{UserID: session.UserID, Key: "ui.page_size", Kind: app.PreferenceKindInt, Value: "20"},
+			{UserID: session.UserID, Key: "ui.theme", Kind: app.PreferenceKindString, Value: "dark"},
+		}
+		want = &user_pb.GetPreferencesResponse{
+			Preferences: []*user_pb.Preference{
+				{Key: "ui.compact_mode", Value: &user_pb.Preference_BoolValue{BoolValue: true}},
+				{Key: "ui.page_size", Value: &user_pb.Preference_IntValue{IntValue: 20}},
+				{Key: "ui.theme", Value: &user_pb.Preference_StringValue{StringValue: "dark"}},
+			},
+		}
+		errInternal = status.Error(codes.Internal, fmt.Sprintf("a.app.GetPreferences: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes  []app.Preference
+		appErr  error
+		want    *user_pb.GetPreferencesResponse
+		wantErr error
+	}{
+		"success":              {prefs, nil, want, nil},
+		"a.app.GetPreferences": {nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+
+			mockApp.EXPECT().GetPreferences(gomock.Any(), session).Return(tc.appRes, tc.appErr)
+
+			res, err := c.GetPreferences(auth(ctx), &user_pb.GetPreferencesRequest{})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_SetPreferences(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pbPrefs = []*user_pb.Preference{
+			{Key: "ui.compact_mode", Value: &user_pb.Preference_BoolValue{BoolValue: true}},
+			{Key: "ui.page_size", Value: &user_pb.Preference_IntValue{IntValue: 50}},
+			{Key: "ui.theme", Value: &user_pb.Preference_StringValue{StringValue: "dark"}},
+		}
+		prefs = []app.Preference{
+			{Key: "ui.compact_mode", Kind: app.PreferenceKindBool, Value: "true"},
+			{Key: "ui.page_size", Kind: app.PreferenceKindInt, Value: "50"},
+			{Key: "ui.theme", Kind: app.PreferenceKindString, Value: "dark"},
+		}
+		want               = &user_pb.SetPreferencesResponse{Preferences: pbPrefs}
+		errInvalidArgument = status.Error(codes.InvalidArgument, fmt.Sprintf("a.app.SetPreferences: %s", app.ErrInvalidArgument))
+		errInternal        = status.Error(codes.Internal, fmt.Sprintf("a.app.SetPreferences: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes  []app.Preference
+		appErr  error
+		want    *user_pb.SetPreferencesResponse
+		wantErr error
+	}{
+		"success":              {prefs, nil, want, nil},
+		"err_invalid_argument": {nil, app.ErrInvalidArgument, nil, errInvalidArgument},
+		"a.app.SetPreferences": {nil, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusDefault)
+
+			mockApp.EXPECT().SetPreferences(gomock.Any(), session, prefs).Return(tc.appRes, tc.appErr)
+
+			res, err := c.SetPreferences(auth(ctx), &user_pb.SetPreferencesRequest{
+				Preferences: pbPrefs,
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_ListDeadTasks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		task = app.Task{
+			ID:        uuid.Must(uuid.NewV4()),
+			User:      user,
+			Kind:      app.TaskKindEventAdd,
+			Attempts:  10,
+			LastError: "any error",
+			CreatedAt: time.Now(),
+			DeadAt:    time.Now(),
+		}
+		want = &user_pb.ListDeadTasksResponse{
+			Tasks: []*user_pb.DeadTask{{
+				Id:        task.ID.String(),
+				Kind:      "EventAdd",
+				UserId:    user.ID.String(),
+				Attempts:  10,
+				LastError: "any error",
+				CreatedAt: timestamppb.New(task.CreatedAt),
+				DeadAt:    timestamppb.New(task.DeadAt),
+			}},
+			Total: 11,
+		}
+		errAccessDenied = status.Error(codes.PermissionDenied, fmt.Sprintf("a.app.ListDeadTasks: %s", app.ErrAccessDenied))
+		errInternal     = status.Error(codes.Internal, fmt.Sprintf("a.app.ListDeadTasks: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appRes   []app.Task
+		appTotal int
+		appErr   error
+		want     *user_pb.ListDeadTasksResponse
+		wantErr  error
+	}{
+		"success":           {[]app.Task{task}, 11, nil, want, nil},
+		"err_access_denied": {nil, 0, app.ErrAccessDenied, nil, errAccessDenied},
+		"err_any":           {nil, 0, errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusAdmin)
+			mockApp.EXPECT().ListDeadTasks(gomock.Any(), adminSession, 10, 5).Return(tc.appRes, tc.appTotal, tc.appErr)
+
+			res, err := c.ListDeadTasks(auth(ctx), &user_pb.ListDeadTasksRequest{
+				Limit:  10,
+				Offset: 5,
+			})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_RetryDeadTask(t *testing.T) {
+	t.Parallel()
+
+	var (
+		taskID          = uuid.Must(uuid.NewV4())
+		errAccessDenied = status.Error(codes.PermissionDenied, fmt.Sprintf("a.app.RetryDeadTask: %s", app.ErrAccessDenied))
+		errNotFound     = status.Error(codes.NotFound, fmt.Sprintf("a.app.RetryDeadTask: %s", app.ErrNotFound))
+		errInternal     = status.Error(codes.Internal, fmt.Sprintf("a.app.RetryDeadTask: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appErr  error
+		want    *user_pb.RetryDeadTaskResponse
+		wantErr error
+	}{
+		"success":           {nil, &user_pb.RetryDeadTaskResponse{}, nil},
+		"err_access_denied": {app.ErrAccessDenied, nil, errAccessDenied},
+		"err_not_found":     {app.ErrNotFound, nil, errNotFound},
+		"err_any":           {errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusAdmin)
+			mockApp.EXPECT().RetryDeadTask(gomock.Any(), adminSession, taskID).Return(tc.appErr)
+
+			res, err := c.RetryDeadTask(auth(ctx), &user_pb.RetryDeadTaskRequest{TaskId: taskID.String()})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
+
+func TestApi_DiscardDeadTask(t *testing.T) {
+	t.Parallel()
+
+	var (
+		taskID          = uuid.Must(uuid.NewV4())
+		errAccessDenied = status.Error(codes.PermissionDenied, fmt.Sprintf("a.app.DiscardDeadTask: %s", app.ErrAccessDenied))
+		errNotFound     = status.Error(codes.NotFound, fmt.Sprintf("a.app.DiscardDeadTask: %s", app.ErrNotFound))
+		errInternal     = status.Error(codes.Internal, fmt.Sprintf("a.app.DiscardDeadTask: %s", errAny))
+	)
+
+	testCases := map[string]struct {
+		appErr  error
+		want    *user_pb.DiscardDeadTaskResponse
+		wantErr error
+	}{
+		"success":           {nil, &user_pb.DiscardDeadTaskResponse{}, nil},
+		"err_access_denied": {app.ErrAccessDenied, nil, errAccessDenied},
+		"err_not_found":     {app.ErrNotFound, nil, errNotFound},
+		"err_any":           {errAny, nil, errInternal},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, c, mockApp, assert := start(t, dom.UserStatusAdmin)
+			mockApp.EXPECT().DiscardDeadTask(gomock.Any(), adminSession, taskID).Return(tc.appErr)
+
+			res, err := c.DiscardDeadTask(auth(ctx), &user_pb.DiscardDeadTaskRequest{TaskId: taskID.String()})
+			assert.ErrorIs(err, tc.wantErr)
+			assert.True(proto.Equal(res, tc.want))
+		})
+	}
+}
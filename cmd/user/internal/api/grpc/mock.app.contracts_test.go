@@ -56,6 +56,52 @@ func (mr *MockapplicationMockRecorder) Auth(ctx, token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*Mockapplication)(nil).Auth), ctx, token)
 }
 
+// BatchGetUsers mocks base method.
+func (m *Mockapplication) BatchGetUsers(ctx context.Context, session dom.Session, keys app.BatchKeys) (*app.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetUsers", ctx, session, keys)
+	ret0, _ := ret[0].(*app.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetUsers indicates an expected call of BatchGetUsers.
+func (mr *MockapplicationMockRecorder) BatchGetUsers(ctx, session, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetUsers", reflect.TypeOf((*Mockapplication)(nil).BatchGetUsers), ctx, session, keys)
+}
+
+// CompleteAvatarUpload mocks base method.
+func (m *Mockapplication) CompleteAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAvatarUpload", ctx, session, uploadID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteAvatarUpload indicates an expected call of CompleteAvatarUpload.
+func (mr *MockapplicationMockRecorder) CompleteAvatarUpload(ctx, session, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAvatarUpload", reflect.TypeOf((*Mockapplication)(nil).CompleteAvatarUpload), ctx, session, uploadID)
+}
+
+// CreateAvatarUpload mocks base method.
+func (m *Mockapplication) CreateAvatarUpload(ctx context.Context, session dom.Session, name, contentType string, size int64) (*app.AvatarUpload, *app.PresignedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAvatarUpload", ctx, session, name, contentType, size)
+	ret0, _ := ret[0].(*app.AvatarUpload)
+	ret1, _ := ret[1].(*app.PresignedURL)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAvatarUpload indicates an expected call of CreateAvatarUpload.
+func (mr *MockapplicationMockRecorder) CreateAvatarUpload(ctx, session, name, contentType, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAvatarUpload", reflect.TypeOf((*Mockapplication)(nil).CreateAvatarUpload), ctx, session, name, contentType, size)
+}
+
 // CreateUser mocks base method.
 func (m *Mockapplication) CreateUser(ctx context.Context, email, username, fullName, password string) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +117,80 @@ func (mr *MockapplicationMockRecorder) CreateUser(ctx, email, username, fullName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*Mockapplication)(nil).CreateUser), ctx, email, username, fullName, password)
 }
 
+// DiscardDeadTask mocks base method.
+func (m *Mockapplication) DiscardDeadTask(ctx context.Context, session dom.Session, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardDeadTask", ctx, session, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardDeadTask indicates an expected call of DiscardDeadTask.
+func (mr *MockapplicationMockRecorder) DiscardDeadTask(ctx, session, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardDeadTask", reflect.TypeOf((*Mockapplication)(nil).DiscardDeadTask), ctx, session, id)
+}
+
+// GetFileURL mocks base method.
+func (m *Mockapplication) GetFileURL(ctx context.Context, session dom.Session, fileID uuid.UUID, size int) (*app.PresignedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileURL", ctx, session, fileID, size)
+	ret0, _ := ret[0].(*app.PresignedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileURL indicates an expected call of GetFileURL.
+func (mr *MockapplicationMockRecorder) GetFileURL(ctx, session, fileID, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileURL", reflect.TypeOf((*Mockapplication)(nil).GetFileURL), ctx, session, fileID, size)
+}
+
+// GetPreferences mocks base method.
+func (m *Mockapplication) GetPreferences(ctx context.Context, session dom.Session) ([]app.Preference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, session)
+	ret0, _ := ret[0].([]app.Preference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockapplicationMockRecorder) GetPreferences(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*Mockapplication)(nil).GetPreferences), ctx, session)
+}
+
+// GetPrivacySettings mocks base method.
+func (m *Mockapplication) GetPrivacySettings(ctx context.Context, session dom.Session) (*app.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacySettings", ctx, session)
+	ret0, _ := ret[0].(*app.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacySettings indicates an expected call of GetPrivacySettings.
+func (mr *MockapplicationMockRecorder) GetPrivacySettings(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacySettings", reflect.TypeOf((*Mockapplication)(nil).GetPrivacySettings), ctx, session)
+}
+
+// GetStorageUsage mocks base method.
+func (m *Mockapplication) GetStorageUsage(ctx context.Context, session dom.Session) (*app.StorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageUsage", ctx, session)
+	ret0, _ := ret[0].(*app.StorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageUsage indicates an expected call of GetStorageUsage.
+func (mr *MockapplicationMockRecorder) GetStorageUsage(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageUsage", reflect.TypeOf((*Mockapplication)(nil).GetStorageUsage), ctx, session)
+}
+
 // GetUsersByIDs mocks base method.
 func (m *Mockapplication) GetUsersByIDs(ctx context.Context, session dom.Session, ids []uuid.UUID) ([]app.User, error) {
 	m.ctrl.T.Helper()
@@ -86,19 +206,35 @@ func (mr *MockapplicationMockRecorder) GetUsersByIDs(ctx, session, ids any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*Mockapplication)(nil).GetUsersByIDs), ctx, session, ids)
 }
 
+// ListDeadTasks mocks base method.
+func (m *Mockapplication) ListDeadTasks(ctx context.Context, session dom.Session, limit, offset int) ([]app.Task, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadTasks", ctx, session, limit, offset)
+	ret0, _ := ret[0].([]app.Task)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeadTasks indicates an expected call of ListDeadTasks.
+func (mr *MockapplicationMockRecorder) ListDeadTasks(ctx, session, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadTasks", reflect.TypeOf((*Mockapplication)(nil).ListDeadTasks), ctx, session, limit, offset)
+}
+
 // ListUserAvatars mocks base method.
-func (m *Mockapplication) ListUserAvatars(ctx context.Context, session dom.Session) ([]app.AvatarInfo, error) {
+func (m *Mockapplication) ListUserAvatars(ctx context.Context, session dom.Session, userID uuid.UUID) ([]app.AvatarInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserAvatars", ctx, session)
+	ret := m.ctrl.Call(m, "ListUserAvatars", ctx, session, userID)
 	ret0, _ := ret[0].([]app.AvatarInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUserAvatars indicates an expected call of ListUserAvatars.
-func (mr *MockapplicationMockRecorder) ListUserAvatars(ctx, session any) *gomock.Call {
+func (mr *MockapplicationMockRecorder) ListUserAvatars(ctx, session, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserAvatars", reflect.TypeOf((*Mockapplication)(nil).ListUserAvatars), ctx, session)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserAvatars", reflect.TypeOf((*Mockapplication)(nil).ListUserAvatars), ctx, session, userID)
 }
 
 // ListUserByFilters mocks base method.
@@ -161,6 +297,79 @@ func (mr *MockapplicationMockRecorder) RemoveAvatar(ctx, session, fileID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAvatar", reflect.TypeOf((*Mockapplication)(nil).RemoveAvatar), ctx, session, fileID)
 }
 
+// ReorderAvatars mocks base method.
+func (m *Mockapplication) ReorderAvatars(ctx context.Context, session dom.Session, fileIDs []uuid.UUID) ([]app.AvatarInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderAvatars", ctx, session, fileIDs)
+	ret0, _ := ret[0].([]app.AvatarInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderAvatars indicates an expected call of ReorderAvatars.
+func (mr *MockapplicationMockRecorder) ReorderAvatars(ctx, session, fileIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderAvatars", reflect.TypeOf((*Mockapplication)(nil).ReorderAvatars), ctx, session, fileIDs)
+}
+
+// ResolveUsername mocks base method.
+func (m *Mockapplication) ResolveUsername(ctx context.Context, session dom.Session, username string) (*app.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveUsername", ctx, session, username)
+	ret0, _ := ret[0].(*app.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveUsername indicates an expected call of ResolveUsername.
+func (mr *MockapplicationMockRecorder) ResolveUsername(ctx, session, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveUsername", reflect.TypeOf((*Mockapplication)(nil).ResolveUsername), ctx, session, username)
+}
+
+// RetryDeadTask mocks base method.
+func (m *Mockapplication) RetryDeadTask(ctx context.Context, session dom.Session, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDeadTask", ctx, session, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryDeadTask indicates an expected call of RetryDeadTask.
+func (mr *MockapplicationMockRecorder) RetryDeadTask(ctx, session, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDeadTask", reflect.TypeOf((*Mockapplication)(nil).RetryDeadTask), ctx, session, id)
+}
+
+// SetCurrentAvatar mocks base method.
+func (m *Mockapplication) SetCurrentAvatar(ctx context.Context, session dom.Session, fileID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCurrentAvatar", ctx, session, fileID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCurrentAvatar indicates an expected call of SetCurrentAvatar.
+func (mr *MockapplicationMockRecorder) SetCurrentAvatar(ctx, session, fileID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentAvatar", reflect.TypeOf((*Mockapplication)(nil).SetCurrentAvatar), ctx, session, fileID)
+}
+
+// SetPreferences mocks base method.
+func (m *Mockapplication) SetPreferences(ctx context.Context, session dom.Session, prefs []app.Preference) ([]app.Preference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreferences", ctx, session, prefs)
+	ret0, _ := ret[0].([]app.Preference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPreferences indicates an expected call of SetPreferences.
+func (mr *MockapplicationMockRecorder) SetPreferences(ctx, session, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreferences", reflect.TypeOf((*Mockapplication)(nil).SetPreferences), ctx, session, prefs)
+}
+
 // UpdatePassword mocks base method.
 func (m *Mockapplication) UpdatePassword(ctx context.Context, session dom.Session, oldPass, newPass string) error {
 	m.ctrl.T.Helper()
@@ -175,18 +384,33 @@ func (mr *MockapplicationMockRecorder) UpdatePassword(ctx, session, oldPass, new
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*Mockapplication)(nil).UpdatePassword), ctx, session, oldPass, newPass)
 }
 
+// UpdatePrivacySettings mocks base method.
+func (m *Mockapplication) UpdatePrivacySettings(ctx context.Context, session dom.Session, settings app.PrivacySettings) (*app.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrivacySettings", ctx, session, settings)
+	ret0, _ := ret[0].(*app.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrivacySettings indicates an expected call of UpdatePrivacySettings.
+func (mr *MockapplicationMockRecorder) UpdatePrivacySettings(ctx, session, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrivacySettings", reflect.TypeOf((*Mockapplication)(nil).UpdatePrivacySettings), ctx, session, settings)
+}
+
 // UpdateUser mocks base method.
-func (m *Mockapplication) UpdateUser(ctx context.Context, session dom.Session, username string, avatarID uuid.UUID) error {
+func (m *Mockapplication) UpdateUser(ctx context.Context, session dom.Session, username string, avatarID uuid.UUID, profile *app.Profile) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", ctx, session, username, avatarID)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, session, username, avatarID, profile)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockapplicationMockRecorder) UpdateUser(ctx, session, username, avatarID any) *gomock.Call {
+func (mr *MockapplicationMockRecorder) UpdateUser(ctx, session, username, avatarID, profile any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*Mockapplication)(nil).UpdateUser), ctx, session, username, avatarID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*Mockapplication)(nil).UpdateUser), ctx, session, username, avatarID, profile)
 }
 
 // UserByID mocks base method.
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/mux"
@@ -79,6 +80,14 @@ func (a *api) uploadAvatar(w http.ResponseWriter, r *http.Request) {
 	case errors.Is(err, app.ErrInvalidImageFormat):
 		errorHandler(w, r, http.StatusBadRequest, err)
 
+		return
+	case errors.Is(err, app.ErrQuarantined):
+		errorHandler(w, r, http.StatusUnprocessableEntity, err)
+
+		return
+	case errors.Is(err, app.ErrQuotaExceeded):
+		errorHandler(w, r, http.StatusRequestEntityTooLarge, err)
+
 		return
 	default:
 		errorHandler(w, r, http.StatusInternalServerError, err)
@@ -103,7 +112,18 @@ func (a *api) downloadAvatar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	file, err := a.app.GetFile(r.Context(), *userSession, fileID)
+	size := 0
+	if s := r.URL.Query().Get("size"); s != "" {
+		var err error
+		size, err = strconv.Atoi(s)
+		if err != nil || size <= 0 {
+			errorHandler(w, r, http.StatusBadRequest, ErrInvalidArgument)
+
+			return
+		}
+	}
+
+	file, err := a.app.GetFile(r.Context(), *userSession, fileID, size)
 	switch {
 	case err == nil:
 		http.ServeContent(w, r, file.Name, file.ModTime, file.ReadSeekCloser)
@@ -118,6 +138,10 @@ func (a *api) downloadAvatar(w http.ResponseWriter, r *http.Request) {
 	case errors.Is(err, app.ErrNotFound):
 		errorHandler(w, r, http.StatusNotFound, err)
 
+		return
+	case errors.Is(err, app.ErrInvalidArgument):
+		errorHandler(w, r, http.StatusBadRequest, err)
+
 		return
 	default:
 		errorHandler(w, r, http.StatusInternalServerError, err)
@@ -4,6 +4,7 @@ package http
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gofrs/uuid"
@@ -20,11 +21,17 @@ var (
 	ErrBadAuthorizationString     = errors.New("bad authorization string")
 	ErrInvalidArgument            = errors.New("invalid argument")
 	ErrMaxAvatarSize              = errors.New("max file size 25 mb")
+	ErrUnsupportedTusVersion      = errors.New("unsupported tus version")
+	ErrInvalidContentType         = errors.New("invalid content type")
 )
 
 type application interface {
 	SaveAvatar(ctx context.Context, session dom.Session, file app.Avatar) (uuid.UUID, error)
-	GetFile(ctx context.Context, session dom.Session, fileID uuid.UUID) (*app.Avatar, error)
+	GetFile(ctx context.Context, session dom.Session, fileID uuid.UUID, size int) (*app.Avatar, error)
+	CreateResumableAvatarUpload(ctx context.Context, session dom.Session, name, contentType string, size int64) (*app.AvatarUpload, error)
+	GetAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) (*app.AvatarUpload, error)
+	WriteAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID, offset int64, chunk io.Reader) (*app.AvatarUpload, uuid.UUID, error)
+	RemoveAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) error
 	Auth(ctx context.Context, token string) (*dom.Session, error)
 }
 
@@ -49,6 +56,15 @@ func New(ctx context.Context, applications application) http.Handler {
 	)
 
 	router.HandleFunc("/user/api/v1/file/avatar", api.uploadAvatar).Methods(http.MethodPost)
+
+	uploads := router.PathPrefix(uploadsPath).Subrouter()
+	uploads.Use(TusResumable)
+	uploads.HandleFunc("", api.uploadOptions).Methods(http.MethodOptions)
+	uploads.HandleFunc("", api.createUpload).Methods(http.MethodPost)
+	uploads.HandleFunc("/{id}", api.uploadOffset).Methods(http.MethodHead)
+	uploads.HandleFunc("/{id}", api.writeUpload).Methods(http.MethodPatch)
+	uploads.HandleFunc("/{id}", api.removeUpload).Methods(http.MethodDelete)
+
 	router.HandleFunc("/user/api/v1/file/avatar/{id}", api.downloadAvatar).Methods(http.MethodGet)
 
 	return router
@@ -123,3 +123,19 @@ func SetSessionToCtx(app application) mux.MiddlewareFunc {
 		})
 	}
 }
+
+// TusResumable sets version of tus protocol to responses and rejects requests of unsupported versions.
+func TusResumable(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerTusResumable, tusVersion)
+
+		if r.Method != http.MethodOptions && r.Header.Get(headerTusResumable) != tusVersion {
+			w.Header().Set(headerTusVersion, tusVersion)
+			errorHandler(w, r, http.StatusPreconditionFailed, ErrUnsupportedTusVersion)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
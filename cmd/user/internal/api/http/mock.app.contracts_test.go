@@ -10,6 +10,7 @@ package http_test
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	app "github.com/ZergsLaw/back-template1/cmd/user/internal/app"
@@ -56,19 +57,63 @@ func (mr *MockapplicationMockRecorder) Auth(ctx, token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*Mockapplication)(nil).Auth), ctx, token)
 }
 
+// CreateResumableAvatarUpload mocks base method.
+func (m *Mockapplication) CreateResumableAvatarUpload(ctx context.Context, session dom.Session, name, contentType string, size int64) (*app.AvatarUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResumableAvatarUpload", ctx, session, name, contentType, size)
+	ret0, _ := ret[0].(*app.AvatarUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResumableAvatarUpload indicates an expected call of CreateResumableAvatarUpload.
+func (mr *MockapplicationMockRecorder) CreateResumableAvatarUpload(ctx, session, name, contentType, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResumableAvatarUpload", reflect.TypeOf((*Mockapplication)(nil).CreateResumableAvatarUpload), ctx, session, name, contentType, size)
+}
+
+// GetAvatarUpload mocks base method.
+func (m *Mockapplication) GetAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) (*app.AvatarUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarUpload", ctx, session, uploadID)
+	ret0, _ := ret[0].(*app.AvatarUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarUpload indicates an expected call of GetAvatarUpload.
+func (mr *MockapplicationMockRecorder) GetAvatarUpload(ctx, session, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarUpload", reflect.TypeOf((*Mockapplication)(nil).GetAvatarUpload), ctx, session, uploadID)
+}
+
 // GetFile mocks base method.
-func (m *Mockapplication) GetFile(ctx context.Context, session dom.Session, fileID uuid.UUID) (*app.Avatar, error) {
+func (m *Mockapplication) GetFile(ctx context.Context, session dom.Session, fileID uuid.UUID, size int) (*app.Avatar, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFile", ctx, session, fileID)
+	ret := m.ctrl.Call(m, "GetFile", ctx, session, fileID, size)
 	ret0, _ := ret[0].(*app.Avatar)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFile indicates an expected call of GetFile.
-func (mr *MockapplicationMockRecorder) GetFile(ctx, session, fileID any) *gomock.Call {
+func (mr *MockapplicationMockRecorder) GetFile(ctx, session, fileID, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*Mockapplication)(nil).GetFile), ctx, session, fileID, size)
+}
+
+// RemoveAvatarUpload mocks base method.
+func (m *Mockapplication) RemoveAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAvatarUpload", ctx, session, uploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAvatarUpload indicates an expected call of RemoveAvatarUpload.
+func (mr *MockapplicationMockRecorder) RemoveAvatarUpload(ctx, session, uploadID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*Mockapplication)(nil).GetFile), ctx, session, fileID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAvatarUpload", reflect.TypeOf((*Mockapplication)(nil).RemoveAvatarUpload), ctx, session, uploadID)
 }
 
 // SaveAvatar mocks base method.
@@ -85,3 +130,19 @@ func (mr *MockapplicationMockRecorder) SaveAvatar(ctx, session, file any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAvatar", reflect.TypeOf((*Mockapplication)(nil).SaveAvatar), ctx, session, file)
 }
+
+// WriteAvatarUpload mocks base method.
+func (m *Mockapplication) WriteAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID, offset int64, chunk io.Reader) (*app.AvatarUpload, uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteAvatarUpload", ctx, session, uploadID, offset, chunk)
+	ret0, _ := ret[0].(*app.AvatarUpload)
+	ret1, _ := ret[1].(uuid.UUID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WriteAvatarUpload indicates an expected call of WriteAvatarUpload.
+func (mr *MockapplicationMockRecorder) WriteAvatarUpload(ctx, session, uploadID, offset, chunk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteAvatarUpload", reflect.TypeOf((*Mockapplication)(nil).WriteAvatarUpload), ctx, session, uploadID, offset, chunk)
+}
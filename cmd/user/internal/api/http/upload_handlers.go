@@ -0,0 +1,234 @@
+package http
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/adapters/session"
+)
+
+// Resumable uploads follow core tus protocol 1.0.0 with creation, expiration and termination extensions.
+// See https://tus.io/protocols/resumable-upload.
+const (
+	uploadsPath = "/user/api/v1/file/avatar/uploads"
+
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,expiration,termination"
+
+	headerTusResumable  = "Tus-Resumable"
+	headerTusVersion    = "Tus-Version"
+	headerTusExtension  = "Tus-Extension"
+	headerTusMaxSize    = "Tus-Max-Size"
+	headerUploadLength  = "Upload-Length"
+	headerUploadOffset  = "Upload-Offset"
+	headerUploadExpires = "Upload-Expires"
+	headerUploadMeta    = "Upload-Metadata"
+	// headerUploadFileID contains id of avatar made from completed upload.
+	headerUploadFileID = "Upload-File-Id"
+
+	contentTypeOffset = "application/offset+octet-stream"
+)
+
+func (*api) uploadOptions(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set(headerTusVersion, tusVersion)
+	w.Header().Set(headerTusExtension, tusExtensions)
+	w.Header().Set(headerTusMaxSize, strconv.Itoa(maxAvatarSize))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) createUpload(w http.ResponseWriter, r *http.Request) {
+	userSession := session.FromContext(r.Context())
+	if userSession == nil {
+		errorHandler(w, r, http.StatusUnauthorized, ErrUserUnauthorized)
+		return
+	}
+
+	size, err := strconv.ParseInt(r.Header.Get(headerUploadLength), 10, 64)
+	if err != nil || size <= 0 {
+		errorHandler(w, r, http.StatusBadRequest, ErrInvalidArgument)
+		return
+	}
+
+	if size > maxAvatarSize {
+		errorHandler(w, r, http.StatusRequestEntityTooLarge, ErrMaxAvatarSize)
+		return
+	}
+
+	meta, err := parseUploadMetadata(r.Header.Get(headerUploadMeta))
+	if err != nil {
+		errorHandler(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, err := a.app.CreateResumableAvatarUpload(r.Context(), *userSession, meta["filename"], meta["filetype"], size)
+	switch {
+	case err == nil:
+		w.Header().Set("Location", uploadsPath+"/"+upload.ID.String())
+		w.Header().Set(headerUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusCreated)
+		return
+	case errors.Is(err, app.ErrInvalidImageFormat), errors.Is(err, app.ErrInvalidArgument), errors.Is(err, app.ErrMaxFiles):
+		errorHandler(w, r, http.StatusBadRequest, err)
+		return
+	case errors.Is(err, app.ErrQuotaExceeded):
+		errorHandler(w, r, http.StatusRequestEntityTooLarge, err)
+		return
+	default:
+		errorHandler(w, r, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (a *api) uploadOffset(w http.ResponseWriter, r *http.Request) {
+	userSession := session.FromContext(r.Context())
+	if userSession == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uploadID := uuid.FromStringOrNil(mux.Vars(r)["id"])
+	if uploadID == uuid.Nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	upload, err := a.app.GetAvatarUpload(r.Context(), *userSession, uploadID)
+	switch {
+	case err == nil:
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set(headerUploadOffset, strconv.FormatInt(upload.Offset, 10))
+		w.Header().Set(headerUploadLength, strconv.FormatInt(upload.Size, 10))
+		w.Header().Set(headerUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		return
+	case errors.Is(err, app.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case errors.Is(err, app.ErrAccessDenied):
+		w.WriteHeader(http.StatusForbidden)
+		return
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *api) writeUpload(w http.ResponseWriter, r *http.Request) {
+	userSession := session.FromContext(r.Context())
+	if userSession == nil {
+		errorHandler(w, r, http.StatusUnauthorized, ErrUserUnauthorized)
+		return
+	}
+
+	uploadID := uuid.FromStringOrNil(mux.Vars(r)["id"])
+	if uploadID == uuid.Nil {
+		errorHandler(w, r, http.StatusNotFound, app.ErrNotFound)
+		return
+	}
+
+	if r.Header.Get("Content-Type") != contentTypeOffset {
+		errorHandler(w, r, http.StatusUnsupportedMediaType, ErrInvalidContentType)
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get(headerUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		errorHandler(w, r, http.StatusBadRequest, ErrInvalidArgument)
+		return
+	}
+
+	upload, fileID, err := a.app.WriteAvatarUpload(r.Context(), *userSession, uploadID, offset, r.Body)
+	switch {
+	case err == nil:
+		if fileID != uuid.Nil {
+			w.Header().Set(headerUploadFileID, fileID.String())
+		}
+		w.Header().Set(headerUploadOffset, strconv.FormatInt(upload.Offset, 10))
+		w.Header().Set(headerUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case errors.Is(err, app.ErrInvalidOffset):
+		errorHandler(w, r, http.StatusConflict, err)
+		return
+	case errors.Is(err, app.ErrNotFound):
+		errorHandler(w, r, http.StatusNotFound, err)
+		return
+	case errors.Is(err, app.ErrAccessDenied):
+		errorHandler(w, r, http.StatusForbidden, err)
+		return
+	case errors.Is(err, app.ErrInvalidImageFormat), errors.Is(err, app.ErrInvalidArgument), errors.Is(err, app.ErrMaxFiles):
+		errorHandler(w, r, http.StatusBadRequest, err)
+		return
+	case errors.Is(err, app.ErrQuotaExceeded):
+		errorHandler(w, r, http.StatusRequestEntityTooLarge, err)
+		return
+	case errors.Is(err, app.ErrQuarantined):
+		errorHandler(w, r, http.StatusUnprocessableEntity, err)
+		return
+	default:
+		errorHandler(w, r, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (a *api) removeUpload(w http.ResponseWriter, r *http.Request) {
+	userSession := session.FromContext(r.Context())
+	if userSession == nil {
+		errorHandler(w, r, http.StatusUnauthorized, ErrUserUnauthorized)
+		return
+	}
+
+	uploadID := uuid.FromStringOrNil(mux.Vars(r)["id"])
+	if uploadID == uuid.Nil {
+		errorHandler(w, r, http.StatusNotFound, app.ErrNotFound)
+		return
+	}
+
+	err := a.app.RemoveAvatarUpload(r.Context(), *userSession, uploadID)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case errors.Is(err, app.ErrNotFound):
+		errorHandler(w, r, http.StatusNotFound, err)
+		return
+	case errors.Is(err, app.ErrAccessDenied):
+		errorHandler(w, r, http.StatusForbidden, err)
+		return
+	default:
+		errorHandler(w, r, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+// parseUploadMetadata parses Upload-Metadata header containing comma separated pairs of key and base64 encoded value.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	meta := make(map[string]string)
+	if header == "" {
+		return meta, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, fmt.Errorf("metadata key: %w", ErrInvalidArgument)
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("metadata %q: %w", key, ErrInvalidArgument)
+		}
+
+		meta[key] = string(decoded)
+	}
+
+	return meta, nil
+}
@@ -1,22 +1,115 @@
 // Package app contains business logic.
 package app
 
-// App manages business logic methods.
-type App struct {
-	repo     Repo
-	hash     PasswordHash
-	sessions Sessions
-	file     FileStore
-	queue    Queue
-}
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+type (
+	// App manages business logic methods.
+	App struct {
+		repo     Repo
+		hash     PasswordHash
+		sessions Sessions
+		file     FileStore
+		image    ImageProcessor
+		scanner  Scanner
+		queue    Queue
+		metrics  Metrics
+		cfg      Config
+		reserved map[string]struct{}
+		workerID string
+	}
+
+	// Config contains business logic settings.
+	Config struct {
+		// ReservedUsernames can't be taken by users.
+		ReservedUsernames []string
+		// UsernameCooldown is period during which released username stays held by previous owner.
+		UsernameCooldown time.Duration
+		// PresignedURLTTL is lifetime of URLs giving direct access to file store.
+		PresignedURLTTL time.Duration
+		// ResumableUploadTTL is lifetime of resumable uploads; abandoned uploads are removed after it.
+		ResumableUploadTTL time.Duration
+		// AvatarsReconcileInterval is period of comparing avatars with file store, zero disables it.
+		AvatarsReconcileInterval time.Duration
+		// StorageQuotas limits bytes taken by avatars of users by their status.
+		// Statuses without quota use quota of dom.UserStatusDefault, zero quota means no limit.
+		StorageQuotas map[dom.UserStatus]int64
+		// TaskLease is period during which claimed task can't be taken by other replicas.
+		// Tasks of crashed replicas are handled by others after it.
+		TaskLease time.Duration
+		// TaskMaxAttempts is amount of attempts to handle task before moving it to dead-letter state.
+		TaskMaxAttempts int
+		// TaskRetryBackoff is delay after first failed attempt, it's doubled after every next one.
+		TaskRetryBackoff time.Duration
+		// TaskRetryMaxBackoff limits delay between attempts.
+		TaskRetryMaxBackoff time.Duration
+		// TaskPollInterval is period of polling tasks, it may be long if repository notifies about new tasks.
+		TaskPollInterval time.Duration
+		// TaskWorkers is amount of tasks published concurrently, tasks of the same user are published in order.
+		TaskWorkers int
+		// TaskRetention is period during which finished tasks are kept.
+		TaskRetention time.Duration
+		// TaskArchive moves finished tasks to archive after retention period instead of removing them.
+		TaskArchive bool
+	}
+)
+
+const (
+	defaultTaskLease           = 30 * time.Second
+	defaultTaskMaxAttempts     = 10
+	defaultTaskRetryBackoff    = time.Second
+	defaultTaskRetryMaxBackoff = 10 * time.Minute
+	defaultTaskWorkers         = 8
+	defaultTaskPollInterval    = time.Second / 10
+	defaultTaskRetention       = 7 * 24 * time.Hour
+)
 
 // New build and returns new App.
-func New(r Repo, ph PasswordHash, a Sessions, f FileStore, q Queue) *App {
+func New(r Repo, ph PasswordHash, a Sessions, f FileStore, img ImageProcessor, s Scanner, q Queue, m Metrics, cfg Config) *App {
+	reserved := make(map[string]struct{}, len(cfg.ReservedUsernames))
+	for _, username := range cfg.ReservedUsernames {
+		reserved[NormalizeUsername(username)] = struct{}{}
+	}
+
+	if cfg.TaskLease <= 0 {
+		cfg.TaskLease = defaultTaskLease
+	}
+	if cfg.TaskMaxAttempts <= 0 {
+		cfg.TaskMaxAttempts = defaultTaskMaxAttempts
+	}
+	if cfg.TaskRetryBackoff <= 0 {
+		cfg.TaskRetryBackoff = defaultTaskRetryBackoff
+	}
+	if cfg.TaskRetryMaxBackoff <= 0 {
+		cfg.TaskRetryMaxBackoff = defaultTaskRetryMaxBackoff
+	}
+	if cfg.TaskPollInterval <= 0 {
+		cfg.TaskPollInterval = defaultTaskPollInterval
+	}
+	if cfg.TaskWorkers <= 0 {
+		cfg.TaskWorkers = defaultTaskWorkers
+	}
+	if cfg.TaskRetention <= 0 {
+		cfg.TaskRetention = defaultTaskRetention
+	}
+
 	return &App{
 		repo:     r,
 		hash:     ph,
 		sessions: a,
 		file:     f,
+		image:    img,
+		scanner:  s,
 		queue:    q,
+		metrics:  m,
+		cfg:      cfg,
+		reserved: reserved,
+		workerID: uuid.Must(uuid.NewV4()).String(),
 	}
 }
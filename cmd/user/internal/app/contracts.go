@@ -2,6 +2,9 @@ package app
 
 import (
 	"context"
+	"io"
+	"net/url"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -13,6 +16,10 @@ type (
 	Repo interface {
 		FileInfoRepo
 		TaskRepo
+		PrivacyRepo
+		PreferenceRepo
+		UsernameHistoryRepo
+		AvatarUploadRepo
 		// Tx starts transaction in database.
 		// Errors: unknown.
 		Tx(ctx context.Context, f func(Repo) error) error
@@ -37,10 +44,18 @@ type (
 		// UsersByIDs returns list of users.
 		// Errors: ErrNotFound, unknown.
 		UsersByIDs(ctx context.Context, ids []uuid.UUID) (users []User, err error)
+		// UsersByKeys returns list of users matched by any of ids, usernames or emails.
+		// Errors: unknown.
+		UsersByKeys(ctx context.Context, keys BatchKeys) (users []User, err error)
+		// LeaseJob takes lease of background job to worker and reports if it's taken.
+		// Lease held by other worker isn't taken until it's over, worker holding lease extends it.
+		// Errors: unknown.
+		LeaseJob(ctx context.Context, job, workerID string, lease time.Duration) (bool, error)
 	}
 	// FileInfoRepo provides to file info repository
 	FileInfoRepo interface {
 		// SaveAvatar adds to the new cache about user avatar to repository.
+		// Avatar is placed to the start of user's gallery, so the newest avatar goes first.
 		// Errors: ErrUserIDAndFileIDExist, ErrMaximumNumberOfStoredFilesReached, unknown.
 		SaveAvatar(ctx context.Context, fileCache AvatarInfo) error
 		// DeleteAvatar delete cache about user avatar info.
@@ -49,12 +64,55 @@ type (
 		// GetAvatar returns cache about user avatar by id.
 		// Errors: ErrNotFound, unknown.
 		GetAvatar(ctx context.Context, fileID uuid.UUID) (*AvatarInfo, error)
-		// ListAvatarByUserID returns list cache user file.
+		// ListAvatarByUserID returns list cache user file including quarantined ordered by position (asc).
 		// Errors: unknown.
 		ListAvatarByUserID(ctx context.Context, userID uuid.UUID) ([]AvatarInfo, error)
-		// GetCountAvatars returns count user avatars.
+		// GetCountAvatars returns count of user's clean avatars, quarantined and missing avatars aren't counted.
 		// Errors: ErrNotFound, unknown.
 		GetCountAvatars(ctx context.Context, ownerID uuid.UUID) (total int, err error)
+		// ListAvatars returns avatars of all users with id greater than after ordered by id (asc).
+		// Errors: unknown.
+		ListAvatars(ctx context.Context, after uuid.UUID, limit int) ([]AvatarInfo, error)
+		// SetAvatarStatus updates status of avatar.
+		// Errors: unknown.
+		SetAvatarStatus(ctx context.Context, fileID uuid.UUID, status AvatarStatus) error
+		// SetAvatarPosition updates position of avatar in user's gallery.
+		// Errors: unknown.
+		SetAvatarPosition(ctx context.Context, fileID uuid.UUID, position int) error
+		// GetStorageUsage returns amount of bytes taken by user's avatars.
+		// Errors: unknown.
+		GetStorageUsage(ctx context.Context, userID uuid.UUID) (usedBytes int64, err error)
+		// AddStorageUsage changes amount of bytes taken by user's avatars by delta and returns new amount.
+		// Errors: unknown.
+		AddStorageUsage(ctx context.Context, userID uuid.UUID, delta int64) (usedBytes int64, err error)
+	}
+
+	// AvatarUploadRepo provides to avatars uploading directly to file store.
+	AvatarUploadRepo interface {
+		// SaveAvatarUpload adds new avatar upload.
+		// Errors: ErrNotFound, unknown.
+		SaveAvatarUpload(ctx context.Context, upload AvatarUpload) error
+		// GetAvatarUpload returns avatar upload by id.
+		// Errors: ErrNotFound, unknown.
+		GetAvatarUpload(ctx context.Context, id uuid.UUID) (*AvatarUpload, error)
+		// DeleteAvatarUpload removes avatar upload by id.
+		// Errors: unknown.
+		DeleteAvatarUpload(ctx context.Context, id uuid.UUID) error
+		// LeaseAvatarUpload takes lease of resumable avatar upload to writer and reports if it's taken.
+		// Lease isn't taken if upload has received other count of bytes than offset
+		// or it's held by other writer until it's over.
+		// Errors: unknown.
+		LeaseAvatarUpload(ctx context.Context, id uuid.UUID, offset int64, writerID string, lease time.Duration) (bool, error)
+		// SetAvatarUploadOffset updates count of bytes received by resumable avatar upload
+		// and releases lease of writer.
+		// Errors: ErrNotFound if lease isn't held by writer, unknown.
+		SetAvatarUploadOffset(ctx context.Context, id uuid.UUID, writerID string, offset int64) error
+		// CompleteAvatarUpload sets id of avatar made from avatar upload.
+		// Errors: unknown.
+		CompleteAvatarUpload(ctx context.Context, id, avatarID uuid.UUID) error
+		// ListExpiredAvatarUploads returns avatar uploads expired before given time ordered by expires_at (asc).
+		// Errors: unknown.
+		ListExpiredAvatarUploads(ctx context.Context, before time.Time, limit int) ([]AvatarUpload, error)
 	}
 
 	// FileStore interface for saving and getting files.
@@ -65,9 +123,63 @@ type (
 		// DownloadFile get file by id.
 		// Errors: unknown.
 		DownloadFile(ctx context.Context, id uuid.UUID) (*Avatar, error)
-		// DeleteFile delete file by id.
+		// UploadThumbnail save thumbnail of file with id.
+		// Errors: unknown.
+		UploadThumbnail(ctx context.Context, id uuid.UUID, t Thumbnail) error
+		// DownloadThumbnail get thumbnail of file by id and size.
+		// Errors: ErrNotFound, unknown.
+		DownloadThumbnail(ctx context.Context, id uuid.UUID, size int) (*Avatar, error)
+		// DeleteFile delete file by id with all its thumbnails.
 		// Errors: unknown.
 		DeleteFile(ctx context.Context, id uuid.UUID) error
+		// UploadURL returns presigned URL for uploading file by id.
+		// Request must contain exactly given content type and size.
+		// Errors: unknown.
+		UploadURL(ctx context.Context, id uuid.UUID, contentType string, size int64, expires time.Duration) (*url.URL, error)
+		// DownloadUpload get file uploaded by UploadURL.
+		// Errors: ErrNotFound, unknown.
+		DownloadUpload(ctx context.Context, id uuid.UUID) (*Avatar, error)
+		// DeleteUpload delete file uploaded by UploadURL.
+		// Errors: unknown.
+		DeleteUpload(ctx context.Context, id uuid.UUID) error
+		// DownloadURL returns presigned URL for downloading file by id.
+		// If size isn't zero, URL points to thumbnail of this size.
+		// Errors: ErrNotFound, unknown.
+		DownloadURL(ctx context.Context, id uuid.UUID, size int, expires time.Duration) (*url.URL, error)
+		// CreateMultipartUpload starts resumable upload of file by id and returns its multipart id.
+		// Completed upload is available by DownloadUpload.
+		// Errors: unknown.
+		CreateMultipartUpload(ctx context.Context, id uuid.UUID, contentType string) (multipartID string, err error)
+		// AppendMultipartUpload writes data to resumable upload starting from offset.
+		// Data written after offset by previous calls is replaced, so repeated call with the same offset is safe.
+		// Returns count of stored bytes even in case of error.
+		// Errors: ErrNotFound, ErrInvalidOffset, unknown.
+		AppendMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string, offset int64, r io.Reader) (int64, error)
+		// CompleteMultipartUpload finishes resumable upload, data after size is dropped.
+		// Errors: ErrNotFound, unknown.
+		CompleteMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string, size int64) error
+		// AbortMultipartUpload removes resumable upload with all received data.
+		// Errors: unknown.
+		AbortMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string) error
+		// ListFiles returns up to limit files uploaded by UploadFile with id greater than after ordered by id (asc).
+		// Errors: unknown.
+		ListFiles(ctx context.Context, after uuid.UUID, limit int) ([]StoredFile, error)
+	}
+
+	// Scanner checks uploaded files for malicious content.
+	Scanner interface {
+		// Scan returns ErrQuarantined if file mustn't become visible for users.
+		// Errors: ErrQuarantined, unknown.
+		Scan(ctx context.Context, f Avatar) error
+	}
+
+	// ImageProcessor prepares uploaded images.
+	ImageProcessor interface {
+		// Avatar decodes image, strips its metadata and centre-crops it to square.
+		// Image must match Avatar.ContentType and be within configured dimensions.
+		// Returns re-encoded square image and its thumbnails by sizes.
+		// Errors: ErrInvalidImageFormat, unknown.
+		Avatar(ctx context.Context, f Avatar, sizes []int) (*Avatar, []Thumbnail, error)
 	}
 
 	// PasswordHash module responsible for hashing password.
@@ -79,18 +191,89 @@ type (
 		Compare(hashedPassword []byte, password []byte) bool
 	}
 
+	// PrivacyRepo provides to user's privacy settings repository.
+	PrivacyRepo interface {
+		// SavePrivacySettings creates or replaces user's privacy settings.
+		// Errors: ErrNotFound, unknown.
+		SavePrivacySettings(ctx context.Context, settings PrivacySettings) (*PrivacySettings, error)
+		// ListPrivacySettings returns privacy settings of users.
+		// Users without saved settings are absent in result.
+		// Errors: unknown.
+		ListPrivacySettings(ctx context.Context, userIDs []uuid.UUID) ([]PrivacySettings, error)
+	}
+
+	// PreferenceRepo provides to user's preferences repository.
+	PreferenceRepo interface {
+		// SavePreferences creates or replaces user's preferences by key.
+		// Errors: ErrNotFound, unknown.
+		SavePreferences(ctx context.Context, prefs []Preference) error
+		// ListPreferences returns saved user's preferences.
+		// Errors: unknown.
+		ListPreferences(ctx context.Context, userID uuid.UUID) ([]Preference, error)
+	}
+
+	// UsernameHistoryRepo provides to history of usernames released by users.
+	UsernameHistoryRepo interface {
+		// SaveUsernameChange adds username released by user to history.
+		// Errors: ErrNotFound, unknown.
+		SaveUsernameChange(ctx context.Context, change UsernameChange) error
+		// LastUsernameChange returns the latest release of username made after since.
+		// Errors: ErrNotFound, unknown.
+		LastUsernameChange(ctx context.Context, username string, since time.Time) (*UsernameChange, error)
+		// ListUnnormalizedUsers returns the oldest users without normalized username, which are left by migrations.
+		// Errors: unknown.
+		ListUnnormalizedUsers(ctx context.Context, limit int) ([]User, error)
+		// NormalizeUsernameHistory sets normalized usernames of history, which are left empty by migrations.
+		// Errors: unknown.
+		NormalizeUsernameHistory(ctx context.Context) error
+	}
+
 	// TaskRepo interface for saving tasks.
 	TaskRepo interface {
 		// SaveTask adds new task to repository.
 		// Errors: unknown.
 		SaveTask(context.Context, Task) (uuid.UUID, error)
-		// FinishTask set column Task.FinishedAt task.
+		// FinishTasks set column Task.FinishedAt for all tasks leased by worker by one query.
+		// Tasks leased by other workers after the lease of worker is over aren't changed.
+		// Errors: unknown.
+		FinishTasks(ctx context.Context, workerID string, ids []uuid.UUID) error
+		// ClaimTasks leases up to limit unfinished tasks to worker and returns them ordered by created_at (asc).
+		// Tasks leased by other workers are skipped until their lease is over,
+		// so every task is handled by one worker at a time.
+		// Tasks of user aren't claimed while previous task of the same user is leased or waits for retry.
+		// Errors: unknown.
+		ClaimTasks(ctx context.Context, workerID string, lease time.Duration, limit int) ([]Task, error)
+		// ListenTasks sends to notify when new tasks may be saved until ctx is canceled.
+		// Notifications are merged while notify is busy, so it should be buffered.
+		// Nothing is sent if repository has no notifications, tasks are found by polling then.
+		// Errors: unknown.
+		ListenTasks(ctx context.Context, notify chan<- struct{}) error
+		// GetTaskBacklog returns count of unfinished and dead tasks and age of the oldest unfinished one.
+		// Errors: unknown.
+		GetTaskBacklog(ctx context.Context) (*TaskBacklog, error)
+		// DeleteFinishedTasks removes up to limit tasks finished before given time and returns their count.
+		// Errors: unknown.
+		DeleteFinishedTasks(ctx context.Context, before time.Time, limit int) (int, error)
+		// ArchiveFinishedTasks moves up to limit tasks finished before given time to archive and returns their count.
 		// Errors: unknown.
-		FinishTask(context.Context, uuid.UUID) error
-		// ListActualTask returns list task by limit and ordered by created_at (ask).
-		// Return tasks without Task.FinishedAt.
+		ArchiveFinishedTasks(ctx context.Context, before time.Time, limit int) (int, error)
+		// FailTask records failed attempt to handle task leased by worker and postpones next attempt by delay.
+		// Task lease is released. Task leased by other worker after the lease of worker is over isn't changed.
 		// Errors: unknown.
-		ListActualTask(context.Context, int) ([]Task, error)
+		FailTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string, delay time.Duration) error
+		// KillTask records failed attempt to handle task leased by worker and moves task to dead-letter state.
+		// Dead tasks aren't claimed. Task leased by other worker after the lease of worker is over isn't changed.
+		// Errors: unknown.
+		KillTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string) error
+		// ListDeadTasks returns dead tasks ordered by Task.DeadAt (desc) and total amount of them.
+		// Errors: unknown.
+		ListDeadTasks(ctx context.Context, limit, offset int) ([]Task, int, error)
+		// RetryDeadTask moves dead task back to processing with reset attempts.
+		// Errors: ErrNotFound, unknown.
+		RetryDeadTask(ctx context.Context, id uuid.UUID) error
+		// DiscardDeadTask removes dead task.
+		// Errors: ErrNotFound, unknown.
+		DiscardDeadTask(ctx context.Context, id uuid.UUID) error
 	}
 
 	// Sessions module for manager user's session.
@@ -107,15 +290,52 @@ type (
 	}
 
 	// Queue sends events to queue.
+	// Events are sent asynchronously, events sent one after another are stored in the same order.
+	// Every event gets id of message and time it occurred at, they are id and creation time of task.
 	Queue interface {
 		// AddUser sends event 'EventAdd' to queue.
 		// Errors: unknown.
-		AddUser(context.Context, uuid.UUID, User) error
+		AddUser(context.Context, uuid.UUID, time.Time, User) (PublishAck, error)
 		// DeleteUser sends event 'EventDel' to queue.
 		// Errors: unknown.
-		DeleteUser(context.Context, uuid.UUID, User) error
+		DeleteUser(context.Context, uuid.UUID, time.Time, User) (PublishAck, error)
 		// UpdateUser sends event 'EventUpdate' to queue.
 		// Errors: unknown.
-		UpdateUser(context.Context, uuid.UUID, User) error
+		UpdateUser(context.Context, uuid.UUID, time.Time, User) (PublishAck, error)
+		// UpdatePreferences sends event 'EventPreferences' to queue.
+		// Errors: unknown.
+		UpdatePreferences(ctx context.Context, id uuid.UUID, occurredAt time.Time, userID uuid.UUID, prefs []Preference) (PublishAck, error)
+		// LoggedIn sends event 'EventLoggedIn' to queue.
+		// Errors: unknown.
+		LoggedIn(context.Context, uuid.UUID, time.Time, User, Activity) (PublishAck, error)
+		// LoggedOut sends event 'EventLoggedOut' to queue.
+		// Errors: unknown.
+		LoggedOut(context.Context, uuid.UUID, time.Time, User, Activity) (PublishAck, error)
+		// LoginFailed sends event 'EventLoginFailed' to queue.
+		// Errors: unknown.
+		LoginFailed(context.Context, uuid.UUID, time.Time, User, Activity) (PublishAck, error)
+		// PasswordChanged sends event 'EventPasswordChanged' to queue.
+		// Errors: unknown.
+		PasswordChanged(context.Context, uuid.UUID, time.Time, User) (PublishAck, error)
+		// AvatarChanged sends event 'EventAvatarChanged' to queue.
+		// Errors: unknown.
+		AvatarChanged(context.Context, uuid.UUID, time.Time, User, Activity) (PublishAck, error)
+	}
+
+	// PublishAck is confirmation of event sent to queue.
+	PublishAck interface {
+		// Wait blocks until event is stored by queue.
+		// Errors: unknown.
+		Wait(context.Context) error
+	}
+
+	// Metrics collects business metrics.
+	Metrics interface {
+		// AvatarsReconciled records result of avatars reconciliation.
+		AvatarsReconciled(report AvatarsReconciliation)
+		// TaskBacklog records state of tasks which aren't published yet.
+		TaskBacklog(backlog TaskBacklog)
+		// TasksRemoved records amount of finished tasks removed or archived by retention.
+		TasksRemoved(count int)
 	}
 )
@@ -1,11 +1,17 @@
 package app
 
 import (
+	"fmt"
 	"io"
+	"net/url"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofrs/uuid"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
 
 	"github.com/ZergsLaw/back-template1/internal/dom"
 )
@@ -20,10 +26,30 @@ type (
 		Email          string
 		StartCreatedAt time.Time
 		EndCreatedAt   time.Time
+		Mode           SearchMode
+		IncludeHidden  bool
 		Limit          uint64
 		Offset         uint64
 	}
 
+	// SearchMode represents mode of searching users.
+	SearchMode uint8
+
+	// BatchKeys contains keys for batch lookup of users.
+	BatchKeys struct {
+		IDs       []uuid.UUID
+		Usernames []string
+		Emails    []string
+	}
+
+	// BatchResult contains result of batch lookup of users.
+	BatchResult struct {
+		// Users found users keyed by requested id, username or email.
+		Users map[string]User
+		// Missing requested keys for which users were not found.
+		Missing []string
+	}
+
 	// User contains user information.
 	User struct {
 		ID        uuid.UUID
@@ -33,10 +59,35 @@ type (
 		PassHash  []byte
 		AvatarID  uuid.UUID
 		Status    dom.UserStatus
+		Profile   Profile
+		Privacy   PrivacySettings
 		CreatedAt time.Time
 		UpdatedAt time.Time
 	}
 
+	// Profile contains optional user's profile attributes.
+	Profile struct {
+		Bio string
+		// Locale BCP 47 language tag, for example "en-US".
+		Locale string
+		// Timezone IANA time zone name, for example "Europe/Moscow".
+		Timezone string
+		Birthday time.Time
+		Links    []string
+	}
+
+	// PrivacySettings contains user's profile visibility settings.
+	// Settings don't apply to the owner and specialists.
+	PrivacySettings struct {
+		UserID         uuid.UUID
+		HideEmail      bool
+		HideStatus     bool
+		HideAvatar     bool
+		HideFromSearch bool
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+	}
+
 	// Avatar contains file information.
 	Avatar struct {
 		ID          uuid.UUID
@@ -47,30 +98,139 @@ type (
 		io.ReadSeekCloser
 	}
 
+	// Thumbnail contains avatar scaled to square of Size pixels.
+	Thumbnail struct {
+		Size int
+		Avatar
+	}
 	// AvatarInfo struct for caching info for finding file.
 	AvatarInfo struct {
-		FileID    uuid.UUID
-		OwnerID   uuid.UUID
+		FileID  uuid.UUID
+		OwnerID uuid.UUID
+		Status  AvatarStatus
+		// Position is place of avatar in user's gallery starting from zero.
+		Position int
+		// Size is amount of bytes taken by avatar with thumbnails in file store.
+		Size      int64
 		CreatedAt time.Time
 		UpdatedAt time.Time
 	}
+	// StoredFile contains info about avatar file found in file store.
+	StoredFile struct {
+		ID      uuid.UUID
+		ModTime time.Time
+	}
+	// AvatarsReconciliation contains result of comparing avatars in repository with files in file store.
+	AvatarsReconciliation struct {
+		// Checked is count of avatars in repository.
+		Checked int
+		// Orphans is count of removed files without avatars in repository.
+		Orphans int
+		// Missing is count of avatars flagged as missing because their files are absent.
+		Missing int
+	}
+	// StorageUsage contains amount of bytes taken by user's avatars and user's quota.
+	// Zero QuotaBytes means no limit.
+	StorageUsage struct {
+		UsedBytes  int64
+		QuotaBytes int64
+	}
+	// AvatarUpload contains info about avatar uploaded by user directly to file store.
+	// MultipartID is empty for uploads by presigned URL.
+	// AvatarID is set when upload is completed, completed upload is kept until it expires.
+	AvatarUpload struct {
+		ID          uuid.UUID
+		OwnerID     uuid.UUID
+		Name        string
+		ContentType string
+		Size        int64
+		MultipartID string
+		Offset      int64
+		AvatarID    uuid.UUID
+		ExpiresAt   time.Time
+		CreatedAt   time.Time
+	}
+	// PresignedURL contains URL giving temporary access to file store without credentials.
+	PresignedURL struct {
+		URL       *url.URL
+		ExpiresAt time.Time
+	}
+
+	// PreferenceKind represents type of preference value.
+	PreferenceKind uint8
+
+	// Preference contains user's preference.
+	Preference struct {
+		UserID uuid.UUID
+		Key    string
+		Kind   PreferenceKind
+		// Value contains value in canonical text form of Kind.
+		Value     string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	// PreferenceSchema describes allowed preference.
+	PreferenceSchema struct {
+		Kind    PreferenceKind
+		Default string
+		// Values contains allowed values for PreferenceKindString.
+		// Any value is allowed if empty.
+		Values []string
+		// Min and Max limits value for PreferenceKindInt.
+		Min int64
+		Max int64
+	}
+
+	// UsernameChange contains username released by user on rename.
+	UsernameChange struct {
+		ID        uuid.UUID
+		UserID    uuid.UUID
+		Username  string
+		CreatedAt time.Time
+	}
 
 	// TaskKind represents kind of task.
 	TaskKind uint8
 
+	// Activity contains details of user's activity published by task.
+	Activity struct {
+		Origin       dom.Origin
+		SessionID    uuid.UUID
+		PrevAvatarID uuid.UUID
+	}
+
 	// Task contains information for executing any deferred logic.
 	Task struct {
-		ID         uuid.UUID
-		User       User
-		Kind       TaskKind
-		CreatedAt  time.Time
-		UpdatedAt  time.Time
-		FinishedAt time.Time
+		ID          uuid.UUID
+		User        User
+		Kind        TaskKind
+		Preferences []Preference
+		Activity    Activity
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		FinishedAt  time.Time
+		Attempts    int // Amount of failed attempts to handle task.
+		NextAttempt time.Time
+		LastError   string
+		DeadAt      time.Time // Set when task is moved to dead-letter state.
+	}
+	// TaskBacklog describes tasks which aren't published yet.
+	TaskBacklog struct {
+		// Unfinished is count of tasks waiting for publishing, including retried ones.
+		Unfinished int
+		// Dead is count of tasks in dead-letter state.
+		Dead int
+		// OldestUnfinished is creation time of the oldest unfinished task, zero if there are none.
+		OldestUnfinished time.Time
 	}
 
 	// FileFormat represents format of file.
 	FileFormat uint8
 
+	// AvatarStatus represents result of scanning uploaded avatar.
+	AvatarStatus uint8
+
 	// SolutionStatus decision made at the time of the update.
 	SolutionStatus uint8
 
@@ -91,12 +251,143 @@ type (
 	}
 )
 
+// Profile limits.
+const (
+	MaxBioLen    = 500
+	MaxLinks     = 5
+	MaxLinkLen   = 256
+	minBirthYear = 1900
+)
+
+// IsEmpty returns true if no profile attribute is set.
+func (p Profile) IsEmpty() bool {
+	return p.Bio == "" && p.Locale == "" && p.Timezone == "" && p.Birthday.IsZero() && len(p.Links) == 0
+}
+
+// IsEmpty returns true if no activity detail is set.
+func (a Activity) IsEmpty() bool {
+	return a.Origin.IP == nil && a.Origin.UserAgent == "" && a.SessionID == uuid.Nil && a.PrevAvatarID == uuid.Nil
+}
+
+func validateProfile(p Profile, now time.Time) error {
+	if utf8.RuneCountInString(p.Bio) > MaxBioLen {
+		return fmt.Errorf("bio: %w", ErrInvalidArgument)
+	}
+
+	if p.Locale != "" {
+		_, err := language.Parse(p.Locale)
+		if err != nil {
+			return fmt.Errorf("language.Parse: %w", ErrInvalidArgument)
+		}
+	}
+
+	if p.Timezone != "" {
+		_, err := time.LoadLocation(p.Timezone)
+		if err != nil {
+			return fmt.Errorf("time.LoadLocation: %w", ErrInvalidArgument)
+		}
+	}
+
+	if !p.Birthday.IsZero() && (p.Birthday.After(now) || p.Birthday.Year() < minBirthYear) {
+		return fmt.Errorf("birthday: %w", ErrInvalidArgument)
+	}
+
+	if len(p.Links) > MaxLinks {
+		return fmt.Errorf("links: %w", ErrInvalidArgument)
+	}
+
+	for _, link := range p.Links {
+		u, err := url.Parse(link)
+		if err != nil || len(link) > MaxLinkLen || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("link %q: %w", link, ErrInvalidArgument)
+		}
+	}
+
+	return nil
+}
+
+// DefaultPrivacySettings returns settings for user which didn't change them.
+func DefaultPrivacySettings(userID uuid.UUID) PrivacySettings {
+	return PrivacySettings{
+		UserID:    userID,
+		HideEmail: true,
+	}
+}
+
+// usernameConfusables maps characters which look like latin letters to them.
+var usernameConfusables = strings.NewReplacer(
+	// Cyrillic.
+	"а", "a", "в", "b", "е", "e", "к", "k", "м", "m", "н", "h", "о", "o", "р", "p", "с", "c", "т", "t", "у", "y", "х", "x",
+	"і", "i", "ј", "j", "ѕ", "s", "һ", "h", "ԁ", "d", "ԛ", "q", "ԝ", "w", "ӏ", "l",
+	// Greek.
+	"α", "a", "β", "b", "ε", "e", "ι", "i", "κ", "k", "ν", "v", "ο", "o", "ρ", "p", "τ", "t", "υ", "u", "χ", "x",
+	// Latin look-alikes.
+	"ı", "i", "ɡ", "g",
+)
+
+// NormalizeUsername returns username form used for uniqueness checks.
+// It applies NFKC normalization, case folding and maps confusable characters to latin letters,
+// so "Bob", "bob" and "bоb" (with cyrillic "о") have the same normalized form.
+func NormalizeUsername(username string) string {
+	username = norm.NFKC.String(username)
+	username = cases.Fold().String(username)
+
+	return usernameConfusables.Replace(username)
+}
+
+// AvatarThumbnailSizes contains sides of square avatar thumbnails in pixels.
+var AvatarThumbnailSizes = []int{64, 128, 256, 512}
+
+// MaxBatchSize is the maximum number of keys in one batch lookup of users.
+const MaxBatchSize = 100
+
+// Len returns total count of keys.
+func (k BatchKeys) Len() int {
+	return len(k.IDs) + len(k.Usernames) + len(k.Emails)
+}
+
+// match adds users found by normalized keys to result and collects missing keys.
+// Result is keyed by keys as they're requested.
+func (r *BatchResult) match(keys []string, found map[string]User, normalize func(string) string) {
+	for _, key := range keys {
+		u, ok := found[normalize(key)]
+		if !ok {
+			r.Missing = append(r.Missing, key)
+
+			continue
+		}
+
+		r.Users[key] = u
+	}
+}
+
+//go:generate stringer -output=stringer.SearchMode.go -type=SearchMode -trimprefix=SearchMode
+const (
+	_ SearchMode = iota
+	SearchModeSubstring
+	SearchModeFuzzy
+)
+
 //go:generate stringer -output=stringer.TaskKind.go -type=TaskKind -trimprefix=TaskKind
 const (
 	_ TaskKind = iota
 	TaskKindEventAdd
 	TaskKindEventDel
 	TaskKindEventUpdate
+	TaskKindEventPreferences
+	TaskKindEventLoggedIn
+	TaskKindEventLoggedOut
+	TaskKindEventLoginFailed
+	TaskKindEventPasswordChanged
+	TaskKindEventAvatarChanged
+)
+
+//go:generate stringer -output=stringer.PreferenceKind.go -type=PreferenceKind -trimprefix=PreferenceKind
+const (
+	_ PreferenceKind = iota
+	PreferenceKindBool
+	PreferenceKindInt
+	PreferenceKindString
 )
 
 //go:generate stringer -output=stringer.FileFormat.go -type=FileFormat -trimprefix=FileFormat
@@ -110,11 +401,18 @@ const (
 	FileFormatSvg
 )
 
+//go:generate stringer -output=stringer.AvatarStatus.go -type=AvatarStatus -trimprefix=AvatarStatus
+const (
+	_ AvatarStatus = iota
+	AvatarStatusClean
+	AvatarStatusQuarantined
+	AvatarStatusMissing
+)
+
 func validateFileFormat(format string) error {
 	switch format {
 	case strings.ToLower(FileFormatWebp.String()), strings.ToLower(FileFormatPng.String()),
-		strings.ToLower(FileFormatJpeg.String()), strings.ToLower(FileFormatGif.String()),
-		strings.ToLower(FileFormatRaw.String()), strings.ToLower(FileFormatSvg.String()):
+		strings.ToLower(FileFormatJpeg.String()), strings.ToLower(FileFormatGif.String()):
 		return nil
 	default:
 		return ErrInvalidImageFormat
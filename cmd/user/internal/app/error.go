@@ -8,6 +8,7 @@ import (
 var (
 	ErrEmailExist           = errors.New("email exist")
 	ErrUsernameExist        = errors.New("username exist")
+	ErrUsernameReserved     = errors.New("username reserved")
 	ErrNotFound             = errors.New("not found")
 	ErrInvalidArgument      = errors.New("invalid argument")
 	ErrNotDifferent         = errors.New("the values must be different")
@@ -17,4 +18,9 @@ var (
 	ErrMaxFiles             = errors.New("post can't save new file")
 	ErrAccessDenied         = errors.New("access denied")
 	ErrInvalidImageFormat   = errors.New("invalid image format")
+	ErrQuarantined          = errors.New("file quarantined")
+	ErrBatchTooLarge        = errors.New("batch too large")
+	ErrInvalidOffset        = errors.New("invalid upload offset")
+	ErrNotSupported         = errors.New("not supported by file store")
+	ErrQuotaExceeded        = errors.New("storage quota exceeded")
 )
@@ -4,24 +4,70 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/gofrs/uuid"
+	"github.com/samber/lo"
 
 	"github.com/ZergsLaw/back-template1/internal/dom"
+	"github.com/ZergsLaw/back-template1/internal/logger"
 )
 
 const (
 	maxAvatarCountInUser = 10
+	maxAvatarSize        = 25 << 20
 )
 
 // SaveAvatar save info about avatar.
+// Avatar is cropped to square without metadata and saved with thumbnails of AvatarThumbnailSizes.
+// Avatar rejected by scanner is saved as quarantined and ErrQuarantined is returned.
 func (a *App) SaveAvatar(ctx context.Context, session dom.Session, file Avatar) (avatarID uuid.UUID, err error) {
 	if err = validateFormat(file.ContentType); err != nil {
 		return uuid.Nil, fmt.Errorf("validateFormat: %w", err)
 	}
 
+	return a.saveAvatar(ctx, session, file, uuid.Nil)
+}
+
+// saveAvatar saves avatar from file, uploadID is set if file is received by avatar upload.
+// Upload is completed in the same transaction as avatar is saved, if it's already completed
+// then errUploadCompleted is returned with id of avatar made by previous call.
+func (a *App) saveAvatar(ctx context.Context, session dom.Session, file Avatar, uploadID uuid.UUID) (avatarID uuid.UUID, err error) {
+	err = a.scanner.Scan(ctx, file)
+	switch {
+	case errors.Is(err, ErrQuarantined):
+		return a.quarantine(ctx, session, file, uploadID)
+	case err != nil:
+		return uuid.Nil, fmt.Errorf("a.scanner.Scan: %w", err)
+	}
+
+	avatar, thumbnails, err := a.image.Avatar(ctx, file, AvatarThumbnailSizes)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("a.image.Avatar: %w", err)
+	}
+
+	size := avatar.Size
+	for i := range thumbnails {
+		size += thumbnails[i].Avatar.Size
+	}
+
+	// Files are uploaded before saving avatar, so repository never refers to absent files.
+	// Files left after failed transaction are removed here or by ReconcileAvatars.
+	avatarID, err = a.uploadAvatar(ctx, *avatar, thumbnails)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var completedID uuid.UUID
 	err = a.repo.Tx(ctx, func(repo Repo) error {
+		completedID, err = completeAvatarUpload(ctx, repo, uploadID, avatarID)
+		if err != nil {
+			return err
+		}
+
 		count, err := repo.GetCountAvatars(ctx, session.UserID)
 		switch {
 		default:
@@ -33,14 +79,16 @@ func (a *App) SaveAvatar(ctx context.Context, session dom.Session, file Avatar)
 			return ErrMaxFiles
 		}
 
-		avatarID, err = a.file.UploadFile(ctx, file)
+		err = a.reserveStorage(ctx, repo, session, size)
 		if err != nil {
-			return fmt.Errorf("a.file.UploadFile: %w", err)
+			return err
 		}
 
 		fileCache := AvatarInfo{
 			FileID:  avatarID,
 			OwnerID: session.UserID,
+			Status:  AvatarStatusClean,
+			Size:    size,
 		}
 		if err = repo.SaveAvatar(ctx, fileCache); err != nil {
 			return fmt.Errorf("repo.SaveAvatar: %w", err)
@@ -50,22 +98,48 @@ func (a *App) SaveAvatar(ctx context.Context, session dom.Session, file Avatar)
 		if err != nil {
 			return fmt.Errorf("repo.ByID: %w", err)
 		}
-		user.AvatarID = avatarID
 
-		_, err = repo.Update(ctx, *user)
-		if err != nil {
-			return fmt.Errorf("repo.Update: %w", err)
-		}
-
-		return nil
+		return a.changeAvatar(ctx, repo, *user, avatarID)
 	})
 	if err != nil {
-		return uuid.Nil, err
+		a.removeOrphan(ctx, avatarID)
+
+		return completedID, err
 	}
 
 	return avatarID, nil
 }
 
+func (a *App) uploadAvatar(ctx context.Context, avatar Avatar, thumbnails []Thumbnail) (uuid.UUID, error) {
+	avatarID, err := a.file.UploadFile(ctx, avatar)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("a.file.UploadFile: %w", err)
+	}
+
+	for i := range thumbnails {
+		err = a.file.UploadThumbnail(ctx, avatarID, thumbnails[i])
+		if err != nil {
+			a.removeOrphan(ctx, avatarID)
+
+			return uuid.Nil, fmt.Errorf("a.file.UploadThumbnail: %w", err)
+		}
+	}
+
+	return avatarID, nil
+}
+
+// removeOrphan removes file which isn't referenced by repository.
+// Error is only logged because file left in store is removed by ReconcileAvatars.
+func (a *App) removeOrphan(ctx context.Context, fileID uuid.UUID) {
+	err := a.file.DeleteFile(ctx, fileID)
+	if err != nil {
+		logger.FromContext(ctx).Error("couldn't remove orphan file",
+			slog.String(logger.Error.String(), err.Error()),
+			slog.String(logger.FileID.String(), fileID.String()),
+		)
+	}
+}
+
 // RemoveAvatar remove info about avatar.
 func (a *App) RemoveAvatar(ctx context.Context, session dom.Session, fileID uuid.UUID) error {
 	fileCache, err := a.repo.GetAvatar(ctx, fileID)
@@ -77,50 +151,217 @@ func (a *App) RemoveAvatar(ctx context.Context, session dom.Session, fileID uuid
 		return ErrAccessDenied
 	}
 
-	return a.repo.Tx(ctx, func(repo Repo) error {
+	err = a.repo.Tx(ctx, func(repo Repo) error {
 		if err = repo.DeleteAvatar(ctx, session.UserID, fileID); err != nil {
 			return fmt.Errorf("a.user.DeleteAvatarCache: %w", err)
 		}
 
-		if err = a.file.DeleteFile(ctx, fileID); err != nil {
-			return fmt.Errorf("a.file.RemoveObject: %w", err)
+		// Only clean avatars take quota.
+		if fileCache.Status == AvatarStatusClean && fileCache.Size > 0 {
+			_, err = repo.AddStorageUsage(ctx, session.UserID, -fileCache.Size)
+			if err != nil {
+				return fmt.Errorf("repo.AddStorageUsage: %w", err)
+			}
 		}
 
-		filesInCache, err := repo.ListAvatarByUserID(ctx, session.UserID)
+		return a.replaceCurrentAvatar(ctx, repo, session.UserID, fileID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// File is removed only after commit, so rolled back avatar keeps its file.
+	a.removeOrphan(ctx, fileID)
+
+	return nil
+}
+
+// ListUserAvatars returns user's gallery ordered by position.
+// If userID is uuid.Nil, gallery of session owner is returned.
+// Quarantined avatars aren't returned, avatars of another user are hidden by privacy settings of that user.
+func (a *App) ListUserAvatars(ctx context.Context, session dom.Session, userID uuid.UUID) ([]AvatarInfo, error) {
+	if userID == uuid.Nil {
+		userID = session.UserID
+	}
+
+	if userID != session.UserID {
+		settings, err := a.repo.ListPrivacySettings(ctx, []uuid.UUID{userID})
 		if err != nil {
-			return fmt.Errorf("repo.ListAvatarByUserID: %w", err)
+			return nil, fmt.Errorf("a.repo.ListPrivacySettings: %w", err)
 		}
 
-		newAvatarID := uuid.Nil
-		if len(filesInCache) > 0 {
-			newAvatarID = filesInCache[0].FileID
+		if len(settings) > 0 && settings[0].HideAvatar {
+			return []AvatarInfo{}, nil
 		}
+	}
+
+	avatars, err := a.repo.ListAvatarByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.ListAvatarByUserID: %w", err)
+	}
+
+	return visibleAvatars(avatars), nil
+}
+
+// SetCurrentAvatar makes avatar from user's gallery shown in user's profile.
+func (a *App) SetCurrentAvatar(ctx context.Context, session dom.Session, fileID uuid.UUID) error {
+	info, err := a.repo.GetAvatar(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("a.repo.GetAvatar: %w", err)
+	}
+
+	if info.OwnerID != session.UserID {
+		return ErrAccessDenied
+	}
+
+	if info.Status != AvatarStatusClean {
+		return fmt.Errorf("avatar %s: %w", fileID, ErrNotFound)
+	}
 
+	return a.repo.Tx(ctx, func(repo Repo) error {
 		user, err := repo.ByID(ctx, session.UserID)
 		if err != nil {
 			return fmt.Errorf("repo.ByID: %w", err)
 		}
-		user.AvatarID = newAvatarID
 
-		_, err = repo.Update(ctx, *user)
+		if user.AvatarID == fileID {
+			return nil
+		}
+
+		return a.changeAvatar(ctx, repo, *user, fileID)
+	})
+}
+
+// replaceCurrentAvatar replaces hidden or removed avatar by the first visible avatar in gallery
+// if the avatar is current avatar of user.
+func (a *App) replaceCurrentAvatar(ctx context.Context, repo Repo, userID, fileID uuid.UUID) error {
+	user, err := repo.ByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("repo.ByID: %w", err)
+	}
+
+	if user.AvatarID != fileID {
+		return nil
+	}
+
+	filesInCache, err := repo.ListAvatarByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("repo.ListAvatarByUserID: %w", err)
+	}
+	filesInCache = visibleAvatars(filesInCache)
+
+	avatarID := uuid.Nil
+	if len(filesInCache) > 0 {
+		avatarID = filesInCache[0].FileID
+	}
+
+	return a.changeAvatar(ctx, repo, *user, avatarID)
+}
+
+// changeAvatar sets current avatar of user and saves event about it.
+func (a *App) changeAvatar(ctx context.Context, repo Repo, user User, avatarID uuid.UUID) error {
+	prevAvatarID := user.AvatarID
+	user.AvatarID = avatarID
+
+	updated, err := repo.Update(ctx, user)
+	if err != nil {
+		return fmt.Errorf("repo.Update: %w", err)
+	}
+
+	task := Task{
+		User:     *updated,
+		Kind:     TaskKindEventAvatarChanged,
+		Activity: Activity{PrevAvatarID: prevAvatarID},
+	}
+
+	_, err = repo.SaveTask(ctx, task)
+	if err != nil {
+		return fmt.Errorf("repo.SaveTask: %w", err)
+	}
+
+	return nil
+}
+
+// ReorderAvatars sets order of user's gallery and returns reordered gallery.
+// fileIDs must contain every avatar returned by ListUserAvatars exactly once.
+// Hidden avatars are moved to the end of gallery.
+func (a *App) ReorderAvatars(ctx context.Context, session dom.Session, fileIDs []uuid.UUID) (gallery []AvatarInfo, err error) {
+	positions := make(map[uuid.UUID]int, len(fileIDs))
+	for i, fileID := range fileIDs {
+		positions[fileID] = i
+	}
+
+	if len(positions) != len(fileIDs) {
+		return nil, fmt.Errorf("duplicate avatars: %w", ErrInvalidArgument)
+	}
+
+	err = a.repo.Tx(ctx, func(repo Repo) error {
+		avatars, err := repo.ListAvatarByUserID(ctx, session.UserID)
 		if err != nil {
-			return fmt.Errorf("repo.Update: %w", err)
+			return fmt.Errorf("repo.ListAvatarByUserID: %w", err)
+		}
+
+		visible := visibleAvatars(avatars)
+		if len(visible) != len(fileIDs) {
+			return fmt.Errorf("got %d avatars, expected %d: %w", len(fileIDs), len(visible), ErrInvalidArgument)
+		}
+
+		for _, info := range visible {
+			if _, ok := positions[info.FileID]; !ok {
+				return fmt.Errorf("avatar %s is absent: %w", info.FileID, ErrInvalidArgument)
+			}
 		}
 
+		next := len(fileIDs)
+		for i := range avatars {
+			position, ok := positions[avatars[i].FileID]
+			if !ok {
+				position = next
+				next++
+			}
+
+			if avatars[i].Position == position {
+				continue
+			}
+
+			err = repo.SetAvatarPosition(ctx, avatars[i].FileID, position)
+			if err != nil {
+				return fmt.Errorf("repo.SetAvatarPosition: %w", err)
+			}
+			avatars[i].Position = position
+		}
+
+		slices.SortFunc(avatars, func(a, b AvatarInfo) int {
+			return a.Position - b.Position
+		})
+		gallery = visibleAvatars(avatars)
+
 		return nil
 	})
-}
+	if err != nil {
+		return nil, err
+	}
 
-// ListUserAvatars get list user avatars.
-func (a *App) ListUserAvatars(ctx context.Context, session dom.Session) ([]AvatarInfo, error) {
-	return a.repo.ListAvatarByUserID(ctx, session.UserID)
+	return gallery, nil
 }
 
 // GetFile get info about user file by file id.
-func (a *App) GetFile(ctx context.Context, _ dom.Session, fileID uuid.UUID) (*Avatar, error) {
-	_, err := a.repo.GetAvatar(ctx, fileID)
+// If size isn't zero, it returns thumbnail of this size.
+// Avatars uploaded without thumbnails are returned as is.
+func (a *App) GetFile(ctx context.Context, _ dom.Session, fileID uuid.UUID, size int) (*Avatar, error) {
+	err := a.checkVisibleAvatar(ctx, fileID, size)
 	if err != nil {
-		return nil, fmt.Errorf("a.user.GetAvatarCache: %w", err)
+		return nil, err
+	}
+
+	if size != 0 {
+		file, err := a.file.DownloadThumbnail(ctx, fileID, size)
+		switch {
+		case err == nil:
+			return file, nil
+		case !errors.Is(err, ErrNotFound):
+			return nil, fmt.Errorf("a.file.DownloadThumbnail: %w", err)
+		}
 	}
 
 	file, err := a.file.DownloadFile(ctx, fileID)
@@ -131,6 +372,71 @@ func (a *App) GetFile(ctx context.Context, _ dom.Session, fileID uuid.UUID) (*Av
 	return file, nil
 }
 
+// checkVisibleAvatar returns error if avatar can't be shown to users in given size.
+func (a *App) checkVisibleAvatar(ctx context.Context, fileID uuid.UUID, size int) error {
+	if size != 0 && !slices.Contains(AvatarThumbnailSizes, size) {
+		return fmt.Errorf("size: %w", ErrInvalidArgument)
+	}
+
+	info, err := a.repo.GetAvatar(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("a.user.GetAvatarCache: %w", err)
+	}
+
+	if info.Status != AvatarStatusClean {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// quarantine saves original file hidden from users and returns ErrQuarantined.
+// Quarantined file doesn't take user's quota.
+// Avatar upload is completed by quarantined file the same way as in saveAvatar.
+func (a *App) quarantine(ctx context.Context, session dom.Session, file Avatar, uploadID uuid.UUID) (uuid.UUID, error) {
+	_, err := file.Seek(0, io.SeekStart)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("file.Seek: %w", err)
+	}
+
+	fileID, err := a.file.UploadFile(ctx, file)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("a.file.UploadFile: %w", err)
+	}
+
+	var completedID uuid.UUID
+	err = a.repo.Tx(ctx, func(repo Repo) error {
+		completedID, err = completeAvatarUpload(ctx, repo, uploadID, fileID)
+		if err != nil {
+			return err
+		}
+
+		err = repo.SaveAvatar(ctx, AvatarInfo{
+			FileID:  fileID,
+			OwnerID: session.UserID,
+			Status:  AvatarStatusQuarantined,
+		})
+		if err != nil {
+			return fmt.Errorf("repo.SaveAvatar: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		a.removeOrphan(ctx, fileID)
+
+		return completedID, err
+	}
+
+	return uuid.Nil, ErrQuarantined
+}
+
+func visibleAvatars(avatars []AvatarInfo) []AvatarInfo {
+	return lo.Filter(avatars, func(info AvatarInfo, _ int) bool {
+		return info.Status == AvatarStatusClean
+	})
+}
+
 func validateFormat(contentType string) error {
 	const contentTypeSize = 2
 	splits := strings.SplitN(contentType, "/", contentTypeSize)
@@ -3,12 +3,10 @@ package app_test
 import (
 	"context"
 	"errors"
-	"os"
 	"strings"
 	"testing"
 
 	"github.com/gofrs/uuid"
-	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
@@ -22,6 +20,12 @@ func TestApp_GetFile(t *testing.T) {
 		fileCache = &app.AvatarInfo{
 			OwnerID: ownerID,
 			FileID:  fileID,
+			Status:  app.AvatarStatusClean,
+		}
+		quarantined = &app.AvatarInfo{
+			OwnerID: ownerID,
+			FileID:  fileID,
+			Status:  app.AvatarStatusQuarantined,
 		}
 		session = dom.Session{
 			ID:     uuid.UUID{},
@@ -33,23 +37,38 @@ func TestApp_GetFile(t *testing.T) {
 			Name:        "name",
 			ContentType: "content_type",
 		}
+		thumbnail = &app.Avatar{
+			ID:          fileID,
+			Name:        "name",
+			ContentType: "image/png",
+			Size:        100,
+		}
+		size = app.AvatarThumbnailSizes[0]
 	)
 
 	testCases := map[string]struct {
-		session             dom.Session
-		fileID              uuid.UUID
-		repoGetFileRes      *app.AvatarInfo
-		repoGetFileErr      error
-		fileDownloadFileRes *app.Avatar
-		fileDownloadFileErr error
-		want                *app.Avatar
-		wantErr             error
+		session                  dom.Session
+		fileID                   uuid.UUID
+		size                     int
+		repoGetFileRes           *app.AvatarInfo
+		repoGetFileErr           error
+		fileDownloadThumbnailRes *app.Avatar
+		fileDownloadThumbnailErr error
+		fileDownloadFileRes      *app.Avatar
+		fileDownloadFileErr      error
+		want                     *app.Avatar
+		wantErr                  error
 	}{
-		"success":                     {session, fileID, fileCache, nil, file, nil, file, nil},
-		"err_not_found_get_file":      {session, uuid.Must(uuid.NewV4()), nil, app.ErrNotFound, nil, nil, nil, app.ErrNotFound},
-		"err_any_get_file":            {session, fileID, nil, errAny, nil, nil, nil, errAny},
-		"err_not_found_download_file": {session, uuid.Must(uuid.NewV4()), fileCache, nil, nil, app.ErrNotFound, nil, app.ErrNotFound},
-		"err_any_download_file":       {session, fileID, fileCache, nil, nil, errAny, nil, errAny},
+		"success":                     {session, fileID, 0, fileCache, nil, nil, nil, file, nil, file, nil},
+		"success_thumbnail":           {session, fileID, size, fileCache, nil, thumbnail, nil, nil, nil, thumbnail, nil},
+		"success_thumbnail_not_found": {session, fileID, size, fileCache, nil, nil, app.ErrNotFound, file, nil, file, nil},
+		"err_quarantined":             {session, fileID, 0, quarantined, nil, nil, nil, nil, nil, nil, app.ErrNotFound},
+		"err_invalid_size":            {session, fileID, 100500, nil, nil, nil, nil, nil, nil, nil, app.ErrInvalidArgument},
+		"err_not_found_get_file":      {session, uuid.Must(uuid.NewV4()), 0, nil, app.ErrNotFound, nil, nil, nil, nil, nil, app.ErrNotFound},
+		"err_any_get_file":            {session, fileID, 0, nil, errAny, nil, nil, nil, nil, nil, errAny},
+		"err_any_download_thumbnail":  {session, fileID, size, fileCache, nil, nil, errAny, nil, nil, nil, errAny},
+		"err_not_found_download_file": {session, uuid.Must(uuid.NewV4()), 0, fileCache, nil, nil, nil, nil, app.ErrNotFound, nil, app.ErrNotFound},
+		"err_any_download_file":       {session, fileID, 0, fileCache, nil, nil, nil, nil, errAny, nil, errAny},
 	}
 
 	for name, tc := range testCases {
@@ -59,13 +78,27 @@ func TestApp_GetFile(t *testing.T) {
 
 			ctx, module, mocks, assert := start(t)
 
-			mocks.repo.EXPECT().GetAvatar(ctx, tc.fileID).Return(tc.repoGetFileRes, tc.repoGetFileErr)
+			if !errors.Is(tc.wantErr, app.ErrInvalidArgument) {
+				mocks.repo.EXPECT().GetAvatar(ctx, tc.fileID).Return(tc.repoGetFileRes, tc.repoGetFileErr)
+			}
+
+			if tc.repoGetFileRes == quarantined {
+				file, err := module.GetFile(ctx, tc.session, tc.fileID, tc.size)
+				assert.ErrorIs(err, tc.wantErr)
+				assert.Nil(file)
+
+				return
+			}
+
+			if tc.repoGetFileRes != nil && tc.size != 0 {
+				mocks.file.EXPECT().DownloadThumbnail(ctx, tc.fileID, tc.size).Return(tc.fileDownloadThumbnailRes, tc.fileDownloadThumbnailErr)
+			}
 
-			if tc.repoGetFileErr == nil {
+			if tc.repoGetFileRes != nil && (tc.size == 0 || errors.Is(tc.fileDownloadThumbnailErr, app.ErrNotFound)) {
 				mocks.file.EXPECT().DownloadFile(ctx, tc.fileID).Return(tc.fileDownloadFileRes, tc.fileDownloadFileErr)
 			}
 
-			file, err := module.GetFile(ctx, tc.session, tc.fileID)
+			file, err := module.GetFile(ctx, tc.session, tc.fileID, tc.size)
 			assert.ErrorIs(err, tc.wantErr)
 			assert.Equal(tc.want, file)
 		})
@@ -75,17 +108,21 @@ func TestApp_GetFile(t *testing.T) {
 func TestApp_SaveAvatar(t *testing.T) {
 	t.Parallel()
 
-	file, err := os.Open(pngFilePath)
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, file.Close()) })
-	fileInfo, err := file.Stat()
-	require.NoError(t, err)
-
 	f := app.Avatar{
-		Name:           fileInfo.Name(),
+		Name:           "avatar.jpeg",
+		ContentType:    "image/jpeg",
+		Size:           4,
+		ReadSeekCloser: nopCloser{strings.NewReader("file")},
+	}
+	processed := app.Avatar{
+		Name:           "avatar.jpeg",
 		ContentType:    "image/jpeg",
-		Size:           fileInfo.Size(),
-		ReadSeekCloser: file,
+		Size:           3,
+		ReadSeekCloser: nopCloser{strings.NewReader("img")},
+	}
+	thumbnails := []app.Thumbnail{
+		{Size: 64, Avatar: processed},
+		{Size: 128, Avatar: processed},
 	}
 	user1 := app.User{
 		ID:       ownerID,
@@ -109,10 +146,13 @@ func TestApp_SaveAvatar(t *testing.T) {
 	testCases := map[string]struct {
 		session                dom.Session
 		file                   app.Avatar
+		scanErr                error
+		imageErr               error
 		repoGetCountAvatarsRes int
 		repoGetCountAvatarsErr error
 		fileUploadFileRes      uuid.UUID
 		fileUploadFileErr      error
+		fileUploadThumbnailErr error
 		repoSaveAvatarCacheErr error
 		repoByIDRes            *app.User
 		repoByIDErr            error
@@ -121,16 +161,22 @@ func TestApp_SaveAvatar(t *testing.T) {
 		want                   uuid.UUID
 		wantErr                error
 	}{
-		"success":                             {session, f, 0, nil, fileID, nil, nil, &user1, nil, nil, &app.User{}, fileID, nil},
-		"success_get_count_avatars_not_found": {session, f, 0, app.ErrNotFound, fileID, nil, nil, &user2, nil, nil, &app.User{}, fileID, nil},
-		"err_max_files":                       {session, f, 10, nil, uuid.Nil, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, app.ErrMaxFiles},
-		"err_any_get_count_avatars":           {session, f, 0, errAny, uuid.Nil, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, errAny},
-		"err_any_upload_file":                 {session, f, 0, nil, uuid.Nil, errAny, nil, nil, nil, nil, &app.User{}, uuid.Nil, errAny},
-		"err_any_save_avatar_cache":           {session, f, 0, nil, uuid.Nil, nil, errAny, nil, nil, nil, &app.User{}, uuid.Nil, errAny},
-		"err_any_by_id":                       {session, f, 0, nil, uuid.Nil, nil, nil, nil, errAny, nil, &app.User{}, uuid.Nil, errAny},
-		"err_any_update":                      {session, f, 0, nil, uuid.Nil, nil, nil, &user3, nil, errAny, &app.User{}, uuid.Nil, errAny},
-		"err_content_type_size":               {session, fileErrContentTypeSize, 0, nil, uuid.Nil, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, app.ErrInvalidImageFormat},
-		"err_unknown_content_type":            {session, fileErrInvalidImageFormat, 0, nil, uuid.Nil, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, app.ErrInvalidImageFormat},
+		"success":                             {session, f, nil, nil, 0, nil, fileID, nil, nil, nil, &user1, nil, nil, &app.User{}, fileID, nil},
+		"success_get_count_avatars_not_found": {session, f, nil, nil, 0, app.ErrNotFound, fileID, nil, nil, nil, &user2, nil, nil, &app.User{}, fileID, nil},
+		"err_quarantined":                     {session, f, app.ErrQuarantined, nil, 0, nil, fileID, nil, nil, nil, nil, nil, nil, nil, uuid.Nil, app.ErrQuarantined},
+		"err_quarantined_upload_file":         {session, f, app.ErrQuarantined, nil, 0, nil, uuid.Nil, errAny, nil, nil, nil, nil, nil, nil, uuid.Nil, errAny},
+		"err_quarantined_save_avatar_cache":   {session, f, app.ErrQuarantined, nil, 0, nil, fileID, nil, nil, errAny, nil, nil, nil, nil, uuid.Nil, errAny},
+		"err_any_scan":                        {session, f, errAny, nil, 0, nil, uuid.Nil, nil, nil, nil, nil, nil, nil, nil, uuid.Nil, errAny},
+		"err_image":                           {session, f, nil, app.ErrInvalidImageFormat, 0, nil, uuid.Nil, nil, nil, nil, nil, nil, nil, nil, uuid.Nil, app.ErrInvalidImageFormat},
+		"err_max_files":                       {session, f, nil, nil, 10, nil, fileID, nil, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, app.ErrMaxFiles},
+		"err_any_get_count_avatars":           {session, f, nil, nil, 0, errAny, uuid.Nil, nil, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, errAny},
+		"err_any_upload_file":                 {session, f, nil, nil, 0, nil, uuid.Nil, errAny, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, errAny},
+		"err_any_upload_thumbnail":            {session, f, nil, nil, 0, nil, fileID, nil, errAny, nil, nil, nil, nil, &app.User{}, uuid.Nil, errAny},
+		"err_any_save_avatar_cache":           {session, f, nil, nil, 0, nil, fileID, nil, nil, errAny, nil, nil, nil, &app.User{}, uuid.Nil, errAny},
+		"err_any_by_id":                       {session, f, nil, nil, 0, nil, fileID, nil, nil, nil, nil, errAny, nil, &app.User{}, uuid.Nil, errAny},
+		"err_any_update":                      {session, f, nil, nil, 0, nil, fileID, nil, nil, nil, &user3, nil, errAny, &app.User{}, uuid.Nil, errAny},
+		"err_content_type_size":               {session, fileErrContentTypeSize, nil, nil, 0, nil, uuid.Nil, nil, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, app.ErrInvalidImageFormat},
+		"err_unknown_content_type":            {session, fileErrInvalidImageFormat, nil, nil, 0, nil, uuid.Nil, nil, nil, nil, nil, nil, nil, &app.User{}, uuid.Nil, app.ErrInvalidImageFormat},
 	}
 
 	for name, tc := range testCases {
@@ -141,35 +187,87 @@ func TestApp_SaveAvatar(t *testing.T) {
 			ctx, module, mocks, assert := start(t)
 
 			splits := strings.Split(tc.file.ContentType, "/")
-			if len(splits) >= 2 {
-				if splits[1] == "jpeg" {
+			if len(splits) >= 2 && splits[1] == "jpeg" {
+				mocks.scanner.EXPECT().Scan(ctx, tc.file).Return(tc.scanErr)
+			}
+
+			if errors.Is(tc.scanErr, app.ErrQuarantined) {
+				mocks.file.EXPECT().UploadFile(ctx, tc.file).Return(tc.fileUploadFileRes, tc.fileUploadFileErr)
+				if tc.fileUploadFileErr == nil {
 					mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo app.Repo) error) error {
 						return fn(mocks.repo)
 					})
+					mocks.repo.EXPECT().SaveAvatar(ctx, app.AvatarInfo{
+						FileID:  tc.fileUploadFileRes,
+						OwnerID: ownerID,
+						Status:  app.AvatarStatusQuarantined,
+					}).Return(tc.repoSaveAvatarCacheErr)
+				}
+				if tc.repoSaveAvatarCacheErr != nil {
+					mocks.file.EXPECT().DeleteFile(ctx, tc.fileUploadFileRes).Return(nil)
+				}
+			}
 
-					mocks.repo.EXPECT().GetCountAvatars(ctx, tc.session.UserID).Return(tc.repoGetCountAvatarsRes, tc.repoGetCountAvatarsErr)
+			if len(splits) >= 2 && splits[1] == "jpeg" && tc.scanErr == nil {
+				if tc.imageErr != nil {
+					mocks.image.EXPECT().Avatar(ctx, tc.file, app.AvatarThumbnailSizes).Return(nil, nil, tc.imageErr)
+				} else {
+					mocks.image.EXPECT().Avatar(ctx, tc.file, app.AvatarThumbnailSizes).Return(&processed, thumbnails, nil)
+				}
+			}
 
-					if (tc.repoGetCountAvatarsErr == nil || errors.Is(tc.repoGetCountAvatarsErr, app.ErrNotFound)) && tc.repoGetCountAvatarsRes < 10 {
-						mocks.file.EXPECT().UploadFile(ctx, tc.file).Return(tc.fileUploadFileRes, tc.fileUploadFileErr)
-					}
+			if len(splits) >= 2 && splits[1] == "jpeg" && tc.scanErr == nil && tc.imageErr == nil {
+				mocks.file.EXPECT().UploadFile(ctx, processed).Return(tc.fileUploadFileRes, tc.fileUploadFileErr)
 
-					if (tc.repoGetCountAvatarsErr == nil || errors.Is(tc.repoGetCountAvatarsErr, app.ErrNotFound)) && tc.repoGetCountAvatarsRes < 10 && tc.fileUploadFileErr == nil {
-						fileCache := app.AvatarInfo{
-							FileID:  tc.fileUploadFileRes,
-							OwnerID: ownerID,
-						}
-						mocks.repo.EXPECT().SaveAvatar(ctx, fileCache).Return(tc.repoSaveAvatarCacheErr)
+				if tc.fileUploadFileErr == nil {
+					mocks.file.EXPECT().UploadThumbnail(ctx, tc.fileUploadFileRes, thumbnails[0]).Return(tc.fileUploadThumbnailErr)
+					if tc.fileUploadThumbnailErr == nil {
+						mocks.file.EXPECT().UploadThumbnail(ctx, tc.fileUploadFileRes, thumbnails[1]).Return(nil)
 					}
+				}
+
+				if tc.fileUploadFileErr == nil && tc.fileUploadThumbnailErr == nil {
+					mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo app.Repo) error) error {
+						return fn(mocks.repo)
+					})
+
+					mocks.repo.EXPECT().GetCountAvatars(ctx, tc.session.UserID).Return(tc.repoGetCountAvatarsRes, tc.repoGetCountAvatarsErr)
+				}
+
+				if tc.fileUploadFileErr == nil && tc.fileUploadThumbnailErr == nil && (tc.repoGetCountAvatarsErr == nil || errors.Is(tc.repoGetCountAvatarsErr, app.ErrNotFound)) && tc.repoGetCountAvatarsRes < 10 {
+					size := processed.Size * int64(1+len(thumbnails))
+					mocks.repo.EXPECT().AddStorageUsage(ctx, tc.session.UserID, size).Return(size, nil)
 
-					if (tc.repoGetCountAvatarsErr == nil || errors.Is(tc.repoGetCountAvatarsErr, app.ErrNotFound)) && tc.repoGetCountAvatarsRes < 10 && tc.fileUploadFileErr == nil && tc.repoSaveAvatarCacheErr == nil {
-						mocks.repo.EXPECT().ByID(ctx, tc.session.UserID).Return(tc.repoByIDRes, tc.repoByIDErr)
+					fileCache := app.AvatarInfo{
+						FileID:  tc.fileUploadFileRes,
+						OwnerID: ownerID,
+						Status:  app.AvatarStatusClean,
+						Size:    size,
 					}
+					mocks.repo.EXPECT().SaveAvatar(ctx, fileCache).Return(tc.repoSaveAvatarCacheErr)
+				}
+
+				if tc.fileUploadFileErr == nil && tc.fileUploadThumbnailErr == nil && (tc.repoGetCountAvatarsErr == nil || errors.Is(tc.repoGetCountAvatarsErr, app.ErrNotFound)) && tc.repoGetCountAvatarsRes < 10 && tc.repoSaveAvatarCacheErr == nil {
+					mocks.repo.EXPECT().ByID(ctx, tc.session.UserID).Return(tc.repoByIDRes, tc.repoByIDErr)
+				}
 
-					if (tc.repoGetCountAvatarsErr == nil || errors.Is(tc.repoGetCountAvatarsErr, app.ErrNotFound)) && tc.repoGetCountAvatarsRes < 10 && tc.fileUploadFileErr == nil && tc.repoSaveAvatarCacheErr == nil && tc.repoByIDErr == nil {
-						tc.repoByIDRes.AvatarID = tc.fileUploadFileRes
-						mocks.repo.EXPECT().Update(ctx, *tc.repoByIDRes).Return(tc.repoUpdateRes, tc.repoUpdateErr)
+				if tc.fileUploadFileErr == nil && tc.fileUploadThumbnailErr == nil && (tc.repoGetCountAvatarsErr == nil || errors.Is(tc.repoGetCountAvatarsErr, app.ErrNotFound)) && tc.repoGetCountAvatarsRes < 10 && tc.repoSaveAvatarCacheErr == nil && tc.repoByIDErr == nil {
+					updated := *tc.repoByIDRes
+					updated.AvatarID = tc.fileUploadFileRes
+					mocks.repo.EXPECT().Update(ctx, updated).Return(tc.repoUpdateRes, tc.repoUpdateErr)
+
+					if tc.repoUpdateErr == nil {
+						mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+							User:     *tc.repoUpdateRes,
+							Kind:     app.TaskKindEventAvatarChanged,
+							Activity: app.Activity{PrevAvatarID: tc.repoByIDRes.AvatarID},
+						}).Return(uuid.Must(uuid.NewV4()), nil)
 					}
 				}
+
+				if tc.fileUploadFileErr == nil && tc.wantErr != nil { // Uploaded files are removed after failure.
+					mocks.file.EXPECT().DeleteFile(ctx, tc.fileUploadFileRes).Return(errAny)
+				}
 			}
 
 			id, err := module.SaveAvatar(ctx, tc.session, tc.file)
@@ -190,13 +288,26 @@ func TestApp_RemoveAvatar(t *testing.T) {
 		fileCache2 = app.AvatarInfo{
 			FileID:  uuid.Must(uuid.NewV4()),
 			OwnerID: ownerID,
+			Status:  app.AvatarStatusClean,
+		}
+		quarantined = app.AvatarInfo{
+			FileID:  uuid.Must(uuid.NewV4()),
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusQuarantined,
+		}
+		sized = app.AvatarInfo{
+			FileID:  fileID,
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusClean,
+			Size:    9,
 		}
-		listFileCache = []app.AvatarInfo{fileCache2}
-		user1         = app.User{
+		listFileCache       = []app.AvatarInfo{fileCache2}
+		listWithQuarantined = []app.AvatarInfo{quarantined, fileCache2}
+		user1               = app.User{
 			ID:       ownerID,
 			Email:    "test@test.com",
 			Name:     "name",
-			AvatarID: uuid.Must(uuid.NewV4()),
+			AvatarID: fileID,
 		}
 		session = dom.Session{
 			ID:     uuid.Must(uuid.NewV4()),
@@ -209,7 +320,12 @@ func TestApp_RemoveAvatar(t *testing.T) {
 			Status: dom.UserStatusDefault,
 		}
 		user2 = user1
+		user3 = user1
+		user4 = user1
+		user5 = user1
+		user6 = user1
 	)
+	user5.AvatarID = fileCache2.FileID
 
 	testCases := map[string]struct {
 		session                        dom.Session
@@ -227,12 +343,15 @@ func TestApp_RemoveAvatar(t *testing.T) {
 		want                           error
 	}{
 		"success":                                   {session, fileID, &fileCache1, nil, nil, nil, listFileCache, nil, &user1, nil, &app.User{}, nil, nil},
+		"success_skip_quarantined":                  {session, fileID, &fileCache1, nil, nil, nil, listWithQuarantined, nil, &user3, nil, &app.User{}, nil, nil},
+		"success_err_any_file_delete_file":          {session, fileID, &fileCache1, nil, nil, errAny, listFileCache, nil, &user4, nil, &app.User{}, nil, nil},
+		"success_not_current":                       {session, fileID, &fileCache1, nil, nil, nil, nil, nil, &user5, nil, nil, nil, nil},
+		"success_release_storage":                   {session, fileID, &sized, nil, nil, nil, nil, nil, &user5, nil, nil, nil, nil},
 		"err_access_denied":                         {sessionAnother, fileID, &fileCache1, nil, nil, nil, nil, nil, nil, nil, &app.User{}, nil, app.ErrAccessDenied},
 		"err_any_repo_get_file":                     {session, fileID, &fileCache1, errAny, nil, nil, nil, nil, nil, nil, &app.User{}, nil, errAny},
 		"err_any_repo_delete_avatar_cache":          {session, fileID, &fileCache1, nil, errAny, nil, nil, nil, nil, nil, &app.User{}, nil, errAny},
-		"err_any_file_delete_file":                  {session, fileID, &fileCache1, nil, nil, errAny, nil, nil, nil, nil, &app.User{}, nil, errAny},
-		"err_any_repo_list_avatar_cache_by_user_id": {session, fileID, &fileCache1, nil, nil, nil, nil, errAny, nil, nil, &app.User{}, nil, errAny},
-		"err_any_repo_by_id":                        {session, fileID, &fileCache1, nil, nil, nil, listFileCache, nil, nil, errAny, &app.User{}, nil, errAny},
+		"err_any_repo_list_avatar_cache_by_user_id": {session, fileID, &fileCache1, nil, nil, nil, nil, errAny, &user6, nil, &app.User{}, nil, errAny},
+		"err_any_repo_by_id":                        {session, fileID, &fileCache1, nil, nil, nil, nil, nil, nil, errAny, &app.User{}, nil, errAny},
 		"err_any_repo_update":                       {session, fileID, &fileCache1, nil, nil, nil, listFileCache, nil, &user2, nil, &app.User{}, errAny, errAny},
 	}
 
@@ -252,26 +371,48 @@ func TestApp_RemoveAvatar(t *testing.T) {
 
 				mocks.repo.EXPECT().DeleteAvatar(ctx, tc.session.UserID, tc.fileID).Return(tc.repoDeleteAvatarCacheErr)
 
-				if tc.repoDeleteAvatarCacheErr == nil {
-					mocks.file.EXPECT().DeleteFile(ctx, tc.fileID).Return(tc.fileDeleteFileErr)
+				if tc.repoDeleteAvatarCacheErr == nil && tc.repoGetFileRes.Status == app.AvatarStatusClean && tc.repoGetFileRes.Size > 0 {
+					mocks.repo.EXPECT().AddStorageUsage(ctx, tc.session.UserID, -tc.repoGetFileRes.Size).Return(int64(0), nil)
 				}
 
-				if tc.repoDeleteAvatarCacheErr == nil && tc.fileDeleteFileErr == nil {
-					mocks.repo.EXPECT().ListAvatarByUserID(ctx, tc.session.UserID).Return(tc.repoListAvatarCacheByUserIDRes, tc.repoListAvatarCacheByUserIDErr)
+				if tc.repoDeleteAvatarCacheErr == nil {
+					var user *app.User
+					if tc.repoByIDRes != nil {
+						user = new(app.User)
+						*user = *tc.repoByIDRes
+					}
+					mocks.repo.EXPECT().ByID(ctx, tc.session.UserID).Return(user, tc.repoByIDErr)
 				}
 
-				if tc.repoDeleteAvatarCacheErr == nil && tc.fileDeleteFileErr == nil && tc.repoListAvatarCacheByUserIDErr == nil {
-					mocks.repo.EXPECT().ByID(ctx, tc.session.UserID).Return(tc.repoByIDRes, tc.repoByIDErr)
+				isCurrent := tc.repoByIDRes != nil && tc.repoByIDRes.AvatarID == tc.fileID
+				if tc.repoDeleteAvatarCacheErr == nil && tc.repoByIDErr == nil && isCurrent {
+					mocks.repo.EXPECT().ListAvatarByUserID(ctx, tc.session.UserID).Return(tc.repoListAvatarCacheByUserIDRes, tc.repoListAvatarCacheByUserIDErr)
 				}
 
-				if tc.repoDeleteAvatarCacheErr == nil && tc.fileDeleteFileErr == nil &&
-					tc.repoListAvatarCacheByUserIDErr == nil && tc.repoByIDErr == nil {
+				if tc.repoDeleteAvatarCacheErr == nil && tc.repoByIDErr == nil && isCurrent && tc.repoListAvatarCacheByUserIDErr == nil {
 					newAvatarID := uuid.Nil
-					if len(tc.repoListAvatarCacheByUserIDRes) > 0 {
-						newAvatarID = tc.repoListAvatarCacheByUserIDRes[0].FileID
+					for _, info := range tc.repoListAvatarCacheByUserIDRes {
+						if info.Status == app.AvatarStatusClean {
+							newAvatarID = info.FileID
+
+							break
+						}
+					}
+					updated := *tc.repoByIDRes
+					updated.AvatarID = newAvatarID
+					mocks.repo.EXPECT().Update(ctx, updated).Return(tc.repoUpdateRes, tc.repoUpdateErr)
+
+					if tc.repoUpdateErr == nil {
+						mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+							User:     *tc.repoUpdateRes,
+							Kind:     app.TaskKindEventAvatarChanged,
+							Activity: app.Activity{PrevAvatarID: tc.fileID},
+						}).Return(uuid.Must(uuid.NewV4()), nil)
 					}
-					tc.repoByIDRes.AvatarID = newAvatarID
-					mocks.repo.EXPECT().Update(ctx, *tc.repoByIDRes).Return(tc.repoUpdateRes, tc.repoUpdateErr)
+				}
+
+				if tc.want == nil { // File is removed only after commit.
+					mocks.file.EXPECT().DeleteFile(ctx, tc.fileID).Return(tc.fileDeleteFileErr)
 				}
 			}
 
@@ -280,3 +421,238 @@ func TestApp_RemoveAvatar(t *testing.T) {
 		})
 	}
 }
+
+func TestApp_ListUserAvatars(t *testing.T) {
+	t.Parallel()
+
+	var (
+		anotherID = uuid.Must(uuid.NewV4())
+		clean     = app.AvatarInfo{
+			FileID:  fileID,
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusClean,
+		}
+		quarantined = app.AvatarInfo{
+			FileID:  uuid.Must(uuid.NewV4()),
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusQuarantined,
+		}
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusDefault,
+		}
+		hidden  = []app.PrivacySettings{{UserID: anotherID, HideAvatar: true}}
+		visible = []app.PrivacySettings{{UserID: anotherID}}
+	)
+
+	testCases := map[string]struct {
+		userID             uuid.UUID
+		repoListPrivacyRes []app.PrivacySettings
+		repoListPrivacyErr error
+		repoListAvatarsRes []app.AvatarInfo
+		repoListAvatarsErr error
+		want               []app.AvatarInfo
+		wantErr            error
+	}{
+		"success_own":          {uuid.Nil, nil, nil, []app.AvatarInfo{clean, quarantined}, nil, []app.AvatarInfo{clean}, nil},
+		"success_another":      {anotherID, visible, nil, []app.AvatarInfo{clean}, nil, []app.AvatarInfo{clean}, nil},
+		"success_hidden":       {anotherID, hidden, nil, nil, nil, []app.AvatarInfo{}, nil},
+		"err_any_list_privacy": {anotherID, nil, errAny, nil, nil, nil, errAny},
+		"err_any_list_avatars": {uuid.Nil, nil, nil, nil, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			userID := tc.userID
+			if userID == uuid.Nil {
+				userID = session.UserID
+			}
+
+			if userID != session.UserID {
+				mocks.repo.EXPECT().ListPrivacySettings(ctx, []uuid.UUID{userID}).Return(tc.repoListPrivacyRes, tc.repoListPrivacyErr)
+			}
+
+			isHidden := len(tc.repoListPrivacyRes) > 0 && tc.repoListPrivacyRes[0].HideAvatar
+			if tc.repoListPrivacyErr == nil && !isHidden {
+				mocks.repo.EXPECT().ListAvatarByUserID(ctx, userID).Return(tc.repoListAvatarsRes, tc.repoListAvatarsErr)
+			}
+
+			res, err := module.ListUserAvatars(ctx, session, tc.userID)
+			assert.ErrorIs(err, tc.wantErr)
+			assert.Equal(tc.want, res)
+		})
+	}
+}
+
+func TestApp_SetCurrentAvatar(t *testing.T) {
+	t.Parallel()
+
+	var (
+		clean = &app.AvatarInfo{
+			FileID:  fileID,
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusClean,
+		}
+		quarantined = &app.AvatarInfo{
+			FileID:  fileID,
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusQuarantined,
+		}
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusDefault,
+		}
+		sessionAnother = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: uuid.Must(uuid.NewV4()),
+			Status: dom.UserStatusDefault,
+		}
+		user = app.User{
+			ID:       ownerID,
+			Email:    "test@test.com",
+			Name:     "name",
+			AvatarID: uuid.Must(uuid.NewV4()),
+		}
+	)
+
+	testCases := map[string]struct {
+		session         dom.Session
+		repoGetFileRes  *app.AvatarInfo
+		repoGetFileErr  error
+		repoByIDErr     error
+		repoUpdateErr   error
+		repoSaveTaskErr error
+		want            error
+	}{
+		"success":                {session, clean, nil, nil, nil, nil, nil},
+		"err_access_denied":      {sessionAnother, clean, nil, nil, nil, nil, app.ErrAccessDenied},
+		"err_not_found":          {session, quarantined, nil, nil, nil, nil, app.ErrNotFound},
+		"err_any_repo_get_file":  {session, nil, errAny, nil, nil, nil, errAny},
+		"err_any_repo_by_id":     {session, clean, nil, errAny, nil, nil, errAny},
+		"err_any_repo_update":    {session, clean, nil, nil, errAny, nil, errAny},
+		"err_any_repo_save_task": {session, clean, nil, nil, nil, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().GetAvatar(ctx, fileID).Return(tc.repoGetFileRes, tc.repoGetFileErr)
+
+			if tc.repoGetFileErr == nil && !errors.Is(tc.want, app.ErrAccessDenied) && !errors.Is(tc.want, app.ErrNotFound) {
+				mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo app.Repo) error) error {
+					return fn(mocks.repo)
+				})
+
+				byIDRes := user
+				mocks.repo.EXPECT().ByID(ctx, tc.session.UserID).Return(&byIDRes, tc.repoByIDErr)
+
+				if tc.repoByIDErr == nil {
+					updated := user
+					updated.AvatarID = fileID
+					mocks.repo.EXPECT().Update(ctx, updated).Return(&updated, tc.repoUpdateErr)
+
+					if tc.repoUpdateErr == nil {
+						mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+							User:     updated,
+							Kind:     app.TaskKindEventAvatarChanged,
+							Activity: app.Activity{PrevAvatarID: user.AvatarID},
+						}).Return(uuid.Must(uuid.NewV4()), tc.repoSaveTaskErr)
+					}
+				}
+			}
+
+			err := module.SetCurrentAvatar(ctx, tc.session, fileID)
+			assert.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func TestApp_ReorderAvatars(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first = app.AvatarInfo{
+			FileID:   uuid.Must(uuid.NewV4()),
+			OwnerID:  ownerID,
+			Status:   app.AvatarStatusClean,
+			Position: 0,
+		}
+		second = app.AvatarInfo{
+			FileID:   uuid.Must(uuid.NewV4()),
+			OwnerID:  ownerID,
+			Status:   app.AvatarStatusClean,
+			Position: 1,
+		}
+		quarantined = app.AvatarInfo{
+			FileID:   uuid.Must(uuid.NewV4()),
+			OwnerID:  ownerID,
+			Status:   app.AvatarStatusQuarantined,
+			Position: 2,
+		}
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusDefault,
+		}
+		gallery   = []app.AvatarInfo{first, second, quarantined}
+		reordered = []app.AvatarInfo{second, first}
+	)
+	reordered[0].Position, reordered[1].Position = 0, 1
+
+	testCases := map[string]struct {
+		fileIDs            []uuid.UUID
+		repoListAvatarsErr error
+		repoSetPositionErr error
+		want               []app.AvatarInfo
+		wantErr            error
+	}{
+		"success":                   {[]uuid.UUID{second.FileID, first.FileID}, nil, nil, reordered, nil},
+		"err_duplicate":             {[]uuid.UUID{first.FileID, first.FileID}, nil, nil, nil, app.ErrInvalidArgument},
+		"err_absent":                {[]uuid.UUID{first.FileID}, nil, nil, nil, app.ErrInvalidArgument},
+		"err_hidden":                {[]uuid.UUID{first.FileID, quarantined.FileID}, nil, nil, nil, app.ErrInvalidArgument},
+		"err_any_repo_list_avatars": {[]uuid.UUID{second.FileID, first.FileID}, errAny, nil, nil, errAny},
+		"err_any_repo_set_position": {[]uuid.UUID{second.FileID, first.FileID}, nil, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			if !strings.HasPrefix(name, "err_duplicate") {
+				mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo app.Repo) error) error {
+					return fn(mocks.repo)
+				})
+
+				listRes := append([]app.AvatarInfo(nil), gallery...)
+				mocks.repo.EXPECT().ListAvatarByUserID(ctx, session.UserID).Return(listRes, tc.repoListAvatarsErr)
+			}
+
+			if tc.repoListAvatarsErr == nil && tc.wantErr == nil {
+				mocks.repo.EXPECT().SetAvatarPosition(ctx, first.FileID, 1).Return(nil)
+				mocks.repo.EXPECT().SetAvatarPosition(ctx, second.FileID, 0).Return(nil)
+			}
+
+			if tc.repoSetPositionErr != nil {
+				mocks.repo.EXPECT().SetAvatarPosition(ctx, first.FileID, 1).Return(tc.repoSetPositionErr)
+			}
+
+			res, err := module.ReorderAvatars(ctx, session, tc.fileIDs)
+			assert.ErrorIs(err, tc.wantErr)
+			assert.Equal(tc.want, res)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -26,16 +27,14 @@ func (a *App) VerificationEmail(ctx context.Context, email string) error {
 }
 
 // VerificationUsername check exists or not username.
+// Reserved usernames and usernames held after rename are not available too.
 func (a *App) VerificationUsername(ctx context.Context, username string) error {
-	_, err := a.repo.ByUsername(ctx, username)
-	switch {
-	case errors.Is(err, ErrNotFound):
-		return nil
-	case err == nil:
-		return ErrUsernameExist
-	default:
-		return fmt.Errorf("a.repo.ByUsername: %w", err)
+	err := a.checkUsername(ctx, a.repo, uuid.Nil, username)
+	if err != nil {
+		return fmt.Errorf("a.checkUsername: %w", err)
 	}
+
+	return nil
 }
 
 // CreateUser create new user by params.
@@ -47,6 +46,11 @@ func (a *App) CreateUser(ctx context.Context, email, username, fullName, passwor
 	email = strings.ToLower(email)
 
 	err = a.repo.Tx(ctx, func(repo Repo) error {
+		err := a.checkUsername(ctx, repo, uuid.Nil, username)
+		if err != nil {
+			return fmt.Errorf("a.checkUsername: %w", err)
+		}
+
 		newUser := User{
 			Email:    email,
 			Name:     username,
@@ -85,42 +89,125 @@ func (a *App) CreateUser(ctx context.Context, email, username, fullName, passwor
 }
 
 // Login make new session and returns sessions token.
+// Successful and failed attempts are published as events.
 func (a *App) Login(ctx context.Context, email, password string, origin dom.Origin) (uuid.UUID, *dom.Token, error) {
 	email = strings.ToLower(email)
 	user, err := a.repo.ByEmail(ctx, email)
-	if err != nil {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return uuid.Nil, nil, a.loginFailed(ctx, User{Email: email}, origin, fmt.Errorf("a.repo.ByEmail: %w", err))
+	case err != nil:
 		return uuid.Nil, nil, fmt.Errorf("a.repo.ByEmail: %w", err)
 	}
 
 	if !a.hash.Compare(user.PassHash, []byte(password)) {
-		return uuid.Nil, nil, ErrInvalidPassword
+		return uuid.Nil, nil, a.loginFailed(ctx, *user, origin, ErrInvalidPassword)
 	}
 
-	token, err := a.sessions.Save(ctx, user.ID, origin, user.Status)
+	var token *dom.Token
+	err = a.withEvent(ctx, Task{
+		User:     *user,
+		Kind:     TaskKindEventLoggedIn,
+		Activity: Activity{Origin: origin},
+	}, func() error {
+		token, err = a.sessions.Save(ctx, user.ID, origin, user.Status)
+		if err != nil {
+			return fmt.Errorf("a.sessions.Save: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return uuid.Nil, nil, fmt.Errorf("a.sessions.Save: %w", err)
+		return uuid.Nil, nil, err
 	}
 
 	return user.ID, token, nil
 }
 
+// loginFailed saves event about failed login and returns cause.
+func (a *App) loginFailed(ctx context.Context, user User, origin dom.Origin, cause error) error {
+	task := Task{
+		User:     user,
+		Kind:     TaskKindEventLoginFailed,
+		Activity: Activity{Origin: origin},
+	}
+
+	_, err := a.repo.SaveTask(ctx, task)
+	if err != nil {
+		return fmt.Errorf("a.repo.SaveTask: %w", err)
+	}
+
+	return cause
+}
+
+// withEvent saves task publishing event about action made in session service and makes the action.
+// The task is saved before the action and is rolled back if the action fails, so the event is never lost.
+func (a *App) withEvent(ctx context.Context, task Task, action func() error) error {
+	return a.repo.Tx(ctx, func(repo Repo) error {
+		_, err := repo.SaveTask(ctx, task)
+		if err != nil {
+			return fmt.Errorf("repo.SaveTask: %w", err)
+		}
+
+		return action()
+	})
+}
+
 // UserByID get user by id.
 func (a *App) UserByID(ctx context.Context, session dom.Session, userID uuid.UUID) (*User, error) {
 	if userID == uuid.Nil {
 		userID = session.UserID
 	}
 
-	return a.repo.ByID(ctx, userID)
+	user, err := a.repo.ByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.ByID: %w", err)
+	}
+
+	users := []User{*user}
+	err = a.withPrivacy(ctx, users)
+	if err != nil {
+		return nil, fmt.Errorf("a.withPrivacy: %w", err)
+	}
+
+	return &users[0], nil
 }
 
 // ListUserByFilters get users by filters.
-func (a *App) ListUserByFilters(ctx context.Context, _ dom.Session, filters SearchParams) ([]User, int, error) {
-	return a.repo.SearchUsers(ctx, filters)
+// Users hidden from search are returned only for specialists.
+func (a *App) ListUserByFilters(ctx context.Context, session dom.Session, filters SearchParams) ([]User, int, error) {
+	filters.IncludeHidden = session.Status.IsSpecialist()
+
+	users, total, err := a.repo.SearchUsers(ctx, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("a.repo.SearchUsers: %w", err)
+	}
+
+	err = a.withPrivacy(ctx, users)
+	if err != nil {
+		return nil, 0, fmt.Errorf("a.withPrivacy: %w", err)
+	}
+
+	return users, total, nil
 }
 
 // Logout remove user's session.
 func (a *App) Logout(ctx context.Context, session dom.Session) error {
-	return a.sessions.Delete(ctx, session.ID)
+	return a.withEvent(ctx, Task{
+		User: User{
+			ID:     session.UserID,
+			Status: session.Status,
+		},
+		Kind:     TaskKindEventLoggedOut,
+		Activity: Activity{SessionID: session.ID},
+	}, func() error {
+		err := a.sessions.Delete(ctx, session.ID)
+		if err != nil {
+			return fmt.Errorf("a.sessions.Delete: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // UpdatePassword update user's password.
@@ -144,12 +231,24 @@ func (a *App) UpdatePassword(ctx context.Context, session dom.Session, oldPass,
 	}
 	user.PassHash = passHash
 
-	_, err = a.repo.Update(ctx, *user)
-	if err != nil {
-		return fmt.Errorf("a.repo.Update: %w", err)
-	}
+	return a.repo.Tx(ctx, func(repo Repo) error {
+		updated, err := repo.Update(ctx, *user)
+		if err != nil {
+			return fmt.Errorf("repo.Update: %w", err)
+		}
 
-	return nil
+		task := Task{
+			User: *updated,
+			Kind: TaskKindEventPasswordChanged,
+		}
+
+		_, err = repo.SaveTask(ctx, task)
+		if err != nil {
+			return fmt.Errorf("repo.SaveTask: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Auth get user session by token.
@@ -158,7 +257,15 @@ func (a *App) Auth(ctx context.Context, token string) (*dom.Session, error) {
 }
 
 // UpdateUser update user's profile.
-func (a *App) UpdateUser(ctx context.Context, session dom.Session, username string, avatarID uuid.UUID) error {
+// If profile is nil, profile attributes stay unchanged, otherwise they are replaced.
+func (a *App) UpdateUser(ctx context.Context, session dom.Session, username string, avatarID uuid.UUID, profile *Profile) error {
+	if profile != nil {
+		err := validateProfile(*profile, time.Now())
+		if err != nil {
+			return fmt.Errorf("validateProfile: %w", err)
+		}
+	}
+
 	u, err := a.repo.ByID(ctx, session.UserID)
 	if err != nil {
 		return fmt.Errorf("a.repo.ByID: %w", err)
@@ -169,12 +276,21 @@ func (a *App) UpdateUser(ctx context.Context, session dom.Session, username stri
 	}
 
 	if avatarID != uuid.Nil {
-		_, err = a.repo.GetAvatar(ctx, avatarID)
+		info, err := a.repo.GetAvatar(ctx, avatarID)
 		if err != nil {
 			return fmt.Errorf("a.repo.GetAvatarCache: %w", err)
 		}
+
+		if info.Status != AvatarStatusClean {
+			return fmt.Errorf("avatar %s: %w", avatarID, ErrNotFound)
+		}
 	}
 
+	if profile == nil {
+		profile = &u.Profile
+	}
+
+	renamed := username != u.Name
 	user := User{
 		ID:       u.ID,
 		Email:    u.Email,
@@ -183,16 +299,114 @@ func (a *App) UpdateUser(ctx context.Context, session dom.Session, username stri
 		PassHash: u.PassHash,
 		AvatarID: avatarID,
 		Status:   u.Status,
+		Profile:  *profile,
 	}
 
-	_, err = a.repo.Update(ctx, user)
+	return a.repo.Tx(ctx, func(repo Repo) error {
+		if renamed {
+			err := a.checkUsername(ctx, repo, u.ID, username)
+			if err != nil {
+				return fmt.Errorf("a.checkUsername: %w", err)
+			}
+		}
+
+		// Concurrent rename to the same name is rejected by unique index as ErrUsernameExist.
+		updated, err := repo.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("repo.Update: %w", err)
+		}
+
+		if avatarID != u.AvatarID {
+			task := Task{
+				User:     *updated,
+				Kind:     TaskKindEventAvatarChanged,
+				Activity: Activity{PrevAvatarID: u.AvatarID},
+			}
+
+			_, err = repo.SaveTask(ctx, task)
+			if err != nil {
+				return fmt.Errorf("repo.SaveTask: %w", err)
+			}
+		}
+
+		if !renamed {
+			return nil
+		}
+
+		err = a.saveRename(ctx, repo, *u, *updated)
+		if err != nil {
+			return fmt.Errorf("a.saveRename: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (a *App) GetUsersByIDs(ctx context.Context, _ dom.Session, ids []uuid.UUID) ([]User, error) {
+	users, err := a.repo.UsersByIDs(ctx, ids)
 	if err != nil {
-		return fmt.Errorf("a.repo.Update: %w", err)
+		return nil, fmt.Errorf("a.repo.UsersByIDs: %w", err)
 	}
 
-	return nil
+	err = a.withPrivacy(ctx, users)
+	if err != nil {
+		return nil, fmt.Errorf("a.withPrivacy: %w", err)
+	}
+
+	return users, nil
 }
 
-func (a *App) GetUsersByIDs(ctx context.Context, _ dom.Session, ids []uuid.UUID) ([]User, error) {
-	return a.repo.UsersByIDs(ctx, ids)
+// BatchGetUsers returns users by mix of ids, usernames and emails.
+// Keys for which users were not found are returned in BatchResult.Missing.
+// Users hiding email are missing by email for everybody except themselves and specialists,
+// otherwise email key of response reveals that email is registered.
+// Emails are matched case-insensitively like in Login and usernames are matched by normalized form
+// like in ResolveUsername, response is keyed by requested keys.
+func (a *App) BatchGetUsers(ctx context.Context, session dom.Session, keys BatchKeys) (*BatchResult, error) {
+	if keys.Len() > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	query := keys
+	query.Emails = make([]string, len(keys.Emails))
+	for i := range keys.Emails {
+		query.Emails[i] = strings.ToLower(keys.Emails[i])
+	}
+
+	users, err := a.repo.UsersByKeys(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.UsersByKeys: %w", err)
+	}
+
+	err = a.withPrivacy(ctx, users)
+	if err != nil {
+		return nil, fmt.Errorf("a.withPrivacy: %w", err)
+	}
+
+	byID := make(map[string]User, len(users))
+	byUsername := make(map[string]User, len(users))
+	byEmail := make(map[string]User, len(users))
+	for i := range users {
+		byID[users[i].ID.String()] = users[i]
+		byUsername[NormalizeUsername(users[i].Name)] = users[i]
+
+		hidden := users[i].Privacy.HideEmail && session.UserID != users[i].ID && !session.Status.IsSpecialist()
+		if !hidden {
+			byEmail[strings.ToLower(users[i].Email)] = users[i]
+		}
+	}
+
+	ids := make([]string, len(keys.IDs))
+	for i := range keys.IDs {
+		ids[i] = keys.IDs[i].String()
+	}
+
+	res := &BatchResult{
+		Users: make(map[string]User, keys.Len()),
+	}
+	res.match(ids, byID, func(id string) string { return id })
+	res.match(keys.Usernames, byUsername, NormalizeUsername)
+	res.match(keys.Emails, byEmail, strings.ToLower)
+
+	return res, nil
 }
@@ -2,6 +2,8 @@ package app_test
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -42,13 +44,29 @@ func TestApp_VerificationEmail(t *testing.T) {
 func TestApp_VerificationUsername(t *testing.T) {
 	t.Parallel()
 
+	var (
+		anotherUserChange = &app.UsernameChange{
+			ID:       uuid.Must(uuid.NewV4()),
+			UserID:   uuid.Must(uuid.NewV4()),
+			Username: "name",
+		}
+	)
+
 	testCases := map[string]struct {
-		repoError error
-		want      error
+		username      string
+		repoError     error
+		repoChange    *app.UsernameChange
+		repoChangeErr error
+		want          error
 	}{
-		"success":                    {app.ErrNotFound, nil},
-		"m.user.ByUsername_exist":    {nil, app.ErrUsernameExist},
-		"m.user.ByUsername_internal": {errAny, errAny},
+		"success":                            {"name", app.ErrNotFound, nil, app.ErrNotFound, nil},
+		"err_reserved":                       {"Admin", nil, nil, nil, app.ErrUsernameReserved},
+		"err_reserved_case_insensitive":      {"support", nil, nil, nil, app.ErrUsernameReserved},
+		"err_reserved_confusable":            {"аdmin", nil, nil, nil, app.ErrUsernameReserved},
+		"m.user.ByUsername_exist":            {"name", nil, nil, nil, app.ErrUsernameExist},
+		"m.user.ByUsername_internal":         {"name", errAny, nil, nil, errAny},
+		"m.user.LastUsernameChange_held":     {"name", app.ErrNotFound, anotherUserChange, nil, app.ErrUsernameExist},
+		"m.user.LastUsernameChange_internal": {"name", app.ErrNotFound, nil, errAny, errAny},
 	}
 
 	for name, tc := range testCases {
@@ -57,9 +75,18 @@ func TestApp_VerificationUsername(t *testing.T) {
 			t.Parallel()
 
 			ctx, module, mocks, assert := start(t)
-			mocks.repo.EXPECT().ByUsername(ctx, "name").Return(nil, tc.repoError)
+			if !errors.Is(tc.want, app.ErrUsernameReserved) {
+				var res *app.User
+				if tc.repoError == nil {
+					res = &app.User{ID: ownerID}
+				}
+				mocks.repo.EXPECT().ByUsername(ctx, tc.username).Return(res, tc.repoError)
+			}
+			if errors.Is(tc.repoError, app.ErrNotFound) {
+				mocks.repo.EXPECT().LastUsernameChange(ctx, tc.username, gomock.Any()).Return(tc.repoChange, tc.repoChangeErr)
+			}
 
-			err := module.VerificationUsername(ctx, "name")
+			err := module.VerificationUsername(ctx, tc.username)
 			assert.ErrorIs(err, tc.want)
 		})
 	}
@@ -123,6 +150,9 @@ func TestApp_CreateUser(t *testing.T) {
 					return fn(mocks.repo)
 				})
 
+				mocks.repo.EXPECT().ByUsername(ctx, username).Return(nil, app.ErrNotFound)
+				mocks.repo.EXPECT().LastUsernameChange(ctx, username, gomock.Any()).Return(nil, app.ErrNotFound)
+
 				mocks.repo.EXPECT().Save(ctx, app.User{
 					Email:    email,
 					Name:     username,
@@ -170,6 +200,21 @@ func TestApp_Login(t *testing.T) {
 		token = &dom.Token{
 			Value: "token",
 		}
+		loggedIn = app.Task{
+			User:     *user,
+			Kind:     app.TaskKindEventLoggedIn,
+			Activity: app.Activity{Origin: origin},
+		}
+		invalidPassword = app.Task{
+			User:     *user,
+			Kind:     app.TaskKindEventLoginFailed,
+			Activity: app.Activity{Origin: origin},
+		}
+		unknownEmail = app.Task{
+			User:     app.User{Email: email},
+			Kind:     app.TaskKindEventLoginFailed,
+			Activity: app.Activity{Origin: origin},
+		}
 	)
 
 	testCases := map[string]struct {
@@ -178,13 +223,20 @@ func TestApp_Login(t *testing.T) {
 		authRes          *dom.Token
 		authErr          error
 		hasherCompareRes bool
+		task             app.Task
+		saveTaskErr      error
 		wantUserID       uuid.UUID
 		wantToke         *dom.Token
 		wantErr          error
 	}{
-		"success":        {user, nil, token, nil, true, user.ID, token, nil},
-		"m.hash.Compare": {user, nil, nil, nil, false, uuid.Nil, nil, app.ErrInvalidPassword},
-		"m.user.ByEmail": {nil, app.ErrNotFound, nil, nil, false, uuid.Nil, nil, app.ErrNotFound},
+		"success":                {user, nil, token, nil, true, loggedIn, nil, user.ID, token, nil},
+		"m.hash.Compare":         {user, nil, nil, nil, false, invalidPassword, nil, uuid.Nil, nil, app.ErrInvalidPassword},
+		"m.user.ByEmail":         {nil, app.ErrNotFound, nil, nil, false, unknownEmail, nil, uuid.Nil, nil, app.ErrNotFound},
+		"m.user.ByEmail_any":     {nil, errAny, nil, nil, false, app.Task{}, nil, uuid.Nil, nil, errAny},
+		"m.sessions.Save":        {user, nil, nil, errAny, true, loggedIn, nil, uuid.Nil, nil, errAny},
+		"m.repo.SaveTask":        {user, nil, nil, nil, true, loggedIn, errAny, uuid.Nil, nil, errAny},
+		"m.repo.SaveTask_failed": {user, nil, nil, nil, false, invalidPassword, errAny, uuid.Nil, nil, errAny},
+		"m.repo.SaveTask_email":  {nil, app.ErrNotFound, nil, nil, false, unknownEmail, errAny, uuid.Nil, nil, errAny},
 	}
 
 	for name, tc := range testCases {
@@ -200,6 +252,14 @@ func TestApp_Login(t *testing.T) {
 			}
 
 			if tc.hasherCompareRes {
+				mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(r app.Repo) error) error {
+					return fn(mocks.repo)
+				})
+			}
+			if tc.task.Kind != 0 {
+				mocks.repo.EXPECT().SaveTask(ctx, tc.task).Return(uuid.Must(uuid.NewV4()), tc.saveTaskErr)
+			}
+			if tc.hasherCompareRes && tc.saveTaskErr == nil {
 				mocks.sessions.EXPECT().Save(ctx, user.ID, origin, dom.UserStatusDefault).Return(tc.authRes, tc.authErr)
 			}
 
@@ -211,6 +271,53 @@ func TestApp_Login(t *testing.T) {
 	}
 }
 
+func TestApp_Logout(t *testing.T) {
+	t.Parallel()
+
+	session := dom.Session{
+		ID:     uuid.Must(uuid.NewV4()),
+		UserID: uuid.Must(uuid.NewV4()),
+		Status: dom.UserStatusDefault,
+	}
+
+	testCases := map[string]struct {
+		deleteErr   error
+		saveTaskErr error
+		want        error
+	}{
+		"success":           {nil, nil, nil},
+		"m.sessions.Delete": {errAny, nil, errAny},
+		"m.repo.SaveTask":   {nil, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(r app.Repo) error) error {
+				return fn(mocks.repo)
+			})
+			mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+				User: app.User{
+					ID:     session.UserID,
+					Status: session.Status,
+				},
+				Kind:     app.TaskKindEventLoggedOut,
+				Activity: app.Activity{SessionID: session.ID},
+			}).Return(uuid.Must(uuid.NewV4()), tc.saveTaskErr)
+			if tc.saveTaskErr == nil {
+				mocks.sessions.EXPECT().Delete(ctx, session.ID).Return(tc.deleteErr)
+			}
+
+			err := module.Logout(ctx, session)
+			assert.ErrorIs(err, tc.want)
+		})
+	}
+}
+
 func TestApp_UserByID(t *testing.T) {
 	t.Parallel()
 
@@ -235,7 +342,15 @@ func TestApp_UserByID(t *testing.T) {
 			ID:     uuid.Must(uuid.NewV4()),
 			UserID: uuid.Must(uuid.NewV4()),
 		}
+		privacy = app.PrivacySettings{
+			UserID:         user.ID,
+			HideFromSearch: true,
+		}
+		want        = *user
+		wantDefault = *user
 	)
+	want.Privacy = privacy
+	wantDefault.Privacy = app.DefaultPrivacySettings(user.ID)
 
 	testCases := map[string]struct {
 		session      *dom.Session
@@ -243,11 +358,16 @@ func TestApp_UserByID(t *testing.T) {
 		userIDSearch uuid.UUID
 		repoRes      *app.User
 		repoErr      error
+		privacyRes   []app.PrivacySettings
+		privacyErr   error
 		want         *app.User
 		wantErr      error
 	}{
-		"success":      {session, user.ID, user.ID, user, nil, user, nil},
-		"success_self": {session2, uuid.Nil, session2.UserID, user, nil, user, nil},
+		"success":                    {session, user.ID, user.ID, user, nil, []app.PrivacySettings{privacy}, nil, &want, nil},
+		"success_self":               {session2, uuid.Nil, session2.UserID, user, nil, []app.PrivacySettings{privacy}, nil, &want, nil},
+		"success_default_privacy":    {session, user.ID, user.ID, user, nil, nil, nil, &wantDefault, nil},
+		"a.repo.ByID":                {session, user.ID, user.ID, nil, errAny, nil, nil, nil, errAny},
+		"a.repo.ListPrivacySettings": {session, user.ID, user.ID, user, nil, nil, errAny, nil, errAny},
 	}
 
 	for name, tc := range testCases {
@@ -258,6 +378,9 @@ func TestApp_UserByID(t *testing.T) {
 			ctx, module, mocks, assert := start(t)
 
 			mocks.repo.EXPECT().ByID(ctx, tc.userIDSearch).Return(tc.repoRes, tc.repoErr)
+			if tc.repoErr == nil {
+				mocks.repo.EXPECT().ListPrivacySettings(ctx, []uuid.UUID{user.ID}).Return(tc.privacyRes, tc.privacyErr)
+			}
 
 			res, err := module.UserByID(ctx, *tc.session, tc.userIDArg)
 			assert.Equal(tc.want, res)
@@ -296,13 +419,16 @@ func TestApp_UpdatePassword(t *testing.T) {
 		newPass              string
 		updateRes            *app.User
 		updateErr            error
+		saveTaskErr          error
 		want                 error
 	}{
-		"success":               {lo.ToPtr(user), nil, true, false, []byte("password"), nil, "pass", "password", &app.User{}, nil, nil},
-		"m.user.ByID":           {nil, app.ErrNotFound, false, true, nil, nil, "pass", "password", &app.User{}, nil, app.ErrNotFound},
-		"m.hash.Hashing":        {lo.ToPtr(user), nil, true, false, nil, errAny, "pass", "password", &app.User{}, nil, errAny},
-		"m.hash.Compare_second": {lo.ToPtr(user), nil, true, true, nil, nil, "pass", "pass", &app.User{}, nil, app.ErrNotDifferent},
-		"m.hash.Compare_first":  {lo.ToPtr(user), nil, false, true, nil, nil, "pass", "password", &app.User{}, nil, app.ErrInvalidPassword},
+		"success":               {lo.ToPtr(user), nil, true, false, []byte("password"), nil, "pass", "password", &app.User{ID: user.ID}, nil, nil, nil},
+		"m.user.ByID":           {nil, app.ErrNotFound, false, true, nil, nil, "pass", "password", &app.User{}, nil, nil, app.ErrNotFound},
+		"m.hash.Hashing":        {lo.ToPtr(user), nil, true, false, nil, errAny, "pass", "password", &app.User{}, nil, nil, errAny},
+		"m.hash.Compare_second": {lo.ToPtr(user), nil, true, true, nil, nil, "pass", "pass", &app.User{}, nil, nil, app.ErrNotDifferent},
+		"m.hash.Compare_first":  {lo.ToPtr(user), nil, false, true, nil, nil, "pass", "password", &app.User{}, nil, nil, app.ErrInvalidPassword},
+		"m.repo.Update":         {lo.ToPtr(user), nil, true, false, []byte("password"), nil, "pass", "password", nil, errAny, nil, errAny},
+		"m.repo.SaveTask":       {lo.ToPtr(user), nil, true, false, []byte("password"), nil, "pass", "password", &app.User{ID: user.ID}, nil, errAny, errAny},
 	}
 
 	for name, tc := range testCases {
@@ -327,10 +453,21 @@ func TestApp_UpdatePassword(t *testing.T) {
 			}
 
 			if tc.hashHashingErr == nil && tc.hashHashingRes != nil {
+				mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(r app.Repo) error) error {
+					return fn(mocks.repo)
+				})
+
 				tc.repoByIDRes.PassHash = tc.hashHashingRes
 				mocks.repo.EXPECT().Update(ctx, *tc.repoByIDRes).Return(tc.updateRes, tc.updateErr)
 			}
 
+			if tc.updateErr == nil && tc.hashHashingRes != nil {
+				mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+					User: *tc.updateRes,
+					Kind: app.TaskKindEventPasswordChanged,
+				}).Return(uuid.Must(uuid.NewV4()), tc.saveTaskErr)
+			}
+
 			err := module.UpdatePassword(ctx, session, tc.oldPass, tc.newPass)
 			assert.ErrorIs(err, tc.want)
 		})
@@ -361,26 +498,62 @@ func TestApp_UpdateUser(t *testing.T) {
 		}
 		newUserName = "new name"
 		newAvatarID = uuid.Must(uuid.NewV4())
+		quarantined = uuid.Must(uuid.NewV4())
+		newProfile  = &app.Profile{
+			Bio:      "bio",
+			Locale:   "en-US",
+			Timezone: "Europe/Moscow",
+			Birthday: time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Links:    []string{"https://example.com"},
+		}
+		invalidProfile = &app.Profile{
+			Timezone: "Unknown/Timezone",
+		}
+		heldChange = &app.UsernameChange{
+			ID:       uuid.Must(uuid.NewV4()),
+			UserID:   uuid.Must(uuid.NewV4()),
+			Username: newUserName,
+		}
+		ownChange = &app.UsernameChange{
+			ID:       uuid.Must(uuid.NewV4()),
+			UserID:   user.ID,
+			Username: newUserName,
+		}
 	)
+	user.Profile = app.Profile{Bio: "old bio"}
 
 	testCases := map[string]struct {
 		session             dom.Session
 		newUserName         string
 		newAvatarID         uuid.UUID
+		newProfile          *app.Profile
 		repoByIDRes         *app.User
 		repoByIDErr         error
 		repoGetFileCacheErr error
+		repoChangeRes       *app.UsernameChange
 		repoUpdateRes       *app.User
 		repoUpdateErr       error
+		repoSaveChangeErr   error
+		repoSaveTaskErr     error
 		want                error
 	}{
-		"success":                      {session, newUserName, newAvatarID, user, nil, nil, &app.User{}, nil, nil},
-		"success_avatar_id_is_empty":   {session, newUserName, uuid.Nil, user, nil, nil, &app.User{}, nil, nil},
-		"err_not_found_by_id":          {sessionAnotherUser, newUserName, newAvatarID, nil, app.ErrNotFound, nil, &app.User{}, nil, app.ErrNotFound},
-		"err_not_found_get_file_cache": {sessionAnotherUser, newUserName, newAvatarID, nil, nil, app.ErrNotFound, &app.User{}, nil, app.ErrNotFound},
-		"err_any_by_id":                {session, newUserName, newAvatarID, nil, errAny, nil, &app.User{}, nil, errAny},
-		"err_any_get_file_cache":       {session, newUserName, newAvatarID, nil, nil, errAny, &app.User{}, nil, errAny},
-		"err_any_update":               {session, newUserName, newAvatarID, user, nil, nil, &app.User{}, errAny, errAny},
+		"success":                      {session, newUserName, newAvatarID, nil, user, nil, nil, nil, &app.User{}, nil, nil, nil, nil},
+		"success_avatar_id_is_empty":   {session, newUserName, uuid.Nil, nil, user, nil, nil, nil, &app.User{}, nil, nil, nil, nil},
+		"success_profile":              {session, newUserName, newAvatarID, newProfile, user, nil, nil, nil, &app.User{}, nil, nil, nil, nil},
+		"success_same_username":        {session, user.Name, newAvatarID, nil, user, nil, nil, nil, &app.User{}, nil, nil, nil, nil},
+		"success_reclaim_own_username": {session, newUserName, newAvatarID, nil, user, nil, nil, ownChange, &app.User{}, nil, nil, nil, nil},
+		"err_invalid_profile":          {session, newUserName, newAvatarID, invalidProfile, nil, nil, nil, nil, nil, nil, nil, nil, app.ErrInvalidArgument},
+		"err_not_found_by_id":          {sessionAnotherUser, newUserName, newAvatarID, nil, nil, app.ErrNotFound, nil, nil, &app.User{}, nil, nil, nil, app.ErrNotFound},
+		"err_not_found_get_file_cache": {sessionAnotherUser, newUserName, newAvatarID, nil, nil, nil, app.ErrNotFound, nil, &app.User{}, nil, nil, nil, app.ErrNotFound},
+		"err_any_by_id":                {session, newUserName, newAvatarID, nil, nil, errAny, nil, nil, &app.User{}, nil, nil, nil, errAny},
+		"err_any_get_file_cache":       {session, newUserName, newAvatarID, nil, nil, nil, errAny, nil, &app.User{}, nil, nil, nil, errAny},
+		"err_quarantined_avatar":       {session, newUserName, quarantined, nil, user, nil, nil, nil, &app.User{}, nil, nil, nil, app.ErrNotFound},
+		"err_username_reserved":        {session, "admin", newAvatarID, nil, user, nil, nil, nil, nil, nil, nil, nil, app.ErrUsernameReserved},
+		"err_username_held":            {session, newUserName, newAvatarID, nil, user, nil, nil, heldChange, nil, nil, nil, nil, app.ErrUsernameExist},
+		"err_any_update":               {session, newUserName, newAvatarID, nil, user, nil, nil, nil, &app.User{}, errAny, nil, nil, errAny},
+		"err_username_taken_on_update": {session, newUserName, newAvatarID, nil, user, nil, nil, nil, nil, app.ErrUsernameExist, nil, nil, app.ErrUsernameExist},
+		"err_any_save_username_change": {session, newUserName, newAvatarID, nil, user, nil, nil, nil, &app.User{}, nil, errAny, nil, errAny},
+		"err_any_save_task":            {session, newUserName, newAvatarID, nil, user, nil, nil, nil, &app.User{}, nil, nil, errAny, errAny},
 	}
 
 	for name, tc := range testCases {
@@ -390,6 +563,13 @@ func TestApp_UpdateUser(t *testing.T) {
 
 			ctx, module, mocks, assert := start(t)
 
+			if tc.newProfile == invalidProfile {
+				err := module.UpdateUser(ctx, tc.session, tc.newUserName, tc.newAvatarID, tc.newProfile)
+				assert.ErrorIs(err, tc.want)
+
+				return
+			}
+
 			mocks.repo.EXPECT().ByID(ctx, tc.session.UserID).Return(tc.repoByIDRes, tc.repoByIDErr)
 
 			if tc.newAvatarID == uuid.Nil {
@@ -397,10 +577,43 @@ func TestApp_UpdateUser(t *testing.T) {
 			}
 
 			if tc.repoByIDErr == nil && tc.newAvatarID != uuid.Nil {
-				mocks.repo.EXPECT().GetAvatar(ctx, tc.newAvatarID).Return(nil, tc.repoGetFileCacheErr)
+				var info *app.AvatarInfo
+				if tc.repoGetFileCacheErr == nil {
+					info = &app.AvatarInfo{FileID: tc.newAvatarID, Status: app.AvatarStatusClean}
+					if tc.newAvatarID == quarantined {
+						info.Status = app.AvatarStatusQuarantined
+					}
+				}
+				mocks.repo.EXPECT().GetAvatar(ctx, tc.newAvatarID).Return(info, tc.repoGetFileCacheErr)
 			}
 
-			if tc.repoByIDErr == nil && tc.repoGetFileCacheErr == nil {
+			if tc.repoByIDErr != nil || tc.repoGetFileCacheErr != nil || tc.newAvatarID == quarantined {
+				err := module.UpdateUser(ctx, tc.session, tc.newUserName, tc.newAvatarID, tc.newProfile)
+				assert.ErrorIs(err, tc.want)
+
+				return
+			}
+
+			mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(r app.Repo) error) error {
+				return fn(mocks.repo)
+			})
+
+			renamed := tc.newUserName != tc.repoByIDRes.Name
+			if renamed && !errors.Is(tc.want, app.ErrUsernameReserved) {
+				changeErr := app.ErrNotFound
+				if tc.repoChangeRes != nil {
+					changeErr = nil
+				}
+				mocks.repo.EXPECT().ByUsername(ctx, tc.newUserName).Return(nil, app.ErrNotFound)
+				mocks.repo.EXPECT().LastUsernameChange(ctx, tc.newUserName, gomock.Any()).Return(tc.repoChangeRes, changeErr)
+			}
+
+			if tc.repoChangeRes != heldChange && !errors.Is(tc.want, app.ErrUsernameReserved) {
+				profile := tc.repoByIDRes.Profile
+				if tc.newProfile != nil {
+					profile = *tc.newProfile
+				}
+
 				updateUser := app.User{
 					ID:        tc.session.UserID,
 					Email:     tc.repoByIDRes.Email,
@@ -409,14 +622,153 @@ func TestApp_UpdateUser(t *testing.T) {
 					PassHash:  tc.repoByIDRes.PassHash,
 					AvatarID:  tc.newAvatarID,
 					Status:    tc.repoByIDRes.Status,
+					Profile:   profile,
 					CreatedAt: time.Time{},
 					UpdatedAt: time.Time{},
 				}
 				mocks.repo.EXPECT().Update(ctx, updateUser).Return(tc.repoUpdateRes, tc.repoUpdateErr)
+
+				avatarChanged := tc.newAvatarID != tc.repoByIDRes.AvatarID
+				if avatarChanged && tc.repoUpdateErr == nil {
+					mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+						User:     *tc.repoUpdateRes,
+						Kind:     app.TaskKindEventAvatarChanged,
+						Activity: app.Activity{PrevAvatarID: tc.repoByIDRes.AvatarID},
+					}).Return(uuid.Must(uuid.NewV4()), tc.repoSaveTaskErr)
+				}
+
+				if renamed && tc.repoUpdateErr == nil && tc.repoSaveTaskErr == nil {
+					mocks.repo.EXPECT().SaveUsernameChange(ctx, app.UsernameChange{
+						UserID:   tc.repoByIDRes.ID,
+						Username: tc.repoByIDRes.Name,
+					}).Return(tc.repoSaveChangeErr)
+				}
+
+				if renamed && tc.repoUpdateErr == nil && tc.repoSaveTaskErr == nil && tc.repoSaveChangeErr == nil {
+					mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+						User: *tc.repoUpdateRes,
+						Kind: app.TaskKindEventUpdate,
+					}).Return(uuid.Must(uuid.NewV4()), nil)
+				}
 			}
 
-			err := module.UpdateUser(ctx, tc.session, tc.newUserName, tc.newAvatarID)
+			err := module.UpdateUser(ctx, tc.session, tc.newUserName, tc.newAvatarID, tc.newProfile)
 			assert.ErrorIs(err, tc.want)
 		})
 	}
 }
+
+func TestApp_BatchGetUsers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		user1 = app.User{
+			ID:     uuid.Must(uuid.NewV4()),
+			Email:  "first@email.com",
+			Name:   "first",
+			Status: dom.UserStatusDefault,
+		}
+		user2 = app.User{
+			ID:     uuid.Must(uuid.NewV4()),
+			Email:  "second@email.com",
+			Name:   "second",
+			Status: dom.UserStatusDefault,
+		}
+		missingID = uuid.Must(uuid.NewV4())
+		keys      = app.BatchKeys{
+			IDs:       []uuid.UUID{user1.ID, missingID},
+			Usernames: []string{user2.Name, "unknown"},
+			Emails:    []string{user1.Email},
+		}
+		privacy1    = app.PrivacySettings{UserID: user1.ID}
+		privacy2    = app.PrivacySettings{UserID: user2.ID, HideStatus: true}
+		hiddenEmail = app.DefaultPrivacySettings(user1.ID)
+		want        = &app.BatchResult{
+			Users: map[string]app.User{
+				user1.ID.String(): withPrivacy(user1, privacy1),
+				user2.Name:        withPrivacy(user2, privacy2),
+				user1.Email:       withPrivacy(user1, privacy1),
+			},
+			Missing: []string{missingID.String(), "unknown"},
+		}
+		wantHidden = &app.BatchResult{
+			Users: map[string]app.User{
+				user1.ID.String(): withPrivacy(user1, hiddenEmail),
+				user2.Name:        withPrivacy(user2, privacy2),
+			},
+			Missing: []string{missingID.String(), "unknown", user1.Email},
+		}
+		wantShown = &app.BatchResult{
+			Users: map[string]app.User{
+				user1.ID.String(): withPrivacy(user1, hiddenEmail),
+				user2.Name:        withPrivacy(user2, privacy2),
+				user1.Email:       withPrivacy(user1, hiddenEmail),
+			},
+			Missing: []string{missingID.String(), "unknown"},
+		}
+		mixedCase = app.BatchKeys{
+			Usernames: []string{"SECOND"},
+			Emails:    []string{"First@Email.COM"},
+		}
+		wantMixedCase = &app.BatchResult{
+			Users: map[string]app.User{
+				"SECOND":          withPrivacy(user2, privacy2),
+				"First@Email.COM": withPrivacy(user1, privacy1),
+			},
+		}
+		tooLarge = app.BatchKeys{
+			Usernames: make([]string, app.MaxBatchSize+1),
+		}
+		stranger   = dom.Session{UserID: uuid.Must(uuid.NewV4()), Status: dom.UserStatusDefault}
+		owner      = dom.Session{UserID: user1.ID, Status: dom.UserStatusDefault}
+		specialist = dom.Session{UserID: uuid.Must(uuid.NewV4()), Status: dom.UserStatusSupport}
+	)
+
+	testCases := map[string]struct {
+		session    dom.Session
+		keys       app.BatchKeys
+		repoRes    []app.User
+		repoErr    error
+		privacyRes []app.PrivacySettings
+		want       *app.BatchResult
+		wantErr    error
+	}{
+		"success":                 {stranger, keys, []app.User{user1, user2}, nil, []app.PrivacySettings{privacy1, privacy2}, want, nil},
+		"hidden_email_stranger":   {stranger, keys, []app.User{user1, user2}, nil, []app.PrivacySettings{privacy2}, wantHidden, nil},
+		"hidden_email_owner":      {owner, keys, []app.User{user1, user2}, nil, []app.PrivacySettings{privacy2}, wantShown, nil},
+		"hidden_email_specialist": {specialist, keys, []app.User{user1, user2}, nil, []app.PrivacySettings{privacy2}, wantShown, nil},
+		"mixed_case":              {stranger, mixedCase, []app.User{user1, user2}, nil, []app.PrivacySettings{privacy1, privacy2}, wantMixedCase, nil},
+		"a.repo.UsersByKeys":      {stranger, keys, nil, errAny, nil, nil, errAny},
+		"err_batch_too_large":     {stranger, tooLarge, nil, nil, nil, nil, app.ErrBatchTooLarge},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			if tc.keys.Len() <= app.MaxBatchSize {
+				// Emails are queried lowercased.
+				query := tc.keys
+				query.Emails = lo.Map(tc.keys.Emails, func(email string, _ int) string { return strings.ToLower(email) })
+				mocks.repo.EXPECT().UsersByKeys(ctx, query).Return(tc.repoRes, tc.repoErr)
+			}
+			if tc.repoErr == nil && len(tc.repoRes) != 0 {
+				mocks.repo.EXPECT().ListPrivacySettings(ctx, []uuid.UUID{user1.ID, user2.ID}).
+					Return(tc.privacyRes, nil)
+			}
+
+			res, err := module.BatchGetUsers(ctx, tc.session, tc.keys)
+			assert.Equal(tc.want, res)
+			assert.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
+
+func withPrivacy(u app.User, settings app.PrivacySettings) app.User {
+	u.Privacy = settings
+
+	return u
+}
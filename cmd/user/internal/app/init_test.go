@@ -3,8 +3,10 @@ package app_test
 import (
 	"context"
 	"errors"
+	"io"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/require"
@@ -15,8 +17,6 @@ import (
 	"github.com/ZergsLaw/back-template1/internal/testhelper"
 )
 
-const pngFilePath = `./testdata/test.png`
-
 var (
 	errAny = errors.New("any error")
 	origin = dom.Origin{
@@ -25,14 +25,31 @@ var (
 	}
 	ownerID = uuid.Must(uuid.NewV4())
 	fileID  = uuid.Must(uuid.NewV4())
+	config  = app.Config{
+		ReservedUsernames:  []string{"admin", "Support"},
+		UsernameCooldown:   time.Hour,
+		PresignedURLTTL:    15 * time.Minute,
+		ResumableUploadTTL: 24 * time.Hour,
+		// Only premium users are limited, so most tests don't expect quota checks.
+		StorageQuotas: map[dom.UserStatus]int64{dom.UserStatusPremium: 1 << 20},
+	}
 )
 
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
 type mocks struct {
 	hasher   *MockPasswordHash
 	repo     *MockRepo
 	sessions *MockSessions
 	file     *MockFileStore
+	image    *MockImageProcessor
+	scanner  *MockScanner
 	queue    *MockQueue
+	metrics  *MockMetrics
 }
 
 func start(t *testing.T) (context.Context, *app.App, *mocks, *require.Assertions) {
@@ -43,16 +60,22 @@ func start(t *testing.T) (context.Context, *app.App, *mocks, *require.Assertions
 	mockHasher := NewMockPasswordHash(ctrl)
 	mockSession := NewMockSessions(ctrl)
 	mockFileStore := NewMockFileStore(ctrl)
+	mockImage := NewMockImageProcessor(ctrl)
+	mockScanner := NewMockScanner(ctrl)
 	mockQueue := NewMockQueue(ctrl)
+	mockMetrics := NewMockMetrics(ctrl)
 
-	module := app.New(mockRepo, mockHasher, mockSession, mockFileStore, mockQueue)
+	module := app.New(mockRepo, mockHasher, mockSession, mockFileStore, mockImage, mockScanner, mockQueue, mockMetrics, config)
 
 	mocks := &mocks{
 		hasher:   mockHasher,
 		repo:     mockRepo,
 		sessions: mockSession,
 		file:     mockFileStore,
+		image:    mockImage,
+		scanner:  mockScanner,
 		queue:    mockQueue,
+		metrics:  mockMetrics,
 	}
 
 	return testhelper.Context(t), module, mocks, require.New(t)
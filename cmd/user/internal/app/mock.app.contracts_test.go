@@ -10,7 +10,10 @@ package app_test
 
 import (
 	context "context"
+	io "io"
+	url "net/url"
 	reflect "reflect"
+	time "time"
 
 	app "github.com/ZergsLaw/back-template1/cmd/user/internal/app"
 	dom "github.com/ZergsLaw/back-template1/internal/dom"
@@ -41,6 +44,36 @@ func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
 	return m.recorder
 }
 
+// AddStorageUsage mocks base method.
+func (m *MockRepo) AddStorageUsage(ctx context.Context, userID uuid.UUID, delta int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStorageUsage", ctx, userID, delta)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStorageUsage indicates an expected call of AddStorageUsage.
+func (mr *MockRepoMockRecorder) AddStorageUsage(ctx, userID, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStorageUsage", reflect.TypeOf((*MockRepo)(nil).AddStorageUsage), ctx, userID, delta)
+}
+
+// ArchiveFinishedTasks mocks base method.
+func (m *MockRepo) ArchiveFinishedTasks(ctx context.Context, before time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveFinishedTasks", ctx, before, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveFinishedTasks indicates an expected call of ArchiveFinishedTasks.
+func (mr *MockRepoMockRecorder) ArchiveFinishedTasks(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveFinishedTasks", reflect.TypeOf((*MockRepo)(nil).ArchiveFinishedTasks), ctx, before, limit)
+}
+
 // ByEmail mocks base method.
 func (m *MockRepo) ByEmail(arg0 context.Context, arg1 string) (*app.User, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +119,35 @@ func (mr *MockRepoMockRecorder) ByUsername(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByUsername", reflect.TypeOf((*MockRepo)(nil).ByUsername), arg0, arg1)
 }
 
+// ClaimTasks mocks base method.
+func (m *MockRepo) ClaimTasks(ctx context.Context, workerID string, lease time.Duration, limit int) ([]app.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTasks", ctx, workerID, lease, limit)
+	ret0, _ := ret[0].([]app.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimTasks indicates an expected call of ClaimTasks.
+func (mr *MockRepoMockRecorder) ClaimTasks(ctx, workerID, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTasks", reflect.TypeOf((*MockRepo)(nil).ClaimTasks), ctx, workerID, lease, limit)
+}
+
+// CompleteAvatarUpload mocks base method.
+func (m *MockRepo) CompleteAvatarUpload(ctx context.Context, id, avatarID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAvatarUpload", ctx, id, avatarID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteAvatarUpload indicates an expected call of CompleteAvatarUpload.
+func (mr *MockRepoMockRecorder) CompleteAvatarUpload(ctx, id, avatarID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAvatarUpload", reflect.TypeOf((*MockRepo)(nil).CompleteAvatarUpload), ctx, id, avatarID)
+}
+
 // DeleteAvatar mocks base method.
 func (m *MockRepo) DeleteAvatar(ctx context.Context, userID, fileID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -100,18 +162,75 @@ func (mr *MockRepoMockRecorder) DeleteAvatar(ctx, userID, fileID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatar", reflect.TypeOf((*MockRepo)(nil).DeleteAvatar), ctx, userID, fileID)
 }
 
-// FinishTask mocks base method.
-func (m *MockRepo) FinishTask(arg0 context.Context, arg1 uuid.UUID) error {
+// DeleteAvatarUpload mocks base method.
+func (m *MockRepo) DeleteAvatarUpload(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatarUpload", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAvatarUpload indicates an expected call of DeleteAvatarUpload.
+func (mr *MockRepoMockRecorder) DeleteAvatarUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatarUpload", reflect.TypeOf((*MockRepo)(nil).DeleteAvatarUpload), ctx, id)
+}
+
+// DeleteFinishedTasks mocks base method.
+func (m *MockRepo) DeleteFinishedTasks(ctx context.Context, before time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinishedTasks", ctx, before, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinishedTasks indicates an expected call of DeleteFinishedTasks.
+func (mr *MockRepoMockRecorder) DeleteFinishedTasks(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinishedTasks", reflect.TypeOf((*MockRepo)(nil).DeleteFinishedTasks), ctx, before, limit)
+}
+
+// DiscardDeadTask mocks base method.
+func (m *MockRepo) DiscardDeadTask(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardDeadTask", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardDeadTask indicates an expected call of DiscardDeadTask.
+func (mr *MockRepoMockRecorder) DiscardDeadTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardDeadTask", reflect.TypeOf((*MockRepo)(nil).DiscardDeadTask), ctx, id)
+}
+
+// FailTask mocks base method.
+func (m *MockRepo) FailTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string, delay time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailTask", ctx, workerID, id, lastErr, delay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailTask indicates an expected call of FailTask.
+func (mr *MockRepoMockRecorder) FailTask(ctx, workerID, id, lastErr, delay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailTask", reflect.TypeOf((*MockRepo)(nil).FailTask), ctx, workerID, id, lastErr, delay)
+}
+
+// FinishTasks mocks base method.
+func (m *MockRepo) FinishTasks(ctx context.Context, workerID string, ids []uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FinishTask", arg0, arg1)
+	ret := m.ctrl.Call(m, "FinishTasks", ctx, workerID, ids)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// FinishTask indicates an expected call of FinishTask.
-func (mr *MockRepoMockRecorder) FinishTask(arg0, arg1 any) *gomock.Call {
+// FinishTasks indicates an expected call of FinishTasks.
+func (mr *MockRepoMockRecorder) FinishTasks(ctx, workerID, ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTask", reflect.TypeOf((*MockRepo)(nil).FinishTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTasks", reflect.TypeOf((*MockRepo)(nil).FinishTasks), ctx, workerID, ids)
 }
 
 // GetAvatar mocks base method.
@@ -129,6 +248,21 @@ func (mr *MockRepoMockRecorder) GetAvatar(ctx, fileID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatar", reflect.TypeOf((*MockRepo)(nil).GetAvatar), ctx, fileID)
 }
 
+// GetAvatarUpload mocks base method.
+func (m *MockRepo) GetAvatarUpload(ctx context.Context, id uuid.UUID) (*app.AvatarUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarUpload", ctx, id)
+	ret0, _ := ret[0].(*app.AvatarUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarUpload indicates an expected call of GetAvatarUpload.
+func (mr *MockRepoMockRecorder) GetAvatarUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarUpload", reflect.TypeOf((*MockRepo)(nil).GetAvatarUpload), ctx, id)
+}
+
 // GetCountAvatars mocks base method.
 func (m *MockRepo) GetCountAvatars(ctx context.Context, ownerID uuid.UUID) (int, error) {
 	m.ctrl.T.Helper()
@@ -144,19 +278,93 @@ func (mr *MockRepoMockRecorder) GetCountAvatars(ctx, ownerID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountAvatars", reflect.TypeOf((*MockRepo)(nil).GetCountAvatars), ctx, ownerID)
 }
 
-// ListActualTask mocks base method.
-func (m *MockRepo) ListActualTask(arg0 context.Context, arg1 int) ([]app.Task, error) {
+// GetStorageUsage mocks base method.
+func (m *MockRepo) GetStorageUsage(ctx context.Context, userID uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListActualTask", arg0, arg1)
-	ret0, _ := ret[0].([]app.Task)
+	ret := m.ctrl.Call(m, "GetStorageUsage", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageUsage indicates an expected call of GetStorageUsage.
+func (mr *MockRepoMockRecorder) GetStorageUsage(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageUsage", reflect.TypeOf((*MockRepo)(nil).GetStorageUsage), ctx, userID)
+}
+
+// GetTaskBacklog mocks base method.
+func (m *MockRepo) GetTaskBacklog(ctx context.Context) (*app.TaskBacklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskBacklog", ctx)
+	ret0, _ := ret[0].(*app.TaskBacklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskBacklog indicates an expected call of GetTaskBacklog.
+func (mr *MockRepoMockRecorder) GetTaskBacklog(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskBacklog", reflect.TypeOf((*MockRepo)(nil).GetTaskBacklog), ctx)
+}
+
+// KillTask mocks base method.
+func (m *MockRepo) KillTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillTask", ctx, workerID, id, lastErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KillTask indicates an expected call of KillTask.
+func (mr *MockRepoMockRecorder) KillTask(ctx, workerID, id, lastErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillTask", reflect.TypeOf((*MockRepo)(nil).KillTask), ctx, workerID, id, lastErr)
+}
+
+// LastUsernameChange mocks base method.
+func (m *MockRepo) LastUsernameChange(ctx context.Context, username string, since time.Time) (*app.UsernameChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastUsernameChange", ctx, username, since)
+	ret0, _ := ret[0].(*app.UsernameChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastUsernameChange indicates an expected call of LastUsernameChange.
+func (mr *MockRepoMockRecorder) LastUsernameChange(ctx, username, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUsernameChange", reflect.TypeOf((*MockRepo)(nil).LastUsernameChange), ctx, username, since)
+}
+
+// LeaseAvatarUpload mocks base method.
+func (m *MockRepo) LeaseAvatarUpload(ctx context.Context, id uuid.UUID, offset int64, writerID string, lease time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseAvatarUpload", ctx, id, offset, writerID, lease)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaseAvatarUpload indicates an expected call of LeaseAvatarUpload.
+func (mr *MockRepoMockRecorder) LeaseAvatarUpload(ctx, id, offset, writerID, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseAvatarUpload", reflect.TypeOf((*MockRepo)(nil).LeaseAvatarUpload), ctx, id, offset, writerID, lease)
+}
+
+// LeaseJob mocks base method.
+func (m *MockRepo) LeaseJob(ctx context.Context, job, workerID string, lease time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseJob", ctx, job, workerID, lease)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListActualTask indicates an expected call of ListActualTask.
-func (mr *MockRepoMockRecorder) ListActualTask(arg0, arg1 any) *gomock.Call {
+// LeaseJob indicates an expected call of LeaseJob.
+func (mr *MockRepoMockRecorder) LeaseJob(ctx, job, workerID, lease any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActualTask", reflect.TypeOf((*MockRepo)(nil).ListActualTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseJob", reflect.TypeOf((*MockRepo)(nil).LeaseJob), ctx, job, workerID, lease)
 }
 
 // ListAvatarByUserID mocks base method.
@@ -174,6 +382,139 @@ func (mr *MockRepoMockRecorder) ListAvatarByUserID(ctx, userID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvatarByUserID", reflect.TypeOf((*MockRepo)(nil).ListAvatarByUserID), ctx, userID)
 }
 
+// ListAvatars mocks base method.
+func (m *MockRepo) ListAvatars(ctx context.Context, after uuid.UUID, limit int) ([]app.AvatarInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvatars", ctx, after, limit)
+	ret0, _ := ret[0].([]app.AvatarInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvatars indicates an expected call of ListAvatars.
+func (mr *MockRepoMockRecorder) ListAvatars(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvatars", reflect.TypeOf((*MockRepo)(nil).ListAvatars), ctx, after, limit)
+}
+
+// ListDeadTasks mocks base method.
+func (m *MockRepo) ListDeadTasks(ctx context.Context, limit, offset int) ([]app.Task, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadTasks", ctx, limit, offset)
+	ret0, _ := ret[0].([]app.Task)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeadTasks indicates an expected call of ListDeadTasks.
+func (mr *MockRepoMockRecorder) ListDeadTasks(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadTasks", reflect.TypeOf((*MockRepo)(nil).ListDeadTasks), ctx, limit, offset)
+}
+
+// ListExpiredAvatarUploads mocks base method.
+func (m *MockRepo) ListExpiredAvatarUploads(ctx context.Context, before time.Time, limit int) ([]app.AvatarUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredAvatarUploads", ctx, before, limit)
+	ret0, _ := ret[0].([]app.AvatarUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredAvatarUploads indicates an expected call of ListExpiredAvatarUploads.
+func (mr *MockRepoMockRecorder) ListExpiredAvatarUploads(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredAvatarUploads", reflect.TypeOf((*MockRepo)(nil).ListExpiredAvatarUploads), ctx, before, limit)
+}
+
+// ListPreferences mocks base method.
+func (m *MockRepo) ListPreferences(ctx context.Context, userID uuid.UUID) ([]app.Preference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPreferences", ctx, userID)
+	ret0, _ := ret[0].([]app.Preference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPreferences indicates an expected call of ListPreferences.
+func (mr *MockRepoMockRecorder) ListPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPreferences", reflect.TypeOf((*MockRepo)(nil).ListPreferences), ctx, userID)
+}
+
+// ListPrivacySettings mocks base method.
+func (m *MockRepo) ListPrivacySettings(ctx context.Context, userIDs []uuid.UUID) ([]app.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrivacySettings", ctx, userIDs)
+	ret0, _ := ret[0].([]app.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrivacySettings indicates an expected call of ListPrivacySettings.
+func (mr *MockRepoMockRecorder) ListPrivacySettings(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrivacySettings", reflect.TypeOf((*MockRepo)(nil).ListPrivacySettings), ctx, userIDs)
+}
+
+// ListUnnormalizedUsers mocks base method.
+func (m *MockRepo) ListUnnormalizedUsers(ctx context.Context, limit int) ([]app.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnnormalizedUsers", ctx, limit)
+	ret0, _ := ret[0].([]app.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnnormalizedUsers indicates an expected call of ListUnnormalizedUsers.
+func (mr *MockRepoMockRecorder) ListUnnormalizedUsers(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnnormalizedUsers", reflect.TypeOf((*MockRepo)(nil).ListUnnormalizedUsers), ctx, limit)
+}
+
+// ListenTasks mocks base method.
+func (m *MockRepo) ListenTasks(ctx context.Context, notify chan<- struct{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenTasks", ctx, notify)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListenTasks indicates an expected call of ListenTasks.
+func (mr *MockRepoMockRecorder) ListenTasks(ctx, notify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenTasks", reflect.TypeOf((*MockRepo)(nil).ListenTasks), ctx, notify)
+}
+
+// NormalizeUsernameHistory mocks base method.
+func (m *MockRepo) NormalizeUsernameHistory(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeUsernameHistory", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NormalizeUsernameHistory indicates an expected call of NormalizeUsernameHistory.
+func (mr *MockRepoMockRecorder) NormalizeUsernameHistory(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeUsernameHistory", reflect.TypeOf((*MockRepo)(nil).NormalizeUsernameHistory), ctx)
+}
+
+// RetryDeadTask mocks base method.
+func (m *MockRepo) RetryDeadTask(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDeadTask", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryDeadTask indicates an expected call of RetryDeadTask.
+func (mr *MockRepoMockRecorder) RetryDeadTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDeadTask", reflect.TypeOf((*MockRepo)(nil).RetryDeadTask), ctx, id)
+}
+
 // Save mocks base method.
 func (m *MockRepo) Save(arg0 context.Context, arg1 app.User) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +544,49 @@ func (mr *MockRepoMockRecorder) SaveAvatar(ctx, fileCache any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAvatar", reflect.TypeOf((*MockRepo)(nil).SaveAvatar), ctx, fileCache)
 }
 
+// SaveAvatarUpload mocks base method.
+func (m *MockRepo) SaveAvatarUpload(ctx context.Context, upload app.AvatarUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAvatarUpload", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAvatarUpload indicates an expected call of SaveAvatarUpload.
+func (mr *MockRepoMockRecorder) SaveAvatarUpload(ctx, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAvatarUpload", reflect.TypeOf((*MockRepo)(nil).SaveAvatarUpload), ctx, upload)
+}
+
+// SavePreferences mocks base method.
+func (m *MockRepo) SavePreferences(ctx context.Context, prefs []app.Preference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreferences", ctx, prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreferences indicates an expected call of SavePreferences.
+func (mr *MockRepoMockRecorder) SavePreferences(ctx, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferences", reflect.TypeOf((*MockRepo)(nil).SavePreferences), ctx, prefs)
+}
+
+// SavePrivacySettings mocks base method.
+func (m *MockRepo) SavePrivacySettings(ctx context.Context, settings app.PrivacySettings) (*app.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePrivacySettings", ctx, settings)
+	ret0, _ := ret[0].(*app.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePrivacySettings indicates an expected call of SavePrivacySettings.
+func (mr *MockRepoMockRecorder) SavePrivacySettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePrivacySettings", reflect.TypeOf((*MockRepo)(nil).SavePrivacySettings), ctx, settings)
+}
+
 // SaveTask mocks base method.
 func (m *MockRepo) SaveTask(arg0 context.Context, arg1 app.Task) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -218,6 +602,20 @@ func (mr *MockRepoMockRecorder) SaveTask(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTask", reflect.TypeOf((*MockRepo)(nil).SaveTask), arg0, arg1)
 }
 
+// SaveUsernameChange mocks base method.
+func (m *MockRepo) SaveUsernameChange(ctx context.Context, change app.UsernameChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUsernameChange", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUsernameChange indicates an expected call of SaveUsernameChange.
+func (mr *MockRepoMockRecorder) SaveUsernameChange(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUsernameChange", reflect.TypeOf((*MockRepo)(nil).SaveUsernameChange), ctx, change)
+}
+
 // SearchUsers mocks base method.
 func (m *MockRepo) SearchUsers(arg0 context.Context, arg1 app.SearchParams) ([]app.User, int, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +632,48 @@ func (mr *MockRepoMockRecorder) SearchUsers(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockRepo)(nil).SearchUsers), arg0, arg1)
 }
 
+// SetAvatarPosition mocks base method.
+func (m *MockRepo) SetAvatarPosition(ctx context.Context, fileID uuid.UUID, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvatarPosition", ctx, fileID, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvatarPosition indicates an expected call of SetAvatarPosition.
+func (mr *MockRepoMockRecorder) SetAvatarPosition(ctx, fileID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvatarPosition", reflect.TypeOf((*MockRepo)(nil).SetAvatarPosition), ctx, fileID, position)
+}
+
+// SetAvatarStatus mocks base method.
+func (m *MockRepo) SetAvatarStatus(ctx context.Context, fileID uuid.UUID, status app.AvatarStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvatarStatus", ctx, fileID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvatarStatus indicates an expected call of SetAvatarStatus.
+func (mr *MockRepoMockRecorder) SetAvatarStatus(ctx, fileID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvatarStatus", reflect.TypeOf((*MockRepo)(nil).SetAvatarStatus), ctx, fileID, status)
+}
+
+// SetAvatarUploadOffset mocks base method.
+func (m *MockRepo) SetAvatarUploadOffset(ctx context.Context, id uuid.UUID, writerID string, offset int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvatarUploadOffset", ctx, id, writerID, offset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvatarUploadOffset indicates an expected call of SetAvatarUploadOffset.
+func (mr *MockRepoMockRecorder) SetAvatarUploadOffset(ctx, id, writerID, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvatarUploadOffset", reflect.TypeOf((*MockRepo)(nil).SetAvatarUploadOffset), ctx, id, writerID, offset)
+}
+
 // Tx mocks base method.
 func (m *MockRepo) Tx(ctx context.Context, f func(app.Repo) error) error {
 	m.ctrl.T.Helper()
@@ -278,12 +718,27 @@ func (mr *MockRepoMockRecorder) UsersByIDs(ctx, ids any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsersByIDs", reflect.TypeOf((*MockRepo)(nil).UsersByIDs), ctx, ids)
 }
 
-// MockFileInfoRepo is a mock of FileInfoRepo interface.
-type MockFileInfoRepo struct {
-	ctrl     *gomock.Controller
-	recorder *MockFileInfoRepoMockRecorder
-}
-
+// UsersByKeys mocks base method.
+func (m *MockRepo) UsersByKeys(ctx context.Context, keys app.BatchKeys) ([]app.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsersByKeys", ctx, keys)
+	ret0, _ := ret[0].([]app.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsersByKeys indicates an expected call of UsersByKeys.
+func (mr *MockRepoMockRecorder) UsersByKeys(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsersByKeys", reflect.TypeOf((*MockRepo)(nil).UsersByKeys), ctx, keys)
+}
+
+// MockFileInfoRepo is a mock of FileInfoRepo interface.
+type MockFileInfoRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileInfoRepoMockRecorder
+}
+
 // MockFileInfoRepoMockRecorder is the mock recorder for MockFileInfoRepo.
 type MockFileInfoRepoMockRecorder struct {
 	mock *MockFileInfoRepo
@@ -301,6 +756,21 @@ func (m *MockFileInfoRepo) EXPECT() *MockFileInfoRepoMockRecorder {
 	return m.recorder
 }
 
+// AddStorageUsage mocks base method.
+func (m *MockFileInfoRepo) AddStorageUsage(ctx context.Context, userID uuid.UUID, delta int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStorageUsage", ctx, userID, delta)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStorageUsage indicates an expected call of AddStorageUsage.
+func (mr *MockFileInfoRepoMockRecorder) AddStorageUsage(ctx, userID, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStorageUsage", reflect.TypeOf((*MockFileInfoRepo)(nil).AddStorageUsage), ctx, userID, delta)
+}
+
 // DeleteAvatar mocks base method.
 func (m *MockFileInfoRepo) DeleteAvatar(ctx context.Context, userID, fileID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -318,231 +788,967 @@ func (mr *MockFileInfoRepoMockRecorder) DeleteAvatar(ctx, userID, fileID any) *g
 // GetAvatar mocks base method.
 func (m *MockFileInfoRepo) GetAvatar(ctx context.Context, fileID uuid.UUID) (*app.AvatarInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAvatar", ctx, fileID)
-	ret0, _ := ret[0].(*app.AvatarInfo)
+	ret := m.ctrl.Call(m, "GetAvatar", ctx, fileID)
+	ret0, _ := ret[0].(*app.AvatarInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatar indicates an expected call of GetAvatar.
+func (mr *MockFileInfoRepoMockRecorder) GetAvatar(ctx, fileID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatar", reflect.TypeOf((*MockFileInfoRepo)(nil).GetAvatar), ctx, fileID)
+}
+
+// GetCountAvatars mocks base method.
+func (m *MockFileInfoRepo) GetCountAvatars(ctx context.Context, ownerID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountAvatars", ctx, ownerID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountAvatars indicates an expected call of GetCountAvatars.
+func (mr *MockFileInfoRepoMockRecorder) GetCountAvatars(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountAvatars", reflect.TypeOf((*MockFileInfoRepo)(nil).GetCountAvatars), ctx, ownerID)
+}
+
+// GetStorageUsage mocks base method.
+func (m *MockFileInfoRepo) GetStorageUsage(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageUsage", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageUsage indicates an expected call of GetStorageUsage.
+func (mr *MockFileInfoRepoMockRecorder) GetStorageUsage(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageUsage", reflect.TypeOf((*MockFileInfoRepo)(nil).GetStorageUsage), ctx, userID)
+}
+
+// ListAvatarByUserID mocks base method.
+func (m *MockFileInfoRepo) ListAvatarByUserID(ctx context.Context, userID uuid.UUID) ([]app.AvatarInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvatarByUserID", ctx, userID)
+	ret0, _ := ret[0].([]app.AvatarInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvatarByUserID indicates an expected call of ListAvatarByUserID.
+func (mr *MockFileInfoRepoMockRecorder) ListAvatarByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvatarByUserID", reflect.TypeOf((*MockFileInfoRepo)(nil).ListAvatarByUserID), ctx, userID)
+}
+
+// ListAvatars mocks base method.
+func (m *MockFileInfoRepo) ListAvatars(ctx context.Context, after uuid.UUID, limit int) ([]app.AvatarInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvatars", ctx, after, limit)
+	ret0, _ := ret[0].([]app.AvatarInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvatars indicates an expected call of ListAvatars.
+func (mr *MockFileInfoRepoMockRecorder) ListAvatars(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvatars", reflect.TypeOf((*MockFileInfoRepo)(nil).ListAvatars), ctx, after, limit)
+}
+
+// SaveAvatar mocks base method.
+func (m *MockFileInfoRepo) SaveAvatar(ctx context.Context, fileCache app.AvatarInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAvatar", ctx, fileCache)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAvatar indicates an expected call of SaveAvatar.
+func (mr *MockFileInfoRepoMockRecorder) SaveAvatar(ctx, fileCache any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAvatar", reflect.TypeOf((*MockFileInfoRepo)(nil).SaveAvatar), ctx, fileCache)
+}
+
+// SetAvatarPosition mocks base method.
+func (m *MockFileInfoRepo) SetAvatarPosition(ctx context.Context, fileID uuid.UUID, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvatarPosition", ctx, fileID, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvatarPosition indicates an expected call of SetAvatarPosition.
+func (mr *MockFileInfoRepoMockRecorder) SetAvatarPosition(ctx, fileID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvatarPosition", reflect.TypeOf((*MockFileInfoRepo)(nil).SetAvatarPosition), ctx, fileID, position)
+}
+
+// SetAvatarStatus mocks base method.
+func (m *MockFileInfoRepo) SetAvatarStatus(ctx context.Context, fileID uuid.UUID, status app.AvatarStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvatarStatus", ctx, fileID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvatarStatus indicates an expected call of SetAvatarStatus.
+func (mr *MockFileInfoRepoMockRecorder) SetAvatarStatus(ctx, fileID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvatarStatus", reflect.TypeOf((*MockFileInfoRepo)(nil).SetAvatarStatus), ctx, fileID, status)
+}
+
+// MockAvatarUploadRepo is a mock of AvatarUploadRepo interface.
+type MockAvatarUploadRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvatarUploadRepoMockRecorder
+}
+
+// MockAvatarUploadRepoMockRecorder is the mock recorder for MockAvatarUploadRepo.
+type MockAvatarUploadRepoMockRecorder struct {
+	mock *MockAvatarUploadRepo
+}
+
+// NewMockAvatarUploadRepo creates a new mock instance.
+func NewMockAvatarUploadRepo(ctrl *gomock.Controller) *MockAvatarUploadRepo {
+	mock := &MockAvatarUploadRepo{ctrl: ctrl}
+	mock.recorder = &MockAvatarUploadRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvatarUploadRepo) EXPECT() *MockAvatarUploadRepoMockRecorder {
+	return m.recorder
+}
+
+// CompleteAvatarUpload mocks base method.
+func (m *MockAvatarUploadRepo) CompleteAvatarUpload(ctx context.Context, id, avatarID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAvatarUpload", ctx, id, avatarID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteAvatarUpload indicates an expected call of CompleteAvatarUpload.
+func (mr *MockAvatarUploadRepoMockRecorder) CompleteAvatarUpload(ctx, id, avatarID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAvatarUpload", reflect.TypeOf((*MockAvatarUploadRepo)(nil).CompleteAvatarUpload), ctx, id, avatarID)
+}
+
+// DeleteAvatarUpload mocks base method.
+func (m *MockAvatarUploadRepo) DeleteAvatarUpload(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatarUpload", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAvatarUpload indicates an expected call of DeleteAvatarUpload.
+func (mr *MockAvatarUploadRepoMockRecorder) DeleteAvatarUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatarUpload", reflect.TypeOf((*MockAvatarUploadRepo)(nil).DeleteAvatarUpload), ctx, id)
+}
+
+// GetAvatarUpload mocks base method.
+func (m *MockAvatarUploadRepo) GetAvatarUpload(ctx context.Context, id uuid.UUID) (*app.AvatarUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarUpload", ctx, id)
+	ret0, _ := ret[0].(*app.AvatarUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarUpload indicates an expected call of GetAvatarUpload.
+func (mr *MockAvatarUploadRepoMockRecorder) GetAvatarUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarUpload", reflect.TypeOf((*MockAvatarUploadRepo)(nil).GetAvatarUpload), ctx, id)
+}
+
+// LeaseAvatarUpload mocks base method.
+func (m *MockAvatarUploadRepo) LeaseAvatarUpload(ctx context.Context, id uuid.UUID, offset int64, writerID string, lease time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseAvatarUpload", ctx, id, offset, writerID, lease)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaseAvatarUpload indicates an expected call of LeaseAvatarUpload.
+func (mr *MockAvatarUploadRepoMockRecorder) LeaseAvatarUpload(ctx, id, offset, writerID, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseAvatarUpload", reflect.TypeOf((*MockAvatarUploadRepo)(nil).LeaseAvatarUpload), ctx, id, offset, writerID, lease)
+}
+
+// ListExpiredAvatarUploads mocks base method.
+func (m *MockAvatarUploadRepo) ListExpiredAvatarUploads(ctx context.Context, before time.Time, limit int) ([]app.AvatarUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredAvatarUploads", ctx, before, limit)
+	ret0, _ := ret[0].([]app.AvatarUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredAvatarUploads indicates an expected call of ListExpiredAvatarUploads.
+func (mr *MockAvatarUploadRepoMockRecorder) ListExpiredAvatarUploads(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredAvatarUploads", reflect.TypeOf((*MockAvatarUploadRepo)(nil).ListExpiredAvatarUploads), ctx, before, limit)
+}
+
+// SaveAvatarUpload mocks base method.
+func (m *MockAvatarUploadRepo) SaveAvatarUpload(ctx context.Context, upload app.AvatarUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAvatarUpload", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAvatarUpload indicates an expected call of SaveAvatarUpload.
+func (mr *MockAvatarUploadRepoMockRecorder) SaveAvatarUpload(ctx, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAvatarUpload", reflect.TypeOf((*MockAvatarUploadRepo)(nil).SaveAvatarUpload), ctx, upload)
+}
+
+// SetAvatarUploadOffset mocks base method.
+func (m *MockAvatarUploadRepo) SetAvatarUploadOffset(ctx context.Context, id uuid.UUID, writerID string, offset int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvatarUploadOffset", ctx, id, writerID, offset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvatarUploadOffset indicates an expected call of SetAvatarUploadOffset.
+func (mr *MockAvatarUploadRepoMockRecorder) SetAvatarUploadOffset(ctx, id, writerID, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvatarUploadOffset", reflect.TypeOf((*MockAvatarUploadRepo)(nil).SetAvatarUploadOffset), ctx, id, writerID, offset)
+}
+
+// MockFileStore is a mock of FileStore interface.
+type MockFileStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileStoreMockRecorder
+}
+
+// MockFileStoreMockRecorder is the mock recorder for MockFileStore.
+type MockFileStoreMockRecorder struct {
+	mock *MockFileStore
+}
+
+// NewMockFileStore creates a new mock instance.
+func NewMockFileStore(ctrl *gomock.Controller) *MockFileStore {
+	mock := &MockFileStore{ctrl: ctrl}
+	mock.recorder = &MockFileStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileStore) EXPECT() *MockFileStoreMockRecorder {
+	return m.recorder
+}
+
+// AbortMultipartUpload mocks base method.
+func (m *MockFileStore) AbortMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", ctx, id, multipartID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockFileStoreMockRecorder) AbortMultipartUpload(ctx, id, multipartID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockFileStore)(nil).AbortMultipartUpload), ctx, id, multipartID)
+}
+
+// AppendMultipartUpload mocks base method.
+func (m *MockFileStore) AppendMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string, offset int64, r io.Reader) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendMultipartUpload", ctx, id, multipartID, offset, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendMultipartUpload indicates an expected call of AppendMultipartUpload.
+func (mr *MockFileStoreMockRecorder) AppendMultipartUpload(ctx, id, multipartID, offset, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendMultipartUpload", reflect.TypeOf((*MockFileStore)(nil).AppendMultipartUpload), ctx, id, multipartID, offset, r)
+}
+
+// CompleteMultipartUpload mocks base method.
+func (m *MockFileStore) CompleteMultipartUpload(ctx context.Context, id uuid.UUID, multipartID string, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipartUpload", ctx, id, multipartID, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteMultipartUpload indicates an expected call of CompleteMultipartUpload.
+func (mr *MockFileStoreMockRecorder) CompleteMultipartUpload(ctx, id, multipartID, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockFileStore)(nil).CompleteMultipartUpload), ctx, id, multipartID, size)
+}
+
+// CreateMultipartUpload mocks base method.
+func (m *MockFileStore) CreateMultipartUpload(ctx context.Context, id uuid.UUID, contentType string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultipartUpload", ctx, id, contentType)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMultipartUpload indicates an expected call of CreateMultipartUpload.
+func (mr *MockFileStoreMockRecorder) CreateMultipartUpload(ctx, id, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultipartUpload", reflect.TypeOf((*MockFileStore)(nil).CreateMultipartUpload), ctx, id, contentType)
+}
+
+// DeleteFile mocks base method.
+func (m *MockFileStore) DeleteFile(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockFileStoreMockRecorder) DeleteFile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFileStore)(nil).DeleteFile), ctx, id)
+}
+
+// DeleteUpload mocks base method.
+func (m *MockFileStore) DeleteUpload(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockFileStoreMockRecorder) DeleteUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockFileStore)(nil).DeleteUpload), ctx, id)
+}
+
+// DownloadFile mocks base method.
+func (m *MockFileStore) DownloadFile(ctx context.Context, id uuid.UUID) (*app.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadFile", ctx, id)
+	ret0, _ := ret[0].(*app.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadFile indicates an expected call of DownloadFile.
+func (mr *MockFileStoreMockRecorder) DownloadFile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockFileStore)(nil).DownloadFile), ctx, id)
+}
+
+// DownloadThumbnail mocks base method.
+func (m *MockFileStore) DownloadThumbnail(ctx context.Context, id uuid.UUID, size int) (*app.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadThumbnail", ctx, id, size)
+	ret0, _ := ret[0].(*app.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadThumbnail indicates an expected call of DownloadThumbnail.
+func (mr *MockFileStoreMockRecorder) DownloadThumbnail(ctx, id, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadThumbnail", reflect.TypeOf((*MockFileStore)(nil).DownloadThumbnail), ctx, id, size)
+}
+
+// DownloadURL mocks base method.
+func (m *MockFileStore) DownloadURL(ctx context.Context, id uuid.UUID, size int, expires time.Duration) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadURL", ctx, id, size, expires)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadURL indicates an expected call of DownloadURL.
+func (mr *MockFileStoreMockRecorder) DownloadURL(ctx, id, size, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURL", reflect.TypeOf((*MockFileStore)(nil).DownloadURL), ctx, id, size, expires)
+}
+
+// DownloadUpload mocks base method.
+func (m *MockFileStore) DownloadUpload(ctx context.Context, id uuid.UUID) (*app.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadUpload", ctx, id)
+	ret0, _ := ret[0].(*app.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadUpload indicates an expected call of DownloadUpload.
+func (mr *MockFileStoreMockRecorder) DownloadUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadUpload", reflect.TypeOf((*MockFileStore)(nil).DownloadUpload), ctx, id)
+}
+
+// ListFiles mocks base method.
+func (m *MockFileStore) ListFiles(ctx context.Context, after uuid.UUID, limit int) ([]app.StoredFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", ctx, after, limit)
+	ret0, _ := ret[0].([]app.StoredFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockFileStoreMockRecorder) ListFiles(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockFileStore)(nil).ListFiles), ctx, after, limit)
+}
+
+// UploadFile mocks base method.
+func (m *MockFileStore) UploadFile(ctx context.Context, f app.Avatar) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", ctx, f)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockFileStoreMockRecorder) UploadFile(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockFileStore)(nil).UploadFile), ctx, f)
+}
+
+// UploadThumbnail mocks base method.
+func (m *MockFileStore) UploadThumbnail(ctx context.Context, id uuid.UUID, t app.Thumbnail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadThumbnail", ctx, id, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadThumbnail indicates an expected call of UploadThumbnail.
+func (mr *MockFileStoreMockRecorder) UploadThumbnail(ctx, id, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadThumbnail", reflect.TypeOf((*MockFileStore)(nil).UploadThumbnail), ctx, id, t)
+}
+
+// UploadURL mocks base method.
+func (m *MockFileStore) UploadURL(ctx context.Context, id uuid.UUID, contentType string, size int64, expires time.Duration) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadURL", ctx, id, contentType, size, expires)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadURL indicates an expected call of UploadURL.
+func (mr *MockFileStoreMockRecorder) UploadURL(ctx, id, contentType, size, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadURL", reflect.TypeOf((*MockFileStore)(nil).UploadURL), ctx, id, contentType, size, expires)
+}
+
+// MockScanner is a mock of Scanner interface.
+type MockScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockScannerMockRecorder
+}
+
+// MockScannerMockRecorder is the mock recorder for MockScanner.
+type MockScannerMockRecorder struct {
+	mock *MockScanner
+}
+
+// NewMockScanner creates a new mock instance.
+func NewMockScanner(ctrl *gomock.Controller) *MockScanner {
+	mock := &MockScanner{ctrl: ctrl}
+	mock.recorder = &MockScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScanner) EXPECT() *MockScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockScanner) Scan(ctx context.Context, f app.Avatar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", ctx, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockScannerMockRecorder) Scan(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockScanner)(nil).Scan), ctx, f)
+}
+
+// MockImageProcessor is a mock of ImageProcessor interface.
+type MockImageProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageProcessorMockRecorder
+}
+
+// MockImageProcessorMockRecorder is the mock recorder for MockImageProcessor.
+type MockImageProcessorMockRecorder struct {
+	mock *MockImageProcessor
+}
+
+// NewMockImageProcessor creates a new mock instance.
+func NewMockImageProcessor(ctrl *gomock.Controller) *MockImageProcessor {
+	mock := &MockImageProcessor{ctrl: ctrl}
+	mock.recorder = &MockImageProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageProcessor) EXPECT() *MockImageProcessorMockRecorder {
+	return m.recorder
+}
+
+// Avatar mocks base method.
+func (m *MockImageProcessor) Avatar(ctx context.Context, f app.Avatar, sizes []int) (*app.Avatar, []app.Thumbnail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Avatar", ctx, f, sizes)
+	ret0, _ := ret[0].(*app.Avatar)
+	ret1, _ := ret[1].([]app.Thumbnail)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Avatar indicates an expected call of Avatar.
+func (mr *MockImageProcessorMockRecorder) Avatar(ctx, f, sizes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Avatar", reflect.TypeOf((*MockImageProcessor)(nil).Avatar), ctx, f, sizes)
+}
+
+// MockPasswordHash is a mock of PasswordHash interface.
+type MockPasswordHash struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHashMockRecorder
+}
+
+// MockPasswordHashMockRecorder is the mock recorder for MockPasswordHash.
+type MockPasswordHashMockRecorder struct {
+	mock *MockPasswordHash
+}
+
+// NewMockPasswordHash creates a new mock instance.
+func NewMockPasswordHash(ctrl *gomock.Controller) *MockPasswordHash {
+	mock := &MockPasswordHash{ctrl: ctrl}
+	mock.recorder = &MockPasswordHashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHash) EXPECT() *MockPasswordHashMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockPasswordHash) Compare(hashedPassword, password []byte) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hashedPassword, password)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockPasswordHashMockRecorder) Compare(hashedPassword, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockPasswordHash)(nil).Compare), hashedPassword, password)
+}
+
+// Hashing mocks base method.
+func (m *MockPasswordHash) Hashing(password string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hashing", password)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hashing indicates an expected call of Hashing.
+func (mr *MockPasswordHashMockRecorder) Hashing(password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hashing", reflect.TypeOf((*MockPasswordHash)(nil).Hashing), password)
+}
+
+// MockPrivacyRepo is a mock of PrivacyRepo interface.
+type MockPrivacyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacyRepoMockRecorder
+}
+
+// MockPrivacyRepoMockRecorder is the mock recorder for MockPrivacyRepo.
+type MockPrivacyRepoMockRecorder struct {
+	mock *MockPrivacyRepo
+}
+
+// NewMockPrivacyRepo creates a new mock instance.
+func NewMockPrivacyRepo(ctrl *gomock.Controller) *MockPrivacyRepo {
+	mock := &MockPrivacyRepo{ctrl: ctrl}
+	mock.recorder = &MockPrivacyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacyRepo) EXPECT() *MockPrivacyRepoMockRecorder {
+	return m.recorder
+}
+
+// ListPrivacySettings mocks base method.
+func (m *MockPrivacyRepo) ListPrivacySettings(ctx context.Context, userIDs []uuid.UUID) ([]app.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrivacySettings", ctx, userIDs)
+	ret0, _ := ret[0].([]app.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrivacySettings indicates an expected call of ListPrivacySettings.
+func (mr *MockPrivacyRepoMockRecorder) ListPrivacySettings(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrivacySettings", reflect.TypeOf((*MockPrivacyRepo)(nil).ListPrivacySettings), ctx, userIDs)
+}
+
+// SavePrivacySettings mocks base method.
+func (m *MockPrivacyRepo) SavePrivacySettings(ctx context.Context, settings app.PrivacySettings) (*app.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePrivacySettings", ctx, settings)
+	ret0, _ := ret[0].(*app.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePrivacySettings indicates an expected call of SavePrivacySettings.
+func (mr *MockPrivacyRepoMockRecorder) SavePrivacySettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePrivacySettings", reflect.TypeOf((*MockPrivacyRepo)(nil).SavePrivacySettings), ctx, settings)
+}
+
+// MockPreferenceRepo is a mock of PreferenceRepo interface.
+type MockPreferenceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceRepoMockRecorder
+}
+
+// MockPreferenceRepoMockRecorder is the mock recorder for MockPreferenceRepo.
+type MockPreferenceRepoMockRecorder struct {
+	mock *MockPreferenceRepo
+}
+
+// NewMockPreferenceRepo creates a new mock instance.
+func NewMockPreferenceRepo(ctrl *gomock.Controller) *MockPreferenceRepo {
+	mock := &MockPreferenceRepo{ctrl: ctrl}
+	mock.recorder = &MockPreferenceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceRepo) EXPECT() *MockPreferenceRepoMockRecorder {
+	return m.recorder
+}
+
+// ListPreferences mocks base method.
+func (m *MockPreferenceRepo) ListPreferences(ctx context.Context, userID uuid.UUID) ([]app.Preference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPreferences", ctx, userID)
+	ret0, _ := ret[0].([]app.Preference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPreferences indicates an expected call of ListPreferences.
+func (mr *MockPreferenceRepoMockRecorder) ListPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPreferences", reflect.TypeOf((*MockPreferenceRepo)(nil).ListPreferences), ctx, userID)
+}
+
+// SavePreferences mocks base method.
+func (m *MockPreferenceRepo) SavePreferences(ctx context.Context, prefs []app.Preference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreferences", ctx, prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreferences indicates an expected call of SavePreferences.
+func (mr *MockPreferenceRepoMockRecorder) SavePreferences(ctx, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferences", reflect.TypeOf((*MockPreferenceRepo)(nil).SavePreferences), ctx, prefs)
+}
+
+// MockUsernameHistoryRepo is a mock of UsernameHistoryRepo interface.
+type MockUsernameHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsernameHistoryRepoMockRecorder
+}
+
+// MockUsernameHistoryRepoMockRecorder is the mock recorder for MockUsernameHistoryRepo.
+type MockUsernameHistoryRepoMockRecorder struct {
+	mock *MockUsernameHistoryRepo
+}
+
+// NewMockUsernameHistoryRepo creates a new mock instance.
+func NewMockUsernameHistoryRepo(ctrl *gomock.Controller) *MockUsernameHistoryRepo {
+	mock := &MockUsernameHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockUsernameHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsernameHistoryRepo) EXPECT() *MockUsernameHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// LastUsernameChange mocks base method.
+func (m *MockUsernameHistoryRepo) LastUsernameChange(ctx context.Context, username string, since time.Time) (*app.UsernameChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastUsernameChange", ctx, username, since)
+	ret0, _ := ret[0].(*app.UsernameChange)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAvatar indicates an expected call of GetAvatar.
-func (mr *MockFileInfoRepoMockRecorder) GetAvatar(ctx, fileID any) *gomock.Call {
+// LastUsernameChange indicates an expected call of LastUsernameChange.
+func (mr *MockUsernameHistoryRepoMockRecorder) LastUsernameChange(ctx, username, since any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatar", reflect.TypeOf((*MockFileInfoRepo)(nil).GetAvatar), ctx, fileID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUsernameChange", reflect.TypeOf((*MockUsernameHistoryRepo)(nil).LastUsernameChange), ctx, username, since)
 }
 
-// GetCountAvatars mocks base method.
-func (m *MockFileInfoRepo) GetCountAvatars(ctx context.Context, ownerID uuid.UUID) (int, error) {
+// ListUnnormalizedUsers mocks base method.
+func (m *MockUsernameHistoryRepo) ListUnnormalizedUsers(ctx context.Context, limit int) ([]app.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCountAvatars", ctx, ownerID)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "ListUnnormalizedUsers", ctx, limit)
+	ret0, _ := ret[0].([]app.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCountAvatars indicates an expected call of GetCountAvatars.
-func (mr *MockFileInfoRepoMockRecorder) GetCountAvatars(ctx, ownerID any) *gomock.Call {
+// ListUnnormalizedUsers indicates an expected call of ListUnnormalizedUsers.
+func (mr *MockUsernameHistoryRepoMockRecorder) ListUnnormalizedUsers(ctx, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountAvatars", reflect.TypeOf((*MockFileInfoRepo)(nil).GetCountAvatars), ctx, ownerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnnormalizedUsers", reflect.TypeOf((*MockUsernameHistoryRepo)(nil).ListUnnormalizedUsers), ctx, limit)
 }
 
-// ListAvatarByUserID mocks base method.
-func (m *MockFileInfoRepo) ListAvatarByUserID(ctx context.Context, userID uuid.UUID) ([]app.AvatarInfo, error) {
+// NormalizeUsernameHistory mocks base method.
+func (m *MockUsernameHistoryRepo) NormalizeUsernameHistory(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAvatarByUserID", ctx, userID)
-	ret0, _ := ret[0].([]app.AvatarInfo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "NormalizeUsernameHistory", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListAvatarByUserID indicates an expected call of ListAvatarByUserID.
-func (mr *MockFileInfoRepoMockRecorder) ListAvatarByUserID(ctx, userID any) *gomock.Call {
+// NormalizeUsernameHistory indicates an expected call of NormalizeUsernameHistory.
+func (mr *MockUsernameHistoryRepoMockRecorder) NormalizeUsernameHistory(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvatarByUserID", reflect.TypeOf((*MockFileInfoRepo)(nil).ListAvatarByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeUsernameHistory", reflect.TypeOf((*MockUsernameHistoryRepo)(nil).NormalizeUsernameHistory), ctx)
 }
 
-// SaveAvatar mocks base method.
-func (m *MockFileInfoRepo) SaveAvatar(ctx context.Context, fileCache app.AvatarInfo) error {
+// SaveUsernameChange mocks base method.
+func (m *MockUsernameHistoryRepo) SaveUsernameChange(ctx context.Context, change app.UsernameChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveAvatar", ctx, fileCache)
+	ret := m.ctrl.Call(m, "SaveUsernameChange", ctx, change)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SaveAvatar indicates an expected call of SaveAvatar.
-func (mr *MockFileInfoRepoMockRecorder) SaveAvatar(ctx, fileCache any) *gomock.Call {
+// SaveUsernameChange indicates an expected call of SaveUsernameChange.
+func (mr *MockUsernameHistoryRepoMockRecorder) SaveUsernameChange(ctx, change any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAvatar", reflect.TypeOf((*MockFileInfoRepo)(nil).SaveAvatar), ctx, fileCache)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUsernameChange", reflect.TypeOf((*MockUsernameHistoryRepo)(nil).SaveUsernameChange), ctx, change)
 }
 
-// MockFileStore is a mock of FileStore interface.
-type MockFileStore struct {
+// MockTaskRepo is a mock of TaskRepo interface.
+type MockTaskRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockFileStoreMockRecorder
+	recorder *MockTaskRepoMockRecorder
 }
 
-// MockFileStoreMockRecorder is the mock recorder for MockFileStore.
-type MockFileStoreMockRecorder struct {
-	mock *MockFileStore
+// MockTaskRepoMockRecorder is the mock recorder for MockTaskRepo.
+type MockTaskRepoMockRecorder struct {
+	mock *MockTaskRepo
 }
 
-// NewMockFileStore creates a new mock instance.
-func NewMockFileStore(ctrl *gomock.Controller) *MockFileStore {
-	mock := &MockFileStore{ctrl: ctrl}
-	mock.recorder = &MockFileStoreMockRecorder{mock}
+// NewMockTaskRepo creates a new mock instance.
+func NewMockTaskRepo(ctrl *gomock.Controller) *MockTaskRepo {
+	mock := &MockTaskRepo{ctrl: ctrl}
+	mock.recorder = &MockTaskRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockFileStore) EXPECT() *MockFileStoreMockRecorder {
+func (m *MockTaskRepo) EXPECT() *MockTaskRepoMockRecorder {
 	return m.recorder
 }
 
-// DeleteFile mocks base method.
-func (m *MockFileStore) DeleteFile(ctx context.Context, id uuid.UUID) error {
+// ArchiveFinishedTasks mocks base method.
+func (m *MockTaskRepo) ArchiveFinishedTasks(ctx context.Context, before time.Time, limit int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFile", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ArchiveFinishedTasks", ctx, before, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteFile indicates an expected call of DeleteFile.
-func (mr *MockFileStoreMockRecorder) DeleteFile(ctx, id any) *gomock.Call {
+// ArchiveFinishedTasks indicates an expected call of ArchiveFinishedTasks.
+func (mr *MockTaskRepoMockRecorder) ArchiveFinishedTasks(ctx, before, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFileStore)(nil).DeleteFile), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveFinishedTasks", reflect.TypeOf((*MockTaskRepo)(nil).ArchiveFinishedTasks), ctx, before, limit)
 }
 
-// DownloadFile mocks base method.
-func (m *MockFileStore) DownloadFile(ctx context.Context, id uuid.UUID) (*app.Avatar, error) {
+// ClaimTasks mocks base method.
+func (m *MockTaskRepo) ClaimTasks(ctx context.Context, workerID string, lease time.Duration, limit int) ([]app.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DownloadFile", ctx, id)
-	ret0, _ := ret[0].(*app.Avatar)
+	ret := m.ctrl.Call(m, "ClaimTasks", ctx, workerID, lease, limit)
+	ret0, _ := ret[0].([]app.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DownloadFile indicates an expected call of DownloadFile.
-func (mr *MockFileStoreMockRecorder) DownloadFile(ctx, id any) *gomock.Call {
+// ClaimTasks indicates an expected call of ClaimTasks.
+func (mr *MockTaskRepoMockRecorder) ClaimTasks(ctx, workerID, lease, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockFileStore)(nil).DownloadFile), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTasks", reflect.TypeOf((*MockTaskRepo)(nil).ClaimTasks), ctx, workerID, lease, limit)
 }
 
-// UploadFile mocks base method.
-func (m *MockFileStore) UploadFile(ctx context.Context, f app.Avatar) (uuid.UUID, error) {
+// DeleteFinishedTasks mocks base method.
+func (m *MockTaskRepo) DeleteFinishedTasks(ctx context.Context, before time.Time, limit int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadFile", ctx, f)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret := m.ctrl.Call(m, "DeleteFinishedTasks", ctx, before, limit)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UploadFile indicates an expected call of UploadFile.
-func (mr *MockFileStoreMockRecorder) UploadFile(ctx, f any) *gomock.Call {
+// DeleteFinishedTasks indicates an expected call of DeleteFinishedTasks.
+func (mr *MockTaskRepoMockRecorder) DeleteFinishedTasks(ctx, before, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockFileStore)(nil).UploadFile), ctx, f)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinishedTasks", reflect.TypeOf((*MockTaskRepo)(nil).DeleteFinishedTasks), ctx, before, limit)
 }
 
-// MockPasswordHash is a mock of PasswordHash interface.
-type MockPasswordHash struct {
-	ctrl     *gomock.Controller
-	recorder *MockPasswordHashMockRecorder
+// DiscardDeadTask mocks base method.
+func (m *MockTaskRepo) DiscardDeadTask(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardDeadTask", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockPasswordHashMockRecorder is the mock recorder for MockPasswordHash.
-type MockPasswordHashMockRecorder struct {
-	mock *MockPasswordHash
+// DiscardDeadTask indicates an expected call of DiscardDeadTask.
+func (mr *MockTaskRepoMockRecorder) DiscardDeadTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardDeadTask", reflect.TypeOf((*MockTaskRepo)(nil).DiscardDeadTask), ctx, id)
 }
 
-// NewMockPasswordHash creates a new mock instance.
-func NewMockPasswordHash(ctrl *gomock.Controller) *MockPasswordHash {
-	mock := &MockPasswordHash{ctrl: ctrl}
-	mock.recorder = &MockPasswordHashMockRecorder{mock}
-	return mock
+// FailTask mocks base method.
+func (m *MockTaskRepo) FailTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string, delay time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailTask", ctx, workerID, id, lastErr, delay)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockPasswordHash) EXPECT() *MockPasswordHashMockRecorder {
-	return m.recorder
+// FailTask indicates an expected call of FailTask.
+func (mr *MockTaskRepoMockRecorder) FailTask(ctx, workerID, id, lastErr, delay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailTask", reflect.TypeOf((*MockTaskRepo)(nil).FailTask), ctx, workerID, id, lastErr, delay)
 }
 
-// Compare mocks base method.
-func (m *MockPasswordHash) Compare(hashedPassword, password []byte) bool {
+// FinishTasks mocks base method.
+func (m *MockTaskRepo) FinishTasks(ctx context.Context, workerID string, ids []uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Compare", hashedPassword, password)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "FinishTasks", ctx, workerID, ids)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Compare indicates an expected call of Compare.
-func (mr *MockPasswordHashMockRecorder) Compare(hashedPassword, password any) *gomock.Call {
+// FinishTasks indicates an expected call of FinishTasks.
+func (mr *MockTaskRepoMockRecorder) FinishTasks(ctx, workerID, ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockPasswordHash)(nil).Compare), hashedPassword, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTasks", reflect.TypeOf((*MockTaskRepo)(nil).FinishTasks), ctx, workerID, ids)
 }
 
-// Hashing mocks base method.
-func (m *MockPasswordHash) Hashing(password string) ([]byte, error) {
+// GetTaskBacklog mocks base method.
+func (m *MockTaskRepo) GetTaskBacklog(ctx context.Context) (*app.TaskBacklog, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Hashing", password)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "GetTaskBacklog", ctx)
+	ret0, _ := ret[0].(*app.TaskBacklog)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Hashing indicates an expected call of Hashing.
-func (mr *MockPasswordHashMockRecorder) Hashing(password any) *gomock.Call {
+// GetTaskBacklog indicates an expected call of GetTaskBacklog.
+func (mr *MockTaskRepoMockRecorder) GetTaskBacklog(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hashing", reflect.TypeOf((*MockPasswordHash)(nil).Hashing), password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskBacklog", reflect.TypeOf((*MockTaskRepo)(nil).GetTaskBacklog), ctx)
 }
 
-// MockTaskRepo is a mock of TaskRepo interface.
-type MockTaskRepo struct {
-	ctrl     *gomock.Controller
-	recorder *MockTaskRepoMockRecorder
+// KillTask mocks base method.
+func (m *MockTaskRepo) KillTask(ctx context.Context, workerID string, id uuid.UUID, lastErr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillTask", ctx, workerID, id, lastErr)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockTaskRepoMockRecorder is the mock recorder for MockTaskRepo.
-type MockTaskRepoMockRecorder struct {
-	mock *MockTaskRepo
+// KillTask indicates an expected call of KillTask.
+func (mr *MockTaskRepoMockRecorder) KillTask(ctx, workerID, id, lastErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillTask", reflect.TypeOf((*MockTaskRepo)(nil).KillTask), ctx, workerID, id, lastErr)
 }
 
-// NewMockTaskRepo creates a new mock instance.
-func NewMockTaskRepo(ctrl *gomock.Controller) *MockTaskRepo {
-	mock := &MockTaskRepo{ctrl: ctrl}
-	mock.recorder = &MockTaskRepoMockRecorder{mock}
-	return mock
+// ListDeadTasks mocks base method.
+func (m *MockTaskRepo) ListDeadTasks(ctx context.Context, limit, offset int) ([]app.Task, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadTasks", ctx, limit, offset)
+	ret0, _ := ret[0].([]app.Task)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTaskRepo) EXPECT() *MockTaskRepoMockRecorder {
-	return m.recorder
+// ListDeadTasks indicates an expected call of ListDeadTasks.
+func (mr *MockTaskRepoMockRecorder) ListDeadTasks(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadTasks", reflect.TypeOf((*MockTaskRepo)(nil).ListDeadTasks), ctx, limit, offset)
 }
 
-// FinishTask mocks base method.
-func (m *MockTaskRepo) FinishTask(arg0 context.Context, arg1 uuid.UUID) error {
+// ListenTasks mocks base method.
+func (m *MockTaskRepo) ListenTasks(ctx context.Context, notify chan<- struct{}) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FinishTask", arg0, arg1)
+	ret := m.ctrl.Call(m, "ListenTasks", ctx, notify)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// FinishTask indicates an expected call of FinishTask.
-func (mr *MockTaskRepoMockRecorder) FinishTask(arg0, arg1 any) *gomock.Call {
+// ListenTasks indicates an expected call of ListenTasks.
+func (mr *MockTaskRepoMockRecorder) ListenTasks(ctx, notify any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTask", reflect.TypeOf((*MockTaskRepo)(nil).FinishTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenTasks", reflect.TypeOf((*MockTaskRepo)(nil).ListenTasks), ctx, notify)
 }
 
-// ListActualTask mocks base method.
-func (m *MockTaskRepo) ListActualTask(arg0 context.Context, arg1 int) ([]app.Task, error) {
+// RetryDeadTask mocks base method.
+func (m *MockTaskRepo) RetryDeadTask(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListActualTask", arg0, arg1)
-	ret0, _ := ret[0].([]app.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RetryDeadTask", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListActualTask indicates an expected call of ListActualTask.
-func (mr *MockTaskRepoMockRecorder) ListActualTask(arg0, arg1 any) *gomock.Call {
+// RetryDeadTask indicates an expected call of RetryDeadTask.
+func (mr *MockTaskRepoMockRecorder) RetryDeadTask(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActualTask", reflect.TypeOf((*MockTaskRepo)(nil).ListActualTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDeadTask", reflect.TypeOf((*MockTaskRepo)(nil).RetryDeadTask), ctx, id)
 }
 
 // SaveTask mocks base method.
@@ -651,43 +1857,232 @@ func (m *MockQueue) EXPECT() *MockQueueMockRecorder {
 }
 
 // AddUser mocks base method.
-func (m *MockQueue) AddUser(arg0 context.Context, arg1 uuid.UUID, arg2 app.User) error {
+func (m *MockQueue) AddUser(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 app.User) (app.PublishAck, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUser", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AddUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddUser indicates an expected call of AddUser.
-func (mr *MockQueueMockRecorder) AddUser(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockQueueMockRecorder) AddUser(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockQueue)(nil).AddUser), arg0, arg1, arg2, arg3)
+}
+
+// AvatarChanged mocks base method.
+func (m *MockQueue) AvatarChanged(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 app.User, arg4 app.Activity) (app.PublishAck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvatarChanged", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AvatarChanged indicates an expected call of AvatarChanged.
+func (mr *MockQueueMockRecorder) AvatarChanged(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockQueue)(nil).AddUser), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvatarChanged", reflect.TypeOf((*MockQueue)(nil).AvatarChanged), arg0, arg1, arg2, arg3, arg4)
 }
 
 // DeleteUser mocks base method.
-func (m *MockQueue) DeleteUser(arg0 context.Context, arg1 uuid.UUID, arg2 app.User) error {
+func (m *MockQueue) DeleteUser(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 app.User) (app.PublishAck, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockQueueMockRecorder) DeleteUser(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockQueueMockRecorder) DeleteUser(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockQueue)(nil).DeleteUser), arg0, arg1, arg2, arg3)
+}
+
+// LoggedIn mocks base method.
+func (m *MockQueue) LoggedIn(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 app.User, arg4 app.Activity) (app.PublishAck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoggedIn", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoggedIn indicates an expected call of LoggedIn.
+func (mr *MockQueueMockRecorder) LoggedIn(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoggedIn", reflect.TypeOf((*MockQueue)(nil).LoggedIn), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoggedOut mocks base method.
+func (m *MockQueue) LoggedOut(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 app.User, arg4 app.Activity) (app.PublishAck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoggedOut", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoggedOut indicates an expected call of LoggedOut.
+func (mr *MockQueueMockRecorder) LoggedOut(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoggedOut", reflect.TypeOf((*MockQueue)(nil).LoggedOut), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoginFailed mocks base method.
+func (m *MockQueue) LoginFailed(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 app.User, arg4 app.Activity) (app.PublishAck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginFailed", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginFailed indicates an expected call of LoginFailed.
+func (mr *MockQueueMockRecorder) LoginFailed(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginFailed", reflect.TypeOf((*MockQueue)(nil).LoginFailed), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PasswordChanged mocks base method.
+func (m *MockQueue) PasswordChanged(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 app.User) (app.PublishAck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordChanged", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PasswordChanged indicates an expected call of PasswordChanged.
+func (mr *MockQueueMockRecorder) PasswordChanged(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordChanged", reflect.TypeOf((*MockQueue)(nil).PasswordChanged), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockQueue) UpdatePreferences(ctx context.Context, id uuid.UUID, occurredAt time.Time, userID uuid.UUID, prefs []app.Preference) (app.PublishAck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, id, occurredAt, userID, prefs)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockQueueMockRecorder) UpdatePreferences(ctx, id, occurredAt, userID, prefs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockQueue)(nil).DeleteUser), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockQueue)(nil).UpdatePreferences), ctx, id, occurredAt, userID, prefs)
 }
 
 // UpdateUser mocks base method.
-func (m *MockQueue) UpdateUser(arg0 context.Context, arg1 uuid.UUID, arg2 app.User) error {
+func (m *MockQueue) UpdateUser(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 app.User) (app.PublishAck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(app.PublishAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockQueueMockRecorder) UpdateUser(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockQueue)(nil).UpdateUser), arg0, arg1, arg2, arg3)
+}
+
+// MockPublishAck is a mock of PublishAck interface.
+type MockPublishAck struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublishAckMockRecorder
+}
+
+// MockPublishAckMockRecorder is the mock recorder for MockPublishAck.
+type MockPublishAckMockRecorder struct {
+	mock *MockPublishAck
+}
+
+// NewMockPublishAck creates a new mock instance.
+func NewMockPublishAck(ctrl *gomock.Controller) *MockPublishAck {
+	mock := &MockPublishAck{ctrl: ctrl}
+	mock.recorder = &MockPublishAckMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublishAck) EXPECT() *MockPublishAckMockRecorder {
+	return m.recorder
+}
+
+// Wait mocks base method.
+func (m *MockPublishAck) Wait(arg0 context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Wait", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockQueueMockRecorder) UpdateUser(arg0, arg1, arg2 any) *gomock.Call {
+// Wait indicates an expected call of Wait.
+func (mr *MockPublishAckMockRecorder) Wait(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockPublishAck)(nil).Wait), arg0)
+}
+
+// MockMetrics is a mock of Metrics interface.
+type MockMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsMockRecorder
+}
+
+// MockMetricsMockRecorder is the mock recorder for MockMetrics.
+type MockMetricsMockRecorder struct {
+	mock *MockMetrics
+}
+
+// NewMockMetrics creates a new mock instance.
+func NewMockMetrics(ctrl *gomock.Controller) *MockMetrics {
+	mock := &MockMetrics{ctrl: ctrl}
+	mock.recorder = &MockMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
+	return m.recorder
+}
+
+// AvatarsReconciled mocks base method.
+func (m *MockMetrics) AvatarsReconciled(report app.AvatarsReconciliation) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AvatarsReconciled", report)
+}
+
+// AvatarsReconciled indicates an expected call of AvatarsReconciled.
+func (mr *MockMetricsMockRecorder) AvatarsReconciled(report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvatarsReconciled", reflect.TypeOf((*MockMetrics)(nil).AvatarsReconciled), report)
+}
+
+// TaskBacklog mocks base method.
+func (m *MockMetrics) TaskBacklog(backlog app.TaskBacklog) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TaskBacklog", backlog)
+}
+
+// TaskBacklog indicates an expected call of TaskBacklog.
+func (mr *MockMetricsMockRecorder) TaskBacklog(backlog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskBacklog", reflect.TypeOf((*MockMetrics)(nil).TaskBacklog), backlog)
+}
+
+// TasksRemoved mocks base method.
+func (m *MockMetrics) TasksRemoved(count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TasksRemoved", count)
+}
+
+// TasksRemoved indicates an expected call of TasksRemoved.
+func (mr *MockMetricsMockRecorder) TasksRemoved(count any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockQueue)(nil).UpdateUser), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TasksRemoved", reflect.TypeOf((*MockMetrics)(nil).TasksRemoved), count)
 }
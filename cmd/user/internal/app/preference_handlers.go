@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+// GetPreferences returns all caller's preferences ordered by key.
+// Preferences which were never set are returned with default values.
+func (a *App) GetPreferences(ctx context.Context, session dom.Session) ([]Preference, error) {
+	saved, err := a.repo.ListPreferences(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.ListPreferences: %w", err)
+	}
+
+	return withDefaults(session.UserID, saved), nil
+}
+
+// SetPreferences changes passed caller's preferences and returns all caller's preferences.
+// Changed preferences are published as event.
+func (a *App) SetPreferences(ctx context.Context, session dom.Session, prefs []Preference) ([]Preference, error) {
+	prefs, err := validatePreferences(session.UserID, prefs)
+	if err != nil {
+		return nil, fmt.Errorf("validatePreferences: %w", err)
+	}
+
+	err = a.repo.Tx(ctx, func(repo Repo) error {
+		err := repo.SavePreferences(ctx, prefs)
+		if err != nil {
+			return fmt.Errorf("repo.SavePreferences: %w", err)
+		}
+
+		u, err := repo.ByID(ctx, session.UserID)
+		if err != nil {
+			return fmt.Errorf("repo.ByID: %w", err)
+		}
+
+		task := Task{
+			User:        *u,
+			Kind:        TaskKindEventPreferences,
+			Preferences: prefs,
+		}
+
+		_, err = repo.SaveTask(ctx, task)
+		if err != nil {
+			return fmt.Errorf("repo.SaveTask: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.GetPreferences(ctx, session)
+}
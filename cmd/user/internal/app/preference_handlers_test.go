@@ -0,0 +1,147 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+func TestApp_GetPreferences(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+		}
+		theme = app.Preference{UserID: ownerID, Key: "ui.theme", Kind: app.PreferenceKindString, Value: "dark"}
+		want  = []app.Preference{
+			{UserID: ownerID, Key: "notifications.email", Kind: app.PreferenceKindBool, Value: "true"},
+			{UserID: ownerID, Key: "notifications.push", Kind: app.PreferenceKindBool, Value: "true"},
+			{UserID: ownerID, Key: "ui.compact_mode", Kind: app.PreferenceKindBool, Value: "false"},
+			{UserID: ownerID, Key: "ui.page_size", Kind: app.PreferenceKindInt, Value: "20"},
+			theme,
+		}
+	)
+
+	testCases := map[string]struct {
+		repoRes []app.Preference
+		repoErr error
+		want    []app.Preference
+		wantErr error
+	}{
+		"success":                {[]app.Preference{theme}, nil, want, nil},
+		"a.repo.ListPreferences": {nil, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().ListPreferences(ctx, ownerID).Return(tc.repoRes, tc.repoErr)
+
+			res, err := module.GetPreferences(ctx, session)
+			assert.Equal(tc.want, res)
+			assert.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
+
+func TestApp_SetPreferences(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+		}
+		user = &app.User{
+			ID:        ownerID,
+			Email:     "email@email.com",
+			Name:      "name",
+			Status:    dom.UserStatusDefault,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		prefs = []app.Preference{
+			{Key: "ui.page_size", Kind: app.PreferenceKindInt, Value: "050"},
+			{Key: "notifications.push", Kind: app.PreferenceKindBool, Value: "false"},
+		}
+		saved = []app.Preference{
+			{UserID: ownerID, Key: "ui.page_size", Kind: app.PreferenceKindInt, Value: "50"},
+			{UserID: ownerID, Key: "notifications.push", Kind: app.PreferenceKindBool, Value: "false"},
+		}
+		unknownKey    = []app.Preference{{Key: "unknown", Kind: app.PreferenceKindBool, Value: "true"}}
+		wrongKind     = []app.Preference{{Key: "ui.page_size", Kind: app.PreferenceKindString, Value: "20"}}
+		outOfRange    = []app.Preference{{Key: "ui.page_size", Kind: app.PreferenceKindInt, Value: "1000"}}
+		notAllowed    = []app.Preference{{Key: "ui.theme", Kind: app.PreferenceKindString, Value: "pink"}}
+		duplicateKeys = []app.Preference{prefs[0], prefs[0]}
+	)
+
+	testCases := map[string]struct {
+		prefs           []app.Preference
+		repoSaveErr     error
+		repoByIDErr     error
+		repoSaveTaskErr error
+		wantErr         error
+	}{
+		"success":              {prefs, nil, nil, nil, nil},
+		"err_unknown_key":      {unknownKey, nil, nil, nil, app.ErrInvalidArgument},
+		"err_wrong_kind":       {wrongKind, nil, nil, nil, app.ErrInvalidArgument},
+		"err_out_of_range":     {outOfRange, nil, nil, nil, app.ErrInvalidArgument},
+		"err_not_allowed":      {notAllowed, nil, nil, nil, app.ErrInvalidArgument},
+		"err_duplicate_keys":   {duplicateKeys, nil, nil, nil, app.ErrInvalidArgument},
+		"repo.SavePreferences": {prefs, errAny, nil, nil, errAny},
+		"repo.ByID":            {prefs, nil, errAny, nil, errAny},
+		"repo.SaveTask":        {prefs, nil, nil, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			if tc.wantErr != app.ErrInvalidArgument {
+				mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(r app.Repo) error) error {
+					return fn(mocks.repo)
+				})
+				mocks.repo.EXPECT().SavePreferences(ctx, saved).Return(tc.repoSaveErr)
+			}
+
+			if tc.wantErr != app.ErrInvalidArgument && tc.repoSaveErr == nil {
+				mocks.repo.EXPECT().ByID(ctx, ownerID).Return(user, tc.repoByIDErr)
+			}
+
+			if tc.wantErr != app.ErrInvalidArgument && tc.repoSaveErr == nil && tc.repoByIDErr == nil {
+				mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+					User:        *user,
+					Kind:        app.TaskKindEventPreferences,
+					Preferences: saved,
+				}).Return(uuid.Must(uuid.NewV4()), tc.repoSaveTaskErr)
+			}
+
+			if tc.wantErr == nil {
+				mocks.repo.EXPECT().ListPreferences(ctx, ownerID).Return(saved, nil)
+			}
+
+			res, err := module.SetPreferences(ctx, session, tc.prefs)
+			assert.ErrorIs(err, tc.wantErr)
+			if tc.wantErr == nil {
+				assert.Len(res, 5)
+				assert.Contains(res, saved[0])
+				assert.Contains(res, saved[1])
+			}
+		})
+	}
+}
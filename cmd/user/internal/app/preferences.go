@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+)
+
+// MaxPreferenceValueLen is the maximum length of PreferenceKindString value.
+const MaxPreferenceValueLen = 256
+
+// preferenceSchemas is a registry of allowed preferences.
+// Preferences with unknown keys are rejected.
+var preferenceSchemas = map[string]PreferenceSchema{
+	"ui.theme":            {Kind: PreferenceKindString, Default: "system", Values: []string{"light", "dark", "system"}},
+	"ui.compact_mode":     {Kind: PreferenceKindBool, Default: "false"},
+	"ui.page_size":        {Kind: PreferenceKindInt, Default: "20", Min: 10, Max: 100},
+	"notifications.email": {Kind: PreferenceKindBool, Default: "true"},
+	"notifications.push":  {Kind: PreferenceKindBool, Default: "true"},
+}
+
+// normalize checks value by schema and returns it in canonical form.
+func (s PreferenceSchema) normalize(kind PreferenceKind, value string) (string, error) {
+	if kind != s.Kind {
+		return "", fmt.Errorf("kind %s: %w", kind, ErrInvalidArgument)
+	}
+
+	switch s.Kind {
+	case PreferenceKindBool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("strconv.ParseBool: %w", ErrInvalidArgument)
+		}
+
+		return strconv.FormatBool(v), nil
+	case PreferenceKindInt:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < s.Min || v > s.Max {
+			return "", fmt.Errorf("value %q: %w", value, ErrInvalidArgument)
+		}
+
+		return strconv.FormatInt(v, 10), nil
+	case PreferenceKindString:
+		if utf8.RuneCountInString(value) > MaxPreferenceValueLen {
+			return "", fmt.Errorf("value: %w", ErrInvalidArgument)
+		}
+
+		if len(s.Values) == 0 {
+			return value, nil
+		}
+
+		for _, allowed := range s.Values {
+			if value == allowed {
+				return value, nil
+			}
+		}
+
+		return "", fmt.Errorf("value %q: %w", value, ErrInvalidArgument)
+	default:
+		return "", fmt.Errorf("kind %s: %w", s.Kind, ErrInvalidArgument)
+	}
+}
+
+// validatePreferences checks preferences by registry and returns them in canonical form.
+func validatePreferences(userID uuid.UUID, prefs []Preference) ([]Preference, error) {
+	res := make([]Preference, len(prefs))
+	keys := make(map[string]struct{}, len(prefs))
+	for i := range prefs {
+		schema, ok := preferenceSchemas[prefs[i].Key]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q: %w", prefs[i].Key, ErrInvalidArgument)
+		}
+
+		_, ok = keys[prefs[i].Key]
+		if ok {
+			return nil, fmt.Errorf("duplicate key %q: %w", prefs[i].Key, ErrInvalidArgument)
+		}
+		keys[prefs[i].Key] = struct{}{}
+
+		value, err := schema.normalize(prefs[i].Kind, prefs[i].Value)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", prefs[i].Key, err)
+		}
+
+		res[i] = Preference{
+			UserID: userID,
+			Key:    prefs[i].Key,
+			Kind:   prefs[i].Kind,
+			Value:  value,
+		}
+	}
+
+	return res, nil
+}
+
+// withDefaults returns all registered preferences ordered by key.
+// Values of saved preferences override defaults.
+func withDefaults(userID uuid.UUID, saved []Preference) []Preference {
+	byKey := make(map[string]Preference, len(saved))
+	for i := range saved {
+		byKey[saved[i].Key] = saved[i]
+	}
+
+	res := make([]Preference, 0, len(preferenceSchemas))
+	for key, schema := range preferenceSchemas {
+		pref, ok := byKey[key]
+		if !ok || pref.Kind != schema.Kind {
+			pref = Preference{
+				UserID: userID,
+				Key:    key,
+				Kind:   schema.Kind,
+				Value:  schema.Default,
+			}
+		}
+
+		res = append(res, pref)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Key < res[j].Key })
+
+	return res
+}
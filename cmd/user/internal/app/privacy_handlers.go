@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+// GetPrivacySettings returns caller's privacy settings.
+func (a *App) GetPrivacySettings(ctx context.Context, session dom.Session) (*PrivacySettings, error) {
+	settings, err := a.repo.ListPrivacySettings(ctx, []uuid.UUID{session.UserID})
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.ListPrivacySettings: %w", err)
+	}
+
+	if len(settings) == 0 {
+		res := DefaultPrivacySettings(session.UserID)
+
+		return &res, nil
+	}
+
+	return &settings[0], nil
+}
+
+// UpdatePrivacySettings replaces caller's privacy settings.
+func (a *App) UpdatePrivacySettings(ctx context.Context, session dom.Session, settings PrivacySettings) (*PrivacySettings, error) {
+	settings.UserID = session.UserID
+
+	res, err := a.repo.SavePrivacySettings(ctx, settings)
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.SavePrivacySettings: %w", err)
+	}
+
+	return res, nil
+}
+
+// withPrivacy fills privacy settings of users.
+// Users without saved settings get DefaultPrivacySettings.
+func (a *App) withPrivacy(ctx context.Context, users []User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(users))
+	for i := range users {
+		ids[i] = users[i].ID
+	}
+
+	settings, err := a.repo.ListPrivacySettings(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("a.repo.ListPrivacySettings: %w", err)
+	}
+
+	byUserID := make(map[uuid.UUID]PrivacySettings, len(settings))
+	for i := range settings {
+		byUserID[settings[i].UserID] = settings[i]
+	}
+
+	for i := range users {
+		s, ok := byUserID[users[i].ID]
+		if !ok {
+			s = DefaultPrivacySettings(users[i].ID)
+		}
+
+		users[i].Privacy = s
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+func TestApp_GetPrivacySettings(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+		}
+		settings = app.PrivacySettings{
+			UserID:         ownerID,
+			HideFromSearch: true,
+		}
+		defaultSettings = app.DefaultPrivacySettings(ownerID)
+	)
+
+	testCases := map[string]struct {
+		repoRes []app.PrivacySettings
+		repoErr error
+		want    *app.PrivacySettings
+		wantErr error
+	}{
+		"success":                    {[]app.PrivacySettings{settings}, nil, &settings, nil},
+		"success_default":            {nil, nil, &defaultSettings, nil},
+		"a.repo.ListPrivacySettings": {nil, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().ListPrivacySettings(ctx, []uuid.UUID{ownerID}).Return(tc.repoRes, tc.repoErr)
+
+			res, err := module.GetPrivacySettings(ctx, session)
+			assert.Equal(tc.want, res)
+			assert.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
+
+func TestApp_UpdatePrivacySettings(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+		}
+		settings = app.PrivacySettings{
+			UserID:    uuid.Must(uuid.NewV4()),
+			HideEmail: true,
+		}
+		saved = app.PrivacySettings{
+			UserID:    ownerID,
+			HideEmail: true,
+		}
+	)
+
+	testCases := map[string]struct {
+		repoRes *app.PrivacySettings
+		repoErr error
+		want    *app.PrivacySettings
+		wantErr error
+	}{
+		"success":                    {&saved, nil, &saved, nil},
+		"a.repo.SavePrivacySettings": {nil, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().SavePrivacySettings(ctx, saved).Return(tc.repoRes, tc.repoErr)
+
+			res, err := module.UpdatePrivacySettings(ctx, session, settings)
+			assert.Equal(tc.want, res)
+			assert.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
@@ -2,113 +2,341 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 
-	"github.com/ZergsLaw/back-template1/internal/dom"
+	"github.com/gofrs/uuid"
+
 	"github.com/ZergsLaw/back-template1/internal/logger"
+	"github.com/ZergsLaw/back-template1/internal/outbox"
 )
 
+var errUnknownTaskKind = errors.New("unknown task kind")
+
+// Process publishes tasks and runs background jobs until ctx is canceled.
+// Tasks are claimed for a lease, so they can be processed by several replicas at the same time.
 func (a *App) Process(ctx context.Context) error {
-	// TODO: Add support for master-slaves nodes.
-	// TODO: Add tests.
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	wg.Add(3)
+	go a.removingExpiredAvatarUploads(ctx, wg)
+	go a.removingFinishedTasks(ctx, wg)
+	go a.reportingTaskBacklog(ctx, wg)
+
+	if a.cfg.AvatarsReconcileInterval > 0 {
+		wg.Add(1)
+		go a.reconcilingAvatars(ctx, wg)
+	}
+
+	a.publishingTasks(ctx)
+
+	return nil
+}
+
+func (a *App) publishingTasks(ctx context.Context) {
+	const taskLimit = 100
 	var (
-		log   = logger.FromContext(ctx)
-		wg    = &sync.WaitGroup{}
-		tasks = make(chan *dom.Event[Task])
+		log    = logger.FromContext(ctx)
+		ticker = time.NewTicker(a.cfg.TaskPollInterval)
+		wg     = &sync.WaitGroup{}
+		notify = make(chan struct{}, 1)
 	)
+	defer ticker.Stop()
 	defer wg.Wait()
 
 	wg.Add(1)
-	go a.collectingTasks(ctx, wg, tasks)
+	go a.listeningTasks(ctx, wg, notify)
 
 	for {
-		var err error
 		select {
 		case <-ctx.Done():
-			return nil
-		case task := <-tasks:
-			switch task.Body().Kind {
-			case TaskKindEventAdd:
-				err = a.handleTaskKindEventAdd(ctx, task.Body())
-			case TaskKindEventDel:
-				err = a.handleTaskKindEventDel(ctx, task.Body())
-			case TaskKindEventUpdate:
-				err = a.handleTaskKindEventUpdate(ctx, task.Body())
+			return
+		case <-ticker.C:
+		case <-notify:
+		}
+
+		leaseCtx, cancel := outbox.WithLease(ctx, a.cfg.TaskLease)
+		tasks, err := a.repo.ClaimTasks(ctx, a.workerID, a.cfg.TaskLease, taskLimit)
+		if err != nil {
+			cancel()
+			log.Error("couldn't claim tasks", slog.String(logger.Error.String(), err.Error()))
+
+			continue
+		}
+
+		a.publishTasks(ctx, leaseCtx, tasks)
+		cancel()
+
+		// Full batch means there are more tasks, they're claimed without waiting.
+		if len(tasks) == taskLimit {
+			select {
+			case notify <- struct{}{}:
 			default:
-				log.Error("unknown task",
-					slog.String(logger.TaskID.String(), task.Body().ID.String()),
-					slog.String(logger.TaskKind.String(), task.Body().Kind.String()),
-				)
+			}
+		}
+	}
+}
+
+// listeningTasks wakes publisher up when tasks are saved.
+// Listening is restarted after failures, tasks are found by polling meanwhile.
+func (a *App) listeningTasks(ctx context.Context, wg *sync.WaitGroup, notify chan<- struct{}) {
+	defer wg.Done()
+
+	const restartTimeout = 5 * time.Second
+	log := logger.FromContext(ctx)
+
+	for {
+		err := a.repo.ListenTasks(ctx, notify)
+		if err != nil {
+			log.Error("couldn't listen tasks", slog.String(logger.Error.String(), err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(restartTimeout):
+		}
+	}
+}
+
+// publishTasks publishes tasks by a pool of workers.
+// Tasks are partitioned by user, so tasks of the same user are published by one worker in order of creation.
+// Published tasks are finished by a single query.
+func (a *App) publishTasks(ctx, leaseCtx context.Context, tasks []Task) {
+	log := logger.FromContext(ctx)
+
+	partitions := make([][]Task, a.cfg.TaskWorkers)
+	for _, task := range tasks {
+		i := taskPartition(task.User.ID, len(partitions))
+		partitions[i] = append(partitions[i], task)
+	}
+
+	var (
+		wg       = sync.WaitGroup{}
+		finished = make([][]uuid.UUID, len(partitions))
+	)
+	for i := range partitions {
+		if len(partitions[i]) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			finished[i] = a.publishPartition(ctx, leaseCtx, partitions[i])
+		}(i)
+	}
+	wg.Wait()
+
+	var ids []uuid.UUID
+	for i := range finished {
+		ids = append(ids, finished[i]...)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	// Unfinished tasks are published again after the lease, consumers deduplicate them by task id.
+	err := a.repo.FinishTasks(ctx, a.workerID, ids)
+	if err != nil {
+		log.Error("couldn't finish tasks", slog.String(logger.Error.String(), err.Error()))
+	}
+}
+
+// publishPartition publishes tasks in rounds: every round publishes the oldest remaining task
+// of every user at once and waits for all acks, so tasks of the same user are stored in order.
+// Remaining tasks of user are skipped after failure and stay leased till the lease is over,
+// ClaimTasks doesn't return them before the failed task, so order of user's tasks is kept.
+func (a *App) publishPartition(ctx, leaseCtx context.Context, tasks []Task) []uuid.UUID {
+	type published struct {
+		task Task
+		ack  PublishAck
+	}
+	var (
+		queues   = make(map[uuid.UUID][]Task)
+		users    []uuid.UUID // Keeps order of users stable.
+		finished []uuid.UUID
+	)
+	for _, task := range tasks {
+		if _, ok := queues[task.User.ID]; !ok {
+			users = append(users, task.User.ID)
+		}
+		queues[task.User.ID] = append(queues[task.User.ID], task)
+	}
+
+	for len(users) > 0 {
+		round := make([]published, 0, len(users))
+		for _, userID := range users {
+			task := queues[userID][0]
+			ack, err := a.publishTask(leaseCtx, task)
+			if err != nil {
+				a.handleTaskFailure(ctx, task, err)
+				delete(queues, userID)
 
 				continue
 			}
+
+			round = append(round, published{task: task, ack: ack})
+		}
+
+		for _, p := range round {
+			userID := p.task.User.ID
+			err := p.ack.Wait(leaseCtx)
 			if err != nil {
-				task.Nack(ctx)
-				log.Error("couldn't handle event", slog.String(logger.Error.String(), err.Error()))
+				a.handleTaskFailure(ctx, p.task, fmt.Errorf("ack.Wait: %w", err))
+				delete(queues, userID)
 
 				continue
 			}
 
-			task.Ack(ctx)
+			finished = append(finished, p.task.ID)
+			queues[userID] = queues[userID][1:]
+			if len(queues[userID]) == 0 {
+				delete(queues, userID)
+			}
 		}
-	}
-}
 
-func (a *App) handleTaskKindEventAdd(ctx context.Context, task Task) error {
-	err := a.queue.AddUser(ctx, task.ID, task.User)
-	if err != nil {
-		return fmt.Errorf("a.queue.AddUser: %w", err)
+		users = slices.DeleteFunc(users, func(id uuid.UUID) bool {
+			_, ok := queues[id]
+			return !ok
+		})
 	}
 
-	err = a.repo.FinishTask(ctx, task.ID)
-	if err != nil {
-		return fmt.Errorf("a.repo.FinishTask: %w", err)
+	return finished
+}
+
+// publishTask sends task to queue without waiting for ack.
+func (a *App) publishTask(ctx context.Context, task Task) (PublishAck, error) {
+	var (
+		ack PublishAck
+		err error
+	)
+	switch task.Kind {
+	case TaskKindEventAdd:
+		ack, err = a.queue.AddUser(ctx, task.ID, task.CreatedAt, task.User)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.AddUser: %w", err)
+		}
+	case TaskKindEventDel:
+		ack, err = a.queue.DeleteUser(ctx, task.ID, task.CreatedAt, task.User)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.DeleteUser: %w", err)
+		}
+	case TaskKindEventUpdate:
+		ack, err = a.queue.UpdateUser(ctx, task.ID, task.CreatedAt, task.User)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.UpdateUser: %w", err)
+		}
+	case TaskKindEventPreferences:
+		ack, err = a.queue.UpdatePreferences(ctx, task.ID, task.CreatedAt, task.User.ID, task.Preferences)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.UpdatePreferences: %w", err)
+		}
+	case TaskKindEventLoggedIn:
+		ack, err = a.queue.LoggedIn(ctx, task.ID, task.CreatedAt, task.User, task.Activity)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.LoggedIn: %w", err)
+		}
+	case TaskKindEventLoggedOut:
+		ack, err = a.queue.LoggedOut(ctx, task.ID, task.CreatedAt, task.User, task.Activity)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.LoggedOut: %w", err)
+		}
+	case TaskKindEventLoginFailed:
+		ack, err = a.queue.LoginFailed(ctx, task.ID, task.CreatedAt, task.User, task.Activity)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.LoginFailed: %w", err)
+		}
+	case TaskKindEventPasswordChanged:
+		ack, err = a.queue.PasswordChanged(ctx, task.ID, task.CreatedAt, task.User)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.PasswordChanged: %w", err)
+		}
+	case TaskKindEventAvatarChanged:
+		ack, err = a.queue.AvatarChanged(ctx, task.ID, task.CreatedAt, task.User, task.Activity)
+		if err != nil {
+			return nil, fmt.Errorf("a.queue.AvatarChanged: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownTaskKind, task.Kind)
 	}
 
-	return nil
+	return ack, nil
 }
 
-func (a *App) handleTaskKindEventDel(ctx context.Context, task Task) error {
-	err := a.queue.DeleteUser(ctx, task.ID, task.User)
-	if err != nil {
-		return fmt.Errorf("a.queue.DeleteUser: %w", err)
+// handleTaskFailure schedules retry of failed task.
+// Nothing is recorded on shutdown, task is claimed again after the lease.
+func (a *App) handleTaskFailure(ctx context.Context, task Task, cause error) {
+	if ctx.Err() != nil {
+		return
 	}
 
-	err = a.repo.FinishTask(ctx, task.ID)
+	log := logger.FromContext(ctx).With(
+		slog.String(logger.TaskID.String(), task.ID.String()),
+		slog.String(logger.TaskKind.String(), task.Kind.String()),
+	)
+	log.Error("couldn't publish task", slog.String(logger.Error.String(), cause.Error()))
+
+	var err error
+	if errors.Is(cause, errUnknownTaskKind) {
+		// Retrying can't help, so task is moved to dead-letter state at once.
+		err = a.repo.KillTask(ctx, a.workerID, task.ID, errUnknownTaskKind.Error())
+	} else {
+		err = a.failTask(ctx, task, cause)
+	}
 	if err != nil {
-		return fmt.Errorf("a.repo.FinishTask: %w", err)
+		log.Error("couldn't fail task", slog.String(logger.Error.String(), err.Error()))
 	}
+}
 
-	return nil
+// taskPartition returns index of partition for tasks of user.
+func taskPartition(userID uuid.UUID, partitions int) int {
+	h := fnv.New32a()
+	_, _ = h.Write(userID.Bytes())
+
+	return int(h.Sum32() % uint32(partitions))
 }
 
-func (a *App) handleTaskKindEventUpdate(ctx context.Context, task Task) error {
-	err := a.queue.UpdateUser(ctx, task.ID, task.User)
-	if err != nil {
-		return fmt.Errorf("a.queue.UpdateUser: %w", err)
+// failTask postpones next attempt to handle task with exponential backoff,
+// task is moved to dead-letter state after last attempt.
+func (a *App) failTask(ctx context.Context, task Task, cause error) error {
+	retry := outbox.Retry{
+		MaxAttempts: a.cfg.TaskMaxAttempts,
+		Backoff:     a.cfg.TaskRetryBackoff,
+		MaxBackoff:  a.cfg.TaskRetryMaxBackoff,
+	}
+
+	attempts := task.Attempts + 1
+	if retry.Dead(attempts) {
+		err := a.repo.KillTask(ctx, a.workerID, task.ID, cause.Error())
+		if err != nil {
+			return fmt.Errorf("a.repo.KillTask: %w", err)
+		}
+
+		return nil
 	}
 
-	err = a.repo.FinishTask(ctx, task.ID)
+	err := a.repo.FailTask(ctx, a.workerID, task.ID, cause.Error(), retry.Delay(attempts))
 	if err != nil {
-		return fmt.Errorf("a.subscribe.FinishTask: %w", err)
+		return fmt.Errorf("a.repo.FailTask: %w", err)
 	}
 
 	return nil
 }
 
-func (a *App) collectingTasks(ctx context.Context, wg *sync.WaitGroup, out chan *dom.Event[Task]) {
+func (a *App) removingFinishedTasks(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	const (
-		taskTickerTimeout = time.Second / 10
-		taskLimit         = 100
-	)
+	const retentionTickerTimeout = 10 * time.Minute
 	var (
 		log    = logger.FromContext(ctx)
-		ticker = time.NewTicker(taskTickerTimeout)
+		ticker = time.NewTicker(retentionTickerTimeout)
 	)
 	defer ticker.Stop()
 
@@ -117,44 +345,89 @@ func (a *App) collectingTasks(ctx context.Context, wg *sync.WaitGroup, out chan
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			tasks, err := a.repo.ListActualTask(ctx, taskLimit)
+			err := a.RemoveFinishedTasks(ctx)
 			if err != nil {
-				log.Error("couldn't get tasks", slog.String(logger.Error.String(), err.Error()))
+				log.Error("couldn't remove finished tasks", slog.String(logger.Error.String(), err.Error()))
+			}
+		}
+	}
+}
 
-				continue
+func (a *App) reportingTaskBacklog(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	const backlogTickerTimeout = 15 * time.Second
+	var (
+		log    = logger.FromContext(ctx)
+		ticker = time.NewTicker(backlogTickerTimeout)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := a.ReportTaskBacklog(ctx)
+			if err != nil {
+				log.Error("couldn't report task backlog", slog.String(logger.Error.String(), err.Error()))
 			}
+		}
+	}
+}
+
+func (a *App) removingExpiredAvatarUploads(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
 
-			handle := func(event *dom.Event[Task], ackCh chan dom.AcknowledgeKind) {
-				select {
-				case <-ctx.Done():
-					return
-				case out <- event:
-				}
-
-				for {
-					select {
-					case <-ctx.Done():
-						return
-					case ack := <-ackCh:
-						if ack == dom.AcknowledgeKindAck {
-							return
-						}
-
-						select {
-						case <-ctx.Done():
-							return
-						case out <- event:
-						}
-					}
-				}
+	const uploadsTickerTimeout = time.Minute
+	var (
+		log    = logger.FromContext(ctx)
+		ticker = time.NewTicker(uploadsTickerTimeout)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := a.RemoveExpiredAvatarUploads(ctx)
+			if err != nil {
+				log.Error("couldn't remove expired avatar uploads", slog.String(logger.Error.String(), err.Error()))
 			}
+		}
+	}
+}
+
+func (a *App) reconcilingAvatars(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
 
-			ackCh := make(chan dom.AcknowledgeKind)
-			for i := range tasks {
-				event := dom.NewEvent(tasks[i].ID, ackCh, tasks[i])
+	var (
+		log    = logger.FromContext(ctx)
+		ticker = time.NewTicker(a.cfg.AvatarsReconcileInterval)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := a.ReconcileAvatars(ctx)
+			if errors.Is(err, errJobLeased) {
+				continue
+			}
+			if err != nil {
+				log.Error("couldn't reconcile avatars", slog.String(logger.Error.String(), err.Error()))
 
-				handle(event, ackCh)
+				continue
 			}
+
+			log.Info("avatars reconciled",
+				slog.Int("checked", report.Checked),
+				slog.Int("orphans", report.Orphans),
+				slog.Int("missing", report.Missing),
+			)
 		}
 	}
 }
@@ -0,0 +1,176 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+func TestApp_Process(t *testing.T) {
+	t.Parallel()
+
+	var (
+		user = app.User{
+			ID:    ownerID,
+			Email: "test@test.com",
+			Name:  "name",
+		}
+		otherUser = app.User{
+			ID:    uuid.Must(uuid.NewV4()),
+			Email: "other@test.com",
+			Name:  "other",
+		}
+		taskAdd = app.Task{
+			ID:        uuid.Must(uuid.NewV4()),
+			User:      user,
+			Kind:      app.TaskKindEventAdd,
+			CreatedAt: time.Now().Add(-time.Minute),
+		}
+		taskAddRetried = app.Task{
+			ID:        taskAdd.ID,
+			CreatedAt: taskAdd.CreatedAt,
+			User:      user,
+			Kind:      app.TaskKindEventAdd,
+			Attempts:  3,
+		}
+		taskAddLast = app.Task{
+			ID:        taskAdd.ID,
+			CreatedAt: taskAdd.CreatedAt,
+			User:      user,
+			Kind:      app.TaskKindEventAdd,
+			Attempts:  9,
+		}
+		taskUnknown = app.Task{
+			ID:   taskAdd.ID,
+			User: user,
+		}
+		taskDel = app.Task{
+			ID:        uuid.Must(uuid.NewV4()),
+			User:      user,
+			Kind:      app.TaskKindEventDel,
+			CreatedAt: time.Now(),
+		}
+		taskOther = app.Task{
+			ID:   uuid.Must(uuid.NewV4()),
+			User: otherUser,
+			Kind: app.TaskKindEventUpdate,
+		}
+	)
+
+	const (
+		publishErr = "a.queue.AddUser: any error"
+		ackErr     = "ack.Wait: any error"
+	)
+
+	testCases := map[string]struct {
+		claimErr   error
+		task       app.Task
+		addUserErr error
+		ackErr     error
+		wantDelay  time.Duration
+		wantErr    string
+		wantKill   string
+	}{
+		"success":                      {nil, taskAdd, nil, nil, 0, "", ""},
+		"err_any_claim":                {errAny, taskAdd, nil, nil, 0, "", ""},
+		"err_any_publish":              {nil, taskAdd, errAny, nil, time.Second, publishErr, ""},
+		"err_any_ack":                  {nil, taskAdd, nil, errAny, time.Second, ackErr, ""},
+		"err_any_publish_backoff":      {nil, taskAddRetried, errAny, nil, 8 * time.Second, publishErr, ""},
+		"err_any_publish_last_attempt": {nil, taskAddLast, errAny, nil, 0, "", publishErr},
+		"err_unknown_kind":             {nil, taskUnknown, nil, nil, 0, "", "unknown task kind"},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			mocks.repo.EXPECT().ListenTasks(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ chan<- struct{}) error {
+					<-ctx.Done()
+
+					return nil
+				})
+
+			// Failed and published tasks are changed only if they're still leased by the same worker.
+			var workerID string
+			sameWorker := gomock.Cond(func(x any) bool { return x == workerID })
+
+			const lease = 30 * time.Second // Default lease.
+			claim := mocks.repo.EXPECT().ClaimTasks(gomock.Any(), gomock.Any(), lease, 100)
+			if tc.claimErr != nil {
+				claim.DoAndReturn(func(context.Context, string, time.Duration, int) ([]app.Task, error) {
+					cancel()
+
+					return nil, tc.claimErr
+				})
+			} else {
+				claim.DoAndReturn(func(_ context.Context, id string, _ time.Duration, _ int) ([]app.Task, error) {
+					workerID = id
+
+					return []app.Task{tc.task, taskOther, taskDel}, nil
+				})
+			}
+			mocks.repo.EXPECT().ClaimTasks(gomock.Any(), gomock.Any(), lease, 100).Return(nil, nil).AnyTimes()
+
+			if tc.claimErr != nil {
+				err := module.Process(ctx)
+				assert.NoError(err)
+
+				return
+			}
+
+			// Tasks of other users don't wait for failed ones.
+			ctrl := gomock.NewController(t)
+			otherAck := NewMockPublishAck(ctrl)
+			otherAck.EXPECT().Wait(gomock.Any()).Return(nil)
+			mocks.queue.EXPECT().UpdateUser(gomock.Any(), taskOther.ID, taskOther.CreatedAt, otherUser).Return(otherAck, nil)
+			finished := []uuid.UUID{taskOther.ID}
+
+			switch {
+			case tc.task.Kind != app.TaskKindEventAdd:
+			case tc.addUserErr != nil:
+				mocks.queue.EXPECT().AddUser(gomock.Any(), tc.task.ID, tc.task.CreatedAt, user).Return(nil, tc.addUserErr)
+			default:
+				ack := NewMockPublishAck(ctrl)
+				addUser := mocks.queue.EXPECT().AddUser(gomock.Any(), tc.task.ID, tc.task.CreatedAt, user).Return(ack, nil)
+				wait := ack.EXPECT().Wait(gomock.Any()).Return(tc.ackErr).After(addUser)
+				if tc.ackErr != nil {
+					break
+				}
+
+				// Next task of the same user is published only after previous one is stored.
+				delAck := NewMockPublishAck(ctrl)
+				delAck.EXPECT().Wait(gomock.Any()).Return(nil)
+				mocks.queue.EXPECT().DeleteUser(gomock.Any(), taskDel.ID, taskDel.CreatedAt, user).Return(delAck, nil).After(wait)
+				finished = append(finished, tc.task.ID, taskDel.ID)
+			}
+
+			switch {
+			case tc.wantKill != "":
+				mocks.repo.EXPECT().KillTask(gomock.Any(), sameWorker, tc.task.ID, tc.wantKill).Return(nil)
+			case tc.wantErr != "":
+				mocks.repo.EXPECT().FailTask(gomock.Any(), sameWorker, tc.task.ID, tc.wantErr, tc.wantDelay).Return(nil)
+			}
+
+			mocks.repo.EXPECT().FinishTasks(gomock.Any(), sameWorker, gomock.InAnyOrder(finished)).
+				DoAndReturn(func(context.Context, string, []uuid.UUID) error {
+					cancel()
+
+					return nil
+				})
+
+			err := module.Process(ctx)
+			assert.NoError(err)
+		})
+	}
+}
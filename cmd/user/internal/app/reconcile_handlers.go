@@ -0,0 +1,160 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// reconcileGracePeriod protects files and avatars which are being saved during reconciliation.
+	reconcileGracePeriod = 10 * time.Minute
+	reconcileBatchSize   = 100
+	jobReconcileAvatars  = "reconcile_avatars"
+)
+
+// errJobLeased is returned when background job is run by other replica.
+var errJobLeased = errors.New("job is leased by other worker")
+
+// ReconcileAvatars compares avatars in repository with files in file store.
+// Files without avatars are removed, avatars without files are flagged as AvatarStatusMissing
+// and hidden from users. Files and avatars created within reconcileGracePeriod aren't changed.
+// Avatars and files are listed by pages ordered by id and compared page by page.
+// Reconciliation is run by one replica at a time, errJobLeased is returned for others.
+func (a *App) ReconcileAvatars(ctx context.Context) (*AvatarsReconciliation, error) {
+	// Lease lasts till next run, so avatars are reconciled once per interval by one of replicas.
+	lease := a.cfg.AvatarsReconcileInterval
+	if lease <= 0 {
+		lease = reconcileGracePeriod
+	}
+
+	ok, err := a.repo.LeaseJob(ctx, jobReconcileAvatars, a.workerID, lease)
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.LeaseJob: %w", err)
+	}
+	if !ok {
+		return nil, errJobLeased
+	}
+
+	// Other replica may take the job after the lease, so reconciliation is stopped by then.
+	ctx, cancel := context.WithTimeout(ctx, lease)
+	defer cancel()
+
+	var (
+		before  = time.Now().Add(-reconcileGracePeriod)
+		report  = AvatarsReconciliation{}
+		files   = pageCursor[StoredFile]{list: a.file.ListFiles, id: func(f StoredFile) uuid.UUID { return f.ID }}
+		avatars = pageCursor[AvatarInfo]{list: a.repo.ListAvatars, id: func(i AvatarInfo) uuid.UUID { return i.FileID }}
+	)
+	for {
+		file, fileOK, err := files.peek(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("a.file.ListFiles: %w", err)
+		}
+
+		info, avatarOK, err := avatars.peek(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("a.repo.ListAvatars: %w", err)
+		}
+
+		switch {
+		case !fileOK && !avatarOK:
+			a.metrics.AvatarsReconciled(report)
+
+			return &report, nil
+		case avatarOK && (!fileOK || idLess(info.FileID, file.ID)): // Avatar without file.
+			report.Checked++
+			avatars.pop()
+
+			if info.Status == AvatarStatusMissing || info.CreatedAt.After(before) {
+				continue
+			}
+
+			err = a.markAvatarMissing(ctx, info)
+			if err != nil {
+				return nil, err
+			}
+			report.Missing++
+		case fileOK && (!avatarOK || idLess(file.ID, info.FileID)): // File without avatar.
+			files.pop()
+
+			if file.ModTime.After(before) {
+				continue
+			}
+
+			err = a.file.DeleteFile(ctx, file.ID)
+			if err != nil {
+				return nil, fmt.Errorf("a.file.DeleteFile: %w", err)
+			}
+			report.Orphans++
+		default:
+			report.Checked++
+			avatars.pop()
+			files.pop()
+		}
+	}
+}
+
+// markAvatarMissing flags avatar as missing and releases storage taken by it.
+// Missing current avatar of user is replaced by the first avatar in gallery.
+func (a *App) markAvatarMissing(ctx context.Context, info AvatarInfo) error {
+	return a.repo.Tx(ctx, func(repo Repo) error {
+		err := repo.SetAvatarStatus(ctx, info.FileID, AvatarStatusMissing)
+		if err != nil {
+			return fmt.Errorf("repo.SetAvatarStatus: %w", err)
+		}
+
+		if info.Status == AvatarStatusClean && info.Size > 0 {
+			_, err = repo.AddStorageUsage(ctx, info.OwnerID, -info.Size)
+			if err != nil {
+				return fmt.Errorf("repo.AddStorageUsage: %w", err)
+			}
+		}
+
+		return a.replaceCurrentAvatar(ctx, repo, info.OwnerID, info.FileID)
+	})
+}
+
+// pageCursor iterates over items listed by pages ordered by id.
+type pageCursor[T any] struct {
+	list  func(ctx context.Context, after uuid.UUID, limit int) ([]T, error)
+	id    func(T) uuid.UUID
+	page  []T
+	after uuid.UUID
+	last  bool
+}
+
+// peek returns current item, ok is false when items are over.
+func (c *pageCursor[T]) peek(ctx context.Context) (item T, ok bool, err error) {
+	if len(c.page) == 0 && !c.last {
+		c.page, err = c.list(ctx, c.after, reconcileBatchSize)
+		if err != nil {
+			return item, false, err
+		}
+
+		c.last = len(c.page) < reconcileBatchSize
+		if len(c.page) > 0 {
+			c.after = c.id(c.page[len(c.page)-1])
+		}
+	}
+
+	if len(c.page) == 0 {
+		return item, false, nil
+	}
+
+	return c.page[0], true, nil
+}
+
+// pop moves cursor to the next item.
+func (c *pageCursor[T]) pop() {
+	c.page = c.page[1:]
+}
+
+// idLess compares ids in the same way as repository and file store order them.
+func idLess(a, b uuid.UUID) bool {
+	return bytes.Compare(a.Bytes(), b.Bytes()) < 0
+}
@@ -0,0 +1,180 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+)
+
+func TestApp_ReconcileAvatars(t *testing.T) {
+	t.Parallel()
+
+	const (
+		job       = "reconcile_avatars"
+		lease     = 10 * time.Minute // Reconciliation isn't scheduled in tests.
+		batchSize = 100
+	)
+
+	// Avatars and files are listed ordered by id.
+	id := func(n int) uuid.UUID {
+		return uuid.Must(uuid.FromString(fmt.Sprintf("00000000-0000-0000-0000-%012d", n)))
+	}
+
+	var (
+		old   = time.Now().Add(-time.Hour)
+		fresh = time.Now()
+
+		stored  = app.AvatarInfo{FileID: id(1), Status: app.AvatarStatusClean, CreatedAt: old}
+		absent  = app.AvatarInfo{FileID: id(2), OwnerID: ownerID, Status: app.AvatarStatusClean, Size: 3, CreatedAt: old}
+		saving  = app.AvatarInfo{FileID: id(4), Status: app.AvatarStatusClean, CreatedAt: fresh}
+		missed  = app.AvatarInfo{FileID: id(5), Status: app.AvatarStatusMissing, CreatedAt: old}
+		gallery = app.AvatarInfo{FileID: id(7), OwnerID: ownerID, Status: app.AvatarStatusClean}
+
+		storedFile   = app.StoredFile{ID: stored.FileID, ModTime: old}
+		orphanFile   = app.StoredFile{ID: id(3), ModTime: old}
+		uploadedFile = app.StoredFile{ID: id(6), ModTime: fresh}
+
+		owner        = app.User{ID: ownerID, AvatarID: gallery.FileID}
+		ownerCurrent = app.User{ID: ownerID, AvatarID: absent.FileID}
+		ownerUpdated = app.User{ID: ownerID, AvatarID: gallery.FileID}
+
+		fullFiles   = make([]app.StoredFile, batchSize+1)
+		fullAvatars = make([]app.AvatarInfo, batchSize+1)
+	)
+	for i := range fullFiles {
+		fullFiles[i] = app.StoredFile{ID: id(100 + i), ModTime: old}
+		fullAvatars[i] = app.AvatarInfo{FileID: id(100 + i), Status: app.AvatarStatusClean, CreatedAt: old}
+	}
+
+	testCases := map[string]struct {
+		leased        bool
+		leaseErr      error
+		files         []app.StoredFile
+		fileListErr   error
+		avatars       []app.AvatarInfo
+		repoListErr   error
+		owner         app.User
+		repoStatusErr error
+		fileDeleteErr error
+		want          *app.AvatarsReconciliation
+		wantErr       error
+	}{
+		"success": {
+			true, nil, []app.StoredFile{storedFile, orphanFile, uploadedFile}, nil,
+			[]app.AvatarInfo{stored, absent, saving, missed}, nil, owner, nil, nil,
+			&app.AvatarsReconciliation{Checked: 4, Orphans: 1, Missing: 1}, nil,
+		},
+		"success_current_avatar": {
+			true, nil, nil, nil, []app.AvatarInfo{absent}, nil, ownerCurrent, nil, nil,
+			&app.AvatarsReconciliation{Checked: 1, Missing: 1}, nil,
+		},
+		"success_pages": {
+			true, nil, fullFiles, nil, fullAvatars, nil, owner, nil, nil,
+			&app.AvatarsReconciliation{Checked: batchSize + 1}, nil,
+		},
+		"success_empty":     {true, nil, nil, nil, nil, nil, owner, nil, nil, &app.AvatarsReconciliation{}, nil},
+		"err_leased":        {false, nil, nil, nil, nil, nil, owner, nil, nil, nil, nil},
+		"err_any_lease":     {false, errAny, nil, nil, nil, nil, owner, nil, nil, nil, errAny},
+		"err_any_file_list": {true, nil, nil, errAny, nil, nil, owner, nil, nil, nil, errAny},
+		"err_any_repo_list": {true, nil, nil, nil, nil, errAny, owner, nil, nil, nil, errAny},
+		"err_any_status":    {true, nil, nil, nil, []app.AvatarInfo{absent}, nil, owner, errAny, nil, nil, errAny},
+		"err_any_delete":    {true, nil, []app.StoredFile{orphanFile}, nil, nil, nil, owner, nil, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().LeaseJob(ctx, job, gomock.Any(), lease).Return(tc.leased, tc.leaseErr)
+
+			if tc.leased {
+				for i := 0; ; i += batchSize {
+					after := uuid.Nil
+					if i > 0 {
+						after = tc.files[i-1].ID
+					}
+					page := tc.files[i:min(i+batchSize, len(tc.files))]
+					mocks.file.EXPECT().ListFiles(gomock.Any(), after, batchSize).Return(page, tc.fileListErr)
+
+					if len(page) < batchSize {
+						break
+					}
+				}
+			}
+
+			if tc.leased && tc.fileListErr == nil {
+				for i := 0; ; i += batchSize {
+					after := uuid.Nil
+					if i > 0 {
+						after = tc.avatars[i-1].FileID
+					}
+					page := tc.avatars[i:min(i+batchSize, len(tc.avatars))]
+					mocks.repo.EXPECT().ListAvatars(gomock.Any(), after, batchSize).Return(page, tc.repoListErr)
+
+					if len(page) < batchSize {
+						break
+					}
+				}
+			}
+
+			for _, info := range tc.avatars {
+				if info != absent {
+					continue
+				}
+
+				mocks.repo.EXPECT().Tx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo app.Repo) error) error {
+					return fn(mocks.repo)
+				})
+				mocks.repo.EXPECT().SetAvatarStatus(gomock.Any(), absent.FileID, app.AvatarStatusMissing).Return(tc.repoStatusErr)
+				if tc.repoStatusErr != nil {
+					continue
+				}
+
+				mocks.repo.EXPECT().AddStorageUsage(gomock.Any(), ownerID, -absent.Size).Return(int64(0), nil)
+				mocks.repo.EXPECT().ByID(gomock.Any(), ownerID).Return(&tc.owner, nil)
+				if tc.owner.AvatarID != absent.FileID {
+					continue
+				}
+
+				// Missing current avatar is replaced by the first visible avatar in gallery.
+				missing := absent
+				missing.Status = app.AvatarStatusMissing
+				mocks.repo.EXPECT().ListAvatarByUserID(gomock.Any(), ownerID).Return([]app.AvatarInfo{missing, gallery}, nil)
+				mocks.repo.EXPECT().Update(gomock.Any(), ownerUpdated).Return(&ownerUpdated, nil)
+				mocks.repo.EXPECT().SaveTask(gomock.Any(), app.Task{
+					User:     ownerUpdated,
+					Kind:     app.TaskKindEventAvatarChanged,
+					Activity: app.Activity{PrevAvatarID: absent.FileID},
+				}).Return(uuid.Must(uuid.NewV4()), nil)
+			}
+
+			for _, file := range tc.files {
+				if file == orphanFile && tc.repoStatusErr == nil {
+					mocks.file.EXPECT().DeleteFile(gomock.Any(), orphanFile.ID).Return(tc.fileDeleteErr)
+				}
+			}
+
+			if tc.want != nil {
+				mocks.metrics.EXPECT().AvatarsReconciled(*tc.want)
+			}
+
+			res, err := module.ReconcileAvatars(ctx)
+			switch {
+			case !tc.leased && tc.leaseErr == nil:
+				assert.Error(err)
+			default:
+				assert.ErrorIs(err, tc.wantErr)
+			}
+			assert.Equal(tc.want, res)
+		})
+	}
+}
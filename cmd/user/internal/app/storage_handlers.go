@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+// GetStorageUsage returns amount of bytes taken by user's avatars and user's quota.
+// Quarantined and missing avatars don't take quota.
+func (a *App) GetStorageUsage(ctx context.Context, session dom.Session) (*StorageUsage, error) {
+	used, err := a.repo.GetStorageUsage(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.GetStorageUsage: %w", err)
+	}
+
+	return &StorageUsage{
+		UsedBytes:  used,
+		QuotaBytes: a.storageQuota(session.Status),
+	}, nil
+}
+
+// checkStorageQuota rejects upload of size bytes in advance if it can't fit into user's quota.
+func (a *App) checkStorageQuota(ctx context.Context, session dom.Session, size int64) error {
+	quota := a.storageQuota(session.Status)
+	if quota == 0 {
+		return nil
+	}
+
+	used, err := a.repo.GetStorageUsage(ctx, session.UserID)
+	if err != nil {
+		return fmt.Errorf("a.repo.GetStorageUsage: %w", err)
+	}
+
+	if used+size > quota {
+		return fmt.Errorf("%d of %d bytes used: %w", used, quota, ErrQuotaExceeded)
+	}
+
+	return nil
+}
+
+// reserveStorage adds size bytes to user's storage usage inside transaction.
+// Transaction must be rolled back if ErrQuotaExceeded is returned.
+func (a *App) reserveStorage(ctx context.Context, repo Repo, session dom.Session, size int64) error {
+	used, err := repo.AddStorageUsage(ctx, session.UserID, size)
+	if err != nil {
+		return fmt.Errorf("repo.AddStorageUsage: %w", err)
+	}
+
+	quota := a.storageQuota(session.Status)
+	if quota > 0 && used > quota {
+		return fmt.Errorf("%d of %d bytes used: %w", used, quota, ErrQuotaExceeded)
+	}
+
+	return nil
+}
+
+func (a *App) storageQuota(status dom.UserStatus) int64 {
+	quota, ok := a.cfg.StorageQuotas[status]
+	if !ok {
+		quota = a.cfg.StorageQuotas[dom.UserStatusDefault]
+	}
+
+	return quota
+}
@@ -0,0 +1,100 @@
+package app_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+func TestApp_GetStorageUsage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusDefault,
+		}
+		premiumSession = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusPremium,
+		}
+	)
+
+	testCases := map[string]struct {
+		session dom.Session
+		repoRes int64
+		repoErr error
+		want    *app.StorageUsage
+		wantErr error
+	}{
+		"success":         {session, 42, nil, &app.StorageUsage{UsedBytes: 42}, nil},
+		"success_premium": {premiumSession, 42, nil, &app.StorageUsage{UsedBytes: 42, QuotaBytes: 1 << 20}, nil},
+		"err_any":         {session, 0, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().GetStorageUsage(ctx, tc.session.UserID).Return(tc.repoRes, tc.repoErr)
+
+			res, err := module.GetStorageUsage(ctx, tc.session)
+			assert.ErrorIs(err, tc.wantErr)
+			assert.Equal(tc.want, res)
+		})
+	}
+}
+
+func TestApp_CreateAvatarUploadQuota(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusPremium,
+		}
+		uploadURL = &url.URL{Scheme: "http", Host: "minio", Path: "/user.avatars/uploads/id"}
+	)
+
+	testCases := map[string]struct {
+		size         int64
+		repoUsageRes int64
+		repoUsageErr error
+		wantErr      error
+	}{
+		"success":            {1024, 1<<20 - 1024, nil, nil},
+		"err_quota_exceeded": {1024, 1<<20 - 1023, nil, app.ErrQuotaExceeded},
+		"err_any_usage":      {1024, 0, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().GetCountAvatars(ctx, session.UserID).Return(0, nil)
+			mocks.repo.EXPECT().GetStorageUsage(ctx, session.UserID).Return(tc.repoUsageRes, tc.repoUsageErr)
+
+			if tc.wantErr == nil {
+				mocks.repo.EXPECT().SaveAvatarUpload(ctx, gomock.Any()).Return(nil)
+				mocks.file.EXPECT().UploadURL(ctx, gomock.Any(), "image/png", tc.size, config.PresignedURLTTL).Return(uploadURL, nil)
+			}
+
+			_, _, err := module.CreateAvatarUpload(ctx, session, "avatar", "image/png", tc.size)
+			assert.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
@@ -11,11 +11,17 @@ func _() {
 	_ = x[TaskKindEventAdd-1]
 	_ = x[TaskKindEventDel-2]
 	_ = x[TaskKindEventUpdate-3]
+	_ = x[TaskKindEventPreferences-4]
+	_ = x[TaskKindEventLoggedIn-5]
+	_ = x[TaskKindEventLoggedOut-6]
+	_ = x[TaskKindEventLoginFailed-7]
+	_ = x[TaskKindEventPasswordChanged-8]
+	_ = x[TaskKindEventAvatarChanged-9]
 }
 
-const _TaskKind_name = "EventAddEventDelEventUpdate"
+const _TaskKind_name = "EventAddEventDelEventUpdateEventPreferencesEventLoggedInEventLoggedOutEventLoginFailedEventPasswordChangedEventAvatarChanged"
 
-var _TaskKind_index = [...]uint8{0, 8, 16, 27}
+var _TaskKind_index = [...]uint8{0, 8, 16, 27, 43, 56, 70, 86, 106, 124}
 
 func (i TaskKind) String() string {
 	i -= 1
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+// ListDeadTasks returns tasks moved to dead-letter state and total amount of them.
+// Available for managers only.
+func (a *App) ListDeadTasks(ctx context.Context, session dom.Session, limit, offset int) ([]Task, int, error) {
+	if !session.Status.IsManager() {
+		return nil, 0, ErrAccessDenied
+	}
+
+	tasks, total, err := a.repo.ListDeadTasks(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("a.repo.ListDeadTasks: %w", err)
+	}
+
+	return tasks, total, nil
+}
+
+// RetryDeadTask returns dead task to processing, task gets all attempts again.
+// Available for managers only.
+func (a *App) RetryDeadTask(ctx context.Context, session dom.Session, id uuid.UUID) error {
+	if !session.Status.IsManager() {
+		return ErrAccessDenied
+	}
+
+	err := a.repo.RetryDeadTask(ctx, id)
+	if err != nil {
+		return fmt.Errorf("a.repo.RetryDeadTask: %w", err)
+	}
+
+	return nil
+}
+
+// DiscardDeadTask removes dead task, so its event is never published.
+// Available for managers only.
+func (a *App) DiscardDeadTask(ctx context.Context, session dom.Session, id uuid.UUID) error {
+	if !session.Status.IsManager() {
+		return ErrAccessDenied
+	}
+
+	err := a.repo.DiscardDeadTask(ctx, id)
+	if err != nil {
+		return fmt.Errorf("a.repo.DiscardDeadTask: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveFinishedTasks removes tasks finished before retention period.
+// Tasks are moved to archive instead if it's enabled.
+func (a *App) RemoveFinishedTasks(ctx context.Context) error {
+	const limit = 1000
+	before := time.Now().Add(-a.cfg.TaskRetention)
+
+	for {
+		var (
+			count int
+			err   error
+		)
+		if a.cfg.TaskArchive {
+			count, err = a.repo.ArchiveFinishedTasks(ctx, before, limit)
+			if err != nil {
+				return fmt.Errorf("a.repo.ArchiveFinishedTasks: %w", err)
+			}
+		} else {
+			count, err = a.repo.DeleteFinishedTasks(ctx, before, limit)
+			if err != nil {
+				return fmt.Errorf("a.repo.DeleteFinishedTasks: %w", err)
+			}
+		}
+		a.metrics.TasksRemoved(count)
+
+		if count < limit {
+			return nil
+		}
+	}
+}
+
+// ReportTaskBacklog records amount and age of tasks which aren't published yet,
+// so stuck publisher can be noticed.
+func (a *App) ReportTaskBacklog(ctx context.Context) error {
+	backlog, err := a.repo.GetTaskBacklog(ctx)
+	if err != nil {
+		return fmt.Errorf("a.repo.GetTaskBacklog: %w", err)
+	}
+
+	a.metrics.TaskBacklog(*backlog)
+
+	return nil
+}
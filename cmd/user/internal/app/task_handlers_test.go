@@ -0,0 +1,208 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+var (
+	adminSession = dom.Session{
+		ID:     uuid.Must(uuid.NewV4()),
+		UserID: uuid.Must(uuid.NewV4()),
+		Status: dom.UserStatusAdmin,
+	}
+	userSession = dom.Session{
+		ID:     uuid.Must(uuid.NewV4()),
+		UserID: ownerID,
+		Status: dom.UserStatusDefault,
+	}
+)
+
+func TestApp_ListDeadTasks(t *testing.T) {
+	t.Parallel()
+
+	tasks := []app.Task{{
+		ID:        uuid.Must(uuid.NewV4()),
+		Kind:      app.TaskKindEventAdd,
+		Attempts:  10,
+		LastError: "any error",
+		DeadAt:    time.Now(),
+	}}
+
+	testCases := map[string]struct {
+		session   dom.Session
+		repoRes   []app.Task
+		repoTotal int
+		repoErr   error
+		want      []app.Task
+		wantTotal int
+		wantErr   error
+	}{
+		"success":           {adminSession, tasks, 11, nil, tasks, 11, nil},
+		"err_access_denied": {userSession, nil, 0, nil, nil, 0, app.ErrAccessDenied},
+		"err_any":           {adminSession, nil, 0, errAny, nil, 0, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			if tc.session.Status.IsManager() {
+				mocks.repo.EXPECT().ListDeadTasks(ctx, 10, 5).Return(tc.repoRes, tc.repoTotal, tc.repoErr)
+			}
+
+			res, total, err := module.ListDeadTasks(ctx, tc.session, 10, 5)
+			assert.ErrorIs(err, tc.wantErr)
+			assert.Equal(tc.want, res)
+			assert.Equal(tc.wantTotal, total)
+		})
+	}
+}
+
+func TestApp_RetryDeadTask(t *testing.T) {
+	t.Parallel()
+
+	taskID := uuid.Must(uuid.NewV4())
+
+	testCases := map[string]struct {
+		session dom.Session
+		repoErr error
+		want    error
+	}{
+		"success":           {adminSession, nil, nil},
+		"err_access_denied": {userSession, nil, app.ErrAccessDenied},
+		"err_not_found":     {adminSession, app.ErrNotFound, app.ErrNotFound},
+		"err_any":           {adminSession, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			if tc.session.Status.IsManager() {
+				mocks.repo.EXPECT().RetryDeadTask(ctx, taskID).Return(tc.repoErr)
+			}
+
+			err := module.RetryDeadTask(ctx, tc.session, taskID)
+			assert.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func TestApp_DiscardDeadTask(t *testing.T) {
+	t.Parallel()
+
+	taskID := uuid.Must(uuid.NewV4())
+
+	testCases := map[string]struct {
+		session dom.Session
+		repoErr error
+		want    error
+	}{
+		"success":           {adminSession, nil, nil},
+		"err_access_denied": {userSession, nil, app.ErrAccessDenied},
+		"err_not_found":     {adminSession, app.ErrNotFound, app.ErrNotFound},
+		"err_any":           {adminSession, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			if tc.session.Status.IsManager() {
+				mocks.repo.EXPECT().DiscardDeadTask(ctx, taskID).Return(tc.repoErr)
+			}
+
+			err := module.DiscardDeadTask(ctx, tc.session, taskID)
+			assert.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func TestApp_RemoveFinishedTasks(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		counts  []int
+		repoErr error
+		want    error
+	}{
+		"success":       {[]int{3}, nil, nil},
+		"success_batch": {[]int{1000, 1000, 5}, nil, nil},
+		"err_any":       {[]int{0}, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			for i, count := range tc.counts {
+				var err error
+				if i == len(tc.counts)-1 {
+					err = tc.repoErr
+				}
+				mocks.repo.EXPECT().DeleteFinishedTasks(ctx, gomock.Any(), 1000).Return(count, err)
+				if err == nil {
+					mocks.metrics.EXPECT().TasksRemoved(count)
+				}
+			}
+
+			err := module.RemoveFinishedTasks(ctx)
+			assert.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func TestApp_ReportTaskBacklog(t *testing.T) {
+	t.Parallel()
+
+	backlog := &app.TaskBacklog{
+		Unfinished:       5,
+		Dead:             1,
+		OldestUnfinished: time.Now(),
+	}
+
+	testCases := map[string]struct {
+		repoRes *app.TaskBacklog
+		repoErr error
+		want    error
+	}{
+		"success": {backlog, nil, nil},
+		"err_any": {nil, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().GetTaskBacklog(ctx).Return(tc.repoRes, tc.repoErr)
+			if tc.repoErr == nil {
+				mocks.metrics.EXPECT().TaskBacklog(*tc.repoRes)
+			}
+
+			err := module.ReportTaskBacklog(ctx)
+			assert.ErrorIs(err, tc.want)
+		})
+	}
+}
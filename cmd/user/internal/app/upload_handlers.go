@@ -0,0 +1,376 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ZergsLaw/back-template1/internal/dom"
+	"github.com/ZergsLaw/back-template1/internal/logger"
+)
+
+// errUploadCompleted is returned when avatar upload was completed by concurrent call.
+var errUploadCompleted = errors.New("avatar upload completed")
+
+// uploadWriteLease is the longest time of writing one chunk of resumable avatar upload.
+const uploadWriteLease = 5 * time.Minute
+
+// CreateAvatarUpload registers avatar upload and returns URL for uploading file directly to file store.
+// Uploaded file becomes avatar only after CompleteAvatarUpload.
+func (a *App) CreateAvatarUpload(ctx context.Context, session dom.Session, name, contentType string, size int64) (*AvatarUpload, *PresignedURL, error) {
+	upload, err := a.newAvatarUpload(ctx, session, name, contentType, size, a.cfg.PresignedURLTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = a.repo.SaveAvatarUpload(ctx, *upload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("a.repo.SaveAvatarUpload: %w", err)
+	}
+
+	u, err := a.file.UploadURL(ctx, upload.ID, upload.ContentType, upload.Size, a.cfg.PresignedURLTTL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("a.file.UploadURL: %w", err)
+	}
+
+	return upload, &PresignedURL{URL: u, ExpiresAt: upload.ExpiresAt}, nil
+}
+
+// CreateResumableAvatarUpload registers avatar upload which receives file by chunks in WriteAvatarUpload.
+// Upload which isn't finished in time is removed by RemoveExpiredAvatarUploads.
+func (a *App) CreateResumableAvatarUpload(ctx context.Context, session dom.Session, name, contentType string, size int64) (*AvatarUpload, error) {
+	upload, err := a.newAvatarUpload(ctx, session, name, contentType, size, a.cfg.ResumableUploadTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	upload.MultipartID, err = a.file.CreateMultipartUpload(ctx, upload.ID, upload.ContentType)
+	if err != nil {
+		return nil, fmt.Errorf("a.file.CreateMultipartUpload: %w", err)
+	}
+
+	err = a.repo.SaveAvatarUpload(ctx, *upload)
+	if err != nil {
+		return nil, fmt.Errorf("a.repo.SaveAvatarUpload: %w", err)
+	}
+
+	return upload, nil
+}
+
+// GetAvatarUpload returns not expired avatar upload of user.
+func (a *App) GetAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) (*AvatarUpload, error) {
+	return a.avatarUpload(ctx, a.repo, session, uploadID)
+}
+
+// WriteAvatarUpload appends chunk to resumable avatar upload starting from offset.
+// Offset must be equal to count of already received bytes.
+// When the whole file is received, upload is completed as in CompleteAvatarUpload and id of new avatar is returned.
+func (a *App) WriteAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID, offset int64, chunk io.Reader) (*AvatarUpload, uuid.UUID, error) {
+	upload, err := a.avatarUpload(ctx, a.repo, session, uploadID)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	if upload.MultipartID == "" {
+		return nil, uuid.Nil, fmt.Errorf("upload isn't resumable: %w", ErrInvalidArgument)
+	}
+
+	if offset != upload.Offset {
+		return nil, uuid.Nil, fmt.Errorf("offset %d, expected %d: %w", offset, upload.Offset, ErrInvalidOffset)
+	}
+
+	if upload.Offset < upload.Size {
+		err = a.appendAvatarUpload(ctx, upload, chunk)
+		if err != nil {
+			return nil, uuid.Nil, err
+		}
+	}
+
+	if upload.Offset < upload.Size {
+		return upload, uuid.Nil, nil
+	}
+
+	if upload.AvatarID != uuid.Nil {
+		avatarID, err := a.uploadedAvatar(ctx, upload.AvatarID)
+		if err != nil {
+			return nil, uuid.Nil, err
+		}
+
+		return upload, avatarID, nil
+	}
+
+	// Multipart upload is absent if it was completed by previous request which failed later.
+	err = a.file.CompleteMultipartUpload(ctx, upload.ID, upload.MultipartID, upload.Size)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, uuid.Nil, fmt.Errorf("a.file.CompleteMultipartUpload: %w", err)
+	}
+
+	avatarID, err := a.CompleteAvatarUpload(ctx, session, upload.ID)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	return upload, avatarID, nil
+}
+
+// appendAvatarUpload writes chunk to file store and advances offset of upload by count of stored bytes.
+// Chunk is written outside of transaction under lease taken by compare-and-set on offset,
+// so concurrent writes from the same offset are rejected without waiting for the transfer.
+func (a *App) appendAvatarUpload(ctx context.Context, upload *AvatarUpload, chunk io.Reader) error {
+	// Deadline is set before lease is taken, so writing is canceled before the lease is over.
+	writeCtx, cancel := context.WithTimeout(ctx, uploadWriteLease)
+	defer cancel()
+
+	writerID := uuid.Must(uuid.NewV4()).String()
+	ok, err := a.repo.LeaseAvatarUpload(ctx, upload.ID, upload.Offset, writerID, uploadWriteLease)
+	if err != nil {
+		return fmt.Errorf("a.repo.LeaseAvatarUpload: %w", err)
+	}
+
+	if !ok {
+		return fmt.Errorf("upload is written by concurrent request: %w", ErrInvalidOffset)
+	}
+
+	// Received part of chunk is kept even if appending failed, so client continues from new offset.
+	n, appendErr := a.file.AppendMultipartUpload(writeCtx, upload.ID, upload.MultipartID, upload.Offset, io.LimitReader(chunk, upload.Size-upload.Offset))
+
+	// Lease is released even if nothing is stored or client is gone, so client can resume at once.
+	err = a.repo.SetAvatarUploadOffset(context.WithoutCancel(ctx), upload.ID, writerID, upload.Offset+n)
+	if err != nil {
+		return fmt.Errorf("a.repo.SetAvatarUploadOffset: %w", err)
+	}
+	upload.Offset += n
+
+	if appendErr != nil {
+		return fmt.Errorf("a.file.AppendMultipartUpload: %w", appendErr)
+	}
+
+	return nil
+}
+
+// RemoveAvatarUpload removes avatar upload with all received data.
+func (a *App) RemoveAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) error {
+	upload, err := a.GetAvatarUpload(ctx, session, uploadID)
+	if err != nil {
+		return err
+	}
+
+	return a.removeAvatarUpload(ctx, *upload)
+}
+
+// RemoveExpiredAvatarUploads removes expired avatar uploads with all received data.
+func (a *App) RemoveExpiredAvatarUploads(ctx context.Context) error {
+	const limit = 100
+
+	for {
+		uploads, err := a.repo.ListExpiredAvatarUploads(ctx, time.Now(), limit)
+		if err != nil {
+			return fmt.Errorf("a.repo.ListExpiredAvatarUploads: %w", err)
+		}
+
+		for i := range uploads {
+			err = a.removeAvatarUpload(ctx, uploads[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(uploads) < limit {
+			return nil
+		}
+	}
+}
+
+// avatarUpload returns not expired avatar upload of user, upload is locked if repo is transaction.
+func (a *App) avatarUpload(ctx context.Context, repo Repo, session dom.Session, uploadID uuid.UUID) (*AvatarUpload, error) {
+	upload, err := repo.GetAvatarUpload(ctx, uploadID)
+	if err != nil {
+		return nil, fmt.Errorf("repo.GetAvatarUpload: %w", err)
+	}
+
+	if upload.OwnerID != session.UserID {
+		return nil, ErrAccessDenied
+	}
+
+	if !upload.ExpiresAt.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+
+	return upload, nil
+}
+
+func (a *App) newAvatarUpload(ctx context.Context, session dom.Session, name, contentType string, size int64, ttl time.Duration) (*AvatarUpload, error) {
+	if err := validateFormat(contentType); err != nil {
+		return nil, fmt.Errorf("validateFormat: %w", err)
+	}
+
+	if size <= 0 || size > maxAvatarSize {
+		return nil, fmt.Errorf("size: %w", ErrInvalidArgument)
+	}
+
+	count, err := a.repo.GetCountAvatars(ctx, session.UserID)
+	switch {
+	case err == nil || errors.Is(err, ErrNotFound):
+	default:
+		return nil, fmt.Errorf("a.repo.GetCountAvatars: %w", err)
+	}
+
+	if count >= maxAvatarCountInUser {
+		return nil, ErrMaxFiles
+	}
+
+	err = a.checkStorageQuota(ctx, session, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AvatarUpload{
+		ID:          uuid.Must(uuid.NewV4()),
+		OwnerID:     session.UserID,
+		Name:        name,
+		ContentType: contentType,
+		Size:        size,
+		ExpiresAt:   time.Now().Add(ttl),
+	}, nil
+}
+
+func (a *App) removeAvatarUpload(ctx context.Context, upload AvatarUpload) error {
+	if upload.MultipartID != "" {
+		err := a.file.AbortMultipartUpload(ctx, upload.ID, upload.MultipartID)
+		if err != nil {
+			return fmt.Errorf("a.file.AbortMultipartUpload: %w", err)
+		}
+	}
+
+	err := a.file.DeleteUpload(ctx, upload.ID)
+	if err != nil {
+		return fmt.Errorf("a.file.DeleteUpload: %w", err)
+	}
+
+	err = a.repo.DeleteAvatarUpload(ctx, upload.ID)
+	if err != nil {
+		return fmt.Errorf("a.repo.DeleteAvatarUpload: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteAvatarUpload makes avatar from file uploaded by URL from CreateAvatarUpload.
+// File is checked and processed the same way as in SaveAvatar.
+// Completed upload is kept until it expires, so repeated call returns avatar made by the first one.
+func (a *App) CompleteAvatarUpload(ctx context.Context, session dom.Session, uploadID uuid.UUID) (avatarID uuid.UUID, err error) {
+	upload, err := a.avatarUpload(ctx, a.repo, session, uploadID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if upload.AvatarID != uuid.Nil {
+		return a.uploadedAvatar(ctx, upload.AvatarID)
+	}
+
+	file, err := a.file.DownloadUpload(ctx, uploadID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("a.file.DownloadUpload: %w", err)
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("couldn't close file", slog.String(logger.Error.String(), err.Error()))
+		}
+	}()
+
+	if file.Size != upload.Size || file.ContentType != upload.ContentType {
+		return uuid.Nil, fmt.Errorf("uploaded %s of %d bytes: %w", file.ContentType, file.Size, ErrInvalidArgument)
+	}
+	file.Name = upload.Name
+
+	// Concurrent call could complete the upload while file was processed.
+	avatarID, err = a.saveAvatar(ctx, session, *file, uploadID)
+	switch {
+	case errors.Is(err, errUploadCompleted):
+		return a.uploadedAvatar(ctx, avatarID)
+	case err != nil && !errors.Is(err, ErrQuarantined):
+		return uuid.Nil, err
+	}
+	saveErr := err
+
+	// Uploaded file isn't needed anymore, it's removed with expired upload if deleting failed.
+	err = a.file.DeleteUpload(ctx, uploadID)
+	if err != nil {
+		logger.FromContext(ctx).Error("couldn't remove uploaded file",
+			slog.String(logger.Error.String(), err.Error()),
+			slog.String(logger.FileID.String(), uploadID.String()),
+		)
+	}
+
+	if saveErr != nil {
+		return uuid.Nil, saveErr
+	}
+
+	return avatarID, nil
+}
+
+// uploadedAvatar returns avatar made from completed avatar upload.
+func (a *App) uploadedAvatar(ctx context.Context, avatarID uuid.UUID) (uuid.UUID, error) {
+	info, err := a.repo.GetAvatar(ctx, avatarID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("a.repo.GetAvatar: %w", err)
+	}
+
+	if info.Status == AvatarStatusQuarantined {
+		return uuid.Nil, ErrQuarantined
+	}
+
+	return avatarID, nil
+}
+
+// completeAvatarUpload locks avatar upload and sets avatar made from it.
+// It does nothing if uploadID is empty. If upload is already completed,
+// errUploadCompleted is returned with id of avatar made from it.
+func completeAvatarUpload(ctx context.Context, repo Repo, uploadID, avatarID uuid.UUID) (uuid.UUID, error) {
+	if uploadID == uuid.Nil {
+		return uuid.Nil, nil
+	}
+
+	upload, err := repo.GetAvatarUpload(ctx, uploadID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("repo.GetAvatarUpload: %w", err)
+	}
+
+	if upload.AvatarID != uuid.Nil {
+		return upload.AvatarID, errUploadCompleted
+	}
+
+	err = repo.CompleteAvatarUpload(ctx, uploadID, avatarID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("repo.CompleteAvatarUpload: %w", err)
+	}
+
+	return uuid.Nil, nil
+}
+
+// GetFileURL returns URL for downloading avatar directly from file store.
+// If size isn't zero, it returns URL of thumbnail of this size.
+// Avatars uploaded without thumbnails are returned as is.
+func (a *App) GetFileURL(ctx context.Context, _ dom.Session, fileID uuid.UUID, size int) (*PresignedURL, error) {
+	err := a.checkVisibleAvatar(ctx, fileID, size)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(a.cfg.PresignedURLTTL)
+	u, err := a.file.DownloadURL(ctx, fileID, size, a.cfg.PresignedURLTTL)
+	if size != 0 && errors.Is(err, ErrNotFound) {
+		u, err = a.file.DownloadURL(ctx, fileID, 0, a.cfg.PresignedURLTTL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("a.file.DownloadURL: %w", err)
+	}
+
+	return &PresignedURL{URL: u, ExpiresAt: expiresAt}, nil
+}
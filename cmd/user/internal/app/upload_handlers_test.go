@@ -0,0 +1,635 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+func TestApp_CreateAvatarUpload(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusDefault,
+		}
+		uploadURL = &url.URL{Scheme: "http", Host: "minio", Path: "/user.avatars/uploads/id"}
+	)
+
+	testCases := map[string]struct {
+		contentType      string
+		size             int64
+		repoCountRes     int
+		repoCountErr     error
+		repoSaveErr      error
+		fileUploadURLErr error
+		wantErr          error
+	}{
+		"success":                  {"image/png", 1024, 0, nil, nil, nil, nil},
+		"success_count_not_found":  {"image/png", 1024, 0, app.ErrNotFound, nil, nil, nil},
+		"err_unknown_content_type": {"image/avi", 1024, 0, nil, nil, nil, app.ErrInvalidImageFormat},
+		"err_empty":                {"image/png", 0, 0, nil, nil, nil, app.ErrInvalidArgument},
+		"err_too_big":              {"image/png", 25<<20 + 1, 0, nil, nil, nil, app.ErrInvalidArgument},
+		"err_max_files":            {"image/png", 1024, 10, nil, nil, nil, app.ErrMaxFiles},
+		"err_any_count":            {"image/png", 1024, 0, errAny, nil, nil, errAny},
+		"err_any_save":             {"image/png", 1024, 0, nil, errAny, nil, errAny},
+		"err_any_upload_url":       {"image/png", 1024, 0, nil, nil, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			valid := !errors.Is(tc.wantErr, app.ErrInvalidImageFormat) && !errors.Is(tc.wantErr, app.ErrInvalidArgument)
+			if valid {
+				mocks.repo.EXPECT().GetCountAvatars(ctx, session.UserID).Return(tc.repoCountRes, tc.repoCountErr)
+			}
+
+			var uploadID uuid.UUID
+			if valid && !errors.Is(tc.wantErr, app.ErrMaxFiles) && !errors.Is(tc.repoCountErr, errAny) {
+				mocks.repo.EXPECT().SaveAvatarUpload(ctx, gomock.Cond(func(x any) bool {
+					upload := x.(app.AvatarUpload)
+					uploadID = upload.ID
+
+					return upload.OwnerID == session.UserID && upload.Name == "avatar" &&
+						upload.ContentType == tc.contentType && upload.Size == tc.size &&
+						time.Until(upload.ExpiresAt) > 0 && time.Until(upload.ExpiresAt) <= config.PresignedURLTTL
+				})).Return(tc.repoSaveErr)
+
+				if tc.repoSaveErr == nil {
+					mocks.file.EXPECT().UploadURL(ctx, gomock.Any(), tc.contentType, tc.size, config.PresignedURLTTL).
+						Return(uploadURL, tc.fileUploadURLErr)
+				}
+			}
+
+			upload, u, err := module.CreateAvatarUpload(ctx, session, "avatar", tc.contentType, tc.size)
+			assert.ErrorIs(err, tc.wantErr)
+			if tc.wantErr != nil {
+				return
+			}
+
+			assert.Equal(uploadID, upload.ID)
+			assert.Equal(uploadURL, u.URL)
+			assert.Equal(upload.ExpiresAt, u.ExpiresAt)
+		})
+	}
+}
+
+func TestApp_CompleteAvatarUpload(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusDefault,
+		}
+		completedID = uuid.Must(uuid.NewV4())
+		upload      = &app.AvatarUpload{
+			ID:          uuid.Must(uuid.NewV4()),
+			OwnerID:     ownerID,
+			Name:        "avatar.png",
+			ContentType: "image/png",
+			Size:        4,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+		anotherUpload = &app.AvatarUpload{
+			ID:          upload.ID,
+			OwnerID:     uuid.Must(uuid.NewV4()),
+			ContentType: "image/png",
+			Size:        4,
+			ExpiresAt:   upload.ExpiresAt,
+		}
+		expiredUpload = &app.AvatarUpload{
+			ID:          upload.ID,
+			OwnerID:     ownerID,
+			ContentType: "image/png",
+			Size:        4,
+			ExpiresAt:   time.Now().Add(-time.Hour),
+		}
+		completedUpload = &app.AvatarUpload{
+			ID:          upload.ID,
+			OwnerID:     ownerID,
+			ContentType: "image/png",
+			Size:        4,
+			AvatarID:    completedID,
+			ExpiresAt:   upload.ExpiresAt,
+		}
+		file = &app.Avatar{
+			ID:             upload.ID,
+			Name:           upload.Name,
+			ContentType:    "image/png",
+			Size:           4,
+			ReadSeekCloser: nopCloser{strings.NewReader("file")},
+		}
+		otherSize = &app.Avatar{
+			ID:             upload.ID,
+			ContentType:    "image/png",
+			Size:           5,
+			ReadSeekCloser: nopCloser{strings.NewReader("file!")},
+		}
+		processed = app.Avatar{
+			Name:           upload.Name,
+			ContentType:    "image/png",
+			Size:           3,
+			ReadSeekCloser: nopCloser{strings.NewReader("img")},
+		}
+		user = app.User{
+			ID:    ownerID,
+			Email: "test@test.com",
+			Name:  "name",
+		}
+		clean = &app.AvatarInfo{
+			FileID:  completedID,
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusClean,
+		}
+		quarantined = &app.AvatarInfo{
+			FileID:  completedID,
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusQuarantined,
+		}
+	)
+
+	testCases := map[string]struct {
+		repoGetRes       *app.AvatarUpload
+		repoGetErr       error
+		txGetRes         *app.AvatarUpload
+		repoGetAvatarRes *app.AvatarInfo
+		fileDownloadRes  *app.Avatar
+		fileDownloadErr  error
+		scanErr          error
+		imageErr         error
+		repoCompleteErr  error
+		fileDeleteErr    error
+		want             uuid.UUID
+		wantErr          error
+	}{
+		"success":                        {upload, nil, upload, nil, file, nil, nil, nil, nil, nil, fileID, nil},
+		"success_file_delete_failed":     {upload, nil, upload, nil, file, nil, nil, nil, nil, errAny, fileID, nil},
+		"success_completed":              {completedUpload, nil, nil, clean, nil, nil, nil, nil, nil, nil, completedID, nil},
+		"success_completed_concurrently": {upload, nil, completedUpload, clean, file, nil, nil, nil, nil, nil, completedID, nil},
+		"err_quarantined":                {upload, nil, upload, nil, file, nil, app.ErrQuarantined, nil, nil, nil, uuid.Nil, app.ErrQuarantined},
+		"err_completed_quarantined":      {completedUpload, nil, nil, quarantined, nil, nil, nil, nil, nil, nil, uuid.Nil, app.ErrQuarantined},
+		"err_not_found":                  {nil, app.ErrNotFound, nil, nil, nil, nil, nil, nil, nil, nil, uuid.Nil, app.ErrNotFound},
+		"err_access_denied":              {anotherUpload, nil, nil, nil, nil, nil, nil, nil, nil, nil, uuid.Nil, app.ErrAccessDenied},
+		"err_expired":                    {expiredUpload, nil, nil, nil, nil, nil, nil, nil, nil, nil, uuid.Nil, app.ErrNotFound},
+		"err_not_uploaded":               {upload, nil, nil, nil, nil, app.ErrNotFound, nil, nil, nil, nil, uuid.Nil, app.ErrNotFound},
+		"err_other_size":                 {upload, nil, nil, nil, otherSize, nil, nil, nil, nil, nil, uuid.Nil, app.ErrInvalidArgument},
+		"err_image":                      {upload, nil, nil, nil, file, nil, nil, app.ErrInvalidImageFormat, nil, nil, uuid.Nil, app.ErrInvalidImageFormat},
+		"err_any_complete":               {upload, nil, upload, nil, file, nil, nil, nil, errAny, nil, uuid.Nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			uploaded := tc.fileDownloadRes == file
+			if tc.fileDownloadRes != nil {
+				f := *tc.fileDownloadRes // App changes returned file.
+				f.ReadSeekCloser = nopCloser{strings.NewReader("file")}
+				tc.fileDownloadRes = &f
+			}
+
+			mocks.repo.EXPECT().GetAvatarUpload(ctx, upload.ID).Return(tc.repoGetRes, tc.repoGetErr)
+
+			if tc.repoGetRes == upload {
+				mocks.file.EXPECT().DownloadUpload(ctx, upload.ID).Return(tc.fileDownloadRes, tc.fileDownloadErr)
+			}
+
+			if uploaded {
+				mocks.scanner.EXPECT().Scan(ctx, *tc.fileDownloadRes).Return(tc.scanErr)
+			}
+
+			if uploaded && errors.Is(tc.scanErr, app.ErrQuarantined) {
+				mocks.file.EXPECT().UploadFile(ctx, *tc.fileDownloadRes).Return(fileID, nil)
+			}
+
+			if uploaded && tc.scanErr == nil {
+				if tc.imageErr != nil {
+					mocks.image.EXPECT().Avatar(ctx, *tc.fileDownloadRes, app.AvatarThumbnailSizes).Return(nil, nil, tc.imageErr)
+				} else {
+					mocks.image.EXPECT().Avatar(ctx, *tc.fileDownloadRes, app.AvatarThumbnailSizes).Return(&processed, nil, nil)
+					mocks.file.EXPECT().UploadFile(ctx, processed).Return(fileID, nil)
+				}
+			}
+
+			if tc.txGetRes != nil {
+				mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo app.Repo) error) error {
+					return fn(mocks.repo)
+				})
+				mocks.repo.EXPECT().GetAvatarUpload(ctx, upload.ID).Return(tc.txGetRes, nil)
+			}
+
+			if tc.txGetRes == upload {
+				mocks.repo.EXPECT().CompleteAvatarUpload(ctx, upload.ID, fileID).Return(tc.repoCompleteErr)
+			}
+
+			if tc.txGetRes == upload && tc.repoCompleteErr == nil && errors.Is(tc.scanErr, app.ErrQuarantined) {
+				mocks.repo.EXPECT().SaveAvatar(ctx, app.AvatarInfo{
+					FileID:  fileID,
+					OwnerID: ownerID,
+					Status:  app.AvatarStatusQuarantined,
+				}).Return(nil)
+			}
+
+			if tc.txGetRes == upload && tc.repoCompleteErr == nil && tc.scanErr == nil {
+				mocks.repo.EXPECT().GetCountAvatars(ctx, ownerID).Return(0, nil)
+				mocks.repo.EXPECT().AddStorageUsage(ctx, ownerID, processed.Size).Return(processed.Size, nil)
+				mocks.repo.EXPECT().SaveAvatar(ctx, app.AvatarInfo{
+					FileID:  fileID,
+					OwnerID: ownerID,
+					Status:  app.AvatarStatusClean,
+					Size:    processed.Size,
+				}).Return(nil)
+				byID := user
+				mocks.repo.EXPECT().ByID(ctx, ownerID).Return(&byID, nil)
+				withAvatar := user
+				withAvatar.AvatarID = fileID
+				mocks.repo.EXPECT().Update(ctx, withAvatar).Return(&withAvatar, nil)
+				mocks.repo.EXPECT().SaveTask(ctx, app.Task{
+					User:     withAvatar,
+					Kind:     app.TaskKindEventAvatarChanged,
+					Activity: app.Activity{PrevAvatarID: user.AvatarID},
+				}).Return(uuid.Must(uuid.NewV4()), nil)
+			}
+
+			if tc.txGetRes == completedUpload || tc.repoCompleteErr != nil { // Files of not saved avatar are removed.
+				mocks.file.EXPECT().DeleteFile(ctx, fileID).Return(nil)
+			}
+
+			if tc.repoGetAvatarRes != nil {
+				mocks.repo.EXPECT().GetAvatar(ctx, completedID).Return(tc.repoGetAvatarRes, nil)
+			}
+
+			if tc.txGetRes == upload && tc.repoCompleteErr == nil {
+				mocks.file.EXPECT().DeleteUpload(ctx, upload.ID).Return(tc.fileDeleteErr)
+			}
+
+			id, err := module.CompleteAvatarUpload(ctx, session, upload.ID)
+			assert.ErrorIs(err, tc.wantErr)
+			assert.Equal(tc.want, id)
+		})
+	}
+}
+
+func TestApp_GetFileURL(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusDefault,
+		}
+		info = &app.AvatarInfo{
+			FileID:  fileID,
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusClean,
+		}
+		quarantined = &app.AvatarInfo{
+			FileID:  fileID,
+			OwnerID: ownerID,
+			Status:  app.AvatarStatusQuarantined,
+		}
+		fileURL      = &url.URL{Scheme: "http", Host: "minio", Path: "/user.avatars/file"}
+		thumbnailURL = &url.URL{Scheme: "http", Host: "minio", Path: "/user.avatars/file/64"}
+		size         = app.AvatarThumbnailSizes[0]
+	)
+
+	testCases := map[string]struct {
+		size            int
+		repoGetRes      *app.AvatarInfo
+		repoGetErr      error
+		thumbnailURLErr error
+		fileURLErr      error
+		want            *url.URL
+		wantErr         error
+	}{
+		"success":                     {0, info, nil, nil, nil, fileURL, nil},
+		"success_thumbnail":           {size, info, nil, nil, nil, thumbnailURL, nil},
+		"success_thumbnail_not_found": {size, info, nil, app.ErrNotFound, nil, fileURL, nil},
+		"err_invalid_size":            {100500, nil, nil, nil, nil, nil, app.ErrInvalidArgument},
+		"err_not_found":               {0, nil, app.ErrNotFound, nil, nil, nil, app.ErrNotFound},
+		"err_quarantined":             {0, quarantined, nil, nil, nil, nil, app.ErrNotFound},
+		"err_any_thumbnail_url":       {size, info, nil, errAny, nil, nil, errAny},
+		"err_any_file_url":            {0, info, nil, nil, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			if !errors.Is(tc.wantErr, app.ErrInvalidArgument) {
+				mocks.repo.EXPECT().GetAvatar(ctx, fileID).Return(tc.repoGetRes, tc.repoGetErr)
+			}
+
+			if tc.repoGetRes == info && tc.size != 0 {
+				mocks.file.EXPECT().DownloadURL(ctx, fileID, tc.size, config.PresignedURLTTL).Return(thumbnailURL, tc.thumbnailURLErr)
+			}
+
+			if tc.repoGetRes == info && (tc.size == 0 || errors.Is(tc.thumbnailURLErr, app.ErrNotFound)) {
+				mocks.file.EXPECT().DownloadURL(ctx, fileID, 0, config.PresignedURLTTL).Return(fileURL, tc.fileURLErr)
+			}
+
+			res, err := module.GetFileURL(ctx, session, fileID, tc.size)
+			assert.ErrorIs(err, tc.wantErr)
+			if tc.wantErr != nil {
+				assert.Nil(res)
+
+				return
+			}
+
+			assert.Equal(tc.want, res.URL)
+			assert.WithinDuration(time.Now().Add(config.PresignedURLTTL), res.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func TestApp_CreateResumableAvatarUpload(t *testing.T) {
+	t.Parallel()
+
+	session := dom.Session{
+		ID:     uuid.Must(uuid.NewV4()),
+		UserID: ownerID,
+		Status: dom.UserStatusDefault,
+	}
+
+	testCases := map[string]struct {
+		contentType     string
+		size            int64
+		repoCountRes    int
+		fileCreateErr   error
+		repoSaveErr     error
+		wantMultipartID string
+		wantErr         error
+	}{
+		"success":                  {"image/png", 1024, 0, nil, nil, "multipart", nil},
+		"err_unknown_content_type": {"image/avi", 1024, 0, nil, nil, "", app.ErrInvalidImageFormat},
+		"err_too_big":              {"image/png", 25<<20 + 1, 0, nil, nil, "", app.ErrInvalidArgument},
+		"err_max_files":            {"image/png", 1024, 10, nil, nil, "", app.ErrMaxFiles},
+		"err_any_create":           {"image/png", 1024, 0, errAny, nil, "", errAny},
+		"err_any_save":             {"image/png", 1024, 0, nil, errAny, "", errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			valid := !errors.Is(tc.wantErr, app.ErrInvalidImageFormat) && !errors.Is(tc.wantErr, app.ErrInvalidArgument)
+			if valid {
+				mocks.repo.EXPECT().GetCountAvatars(ctx, session.UserID).Return(tc.repoCountRes, nil)
+			}
+
+			if valid && !errors.Is(tc.wantErr, app.ErrMaxFiles) {
+				mocks.file.EXPECT().CreateMultipartUpload(ctx, gomock.Any(), tc.contentType).Return("multipart", tc.fileCreateErr)
+			}
+
+			if valid && !errors.Is(tc.wantErr, app.ErrMaxFiles) && tc.fileCreateErr == nil {
+				mocks.repo.EXPECT().SaveAvatarUpload(ctx, gomock.Cond(func(x any) bool {
+					upload := x.(app.AvatarUpload)
+
+					return upload.OwnerID == session.UserID && upload.MultipartID == "multipart" &&
+						upload.Size == tc.size && upload.Offset == 0 &&
+						time.Until(upload.ExpiresAt) > config.PresignedURLTTL &&
+						time.Until(upload.ExpiresAt) <= config.ResumableUploadTTL
+				})).Return(tc.repoSaveErr)
+			}
+
+			upload, err := module.CreateResumableAvatarUpload(ctx, session, "avatar", tc.contentType, tc.size)
+			assert.ErrorIs(err, tc.wantErr)
+			if tc.wantErr != nil {
+				return
+			}
+
+			assert.Equal(tc.wantMultipartID, upload.MultipartID)
+			assert.Equal(tc.size, upload.Size)
+		})
+	}
+}
+
+func TestApp_WriteAvatarUpload(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+			Status: dom.UserStatusDefault,
+		}
+		upload = &app.AvatarUpload{
+			ID:          uuid.Must(uuid.NewV4()),
+			OwnerID:     ownerID,
+			Name:        "avatar.png",
+			ContentType: "image/png",
+			Size:        10,
+			MultipartID: "multipart",
+			Offset:      2,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+		received  = &app.AvatarUpload{}
+		completed = &app.AvatarUpload{}
+		presigned = &app.AvatarUpload{}
+		expired   = &app.AvatarUpload{}
+		another   = &app.AvatarUpload{}
+	)
+	*received, *completed, *presigned, *expired, *another = *upload, *upload, *upload, *upload, *upload
+	received.Offset = upload.Size
+	completed.Offset = upload.Size
+	completed.AvatarID = fileID
+	presigned.MultipartID = ""
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	another.OwnerID = uuid.Must(uuid.NewV4())
+
+	testCases := map[string]struct {
+		offset          int64
+		repoGetRes      *app.AvatarUpload
+		repoGetErr      error
+		leaseRes        bool
+		leaseErr        error
+		appendRes       int64
+		appendErr       error
+		setOffsetErr    error
+		completeErr     error
+		fileDownloadErr error
+		wantOffset      int64
+		wantErr         error
+	}{
+		"success_chunk":           {2, upload, nil, true, nil, 4, nil, nil, nil, nil, 6, nil},
+		"success_retry_completed": {10, completed, nil, false, nil, 0, nil, nil, nil, nil, 10, nil},
+		"err_not_uploaded":        {2, upload, nil, true, nil, 8, nil, nil, nil, app.ErrNotFound, 0, app.ErrNotFound},
+		"err_retry_not_uploaded":  {10, received, nil, false, nil, 0, nil, nil, app.ErrNotFound, app.ErrNotFound, 0, app.ErrNotFound},
+		"err_not_found":           {2, nil, app.ErrNotFound, false, nil, 0, nil, nil, nil, nil, 0, app.ErrNotFound},
+		"err_expired":             {2, expired, nil, false, nil, 0, nil, nil, nil, nil, 0, app.ErrNotFound},
+		"err_access_denied":       {2, another, nil, false, nil, 0, nil, nil, nil, nil, 0, app.ErrAccessDenied},
+		"err_not_resumable":       {2, presigned, nil, false, nil, 0, nil, nil, nil, nil, 0, app.ErrInvalidArgument},
+		"err_invalid_offset":      {0, upload, nil, false, nil, 0, nil, nil, nil, nil, 0, app.ErrInvalidOffset},
+		"err_leased":              {2, upload, nil, false, nil, 0, nil, nil, nil, nil, 0, app.ErrInvalidOffset},
+		"err_any_lease":           {2, upload, nil, false, errAny, 0, nil, nil, nil, nil, 0, errAny},
+		"err_any_append":          {2, upload, nil, true, nil, 3, errAny, nil, nil, nil, 0, errAny},
+		"err_any_set_offset":      {2, upload, nil, true, nil, 3, nil, errAny, nil, nil, 0, errAny},
+		"err_any_complete":        {2, upload, nil, true, nil, 8, nil, nil, errAny, nil, 0, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			get := func(context.Context, uuid.UUID) (*app.AvatarUpload, error) {
+				if tc.repoGetRes == nil {
+					return nil, tc.repoGetErr
+				}
+				res := *tc.repoGetRes // App changes returned upload.
+
+				return &res, nil
+			}
+			mocks.repo.EXPECT().GetAvatarUpload(ctx, upload.ID).DoAndReturn(get)
+
+			valid := tc.repoGetErr == nil && !errors.Is(tc.wantErr, app.ErrNotFound) &&
+				!errors.Is(tc.wantErr, app.ErrAccessDenied) && !errors.Is(tc.wantErr, app.ErrInvalidArgument) &&
+				tc.offset == tc.repoGetRes.Offset
+			if tc.fileDownloadErr != nil {
+				valid = true
+			}
+
+			chunk := strings.NewReader("chunk")
+			if valid && tc.repoGetRes.Offset < tc.repoGetRes.Size {
+				// Writer holding lease advances offset.
+				var writerID string
+				mocks.repo.EXPECT().LeaseAvatarUpload(ctx, upload.ID, tc.repoGetRes.Offset, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ int64, id string, lease time.Duration) (bool, error) {
+						writerID = id
+						assert.Positive(lease)
+
+						return tc.leaseRes, tc.leaseErr
+					})
+				if tc.leaseRes {
+					sameWriter := gomock.Cond(func(x any) bool { return x == writerID })
+					mocks.file.EXPECT().AppendMultipartUpload(gomock.Any(), upload.ID, upload.MultipartID, tc.repoGetRes.Offset, gomock.Any()).
+						Return(tc.appendRes, tc.appendErr)
+					mocks.repo.EXPECT().SetAvatarUploadOffset(gomock.Any(), upload.ID, sameWriter, tc.repoGetRes.Offset+tc.appendRes).
+						Return(tc.setOffsetErr)
+				}
+			}
+
+			if valid && tc.repoGetRes.AvatarID != uuid.Nil {
+				mocks.repo.EXPECT().GetAvatar(ctx, tc.repoGetRes.AvatarID).
+					Return(&app.AvatarInfo{FileID: tc.repoGetRes.AvatarID, Status: app.AvatarStatusClean}, nil)
+			}
+
+			completed := valid && tc.leaseErr == nil && tc.appendErr == nil && tc.setOffsetErr == nil &&
+				(tc.leaseRes || tc.repoGetRes.Offset == tc.repoGetRes.Size) &&
+				tc.repoGetRes.Offset+tc.appendRes == tc.repoGetRes.Size && tc.repoGetRes.AvatarID == uuid.Nil
+			if completed {
+				mocks.file.EXPECT().CompleteMultipartUpload(ctx, upload.ID, upload.MultipartID, upload.Size).Return(tc.completeErr)
+			}
+
+			if completed && (tc.completeErr == nil || errors.Is(tc.completeErr, app.ErrNotFound)) {
+				mocks.repo.EXPECT().GetAvatarUpload(ctx, upload.ID).DoAndReturn(get)
+				mocks.file.EXPECT().DownloadUpload(ctx, upload.ID).Return(nil, tc.fileDownloadErr)
+			}
+
+			res, avatarID, err := module.WriteAvatarUpload(ctx, session, upload.ID, tc.offset, chunk)
+			assert.ErrorIs(err, tc.wantErr)
+			if tc.wantErr != nil {
+				assert.Nil(res)
+				assert.Equal(uuid.Nil, avatarID)
+
+				return
+			}
+
+			assert.Equal(tc.wantOffset, res.Offset)
+			assert.Equal(tc.repoGetRes.AvatarID, avatarID)
+		})
+	}
+}
+
+func TestApp_RemoveExpiredAvatarUploads(t *testing.T) {
+	t.Parallel()
+
+	var (
+		resumable = app.AvatarUpload{
+			ID:          uuid.Must(uuid.NewV4()),
+			OwnerID:     ownerID,
+			MultipartID: "multipart",
+		}
+		presigned = app.AvatarUpload{
+			ID:      uuid.Must(uuid.NewV4()),
+			OwnerID: ownerID,
+		}
+	)
+
+	testCases := map[string]struct {
+		repoListRes   []app.AvatarUpload
+		repoListErr   error
+		fileAbortErr  error
+		fileDeleteErr error
+		repoDeleteErr error
+		wantErr       error
+	}{
+		"success":             {[]app.AvatarUpload{resumable, presigned}, nil, nil, nil, nil, nil},
+		"success_empty":       {nil, nil, nil, nil, nil, nil},
+		"err_any_list":        {nil, errAny, nil, nil, nil, errAny},
+		"err_any_abort":       {[]app.AvatarUpload{resumable}, nil, errAny, nil, nil, errAny},
+		"err_any_file_delete": {[]app.AvatarUpload{presigned}, nil, nil, errAny, nil, errAny},
+		"err_any_repo_delete": {[]app.AvatarUpload{presigned}, nil, nil, nil, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().ListExpiredAvatarUploads(ctx, gomock.Cond(func(x any) bool {
+				return time.Since(x.(time.Time)) < time.Minute
+			}), 100).Return(tc.repoListRes, tc.repoListErr)
+
+			for _, upload := range tc.repoListRes {
+				if upload.MultipartID != "" {
+					mocks.file.EXPECT().AbortMultipartUpload(ctx, upload.ID, upload.MultipartID).Return(tc.fileAbortErr)
+					if tc.fileAbortErr != nil {
+						break
+					}
+				}
+
+				mocks.file.EXPECT().DeleteUpload(ctx, upload.ID).Return(tc.fileDeleteErr)
+				if tc.fileDeleteErr != nil {
+					break
+				}
+
+				mocks.repo.EXPECT().DeleteAvatarUpload(ctx, upload.ID).Return(tc.repoDeleteErr)
+			}
+
+			err := module.RemoveExpiredAvatarUploads(ctx)
+			assert.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
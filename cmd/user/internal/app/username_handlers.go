@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+const (
+	backfillBatchSize = 100
+	// maxUsernameLen equals to max length of username in API.
+	maxUsernameLen = 32
+)
+
+// ResolveUsername returns user by username.
+// If nobody uses username now, it follows the latest rename made during username cooldown.
+func (a *App) ResolveUsername(ctx context.Context, _ dom.Session, username string) (*User, error) {
+	user, err := a.repo.ByUsername(ctx, username)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrNotFound) && a.cfg.UsernameCooldown > 0:
+		var change *UsernameChange
+		change, err = a.repo.LastUsernameChange(ctx, username, time.Now().Add(-a.cfg.UsernameCooldown))
+		if err != nil {
+			return nil, fmt.Errorf("a.repo.LastUsernameChange: %w", err)
+		}
+
+		user, err = a.repo.ByID(ctx, change.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("a.repo.ByID: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("a.repo.ByUsername: %w", err)
+	}
+
+	users := []User{*user}
+	err = a.withPrivacy(ctx, users)
+	if err != nil {
+		return nil, fmt.Errorf("a.withPrivacy: %w", err)
+	}
+
+	return &users[0], nil
+}
+
+// checkUsername returns error if username can't be taken by user.
+// Use uuid.Nil as userID for new user.
+func (a *App) checkUsername(ctx context.Context, repo Repo, userID uuid.UUID, username string) error {
+	_, ok := a.reserved[NormalizeUsername(username)]
+	if ok {
+		return ErrUsernameReserved
+	}
+
+	user, err := repo.ByUsername(ctx, username)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("repo.ByUsername: %w", err)
+	case user.ID != userID:
+		return ErrUsernameExist
+	}
+
+	if a.cfg.UsernameCooldown <= 0 {
+		return nil
+	}
+
+	change, err := repo.LastUsernameChange(ctx, username, time.Now().Add(-a.cfg.UsernameCooldown))
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("repo.LastUsernameChange: %w", err)
+	case change.UserID != userID:
+		return ErrUsernameExist
+	default:
+		return nil
+	}
+}
+
+// saveRename adds username released by user to history and publishes user with the new username.
+func (a *App) saveRename(ctx context.Context, repo Repo, prev, updated User) error {
+	err := repo.SaveUsernameChange(ctx, UsernameChange{
+		UserID:   prev.ID,
+		Username: prev.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("repo.SaveUsernameChange: %w", err)
+	}
+
+	_, err = repo.SaveTask(ctx, Task{
+		User: updated,
+		Kind: TaskKindEventUpdate,
+	})
+	if err != nil {
+		return fmt.Errorf("repo.SaveTask: %w", err)
+	}
+
+	return nil
+}
+
+// BackfillUsernames sets normalized usernames which are left empty by migrations and returns count of renamed users.
+// It's run once by admin command, because normalization is implemented by NormalizeUsername only.
+// Users are processed from the oldest one, so the oldest user keeps the name and every next user
+// whose name can't be taken gets numeric suffix, for example "bob_2".
+// Renames follow the rules of UpdateUser, so reserved usernames and cooldown are respected,
+// released usernames are added to history and updated users are published.
+func (a *App) BackfillUsernames(ctx context.Context) (renamed int, err error) {
+	// History is normalized first, so cooldown of released usernames is respected.
+	err = a.repo.NormalizeUsernameHistory(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("a.repo.NormalizeUsernameHistory: %w", err)
+	}
+
+	for {
+		users, err := a.repo.ListUnnormalizedUsers(ctx, backfillBatchSize)
+		if err != nil {
+			return renamed, fmt.Errorf("a.repo.ListUnnormalizedUsers: %w", err)
+		}
+
+		for i := range users {
+			ok, err := a.backfillUsername(ctx, users[i].ID)
+			if err != nil {
+				return renamed, fmt.Errorf("a.backfillUsername: %w", err)
+			}
+
+			if ok {
+				renamed++
+			}
+		}
+
+		if len(users) < backfillBatchSize {
+			return renamed, nil
+		}
+	}
+}
+
+// backfillUsername sets normalized username of user and reports if user is renamed.
+// Every attempt is made in its own transaction, because name collision is detected by unique index
+// and fails the transaction.
+func (a *App) backfillUsername(ctx context.Context, userID uuid.UUID) (renamed bool, err error) {
+	for n := 1; ; n++ {
+		err = a.repo.Tx(ctx, func(repo Repo) error {
+			u, err := repo.ByID(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("repo.ByID: %w", err)
+			}
+
+			user := *u
+			if n > 1 {
+				user.Name = withSuffix(u.Name, n)
+			}
+
+			err = a.checkUsername(ctx, repo, u.ID, user.Name)
+			if err != nil {
+				return fmt.Errorf("a.checkUsername: %w", err)
+			}
+
+			updated, err := repo.Update(ctx, user)
+			if err != nil {
+				return fmt.Errorf("repo.Update: %w", err)
+			}
+
+			renamed = user.Name != u.Name
+			if !renamed {
+				return nil
+			}
+
+			return a.saveRename(ctx, repo, *u, *updated)
+		})
+		switch {
+		case errors.Is(err, ErrUsernameExist), errors.Is(err, ErrUsernameReserved):
+		case err != nil:
+			return false, fmt.Errorf("a.repo.Tx: %w", err)
+		default:
+			return renamed, nil
+		}
+	}
+}
+
+// withSuffix returns name with numeric suffix, name is cut to keep result not longer than maxUsernameLen.
+func withSuffix(name string, n int) string {
+	suffix := "_" + strconv.Itoa(n)
+
+	runes := []rune(name)
+	if len(runes)+len(suffix) > maxUsernameLen {
+		runes = runes[:maxUsernameLen-len(suffix)]
+	}
+
+	return string(runes) + suffix
+}
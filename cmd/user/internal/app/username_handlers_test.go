@@ -0,0 +1,198 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+func TestApp_ResolveUsername(t *testing.T) {
+	t.Parallel()
+
+	var (
+		session = dom.Session{
+			ID:     uuid.Must(uuid.NewV4()),
+			UserID: ownerID,
+		}
+		username = "old_name"
+		user     = app.User{
+			ID:     uuid.Must(uuid.NewV4()),
+			Email:  "email@mail.com",
+			Name:   "new_name",
+			Status: dom.UserStatusDefault,
+		}
+		change = &app.UsernameChange{
+			ID:       uuid.Must(uuid.NewV4()),
+			UserID:   user.ID,
+			Username: username,
+		}
+		want = withPrivacy(user, app.DefaultPrivacySettings(user.ID))
+	)
+
+	testCases := map[string]struct {
+		byUsernameRes *app.User
+		byUsernameErr error
+		changeRes     *app.UsernameChange
+		changeErr     error
+		byIDRes       *app.User
+		byIDErr       error
+		want          *app.User
+		wantErr       error
+	}{
+		"success":                       {&user, nil, nil, nil, nil, nil, &want, nil},
+		"success_renamed":               {nil, app.ErrNotFound, change, nil, &user, nil, &want, nil},
+		"a.repo.ByUsername":             {nil, errAny, nil, nil, nil, nil, nil, errAny},
+		"a.repo.LastUsernameChange":     {nil, app.ErrNotFound, nil, app.ErrNotFound, nil, nil, nil, app.ErrNotFound},
+		"a.repo.LastUsernameChange_any": {nil, app.ErrNotFound, nil, errAny, nil, nil, nil, errAny},
+		"a.repo.ByID":                   {nil, app.ErrNotFound, change, nil, nil, errAny, nil, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().ByUsername(ctx, username).Return(tc.byUsernameRes, tc.byUsernameErr)
+			if errors.Is(tc.byUsernameErr, app.ErrNotFound) {
+				mocks.repo.EXPECT().LastUsernameChange(ctx, username, gomock.Any()).Return(tc.changeRes, tc.changeErr)
+			}
+			if tc.changeRes != nil {
+				mocks.repo.EXPECT().ByID(ctx, tc.changeRes.UserID).Return(tc.byIDRes, tc.byIDErr)
+			}
+			if tc.wantErr == nil {
+				mocks.repo.EXPECT().ListPrivacySettings(ctx, []uuid.UUID{user.ID}).Return(nil, nil)
+			}
+
+			res, err := module.ResolveUsername(ctx, session, username)
+			assert.Equal(tc.want, res)
+			assert.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		username string
+		want     string
+	}{
+		"lower":       {"bob", "bob"},
+		"case":        {"BoB", "bob"},
+		"fullwidth":   {"ｂｏｂ", "bob"},
+		"cyrillic":    {"bоb", "bob"},
+		"greek":       {"Βοb", "bob"},
+		"digits":      {"bob0", "bob0"},
+		"unchanged":   {"andrey_maslov", "andrey_maslov"},
+		"ligature":    {"ﬁsh", "fish"},
+		"sharp_s":     {"straße", "strasse"},
+		"dotless_i":   {"bıll", "bill"},
+		"mixed_parts": {"Аdmin", "admin"},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			assert.Equal(tc.want, app.NormalizeUsername(tc.username))
+		})
+	}
+}
+
+func TestApp_BackfillUsernames(t *testing.T) {
+	t.Parallel()
+
+	var (
+		kept = app.User{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "bob",
+		}
+		taken = app.User{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "Bob",
+		}
+		reserved = app.User{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "admin",
+		}
+		users = []app.User{kept, taken, reserved}
+	)
+
+	testCases := map[string]struct {
+		historyErr error
+		listErr    error
+		updateErr  error
+		want       int
+		wantErr    error
+	}{
+		"success":                         {nil, nil, nil, 2, nil},
+		"a.repo.NormalizeUsernameHistory": {errAny, nil, nil, 0, errAny},
+		"a.repo.ListUnnormalizedUsers":    {nil, errAny, nil, 0, errAny},
+		"a.backfillUsername":              {nil, nil, errAny, 0, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, module, mocks, assert := start(t)
+
+			mocks.repo.EXPECT().NormalizeUsernameHistory(ctx).Return(tc.historyErr)
+			if tc.historyErr == nil {
+				mocks.repo.EXPECT().ListUnnormalizedUsers(ctx, gomock.Any()).Return(users, tc.listErr)
+			}
+
+			if tc.historyErr == nil && tc.listErr == nil {
+				mocks.repo.EXPECT().Tx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(r app.Repo) error) error {
+					return fn(mocks.repo)
+				}).AnyTimes()
+
+				mocks.repo.EXPECT().ByID(ctx, kept.ID).Return(&kept, nil)
+				mocks.repo.EXPECT().ByUsername(ctx, kept.Name).Return(&kept, nil)
+				mocks.repo.EXPECT().LastUsernameChange(ctx, kept.Name, gomock.Any()).Return(nil, app.ErrNotFound)
+				mocks.repo.EXPECT().Update(ctx, kept).Return(&kept, tc.updateErr)
+			}
+
+			if tc.wantErr == nil {
+				// The name is free by the old unique index, but it's taken by normalized form of older user.
+				mocks.repo.EXPECT().ByID(ctx, taken.ID).Return(&taken, nil).Times(2)
+				mocks.repo.EXPECT().ByUsername(ctx, taken.Name).Return(&taken, nil)
+				mocks.repo.EXPECT().LastUsernameChange(ctx, taken.Name, gomock.Any()).Return(nil, app.ErrNotFound)
+				mocks.repo.EXPECT().Update(ctx, taken).Return(nil, app.ErrUsernameExist)
+
+				renamedTaken := taken
+				renamedTaken.Name = "Bob_2"
+				mocks.repo.EXPECT().ByUsername(ctx, renamedTaken.Name).Return(nil, app.ErrNotFound)
+				mocks.repo.EXPECT().LastUsernameChange(ctx, renamedTaken.Name, gomock.Any()).Return(nil, app.ErrNotFound)
+				mocks.repo.EXPECT().Update(ctx, renamedTaken).Return(&renamedTaken, nil)
+				mocks.repo.EXPECT().SaveUsernameChange(ctx, app.UsernameChange{UserID: taken.ID, Username: taken.Name}).Return(nil)
+				mocks.repo.EXPECT().SaveTask(ctx, app.Task{User: renamedTaken, Kind: app.TaskKindEventUpdate}).Return(uuid.Must(uuid.NewV4()), nil)
+
+				renamedReserved := reserved
+				renamedReserved.Name = "admin_2"
+				mocks.repo.EXPECT().ByID(ctx, reserved.ID).Return(&reserved, nil).Times(2)
+				mocks.repo.EXPECT().ByUsername(ctx, renamedReserved.Name).Return(nil, app.ErrNotFound)
+				mocks.repo.EXPECT().LastUsernameChange(ctx, renamedReserved.Name, gomock.Any()).Return(nil, app.ErrNotFound)
+				mocks.repo.EXPECT().Update(ctx, renamedReserved).Return(&renamedReserved, nil)
+				mocks.repo.EXPECT().SaveUsernameChange(ctx, app.UsernameChange{UserID: reserved.ID, Username: reserved.Name}).Return(nil)
+				mocks.repo.EXPECT().SaveTask(ctx, app.Task{User: renamedReserved, Kind: app.TaskKindEventUpdate}).Return(uuid.Must(uuid.NewV4()), nil)
+			}
+
+			res, err := module.BackfillUsernames(ctx)
+			assert.Equal(tc.want, res)
+			assert.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
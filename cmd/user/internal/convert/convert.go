@@ -0,0 +1,76 @@
+// Package convert contains conversion of domain models to API models,
+// which are shared by gRPC API and published events.
+package convert
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	user_pb "github.com/ZergsLaw/back-template1/api/user/v1"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
+	"github.com/ZergsLaw/back-template1/internal/dom"
+)
+
+// User converts user with its profile and timestamps.
+func User(u app.User) *user_pb.User {
+	return &user_pb.User{
+		Id:        u.ID.String(),
+		Username:  u.Name,
+		Email:     u.Email,
+		AvatarId:  u.AvatarID.String(),
+		Kind:      dom.UserStatusToAPI(u.Status),
+		FullName:  u.FullName,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+		Profile:   Profile(u.Profile),
+	}
+}
+
+// Profile converts profile of user, nil is returned for empty profile.
+func Profile(p app.Profile) *user_pb.Profile {
+	if p.IsEmpty() {
+		return nil
+	}
+
+	res := &user_pb.Profile{
+		Bio:      p.Bio,
+		Locale:   p.Locale,
+		Timezone: p.Timezone,
+		Links:    p.Links,
+	}
+	if !p.Birthday.IsZero() {
+		res.Birthday = timestamppb.New(p.Birthday)
+	}
+
+	return res
+}
+
+// Preference converts preference value from canonical text form.
+// Values are validated by app, so parse errors are impossible.
+func Preference(p app.Preference) *user_pb.Preference {
+	res := &user_pb.Preference{Key: p.Key}
+
+	switch p.Kind {
+	case app.PreferenceKindBool:
+		v, _ := strconv.ParseBool(p.Value)
+		res.Value = &user_pb.Preference_BoolValue{BoolValue: v}
+	case app.PreferenceKindInt:
+		v, _ := strconv.ParseInt(p.Value, 10, 64)
+		res.Value = &user_pb.Preference_IntValue{IntValue: v}
+	case app.PreferenceKindString:
+		res.Value = &user_pb.Preference_StringValue{StringValue: p.Value}
+	}
+
+	return res
+}
+
+// Preferences converts list of preferences.
+func Preferences(prefs []app.Preference) []*user_pb.Preference {
+	res := make([]*user_pb.Preference, len(prefs))
+	for i := range prefs {
+		res[i] = Preference(prefs[i])
+	}
+
+	return res
+}
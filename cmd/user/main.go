@@ -21,12 +21,16 @@ import (
 	user_pb "github.com/ZergsLaw/back-template1/api/user/v1"
 	session_client "github.com/ZergsLaw/back-template1/cmd/session/client"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/files"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/images"
+	app_metrics "github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/metrics"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/queue"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/repo"
+	"github.com/ZergsLaw/back-template1/cmd/user/internal/adapters/scanner"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/api/grpc"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/api/http"
 	"github.com/ZergsLaw/back-template1/cmd/user/internal/app"
 	session_adapter "github.com/ZergsLaw/back-template1/internal/adapters/session"
+	"github.com/ZergsLaw/back-template1/internal/dom"
 	"github.com/ZergsLaw/back-template1/internal/flags"
 	"github.com/ZergsLaw/back-template1/internal/grpchelper"
 	"github.com/ZergsLaw/back-template1/internal/logger"
@@ -42,6 +46,8 @@ type (
 		DB        dbConfig        `yaml:"db"`
 		FileStore fileStoreConfig `yaml:"file_store"`
 		Queue     queueConfig     `yaml:"queue"`
+		Username  usernameConfig  `yaml:"username"`
+		Outbox    outboxConfig    `yaml:"outbox"`
 		DevMode   bool            `yaml:"dev_mode"`
 	}
 	server struct {
@@ -60,12 +66,31 @@ type (
 		Cockroach  connectors.CockroachDB `yaml:"cockroach"`
 	}
 	fileStoreConfig struct {
-		Secure       bool   `yaml:"secure"`
-		Endpoint     string `yaml:"endpoint"`
-		AccessKey    string `yaml:"access_key"`
-		SecretKey    string `yaml:"secret_key"`
-		SessionToken string `yaml:"session_token"`
-		Region       string `yaml:"region"`
+		Backend            files.Backend `yaml:"backend"`
+		S3                 s3Config      `yaml:"s3"`
+		Local              localFSConfig `yaml:"local"`
+		PresignedURLTTL    time.Duration `yaml:"presigned_url_ttl"`
+		ResumableUploadTTL time.Duration `yaml:"resumable_upload_ttl"`
+		ReconcileInterval  time.Duration `yaml:"reconcile_interval"`
+		StorageQuota       quotaConfig   `yaml:"storage_quota"`
+	}
+	// quotaConfig contains storage quotas in bytes by user status, zero means no limit.
+	quotaConfig struct {
+		Default int64 `yaml:"default"`
+		Premium int64 `yaml:"premium"`
+	}
+	s3Config struct {
+		Secure         bool   `yaml:"secure"`
+		Endpoint       string `yaml:"endpoint"`
+		PublicEndpoint string `yaml:"public_endpoint"`
+		AccessKey      string `yaml:"access_key"`
+		SecretKey      string `yaml:"secret_key"`
+		SessionToken   string `yaml:"session_token"`
+		Region         string `yaml:"region"`
+		Bucket         string `yaml:"bucket"`
+	}
+	localFSConfig struct {
+		Root string `yaml:"root"`
 	}
 	clients struct {
 		Session string `yaml:"session"`
@@ -75,6 +100,21 @@ type (
 		Username string   `yaml:"username"`
 		Password string   `yaml:"password"`
 	}
+	outboxConfig struct {
+		Driver          string        `yaml:"driver"`
+		PollInterval    time.Duration `yaml:"poll_interval"`
+		Lease           time.Duration `yaml:"lease"`
+		MaxAttempts     int           `yaml:"max_attempts"`
+		RetryBackoff    time.Duration `yaml:"retry_backoff"`
+		RetryMaxBackoff time.Duration `yaml:"retry_max_backoff"`
+		Workers         int           `yaml:"workers"`
+		Retention       time.Duration `yaml:"retention"`
+		Archive         bool          `yaml:"archive"`
+	}
+	usernameConfig struct {
+		Reserved []string      `yaml:"reserved"`
+		Cooldown time.Duration `yaml:"cooldown"`
+	}
 )
 
 var (
@@ -84,10 +124,17 @@ var (
 
 const version = "v0.1.0"
 
+// cmdBackfillUsernames is admin command which is run once after migration
+// to set normalized usernames of users created before it.
+const cmdBackfillUsernames = "backfill_usernames"
+
+var errUnknownCommand = errors.New("unknown command")
+
 func main() {
 	flag.Var(cfgFile, "cfg", "path to config file")
 	flag.Var(logLevel, "log_level", "log level")
 	flag.Parse()
+	command := flag.Arg(0)
 
 	log := buildLogger(logLevel.Level)
 	grpclog.SetLoggerV2(grpchelper.NewLogger(log))
@@ -98,7 +145,7 @@ func main() {
 	defer cancel()
 	go forceShutdown(ctx)
 
-	err := start(ctx, cfgFile, appName)
+	err := start(ctx, cfgFile, appName, command)
 	if err != nil {
 		log.Error("shutdown",
 			slog.String(logger.Error.String(), err.Error()),
@@ -107,7 +154,7 @@ func main() {
 	}
 }
 
-func start(ctx context.Context, cfgFile io.Reader, appName string) error {
+func start(ctx context.Context, cfgFile io.Reader, appName, command string) error {
 	cfg := config{}
 	err := yaml.NewDecoder(cfgFile).Decode(&cfg)
 	if err != nil {
@@ -116,7 +163,49 @@ func start(ctx context.Context, cfgFile io.Reader, appName string) error {
 
 	reg := prometheus.NewPedanticRegistry()
 
-	return run(ctx, cfg, reg, appName)
+	switch command {
+	case "":
+		return run(ctx, cfg, reg, appName)
+	case cmdBackfillUsernames:
+		return backfillUsernames(ctx, cfg, reg, appName)
+	default:
+		return fmt.Errorf("%w: %s", errUnknownCommand, command)
+	}
+}
+
+func backfillUsernames(ctx context.Context, cfg config, reg *prometheus.Registry, namespace string) error {
+	log := logger.FromContext(ctx)
+
+	r, err := repo.New(ctx, reg, namespace, repo.Config{
+		Cockroach:  cfg.DB.Cockroach,
+		MigrateDir: cfg.DB.MigrateDir,
+		Driver:     cfg.DB.Driver,
+		Outbox:     cfg.Outbox.Driver,
+	})
+	if err != nil {
+		return fmt.Errorf("repo.New: %w", err)
+	}
+	defer func() {
+		err := r.Close()
+		if err != nil {
+			log.Error("close database connection", slog.String(logger.Error.String(), err.Error()))
+		}
+	}()
+
+	// Renamed users are published by tasks processed by the service, so other adapters aren't needed.
+	module := app.New(r, nil, nil, nil, nil, nil, nil, app_metrics.New(reg, namespace), app.Config{
+		ReservedUsernames: cfg.Username.Reserved,
+		UsernameCooldown:  cfg.Username.Cooldown,
+	})
+
+	renamed, err := module.BackfillUsernames(ctx)
+	if err != nil {
+		return fmt.Errorf("module.BackfillUsernames: %w", err)
+	}
+
+	log.Info("usernames are backfilled", slog.Int("renamed", renamed))
+
+	return nil
 }
 
 func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace string) error {
@@ -127,6 +216,7 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 		Cockroach:  cfg.DB.Cockroach,
 		MigrateDir: cfg.DB.MigrateDir,
 		Driver:     cfg.DB.Driver,
+		Outbox:     cfg.Outbox.Driver,
 	})
 	if err != nil {
 		return fmt.Errorf("repo.New: %w", err)
@@ -139,16 +229,30 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 	}()
 
 	fileStore, err := files.New(ctx, reg, namespace, files.Config{
-		Secure:       cfg.FileStore.Secure,
-		Endpoint:     cfg.FileStore.Endpoint,
-		AccessKey:    cfg.FileStore.AccessKey,
-		SecretKey:    cfg.FileStore.SecretKey,
-		SessionToken: cfg.FileStore.SessionToken,
-		Region:       cfg.FileStore.Region,
+		Backend: cfg.FileStore.Backend,
+		S3: files.S3Config{
+			Secure:         cfg.FileStore.S3.Secure,
+			Endpoint:       cfg.FileStore.S3.Endpoint,
+			AccessKey:      cfg.FileStore.S3.AccessKey,
+			SecretKey:      cfg.FileStore.S3.SecretKey,
+			SessionToken:   cfg.FileStore.S3.SessionToken,
+			Region:         cfg.FileStore.S3.Region,
+			PublicEndpoint: cfg.FileStore.S3.PublicEndpoint,
+			Bucket:         cfg.FileStore.S3.Bucket,
+		},
+		Local: files.LocalConfig{
+			Root: cfg.FileStore.Local.Root,
+		},
 	})
 	if err != nil {
 		return fmt.Errorf("files.New: %w", err)
 	}
+	defer func() {
+		err := fileStore.Close()
+		if err != nil {
+			log.Error("close file store", slog.String(logger.Error.String(), err.Error()))
+		}
+	}()
 
 	client, err := session_client.New(ctx, log, reg, namespace, cfg.Clients.Session)
 	if err != nil {
@@ -173,7 +277,25 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 
 	ph := password.New()
 
-	module := app.New(r, ph, sessionSvc, fileStore, q)
+	module := app.New(r, ph, sessionSvc, fileStore, images.New(), scanner.New(), q, app_metrics.New(reg, namespace), app.Config{
+		ReservedUsernames:        cfg.Username.Reserved,
+		UsernameCooldown:         cfg.Username.Cooldown,
+		PresignedURLTTL:          cfg.FileStore.PresignedURLTTL,
+		ResumableUploadTTL:       cfg.FileStore.ResumableUploadTTL,
+		AvatarsReconcileInterval: cfg.FileStore.ReconcileInterval,
+		StorageQuotas: map[dom.UserStatus]int64{
+			dom.UserStatusDefault: cfg.FileStore.StorageQuota.Default,
+			dom.UserStatusPremium: cfg.FileStore.StorageQuota.Premium,
+		},
+		TaskLease:           cfg.Outbox.Lease,
+		TaskMaxAttempts:     cfg.Outbox.MaxAttempts,
+		TaskRetryBackoff:    cfg.Outbox.RetryBackoff,
+		TaskRetryMaxBackoff: cfg.Outbox.RetryMaxBackoff,
+		TaskPollInterval:    cfg.Outbox.PollInterval,
+		TaskWorkers:         cfg.Outbox.Workers,
+		TaskRetention:       cfg.Outbox.Retention,
+		TaskArchive:         cfg.Outbox.Archive,
+	})
 	grpcAPI := grpc.New(ctx, m, module, reg, namespace)
 
 	httpAPI := http.New(ctx, module)
@@ -0,0 +1,11 @@
+-- up
+create unique index users_name_normalized_key on users (name_normalized);
+
+drop index username_history@username_history_username_created_at_idx;
+create index username_history_username_normalized_created_at_idx on username_history (username_normalized, created_at desc);
+
+-- down
+drop index username_history@username_history_username_normalized_created_at_idx;
+create index username_history_username_created_at_idx on username_history (username, created_at desc);
+
+drop index users@users_name_normalized_key;
@@ -0,0 +1,7 @@
+-- up
+alter table avatars
+    add column status text not null default 'Clean';
+
+-- down
+alter table avatars
+    drop column status;
@@ -0,0 +1,19 @@
+-- up
+create table avatar_uploads
+(
+    id           uuid      not null default gen_random_uuid(),
+    owner_id     uuid      not null,
+    name         text      not null,
+    content_type text      not null,
+    size         int8      not null,
+    expires_at   timestamp not null,
+    created_at   timestamp not null default now(),
+
+    primary key (id),
+    foreign key (owner_id) references users on delete cascade
+);
+
+create index avatar_uploads_expires_at_idx on avatar_uploads (expires_at);
+
+-- down
+drop table avatar_uploads;
@@ -0,0 +1,9 @@
+-- up
+alter table avatar_uploads
+    add column multipart_id text not null default '',
+    add column uploaded_size int8 not null default 0;
+
+-- down
+alter table avatar_uploads
+    drop column multipart_id,
+    drop column uploaded_size;
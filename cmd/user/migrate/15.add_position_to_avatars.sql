@@ -0,0 +1,7 @@
+-- up
+alter table avatars
+    add column position int8 not null default 0;
+
+-- down
+alter table avatars
+    drop column position;
@@ -0,0 +1,13 @@
+-- up
+-- Galleries keep previous order: the newest avatar goes first.
+update avatars
+set position = backfill.position
+from (select id,
+             row_number() over (partition by owner_id order by created_at desc, id) - 1 as position
+      from avatars) as backfill
+where avatars.id = backfill.id;
+
+-- down
+update avatars
+set position = 0
+where position != 0;
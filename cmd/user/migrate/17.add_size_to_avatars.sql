@@ -0,0 +1,8 @@
+-- up
+-- Size of existing avatars is unknown, they have size 0 and don't take quota, see migration 25.
+alter table avatars
+    add column size int8 not null default 0;
+
+-- down
+alter table avatars
+    drop column size;
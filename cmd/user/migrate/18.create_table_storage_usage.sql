@@ -0,0 +1,14 @@
+-- up
+create table storage_usage
+(
+    user_id    uuid      not null,
+    used_bytes int8      not null default 0,
+    created_at timestamp not null default now(),
+    updated_at timestamp not null default now(),
+
+    primary key (user_id),
+    foreign key (user_id) references users on delete cascade
+);
+
+-- down
+drop table storage_usage;
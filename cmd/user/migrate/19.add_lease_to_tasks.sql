@@ -0,0 +1,13 @@
+-- up
+alter table tasks
+    add column locked_by text;
+
+alter table tasks
+    add column locked_until timestamp;
+
+-- down
+alter table tasks
+    drop column locked_until;
+
+alter table tasks
+    drop column locked_by;
@@ -0,0 +1,25 @@
+-- up
+alter table tasks
+    add column attempts int4 not null default 0;
+
+alter table tasks
+    add column next_attempt_at timestamp not null default now();
+
+alter table tasks
+    add column last_error text not null default '';
+
+alter table tasks
+    add column dead_at timestamp;
+
+-- down
+alter table tasks
+    drop column dead_at;
+
+alter table tasks
+    drop column last_error;
+
+alter table tasks
+    drop column next_attempt_at;
+
+alter table tasks
+    drop column attempts;
@@ -0,0 +1,15 @@
+-- up
+alter table tasks
+    add column user_id uuid;
+
+update tasks
+set user_id = (convert_from(user_bytes, 'UTF8')::jsonb ->> 'id')::uuid
+where finished_at is null;
+
+create index tasks_user_id_created_at_idx on tasks (user_id, created_at);
+
+-- down
+drop index tasks@tasks_user_id_created_at_idx;
+
+alter table tasks
+    drop column user_id;
@@ -0,0 +1,28 @@
+-- up
+create index tasks_unfinished_created_at_idx on tasks (created_at)
+    where finished_at is null and dead_at is null;
+
+create index tasks_finished_at_idx on tasks (finished_at)
+    where finished_at is not null;
+
+create table tasks_archive
+(
+    id                uuid      not null,
+    user_id           uuid,
+    kind              text      not null,
+    user_bytes        bytea     not null,
+    preferences_bytes bytea,
+    attempts          int4      not null,
+    created_at        timestamp not null,
+    finished_at       timestamp not null,
+    archived_at       timestamp not null default now(),
+
+    primary key (id)
+);
+
+-- down
+drop table tasks_archive;
+
+drop index tasks@tasks_finished_at_idx;
+
+drop index tasks@tasks_unfinished_created_at_idx;
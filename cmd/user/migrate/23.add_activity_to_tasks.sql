@@ -0,0 +1,13 @@
+-- up
+alter table tasks
+    add column activity_bytes bytea;
+
+alter table tasks_archive
+    add column activity_bytes bytea;
+
+-- down
+alter table tasks_archive
+    drop column activity_bytes;
+
+alter table tasks
+    drop column activity_bytes;
@@ -0,0 +1,7 @@
+-- up
+alter table avatar_uploads
+    add column avatar_id uuid;
+
+-- down
+alter table avatar_uploads
+    drop column avatar_id;
@@ -0,0 +1,13 @@
+-- up
+-- Avatars saved before size was tracked keep size 0, so they don't take quota.
+-- Usage of every user is recalculated from known sizes of clean avatars.
+insert into storage_usage
+    (user_id, used_bytes)
+select owner_id, sum(size)
+from avatars
+where status = 'Clean'
+group by owner_id
+on conflict (user_id) do update
+set used_bytes = excluded.used_bytes, updated_at = now();
+
+-- down
@@ -0,0 +1,12 @@
+-- up
+create table job_leases
+(
+    job          text      not null,
+    locked_by    text      not null,
+    locked_until timestamp not null,
+
+    primary key (job)
+);
+
+-- down
+drop table job_leases;
@@ -0,0 +1,9 @@
+-- up
+alter table avatar_uploads
+    add column locked_by text,
+    add column locked_until timestamp;
+
+-- down
+alter table avatar_uploads
+    drop column locked_by,
+    drop column locked_until;
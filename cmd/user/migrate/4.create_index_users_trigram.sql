@@ -0,0 +1,9 @@
+-- up
+create index users_name_trgm_idx on users using gin (name gin_trgm_ops);
+create index users_full_name_trgm_idx on users using gin (full_name gin_trgm_ops);
+create index users_email_trgm_idx on users using gin (email gin_trgm_ops);
+
+-- down
+drop index users@users_email_trgm_idx;
+drop index users@users_full_name_trgm_idx;
+drop index users@users_name_trgm_idx;
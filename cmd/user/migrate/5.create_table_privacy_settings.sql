@@ -0,0 +1,17 @@
+-- up
+create table privacy_settings
+(
+    user_id          uuid      not null,
+    hide_email       boolean   not null default true,
+    hide_status      boolean   not null default false,
+    hide_avatar      boolean   not null default false,
+    hide_from_search boolean   not null default false,
+    created_at       timestamp not null default now(),
+    updated_at       timestamp not null default now(),
+
+    primary key (user_id),
+    foreign key (user_id) references users on delete cascade
+);
+
+-- down
+drop table privacy_settings;
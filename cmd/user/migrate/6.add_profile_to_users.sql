@@ -0,0 +1,15 @@
+-- up
+alter table users
+    add column bio text not null default '',
+    add column locale text not null default '',
+    add column timezone text not null default '',
+    add column birthday date,
+    add column links text[] not null default '{}';
+
+-- down
+alter table users
+    drop column bio,
+    drop column locale,
+    drop column timezone,
+    drop column birthday,
+    drop column links;
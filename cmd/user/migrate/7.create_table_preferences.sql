@@ -0,0 +1,22 @@
+-- up
+create table preferences
+(
+    user_id    uuid      not null,
+    key        text      not null,
+    kind       text      not null,
+    value      text      not null,
+    created_at timestamp not null default now(),
+    updated_at timestamp not null default now(),
+
+    primary key (user_id, key),
+    foreign key (user_id) references users on delete cascade
+);
+
+alter table tasks
+    add column preferences_bytes bytea;
+
+-- down
+alter table tasks
+    drop column preferences_bytes;
+
+drop table preferences;
@@ -0,0 +1,16 @@
+-- up
+create table username_history
+(
+    id         uuid      not null default gen_random_uuid(),
+    user_id    uuid      not null,
+    username   text      not null,
+    created_at timestamp not null default now(),
+
+    primary key (id),
+    foreign key (user_id) references users on delete cascade
+);
+
+create index username_history_username_created_at_idx on username_history (username, created_at desc);
+
+-- down
+drop table username_history;
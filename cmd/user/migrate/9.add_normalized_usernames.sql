@@ -0,0 +1,15 @@
+-- up
+-- Normalized usernames are backfilled by "backfill_usernames" command of the service,
+-- because normalization (NFKC, case folding and confusable characters) is implemented by app.NormalizeUsername only.
+alter table users
+    add column name_normalized text;
+
+alter table username_history
+    add column username_normalized text;
+
+-- down
+alter table username_history
+    drop column username_normalized;
+
+alter table users
+    drop column name_normalized;
@@ -21,6 +21,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	events_pb "github.com/ZergsLaw/back-template/api/events/v1"
 	user_pb "github.com/ZergsLaw/back-template/api/user/v1"
 	"github.com/ZergsLaw/back-template/internal/queue"
 	"github.com/ZergsLaw/back-template/internal/testhelper"
@@ -87,9 +88,13 @@ func TestE2E(t *testing.T) {
 		}
 
 		assert.Equal(user_pb.TopicAdd, message.Subject())
-		eventAdd := user_pb.Event{}
-		err = message.Unmarshal(&eventAdd)
+		env := events_pb.Envelope{}
+		err = message.Unmarshal(&env)
 		assert.NoError(err)
+		decoded, err := user_pb.Registry().Decode(&env)
+		assert.NoError(err)
+		eventAdd, ok := decoded.(*user_pb.Event)
+		assert.True(ok)
 		assert.Equal(createUserResp.Id, eventAdd.GetAdd().User.Id)
 		assert.Equal(selfInfo.User.Email, eventAdd.GetAdd().User.Email)
 
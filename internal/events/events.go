@@ -0,0 +1,202 @@
+// Package events wraps events to versioned envelopes and decodes them back.
+//
+// Producer wraps event by Wrap with current schema version of event type.
+// Consumer decodes envelope by Registry, which knows decoders of all versions
+// and upcasters converting every version to the next one, so consumer always
+// gets event of the latest version it knows.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/protovalidate-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	events_pb "github.com/ZergsLaw/back-template1/api/events/v1"
+)
+
+// Errors.
+var (
+	ErrUnknownEvent   = errors.New("unknown event type")
+	ErrUnknownVersion = errors.New("unknown schema version")
+)
+
+type (
+	// Meta contains envelope fields describing event.
+	Meta struct {
+		Producer      string
+		CorrelationID string
+		OccurredAt    time.Time
+	}
+	// Decoder decodes payload of one schema version.
+	Decoder func(payload []byte) (proto.Message, error)
+	// Upcaster converts event of one schema version to the next one.
+	Upcaster func(proto.Message) (proto.Message, error)
+	// Registry maps event types and schema versions to decoders and upcasters.
+	Registry struct {
+		mu    sync.RWMutex
+		types map[string][]version // Index is version - 1.
+	}
+	version struct {
+		decode Decoder
+		upcast Upcaster // Nil for the latest version.
+	}
+	correlationIDKey struct{}
+)
+
+// validator checks buf.validate rules of events, generated ValidateAll methods don't know these rules.
+var validator = sync.OnceValues(func() (*protovalidate.Validator, error) {
+	return protovalidate.New()
+})
+
+// Wrap builds envelope of event, version is schema version of event.
+// Event is validated by its buf.validate rules, because envelope validation doesn't see payload.
+// Correlation ID is taken from ctx if meta doesn't contain it.
+func Wrap(ctx context.Context, eventType string, version uint32, event proto.Message, meta Meta) (*events_pb.Envelope, error) {
+	err := Validate(event)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("proto.Marshal: %w", err)
+	}
+
+	if meta.CorrelationID == "" {
+		meta.CorrelationID = CorrelationID(ctx)
+	}
+	if meta.OccurredAt.IsZero() {
+		meta.OccurredAt = time.Now()
+	}
+
+	return &events_pb.Envelope{
+		EventType:     eventType,
+		SchemaVersion: version,
+		OccurredAt:    timestamppb.New(meta.OccurredAt),
+		Producer:      meta.Producer,
+		CorrelationId: meta.CorrelationID,
+		Payload:       payload,
+	}, nil
+}
+
+// DecoderOf returns decoder of payload to message of type T.
+func DecoderOf[T any, PT interface {
+	*T
+	proto.Message
+}]() Decoder {
+	return func(payload []byte) (proto.Message, error) {
+		event := PT(new(T))
+		err := proto.Unmarshal(payload, event)
+		if err != nil {
+			return nil, fmt.Errorf("proto.Unmarshal: %w", err)
+		}
+
+		return event, nil
+	}
+}
+
+// NewRegistry build and returns empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		types: make(map[string][]version),
+	}
+}
+
+// Register adds the next schema version of event type, versions are registered in order starting from 1.
+// Upcaster of previous version converts its events to this one, it's required for every version except the first.
+func (r *Registry) Register(eventType string, decode Decoder, upcast Upcaster) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := r.types[eventType]
+	switch {
+	case decode == nil:
+		panic(fmt.Sprintf("events: nil decoder of %s", eventType))
+	case len(versions) == 0 && upcast != nil:
+		panic(fmt.Sprintf("events: upcaster of the first version of %s", eventType))
+	case len(versions) != 0 && upcast == nil:
+		panic(fmt.Sprintf("events: nil upcaster of %s v%d", eventType, len(versions)))
+	case len(versions) != 0:
+		versions[len(versions)-1].upcast = upcast
+	}
+
+	r.types[eventType] = append(versions, version{decode: decode})
+
+	return r
+}
+
+// Latest returns the latest schema version of event type, zero if type isn't registered.
+func (r *Registry) Latest(eventType string) uint32 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return uint32(len(r.types[eventType]))
+}
+
+// Decode returns event from envelope upcasted to the latest registered version.
+// Upcasted event is validated by its buf.validate rules.
+// Errors: ErrUnknownEvent, ErrUnknownVersion, unknown.
+func (r *Registry) Decode(env *events_pb.Envelope) (proto.Message, error) {
+	r.mu.RLock()
+	versions, ok := r.types[env.EventType]
+	r.mu.RUnlock()
+
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, env.EventType)
+	case env.SchemaVersion == 0 || int(env.SchemaVersion) > len(versions):
+		return nil, fmt.Errorf("%w: %s v%d", ErrUnknownVersion, env.EventType, env.SchemaVersion)
+	}
+
+	event, err := versions[env.SchemaVersion-1].decode(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s v%d: %w", env.EventType, env.SchemaVersion, err)
+	}
+
+	for i := int(env.SchemaVersion) - 1; i < len(versions)-1; i++ {
+		event, err = versions[i].upcast(event)
+		if err != nil {
+			return nil, fmt.Errorf("upcast %s v%d: %w", env.EventType, i+1, err)
+		}
+	}
+
+	err = Validate(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Validate checks buf.validate rules of event.
+func Validate(event proto.Message) error {
+	v, err := validator()
+	if err != nil {
+		return fmt.Errorf("protovalidate.New: %w", err)
+	}
+
+	err = v.Validate(event)
+	if err != nil {
+		return fmt.Errorf("v.Validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithCorrelationID returns ctx with correlation ID used by Wrap.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns correlation ID from ctx, empty if it isn't set.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+
+	return id
+}
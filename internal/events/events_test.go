@@ -0,0 +1,141 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	events_pb "github.com/ZergsLaw/back-template1/api/events/v1"
+	"github.com/ZergsLaw/back-template1/internal/events"
+)
+
+const eventType = "test.events.counter"
+
+var errUpcast = errors.New("upcast error")
+
+// registry returns registry where counter was a string in v1 and became int64 in v2.
+func registry() *events.Registry {
+	return events.NewRegistry().
+		Register(eventType, events.DecoderOf[wrapperspb.StringValue](), nil).
+		Register(eventType, events.DecoderOf[wrapperspb.Int64Value](), func(msg proto.Message) (proto.Message, error) {
+			v, err := strconv.ParseInt(msg.(*wrapperspb.StringValue).Value, 10, 64)
+			if err != nil {
+				return nil, errUpcast
+			}
+
+			return wrapperspb.Int64(v), nil
+		})
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		eventType string
+		version   uint32
+		event     proto.Message
+		want      proto.Message
+		wantErr   error
+	}{
+		"success_v1":          {eventType, 1, wrapperspb.String("42"), wrapperspb.Int64(42), nil},
+		"success_v2":          {eventType, 2, wrapperspb.Int64(42), wrapperspb.Int64(42), nil},
+		"err_unknown_event":   {"unknown", 1, wrapperspb.Int64(42), nil, events.ErrUnknownEvent},
+		"err_unknown_version": {eventType, 3, wrapperspb.Int64(42), nil, events.ErrUnknownVersion},
+		"err_zero_version":    {eventType, 0, wrapperspb.Int64(42), nil, events.ErrUnknownVersion},
+		"err_upcast":          {eventType, 1, wrapperspb.String("abc"), nil, errUpcast},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			r := registry()
+
+			env, err := events.Wrap(context.Background(), tc.eventType, tc.version, tc.event, events.Meta{Producer: "test"})
+			assert.NoError(err)
+
+			res, err := r.Decode(env)
+			assert.ErrorIs(err, tc.wantErr)
+			if tc.want != nil {
+				assert.True(proto.Equal(tc.want, res), "want %v, got %v", tc.want, res)
+			}
+		})
+	}
+}
+
+func TestRegistry_Latest(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	r := registry()
+
+	assert.EqualValues(2, r.Latest(eventType))
+	assert.EqualValues(0, r.Latest("unknown"))
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		assert      = require.New(t)
+		occurredAt  = time.Now().Add(-time.Hour).UTC()
+		correlation = "correlation"
+		event       = wrapperspb.String("42")
+	)
+
+	ctx := events.WithCorrelationID(context.Background(), correlation)
+	env, err := events.Wrap(ctx, eventType, 1, event, events.Meta{
+		Producer:   "test",
+		OccurredAt: occurredAt,
+	})
+	assert.NoError(err)
+	assert.NoError(env.Validate())
+
+	payload, err := proto.Marshal(event)
+	assert.NoError(err)
+
+	assert.True(proto.Equal(&events_pb.Envelope{
+		EventType:     eventType,
+		SchemaVersion: 1,
+		OccurredAt:    env.OccurredAt,
+		Producer:      "test",
+		CorrelationId: correlation,
+		Payload:       payload,
+	}, env))
+	assert.Equal(occurredAt, env.OccurredAt.AsTime())
+
+	env, err = events.Wrap(ctx, eventType, 1, event, events.Meta{Producer: "test", CorrelationID: "explicit"})
+	assert.NoError(err)
+	assert.Equal("explicit", env.CorrelationId)
+	assert.False(env.OccurredAt.AsTime().IsZero())
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		assert  = require.New(t)
+		ctx     = context.Background()
+		invalid = &events_pb.Envelope{}
+		meta    = events.Meta{Producer: "test"}
+	)
+
+	_, err := events.Wrap(ctx, eventType, 1, invalid, meta)
+	assert.ErrorAs(err, new(*protovalidate.ValidationError))
+
+	payload, err := proto.Marshal(invalid)
+	assert.NoError(err)
+
+	r := events.NewRegistry().Register(eventType, events.DecoderOf[events_pb.Envelope](), nil)
+	_, err = r.Decode(&events_pb.Envelope{EventType: eventType, SchemaVersion: 1, Payload: payload})
+	assert.ErrorAs(err, new(*protovalidate.ValidationError))
+}
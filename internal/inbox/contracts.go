@@ -0,0 +1,19 @@
+package inbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Repo keeps IDs of processed messages.
+type Repo interface {
+	// Tx runs f in transaction which records message as processed by consumer.
+	// Errors: ErrDuplicate, unknown.
+	Tx(ctx context.Context, consumer string, msgID uuid.UUID, f func(*sqlx.Tx) error) error
+	// RemoveExpired removes up to limit records created before given time and returns their count.
+	// Errors: unknown.
+	RemoveExpired(ctx context.Context, before time.Time, limit int) (int, error)
+}
@@ -0,0 +1,3 @@
+package inbox_test
+
+//go:generate mockgen -source=contracts.go -destination mock.contracts_test.go -package inbox_test
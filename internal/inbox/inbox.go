@@ -0,0 +1,149 @@
+// Package inbox implements deduplication of consumed messages.
+//
+// ID of every processed message is recorded in inbox table in the same
+// transaction as effects of processing, so redelivered messages are
+// recognized and skipped. Records are removed after retention period,
+// it must be longer than redelivery period of the queue.
+//
+// Services wrap message handlers by Inbox.Wrap or record messages by Record
+// in transactions of their repositories, and add inbox table to their migrations,
+// see cmd/session/migrate/2.create_table_inbox.sql.
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ZergsLaw/back-template1/internal/logger"
+	"github.com/ZergsLaw/back-template1/internal/queue"
+)
+
+// ErrDuplicate is returned when message was already processed by consumer.
+var ErrDuplicate = errors.New("duplicate message")
+
+type (
+	// Config provide settings for Inbox.
+	Config struct {
+		// Retention is period during which records of processed messages are kept.
+		Retention time.Duration
+	}
+	// Inbox wraps message handlers to process every message once
+	// and removes records of processed messages after retention period.
+	Inbox struct {
+		repo Repo
+		cfg  Config
+	}
+	// Handler handles message inside transaction, changes made in tx are committed
+	// together with record of message.
+	Handler func(ctx context.Context, tx *sqlx.Tx, msg queue.Message) error
+)
+
+const defaultRetention = 7 * 24 * time.Hour
+
+// New build and returns new Inbox, zero settings are replaced by defaults.
+func New(repo Repo, cfg Config) *Inbox {
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultRetention
+	}
+
+	return &Inbox{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Record marks message as processed by consumer inside tx.
+// Errors: ErrDuplicate, unknown.
+func Record(ctx context.Context, tx *sqlx.Tx, consumer string, msgID uuid.UUID) error {
+	// Conflict isn't reported as error, because failed statement aborts transaction in Postgres.
+	const query = `
+	insert into inbox
+		(consumer, message_id)
+	values
+		($1, $2)
+	on conflict do nothing
+	returning message_id`
+
+	err := tx.GetContext(ctx, &uuid.UUID{}, query, consumer, msgID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrDuplicate
+	case err != nil:
+		return fmt.Errorf("tx.GetContext: %w", err)
+	}
+
+	return nil
+}
+
+// Wrap returns handler for queue.Queue.Subscribe, which runs h once per message.
+// Message is acknowledged after commit, duplicates are acknowledged without running h.
+func (i *Inbox) Wrap(consumer string, h Handler) func(context.Context, queue.Message) error {
+	return func(ctx context.Context, msg queue.Message) error {
+		err := i.repo.Tx(ctx, consumer, msg.ID(), func(tx *sqlx.Tx) error {
+			return h(ctx, tx, msg)
+		})
+		switch {
+		case errors.Is(err, ErrDuplicate):
+		case err != nil:
+			nackErr := msg.Nack(ctx)
+			if nackErr != nil {
+				return fmt.Errorf("msg.Nack: %w", errors.Join(err, nackErr))
+			}
+
+			return fmt.Errorf("i.repo.Tx: %w", err)
+		}
+
+		err = msg.Ack(ctx)
+		if err != nil {
+			return fmt.Errorf("msg.Ack: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Process removes expired records until ctx is canceled.
+func (i *Inbox) Process(ctx context.Context) error {
+	const cleanTickerTimeout = 10 * time.Minute
+	var (
+		log    = logger.FromContext(ctx)
+		ticker = time.NewTicker(cleanTickerTimeout)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			err := i.RemoveExpired(ctx)
+			if err != nil {
+				log.Error("couldn't remove expired inbox records", slog.String(logger.Error.String(), err.Error()))
+			}
+		}
+	}
+}
+
+// RemoveExpired removes records older than retention period.
+func (i *Inbox) RemoveExpired(ctx context.Context) error {
+	const limit = 1000
+	before := time.Now().Add(-i.cfg.Retention)
+
+	for {
+		count, err := i.repo.RemoveExpired(ctx, before, limit)
+		if err != nil {
+			return fmt.Errorf("i.repo.RemoveExpired: %w", err)
+		}
+
+		if count < limit {
+			return nil
+		}
+	}
+}
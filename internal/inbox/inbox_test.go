@@ -0,0 +1,185 @@
+package inbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ZergsLaw/back-template1/internal/inbox"
+	"github.com/ZergsLaw/back-template1/internal/queue"
+	"github.com/ZergsLaw/back-template1/internal/testhelper"
+)
+
+var errAny = errors.New("any error")
+
+func TestInbox_Wrap(t *testing.T) {
+	t.Parallel()
+
+	const consumer = "consumer"
+
+	testCases := map[string]struct {
+		repoErr     error
+		handlerErr  error
+		wantHandled bool
+		wantAck     bool
+		wantErr     error
+	}{
+		"success":         {nil, nil, true, true, nil},
+		"success_dup":     {inbox.ErrDuplicate, nil, false, true, nil},
+		"err_any_handler": {nil, errAny, true, false, errAny},
+		"err_any_repo":    {errAny, nil, false, false, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, in, repo, assert := start(t)
+			msg := &testMessage{id: uuid.Must(uuid.NewV4())}
+
+			repo.EXPECT().Tx(ctx, consumer, msg.id, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, _ uuid.UUID, f func(*sqlx.Tx) error) error {
+					if tc.repoErr != nil {
+						return tc.repoErr
+					}
+
+					return f(nil)
+				})
+
+			handled := false
+			handler := in.Wrap(consumer, func(_ context.Context, _ *sqlx.Tx, m queue.Message) error {
+				assert.Equal(msg, m)
+				handled = true
+
+				return tc.handlerErr
+			})
+
+			err := handler(ctx, msg)
+			assert.ErrorIs(err, tc.wantErr)
+			assert.Equal(tc.wantHandled, handled)
+			assert.Equal(tc.wantAck, msg.acked)
+			assert.Equal(!tc.wantAck, msg.nacked)
+		})
+	}
+}
+
+func TestInbox_WrapRedelivery(t *testing.T) {
+	t.Parallel()
+
+	const consumer = "consumer"
+
+	ctx, in, repo, assert := start(t)
+	msg := &testMessage{id: uuid.Must(uuid.NewV4())}
+
+	// Record is committed by the first delivery only, like by inbox table.
+	recorded := make(map[uuid.UUID]bool)
+	repo.EXPECT().Tx(ctx, consumer, msg.id, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, id uuid.UUID, f func(*sqlx.Tx) error) error {
+			if recorded[id] {
+				return inbox.ErrDuplicate
+			}
+
+			err := f(nil)
+			if err != nil {
+				return err
+			}
+			recorded[id] = true
+
+			return nil
+		}).Times(3)
+
+	effects := 0
+	fail := true
+	handler := in.Wrap(consumer, func(context.Context, *sqlx.Tx, queue.Message) error {
+		// The first delivery fails, so its effects are rolled back and message is redelivered.
+		if fail {
+			fail = false
+
+			return errAny
+		}
+		effects++
+
+		return nil
+	})
+
+	assert.ErrorIs(handler(ctx, msg), errAny)
+	assert.True(msg.nacked)
+
+	for i := 0; i < 2; i++ {
+		msg.acked = false
+		assert.NoError(handler(ctx, msg))
+		assert.True(msg.acked)
+	}
+	assert.Equal(1, effects)
+}
+
+func TestInbox_RemoveExpired(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		counts  []int
+		repoErr error
+		want    error
+	}{
+		"success":       {[]int{3}, nil, nil},
+		"success_batch": {[]int{1000, 5}, nil, nil},
+		"err_any":       {[]int{0}, errAny, errAny},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, in, repo, assert := start(t)
+
+			for i := range tc.counts {
+				var (
+					count = tc.counts[i]
+					err   error
+				)
+				if i == len(tc.counts)-1 {
+					err = tc.repoErr
+				}
+				repo.EXPECT().RemoveExpired(ctx, gomock.Any(), 1000).
+					DoAndReturn(func(_ context.Context, before time.Time, _ int) (int, error) {
+						// Default retention.
+						assert.WithinDuration(time.Now().Add(-7*24*time.Hour), before, time.Minute)
+
+						return count, err
+					})
+			}
+
+			err := in.RemoveExpired(ctx)
+			assert.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func start(t *testing.T) (context.Context, *inbox.Inbox, *MockRepo, *require.Assertions) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepo(ctrl)
+
+	return testhelper.Context(t), inbox.New(repo, inbox.Config{}), repo, require.New(t)
+}
+
+type testMessage struct {
+	id     uuid.UUID
+	acked  bool
+	nacked bool
+}
+
+func (m *testMessage) ID() uuid.UUID              { return m.id }
+func (*testMessage) Subject() string              { return "subject" }
+func (*testMessage) Unmarshal(any) error          { return nil }
+func (m *testMessage) Ack(context.Context) error  { m.acked = true; return nil }
+func (m *testMessage) Nack(context.Context) error { m.nacked = true; return nil }
@@ -0,0 +1,72 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ Repo = &SQL{}
+
+type (
+	// DB runs queries, it's implemented by database.SQL.
+	DB interface {
+		NoTx(func(*sqlx.DB) error) error
+		Tx(context.Context, *sql.TxOptions, func(*sqlx.Tx) error) error
+	}
+	// SQL implements Repo by inbox table.
+	SQL struct {
+		db DB
+	}
+)
+
+// NewSQL build and returns new inbox repository.
+func NewSQL(db DB) *SQL {
+	return &SQL{db: db}
+}
+
+// Tx implements Repo.
+func (s *SQL) Tx(ctx context.Context, consumer string, msgID uuid.UUID, f func(*sqlx.Tx) error) error {
+	return s.db.Tx(ctx, nil, func(tx *sqlx.Tx) error {
+		err := Record(ctx, tx, consumer, msgID)
+		if err != nil {
+			return err
+		}
+
+		return f(tx)
+	})
+}
+
+// RemoveExpired implements Repo.
+func (s *SQL) RemoveExpired(ctx context.Context, before time.Time, limit int) (count int, err error) {
+	err = s.db.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		delete from inbox
+		where (consumer, message_id) in (
+			select consumer, message_id from inbox
+			where created_at < $1
+			order by created_at asc
+			limit $2
+		)
+		returning message_id`
+
+		var ids []uuid.UUID
+		err = db.SelectContext(ctx, &ids, query, before, limit)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", err)
+		}
+
+		count = len(ids)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
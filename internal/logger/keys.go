@@ -20,4 +20,6 @@ const (
 	Stack              // stack
 	TaskID             // task_id
 	TaskKind           // task_kind
+	FileID             // file_id
+	MessageID          // message_id
 )
@@ -20,11 +20,13 @@ func _() {
 	_ = x[Stack-10]
 	_ = x[TaskID-11]
 	_ = x[TaskKind-12]
+	_ = x[FileID-13]
+	_ = x[MessageID-14]
 }
 
-const _LogKey_name = "versionpanic_reasonurlerrreasonhostportmoduleenvironmentstacktask_idtask_kind"
+const _LogKey_name = "versionpanic_reasonurlerrreasonhostportmoduleenvironmentstacktask_idtask_kindfile_idmessage_id"
 
-var _LogKey_index = [...]uint8{0, 7, 19, 22, 25, 31, 35, 39, 45, 56, 61, 68, 77}
+var _LogKey_index = [...]uint8{0, 7, 19, 22, 25, 31, 35, 39, 45, 56, 61, 68, 77, 84, 94}
 
 func (i LogKey) String() string {
 	i -= 1
@@ -0,0 +1,34 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type (
+	// Publisher sends messages to queue, it's implemented by queue.Queue.
+	Publisher interface {
+		// Publish sends event to topic, msgID is used by queue for deduplication.
+		Publish(ctx context.Context, topic string, msgID uuid.UUID, event any) error
+	}
+	// Repo keeps messages waiting for publishing.
+	Repo interface {
+		// Claim leases up to limit messages ready for publishing to worker and returns them ordered by creation time.
+		// Messages leased by other workers are skipped until their lease is over.
+		// Errors: unknown.
+		Claim(ctx context.Context, workerID string, lease time.Duration, limit int) ([]Message, error)
+		// Delete removes published messages.
+		// Errors: unknown.
+		Delete(ctx context.Context, ids []uuid.UUID) error
+		// Fail records failed attempt to publish message leased by worker and postpones next attempt by delay.
+		// Message leased by other worker after the lease of worker is over isn't changed.
+		// Errors: unknown.
+		Fail(ctx context.Context, workerID string, id uuid.UUID, lastErr string, delay time.Duration) error
+		// Kill records failed attempt to publish message leased by worker and moves it to dead-letter state.
+		// Dead messages aren't claimed. Message leased by other worker after the lease of worker is over isn't changed.
+		// Errors: unknown.
+		Kill(ctx context.Context, workerID string, id uuid.UUID, lastErr string) error
+	}
+)
@@ -0,0 +1,3 @@
+package outbox_test
+
+//go:generate mockgen -source=contracts.go -destination mock.contracts_test.go -package outbox_test
@@ -0,0 +1,90 @@
+// Package outbox implements transactional outbox for proto events.
+//
+// Events are written to outbox table in the same transaction as business data,
+// and Relay publishes them to queue after commit, so events are never lost
+// and never published for rolled back changes. Events may be published more
+// than once, consumers deduplicate them by message ID.
+//
+// Services add outbox table to their migrations, see cmd/session/migrate/3.create_table_outbox.sql.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ErrUnknownType is returned when message type isn't registered in proto registry.
+var ErrUnknownType = errors.New("unknown message type")
+
+type (
+	// Message is event waiting for publishing.
+	Message struct {
+		ID        uuid.UUID
+		Topic     string
+		Type      string // Full name of proto message.
+		Payload   []byte
+		Attempts  int // Amount of failed attempts to publish message.
+		CreatedAt time.Time
+	}
+
+	validator interface {
+		ValidateAll() error
+	}
+)
+
+// Write saves event to outbox inside tx, event is published only if tx is committed.
+// Event is validated before saving if it has ValidateAll method, because invalid event can't be published.
+func Write(ctx context.Context, tx *sqlx.Tx, topic string, event proto.Message) (uuid.UUID, error) {
+	if event, ok := event.(validator); ok {
+		err := event.ValidateAll()
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("event.ValidateAll: %w", err)
+		}
+	}
+
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("proto.Marshal: %w", err)
+	}
+
+	const query = `
+	insert into outbox
+		(id, topic, message_type, payload)
+	values
+		($1, $2, $3, $4)`
+
+	id := uuid.Must(uuid.NewV4())
+	_, err = tx.ExecContext(ctx, query, id, topic, string(event.ProtoReflect().Descriptor().FullName()), payload)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("tx.ExecContext: %w", err)
+	}
+
+	return id, nil
+}
+
+// Event decodes message payload to proto message of registered type.
+func (m Message) Event() (proto.Message, error) {
+	typ, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(m.Type))
+	if errors.Is(err, protoregistry.NotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, m.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("protoregistry.GlobalTypes.FindMessageByName: %w", err)
+	}
+
+	event := typ.New().Interface()
+	err = proto.Unmarshal(m.Payload, event)
+	if err != nil {
+		return nil, fmt.Errorf("proto.Unmarshal: %w", err)
+	}
+
+	return event, nil
+}
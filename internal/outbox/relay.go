@@ -0,0 +1,179 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ZergsLaw/back-template1/internal/logger"
+)
+
+type (
+	// Config provide settings for Relay.
+	Config struct {
+		// PollInterval is period of polling new messages.
+		PollInterval time.Duration
+		// Lease is period during which claimed message can't be taken by other replicas.
+		Lease time.Duration
+		// BatchSize limits amount of messages claimed at once.
+		BatchSize int
+		// MaxAttempts is amount of attempts to publish message before moving it to dead-letter state.
+		MaxAttempts int
+		// RetryBackoff is delay after first failed attempt, it's doubled after every next one.
+		RetryBackoff time.Duration
+		// RetryMaxBackoff limits delay between attempts.
+		RetryMaxBackoff time.Duration
+	}
+	// Relay publishes messages from outbox to queue.
+	// Messages are published in order of writing, failed message is retried later
+	// and doesn't block following ones.
+	Relay struct {
+		repo      Repo
+		publisher Publisher
+		cfg       Config
+		retry     Retry
+		workerID  string
+	}
+)
+
+const (
+	defaultPollInterval    = time.Second / 10
+	defaultLease           = 30 * time.Second
+	defaultBatchSize       = 100
+	defaultMaxAttempts     = 10
+	defaultRetryBackoff    = time.Second
+	defaultRetryMaxBackoff = 10 * time.Minute
+)
+
+// NewRelay build and returns new Relay, zero settings are replaced by defaults.
+func NewRelay(repo Repo, publisher Publisher, cfg Config) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultLease
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+	if cfg.RetryMaxBackoff <= 0 {
+		cfg.RetryMaxBackoff = defaultRetryMaxBackoff
+	}
+
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		cfg:       cfg,
+		retry: Retry{
+			MaxAttempts: cfg.MaxAttempts,
+			Backoff:     cfg.RetryBackoff,
+			MaxBackoff:  cfg.RetryMaxBackoff,
+		},
+		workerID: uuid.Must(uuid.NewV4()).String(),
+	}
+}
+
+// Process publishes messages until ctx is canceled.
+// Messages are claimed for a lease, so relays of several replicas can run at the same time.
+func (r *Relay) Process(ctx context.Context) error {
+	var (
+		log    = logger.FromContext(ctx)
+		ticker = time.NewTicker(r.cfg.PollInterval)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			err := r.publishBatch(ctx)
+			if err != nil {
+				log.Error("couldn't publish outbox messages", slog.String(logger.Error.String(), err.Error()))
+			}
+		}
+	}
+}
+
+// publishBatch claims and publishes one batch of messages, published messages are deleted by one query.
+func (r *Relay) publishBatch(ctx context.Context) error {
+	leaseCtx, cancel := WithLease(ctx, r.cfg.Lease)
+	defer cancel()
+
+	msgs, err := r.repo.Claim(ctx, r.workerID, r.cfg.Lease, r.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("r.repo.Claim: %w", err)
+	}
+
+	published := make([]uuid.UUID, 0, len(msgs))
+	for _, msg := range msgs {
+		if leaseCtx.Err() != nil {
+			break
+		}
+
+		err = r.publish(leaseCtx, msg)
+		if err != nil {
+			r.fail(ctx, msg, err)
+
+			continue
+		}
+
+		published = append(published, msg.ID)
+	}
+
+	if len(published) == 0 {
+		return nil
+	}
+
+	// Not deleted messages are published again after the lease, consumers deduplicate them by message id.
+	err = r.repo.Delete(ctx, published)
+	if err != nil {
+		return fmt.Errorf("r.repo.Delete: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Relay) publish(ctx context.Context, msg Message) error {
+	event, err := msg.Event()
+	if err != nil {
+		return fmt.Errorf("msg.Event: %w", err)
+	}
+
+	err = r.publisher.Publish(ctx, msg.Topic, msg.ID, event)
+	if err != nil {
+		return fmt.Errorf("r.publisher.Publish: %w", err)
+	}
+
+	return nil
+}
+
+// fail postpones next attempt to publish message with exponential backoff,
+// message is moved to dead-letter state after last attempt or if it can't be decoded.
+func (r *Relay) fail(ctx context.Context, msg Message, cause error) {
+	log := logger.FromContext(ctx).With(slog.String(logger.MessageID.String(), msg.ID.String()))
+	log.Error("couldn't publish outbox message", slog.String(logger.Error.String(), cause.Error()))
+
+	var (
+		attempts = msg.Attempts + 1
+		err      error
+	)
+	if r.retry.Dead(attempts) || errors.Is(cause, ErrUnknownType) {
+		err = r.repo.Kill(ctx, r.workerID, msg.ID, cause.Error())
+	} else {
+		err = r.repo.Fail(ctx, r.workerID, msg.ID, cause.Error(), r.retry.Delay(attempts))
+	}
+	if err != nil {
+		log.Error("couldn't fail outbox message", slog.String(logger.Error.String(), err.Error()))
+	}
+}
@@ -0,0 +1,207 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ZergsLaw/back-template1/internal/outbox"
+	"github.com/ZergsLaw/back-template1/internal/testhelper"
+)
+
+var errAny = errors.New("any error")
+
+func TestRelay_Process(t *testing.T) {
+	t.Parallel()
+
+	const (
+		topic          = "test.events.v1.value"
+		publishErr     = "r.publisher.Publish: any error"
+		unknownTypeErr = "msg.Event: unknown message type: unknown.Type"
+		lease          = 30 * time.Second // Default lease.
+		batchSize      = 100              // Default batch size.
+	)
+	var (
+		event = wrapperspb.String("value")
+		msg   = outbox.Message{
+			ID:      uuid.Must(uuid.NewV4()),
+			Topic:   topic,
+			Type:    string(event.ProtoReflect().Descriptor().FullName()),
+			Payload: must(proto.Marshal(event)),
+		}
+		msgRetried     = withAttempts(msg, 3)
+		msgLastAttempt = withAttempts(msg, 9)
+		msgUnknownType = msg
+		msgNext        = msg
+	)
+	msgUnknownType.Type = "unknown.Type"
+	msgNext.ID = uuid.Must(uuid.NewV4())
+
+	testCases := map[string]struct {
+		claimErr   error
+		msg        outbox.Message
+		publishErr error
+		wantRetry  string
+		wantDelay  time.Duration
+		wantKill   string
+	}{
+		"success":          {nil, msg, nil, "", 0, ""},
+		"err_any_claim":    {errAny, msg, nil, "", 0, ""},
+		"err_any_publish":  {nil, msg, errAny, publishErr, time.Second, ""},
+		"err_any_backoff":  {nil, msgRetried, errAny, publishErr, 8 * time.Second, ""},
+		"err_last_attempt": {nil, msgLastAttempt, errAny, "", 0, publishErr},
+		"err_unknown_type": {nil, msgUnknownType, nil, "", 0, unknownTypeErr},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepo(ctrl)
+			publisher := NewMockPublisher(ctrl)
+			assert := require.New(t)
+
+			ctx, cancel := context.WithCancel(testhelper.Context(t))
+			defer cancel()
+
+			// Failed messages are changed only if they're still leased by the same worker.
+			var workerID string
+			sameWorker := gomock.Cond(func(x any) bool { return x == workerID })
+
+			claim := repo.EXPECT().Claim(gomock.Any(), gomock.Any(), lease, batchSize)
+			if tc.claimErr != nil {
+				claim.DoAndReturn(func(context.Context, string, time.Duration, int) ([]outbox.Message, error) {
+					cancel()
+
+					return nil, tc.claimErr
+				})
+			} else {
+				claim.DoAndReturn(func(_ context.Context, id string, _ time.Duration, _ int) ([]outbox.Message, error) {
+					workerID = id
+
+					return []outbox.Message{tc.msg, msgNext}, nil
+				})
+			}
+			repo.EXPECT().Claim(gomock.Any(), gomock.Any(), lease, batchSize).Return(nil, nil).AnyTimes()
+
+			published := []uuid.UUID{msgNext.ID}
+			if tc.claimErr == nil {
+				if tc.msg.Type == msg.Type {
+					publisher.EXPECT().Publish(gomock.Any(), topic, tc.msg.ID, protoEq(event)).Return(tc.publishErr)
+				}
+				// Failed messages don't block following ones.
+				publisher.EXPECT().Publish(gomock.Any(), topic, msgNext.ID, protoEq(event)).Return(nil)
+			}
+
+			switch {
+			case tc.claimErr != nil:
+			case tc.wantKill != "":
+				repo.EXPECT().Kill(gomock.Any(), sameWorker, tc.msg.ID, tc.wantKill).Return(nil)
+			case tc.wantRetry != "":
+				repo.EXPECT().Fail(gomock.Any(), sameWorker, tc.msg.ID, tc.wantRetry, tc.wantDelay).Return(nil)
+			default:
+				published = []uuid.UUID{tc.msg.ID, msgNext.ID}
+			}
+
+			if tc.claimErr == nil {
+				repo.EXPECT().Delete(gomock.Any(), published).
+					DoAndReturn(func(context.Context, []uuid.UUID) error {
+						cancel()
+
+						return nil
+					})
+			}
+
+			relay := outbox.NewRelay(repo, publisher, outbox.Config{})
+			err := relay.Process(ctx)
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestMessage_Event(t *testing.T) {
+	t.Parallel()
+
+	event := wrapperspb.String("value")
+	msg := outbox.Message{
+		Type:    string(event.ProtoReflect().Descriptor().FullName()),
+		Payload: must(proto.Marshal(event)),
+	}
+	unknown := msg
+	unknown.Type = "unknown.Type"
+	broken := msg
+	broken.Payload = []byte{0xff}
+
+	testCases := map[string]struct {
+		msg     outbox.Message
+		want    proto.Message
+		wantErr error
+	}{
+		"success":          {msg, event, nil},
+		"err_unknown_type": {unknown, nil, outbox.ErrUnknownType},
+		"err_broken":       {broken, nil, nil},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+
+			res, err := tc.msg.Event()
+			switch {
+			case tc.want != nil:
+				assert.NoError(err)
+				assert.True(proto.Equal(tc.want, res))
+			case tc.wantErr != nil:
+				assert.ErrorIs(err, tc.wantErr)
+			default:
+				assert.Error(err)
+			}
+		})
+	}
+}
+
+func withAttempts(msg outbox.Message, attempts int) outbox.Message {
+	msg.Attempts = attempts
+
+	return msg
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+type protoMatcher struct {
+	want proto.Message
+}
+
+func protoEq(want proto.Message) gomock.Matcher {
+	return protoMatcher{want: want}
+}
+
+// Matches implements gomock.Matcher.
+func (m protoMatcher) Matches(x any) bool {
+	msg, ok := x.(proto.Message)
+
+	return ok && proto.Equal(m.want, msg)
+}
+
+// String implements gomock.Matcher.
+func (m protoMatcher) String() string {
+	return "is equal to " + string(m.want.ProtoReflect().Descriptor().FullName())
+}
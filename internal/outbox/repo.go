@@ -0,0 +1,149 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var _ Repo = &SQL{}
+
+// claimQuery leases messages ready for next attempt to worker,
+// messages leased by other workers are skipped until their lease is over.
+const claimQuery = `
+	update outbox
+	set locked_by = $1, locked_until = now() + $2 * interval '1 microsecond'
+	where id in (
+		select id from outbox
+		where dead_at is null and next_attempt_at <= now()
+			and (locked_until is null or locked_until < now())
+		order by created_at asc
+		limit $3
+		for update skip locked
+	)
+	returning id, topic, message_type, payload, attempts, created_at`
+
+type (
+	// DB runs queries outside of transaction, it's implemented by database.SQL.
+	DB interface {
+		NoTx(func(*sqlx.DB) error) error
+	}
+	// SQL implements Repo by outbox table.
+	SQL struct {
+		db DB
+	}
+
+	message struct {
+		ID          uuid.UUID `db:"id"`
+		Topic       string    `db:"topic"`
+		MessageType string    `db:"message_type"`
+		Payload     []byte    `db:"payload"`
+		Attempts    int       `db:"attempts"`
+		CreatedAt   time.Time `db:"created_at"`
+	}
+)
+
+// NewSQL build and returns new outbox repository.
+func NewSQL(db DB) *SQL {
+	return &SQL{db: db}
+}
+
+// Claim implements Repo.
+func (s *SQL) Claim(ctx context.Context, workerID string, lease time.Duration, limit int) (msgs []Message, err error) {
+	err = s.db.NoTx(func(db *sqlx.DB) error {
+		res := make([]message, 0, limit)
+		err = db.SelectContext(ctx, &res, claimQuery, workerID, lease.Microseconds(), limit)
+		if err != nil {
+			return fmt.Errorf("db.SelectContext: %w", err)
+		}
+
+		msgs = make([]Message, len(res))
+		for i := range res {
+			msgs[i] = res[i].convert()
+		}
+
+		// Update statements don't keep order of rows.
+		slices.SortStableFunc(msgs, func(a, b Message) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// Delete implements Repo.
+func (s *SQL) Delete(ctx context.Context, ids []uuid.UUID) error {
+	return s.db.NoTx(func(db *sqlx.DB) error {
+		const query = `delete from outbox where id = any($1)`
+
+		_, err := db.ExecContext(ctx, query, pq.Array(ids))
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Fail implements Repo.
+func (s *SQL) Fail(ctx context.Context, workerID string, id uuid.UUID, lastErr string, delay time.Duration) error {
+	return s.db.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		update outbox set
+		attempts = attempts + 1,
+		last_error = $2,
+		next_attempt_at = now() + $3 * interval '1 microsecond',
+		locked_by = null,
+		locked_until = null
+		where id = $1 and locked_by = $4`
+
+		_, err := db.ExecContext(ctx, query, id, lastErr, delay.Microseconds(), workerID)
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Kill implements Repo.
+func (s *SQL) Kill(ctx context.Context, workerID string, id uuid.UUID, lastErr string) error {
+	return s.db.NoTx(func(db *sqlx.DB) error {
+		const query = `
+		update outbox set
+		attempts = attempts + 1,
+		last_error = $2,
+		dead_at = now(),
+		locked_by = null,
+		locked_until = null
+		where id = $1 and locked_by = $3`
+
+		_, err := db.ExecContext(ctx, query, id, lastErr, workerID)
+		if err != nil {
+			return fmt.Errorf("db.ExecContext: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (m message) convert() Message {
+	return Message{
+		ID:        m.ID,
+		Topic:     m.Topic,
+		Type:      m.MessageType,
+		Payload:   m.Payload,
+		Attempts:  m.Attempts,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// Retry is policy of retrying failed attempts to publish message with exponential backoff.
+// It's used by Relay and by services publishing messages of their own tables.
+type Retry struct {
+	// MaxAttempts is amount of attempts before moving message to dead-letter state.
+	MaxAttempts int
+	// Backoff is delay after first failed attempt, it's doubled after every next one.
+	Backoff time.Duration
+	// MaxBackoff limits delay between attempts.
+	MaxBackoff time.Duration
+}
+
+// Dead returns true if message has to be moved to dead-letter state after given amount of failed attempts.
+func (r Retry) Dead(attempts int) bool {
+	return attempts >= r.MaxAttempts
+}
+
+// Delay returns delay before next attempt after given amount of failed attempts.
+func (r Retry) Delay(attempts int) time.Duration {
+	backoff := r.Backoff
+	for i := 1; i < attempts && backoff < r.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, r.MaxBackoff)
+}
+
+// WithLease returns ctx which is done when lease of claimed messages is over.
+// Messages with expired lease may be already taken by other replica, so their publishing isn't continued.
+// Lease has to be taken before claiming, so it isn't over later than lease kept by repository.
+func WithLease(ctx context.Context, lease time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, time.Now().Add(lease))
+}
@@ -0,0 +1,43 @@
+package outbox_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZergsLaw/back-template1/internal/outbox"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	retry := outbox.Retry{
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxBackoff:  5 * time.Second,
+	}
+
+	testCases := map[string]struct {
+		attempts  int
+		wantDead  bool
+		wantDelay time.Duration
+	}{
+		"first":      {1, false, time.Second},
+		"third":      {3, false, 4 * time.Second},
+		"max_delay":  {4, false, 5 * time.Second},
+		"last":       {5, true, 5 * time.Second},
+		"over_limit": {100, true, 5 * time.Second},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			assert.Equal(tc.wantDead, retry.Dead(tc.attempts))
+			assert.Equal(tc.wantDelay, retry.Delay(tc.attempts))
+		})
+	}
+}
@@ -108,9 +108,12 @@ func (c *Queue) connect(ctx context.Context, urls, namespace, username, password
 	c.jetStream, err = c.conn.JetStream(
 		nats.Context(ctx),
 		nats.PublishAsyncErrHandler(func(_ nats.JetStream, msg *nats.Msg, err error) {
-			c.asyncErrHandler <- AsyncErrMsg{
+			select {
+			case c.asyncErrHandler <- AsyncErrMsg{
 				msg: msg,
 				Err: err,
+			}:
+			default:
 			}
 		}),
 	)
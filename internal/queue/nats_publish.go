@@ -35,3 +35,52 @@ func (c *Queue) Publish(ctx context.Context, topic string, msgID uuid.UUID, even
 
 	return nil
 }
+
+// PublishAck is confirmation of asynchronously published message.
+type PublishAck struct {
+	future nats.PubAckFuture
+}
+
+// PublishAsync sends message to queue without waiting for its confirmation.
+// Messages published via one connection are stored by stream in order of publishing.
+func (c *Queue) PublishAsync(ctx context.Context, topic string, msgID uuid.UUID, event any) (*PublishAck, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if event, ok := event.(Validator); ok {
+		err := event.ValidateAll()
+		if err != nil {
+			return nil, fmt.Errorf("event.ValidateAll: %w", err)
+		}
+	}
+
+	buf, err := c.encoder.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("c.encoder.Marshal: %w", err)
+	}
+
+	future, err := c.jetStream.PublishAsync(
+		topic,
+		buf,
+		nats.MsgId(msgID.String()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("c.jetStream.PublishAsync: %w", err)
+	}
+
+	return &PublishAck{future: future}, nil
+}
+
+// Wait blocks until message is stored by stream.
+// Confirmation may be lost, so ctx should have deadline.
+func (a *PublishAck) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-a.future.Ok():
+		return nil
+	case err := <-a.future.Err():
+		return fmt.Errorf("publish: %w", err)
+	}
+}